@@ -10,8 +10,14 @@ import (
 
 	"github.com/backend-app/backend/internal/api"
 	"github.com/backend-app/backend/internal/database"
+	"github.com/backend-app/backend/internal/events"
 	"github.com/backend-app/backend/internal/grpc"
+	"github.com/backend-app/backend/internal/jobs"
+	"github.com/backend-app/backend/internal/presence"
+	"github.com/backend-app/backend/internal/progress"
+	"github.com/backend-app/backend/internal/push"
 	"github.com/backend-app/backend/internal/repository"
+	"github.com/backend-app/backend/internal/storage"
 	"github.com/backend-app/backend/internal/webrtc"
 	"github.com/backend-app/backend/internal/websocket"
 	"github.com/backend-app/backend/pkg/config"
@@ -74,7 +80,17 @@ func main() {
 
 	log.Info().Msg("Connected to Redis")
 
-	grpcServer := grpc.NewServer(cfg, db, redisClient)
+	deviceRepo := repository.NewDeviceRepo(db)
+
+	pushService, err := push.New(&cfg.Push, deviceRepo)
+	if err != nil {
+		log.Fatal().Err(err).Msg("Failed to initialize push notifications")
+	}
+
+	// Хаб signaling общий для WebSocket сервера и gRPC SignalingService
+	signalingHub := websocket.NewHub(cfg, db, deviceRepo, redisClient, pushService)
+
+	grpcServer := grpc.NewServer(cfg, db, redisClient, pushService, signalingHub)
 	go func() {
 		if err := grpcServer.Start(); err != nil {
 			log.Fatal().Err(err).Msg("Failed to start gRPC server")
@@ -90,7 +106,34 @@ func main() {
 	defer grpcClients.Close()
 	log.Info().Msg("gRPC clients connected")
 
-	deviceRepo := repository.NewDeviceRepo(db)
+	userRepo := repository.NewUserRepo(db)
+	fileRepo := repository.NewFileRepo(db)
+	transferRepo := repository.NewTransferRepo(db)
+	accountRepo := repository.NewAccountRepo(db)
+
+	localStorage, err := storage.NewLocalStorage(cfg.Storage.LocalPath)
+	if err != nil {
+		log.Fatal().Err(err).Msg("Failed to initialize storage")
+	}
+
+	jobsCtx, jobsCancel := context.WithCancel(context.Background())
+	defer jobsCancel()
+
+	exportWorker := jobs.NewExportWorker(&cfg.Account, userRepo, deviceRepo, fileRepo, transferRepo, accountRepo, localStorage)
+	go exportWorker.Run(jobsCtx)
+
+	accountPurger := jobs.NewAccountPurger(&cfg.Account, userRepo, deviceRepo, fileRepo, transferRepo, accountRepo, localStorage)
+	go accountPurger.Run(jobsCtx)
+
+	devicePruner := jobs.NewDevicePruner(&cfg.Device, deviceRepo, presence.NewStore(redisClient, cfg.Presence.TTL), events.NewBus(redisClient), pushService)
+	go devicePruner.Run(jobsCtx)
+
+	fileCleaner := jobs.NewFileCleaner(&cfg.Transfer, fileRepo, localStorage)
+	go fileCleaner.Run(jobsCtx)
+
+	transferWatchdog := jobs.NewTransferWatchdog(&cfg.Transfer, transferRepo, deviceRepo, fileRepo, events.NewBus(redisClient), progress.NewBroker(redisClient))
+	go transferWatchdog.Run(jobsCtx)
+	log.Info().Msg("Background jobs started")
 
 	turnServer, err := webrtc.NewTurnServer(&cfg.WebRTC)
 	if err != nil {
@@ -118,7 +161,7 @@ func main() {
 	}()
 	log.Info().Str("port", cfg.Server.Port).Msg("HTTP server started")
 
-	wsServer := websocket.NewServer(cfg, db, deviceRepo)
+	wsServer := websocket.NewServer(cfg, signalingHub)
 	go func() {
 		if err := wsServer.Start(); err != nil {
 			log.Fatal().Err(err).Msg("Failed to start WebSocket server")
@@ -139,11 +182,14 @@ func main() {
 		log.Error().Err(err).Msg("HTTP server forced to shutdown")
 	}
 
+	// Хаб закрывает сессии до остановки gRPC: GracefulStop ждет завершения потоков Connect
+	if err := signalingHub.Shutdown(ctx); err != nil {
+		log.Error().Err(err).Msg("Signaling hub forced to shutdown")
+	}
+
 	grpcServer.Stop()
 
-	if err := wsServer.Shutdown(ctx); err != nil {
-		log.Error().Err(err).Msg("WebSocket server forced to shutdown")
-	}
+	jobsCancel()
 
 	turnCancel()
 	if err := turnServer.Stop(); err != nil {
@@ -24,9 +24,14 @@ const docTemplate = `{
     "host": "{{.Host}}",
     "basePath": "{{.BasePath}}",
     "paths": {
-        "/auth/login": {
-            "post": {
-                "description": "Аутентифицирует пользователя и возвращает JWT токены",
+        "/account/deletion": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Возвращает информацию о запланированном удалении аккаунта",
                 "consumes": [
                     "application/json"
                 ],
@@ -34,47 +39,18 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "auth"
-                ],
-                "summary": "Вход в систему",
-                "parameters": [
-                    {
-                        "description": "Данные для входа",
-                        "name": "request",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "$ref": "#/definitions/handlers.LoginRequest"
-                        }
-                    }
+                    "account"
                 ],
+                "summary": "Статус удаления аккаунта",
                 "responses": {
                     "200": {
-                        "description": "Успешный вход",
-                        "schema": {
-                            "$ref": "#/definitions/handlers.AuthResponse"
-                        }
-                    },
-                    "400": {
-                        "description": "Неверный формат данных",
+                        "description": "Статус удаления",
                         "schema": {
-                            "type": "object",
-                            "additionalProperties": {
-                                "type": "string"
-                            }
+                            "$ref": "#/definitions/handlers.AccountDeletionResponse"
                         }
                     },
                     "401": {
-                        "description": "Неверный email или пароль",
-                        "schema": {
-                            "type": "object",
-                            "additionalProperties": {
-                                "type": "string"
-                            }
-                        }
-                    },
-                    "500": {
-                        "description": "Внутренняя ошибка сервера",
+                        "description": "Не авторизован",
                         "schema": {
                             "type": "object",
                             "additionalProperties": {
@@ -83,11 +59,14 @@ const docTemplate = `{
                         }
                     }
                 }
-            }
-        },
-        "/auth/refresh": {
+            },
             "post": {
-                "description": "Обновляет access и refresh токены используя валидный refresh token. Возвращает новую пару токенов.",
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Планирует удаление аккаунта после периода ожидания. До его окончания удаление можно отменить. Единственный владелец организации должен сначала передать владение.",
                 "consumes": [
                     "application/json"
                 ],
@@ -95,32 +74,29 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "auth"
+                    "account"
                 ],
-                "summary": "Обновление токенов",
+                "summary": "Запрос удаления аккаунта",
                 "parameters": [
                     {
-                        "description": "Refresh token",
+                        "description": "Подтверждение паролем",
                         "name": "request",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/handlers.RefreshRequest"
+                            "$ref": "#/definitions/handlers.RequestAccountDeletionRequest"
                         }
                     }
                 ],
                 "responses": {
-                    "200": {
-                        "description": "Токены успешно обновлены\" example:\"{\\\"access_token\\\":\\\"eyJhbGci...\\\",\\\"refresh_token\\\":\\\"eyJhbGci...\\\"}",
+                    "202": {
+                        "description": "Удаление запланировано",
                         "schema": {
-                            "type": "object",
-                            "additionalProperties": {
-                                "type": "string"
-                            }
+                            "$ref": "#/definitions/handlers.AccountDeletionResponse"
                         }
                     },
                     "400": {
-                        "description": "Неверный формат данных\" example:\"{\\\"error\\\":\\\"invalid request format\\\"}",
+                        "description": "Неверный формат данных",
                         "schema": {
                             "type": "object",
                             "additionalProperties": {
@@ -129,59 +105,7 @@ const docTemplate = `{
                         }
                     },
                     "401": {
-                        "description": "Невалидный или истекший refresh token\" example:\"{\\\"error\\\":\\\"invalid or expired refresh token\\\"}",
-                        "schema": {
-                            "type": "object",
-                            "additionalProperties": {
-                                "type": "string"
-                            }
-                        }
-                    },
-                    "500": {
-                        "description": "Внутренняя ошибка сервера\" example:\"{\\\"error\\\":\\\"failed to refresh token\\\"}",
-                        "schema": {
-                            "type": "object",
-                            "additionalProperties": {
-                                "type": "string"
-                            }
-                        }
-                    }
-                }
-            }
-        },
-        "/auth/register": {
-            "post": {
-                "description": "Создает нового пользователя и возвращает JWT токены",
-                "consumes": [
-                    "application/json"
-                ],
-                "produces": [
-                    "application/json"
-                ],
-                "tags": [
-                    "auth"
-                ],
-                "summary": "Регистрация нового пользователя",
-                "parameters": [
-                    {
-                        "description": "Данные для регистрации",
-                        "name": "request",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "$ref": "#/definitions/handlers.RegisterRequest"
-                        }
-                    }
-                ],
-                "responses": {
-                    "201": {
-                        "description": "Пользователь успешно зарегистрирован",
-                        "schema": {
-                            "$ref": "#/definitions/handlers.AuthResponse"
-                        }
-                    },
-                    "400": {
-                        "description": "Неверный формат данных",
+                        "description": "Не авторизован или неверный пароль",
                         "schema": {
                             "type": "object",
                             "additionalProperties": {
@@ -190,7 +114,7 @@ const docTemplate = `{
                         }
                     },
                     "409": {
-                        "description": "Пользователь с таким email уже существует",
+                        "description": "Удаление уже запланировано или пользователь - единственный владелец организации",
                         "schema": {
                             "type": "object",
                             "additionalProperties": {
@@ -208,16 +132,14 @@ const docTemplate = `{
                         }
                     }
                 }
-            }
-        },
-        "/devices": {
-            "get": {
+            },
+            "delete": {
                 "security": [
                     {
                         "BearerAuth": []
                     }
                 ],
-                "description": "Возвращает список всех устройств пользователя",
+                "description": "Отменяет запланированное удаление аккаунта, пока не истек период ожидания",
                 "consumes": [
                     "application/json"
                 ],
@@ -225,14 +147,17 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "devices"
+                    "account"
                 ],
-                "summary": "Список устройств",
+                "summary": "Отмена удаления аккаунта",
                 "responses": {
                     "200": {
-                        "description": "Список устройств",
+                        "description": "Удаление отменено",
                         "schema": {
-                            "$ref": "#/definitions/handlers.ListDevicesResponse"
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "boolean"
+                            }
                         }
                     },
                     "401": {
@@ -244,8 +169,8 @@ const docTemplate = `{
                             }
                         }
                     },
-                    "500": {
-                        "description": "Внутренняя ошибка сервера",
+                    "409": {
+                        "description": "Удаление не запрашивалось",
                         "schema": {
                             "type": "object",
                             "additionalProperties": {
@@ -254,14 +179,16 @@ const docTemplate = `{
                         }
                     }
                 }
-            },
+            }
+        },
+        "/account/export": {
             "post": {
                 "security": [
                     {
                         "BearerAuth": []
                     }
                 ],
-                "description": "Регистрирует новое устройство для пользователя и возвращает device_token для QR-кода",
+                "description": "Ставит в очередь сборку ZIP архива с профилем, устройствами, историей передач и всеми файлами пользователя",
                 "consumes": [
                     "application/json"
                 ],
@@ -269,34 +196,14 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "devices"
-                ],
-                "summary": "Регистрация устройства",
-                "parameters": [
-                    {
-                        "description": "Данные устройства",
-                        "name": "request",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "$ref": "#/definitions/handlers.RegisterDeviceRequest"
-                        }
-                    }
+                    "account"
                 ],
+                "summary": "Запрос экспорта данных",
                 "responses": {
-                    "201": {
-                        "description": "Устройство успешно зарегистрировано",
-                        "schema": {
-                            "$ref": "#/definitions/handlers.RegisterDeviceResponse"
-                        }
-                    },
-                    "400": {
-                        "description": "Неверный формат данных",
+                    "202": {
+                        "description": "Экспорт поставлен в очередь",
                         "schema": {
-                            "type": "object",
-                            "additionalProperties": {
-                                "type": "string"
-                            }
+                            "$ref": "#/definitions/handlers.DataExportResponse"
                         }
                     },
                     "401": {
@@ -320,14 +227,14 @@ const docTemplate = `{
                 }
             }
         },
-        "/devices/{id}": {
+        "/account/export/{id}": {
             "get": {
                 "security": [
                     {
                         "BearerAuth": []
                     }
                 ],
-                "description": "Возвращает информацию об устройстве по ID",
+                "description": "Возвращает состояние экспорта данных пользователя",
                 "consumes": [
                     "application/json"
                 ],
@@ -335,14 +242,14 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "devices"
+                    "account"
                 ],
-                "summary": "Получение устройства",
+                "summary": "Статус экспорта данных",
                 "parameters": [
                     {
                         "type": "string",
                         "format": "uuid",
-                        "description": "ID устройства",
+                        "description": "ID экспорта",
                         "name": "id",
                         "in": "path",
                         "required": true
@@ -350,13 +257,13 @@ const docTemplate = `{
                 ],
                 "responses": {
                     "200": {
-                        "description": "Информация об устройстве",
+                        "description": "Состояние экспорта",
                         "schema": {
-                            "$ref": "#/definitions/handlers.DeviceResponse"
+                            "$ref": "#/definitions/handlers.DataExportResponse"
                         }
                     },
                     "400": {
-                        "description": "Неверный ID устройства",
+                        "description": "Неверный ID экспорта",
                         "schema": {
                             "type": "object",
                             "additionalProperties": {
@@ -374,7 +281,7 @@ const docTemplate = `{
                         }
                     },
                     "403": {
-                        "description": "Нет доступа к устройству",
+                        "description": "Нет доступа к экспорту",
                         "schema": {
                             "type": "object",
                             "additionalProperties": {
@@ -383,7 +290,7 @@ const docTemplate = `{
                         }
                     },
                     "404": {
-                        "description": "Устройство не найдено",
+                        "description": "Экспорт не найден",
                         "schema": {
                             "type": "object",
                             "additionalProperties": {
@@ -392,57 +299,41 @@ const docTemplate = `{
                         }
                     }
                 }
-            },
-            "put": {
+            }
+        },
+        "/account/export/{id}/download": {
+            "get": {
                 "security": [
                     {
                         "BearerAuth": []
                     }
                 ],
-                "description": "Обновляет информацию об устройстве",
+                "description": "Скачивает готовый ZIP архив с данными пользователя",
                 "consumes": [
                     "application/json"
                 ],
                 "produces": [
-                    "application/json"
+                    "application/zip"
                 ],
                 "tags": [
-                    "devices"
+                    "account"
                 ],
-                "summary": "Обновление устройства",
+                "summary": "Скачивание экспорта данных",
                 "parameters": [
                     {
                         "type": "string",
                         "format": "uuid",
-                        "description": "ID устройства",
+                        "description": "ID экспорта",
                         "name": "id",
                         "in": "path",
                         "required": true
-                    },
-                    {
-                        "description": "Данные для обновления",
-                        "name": "request",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "$ref": "#/definitions/handlers.UpdateDeviceRequest"
-                        }
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "Устройство обновлено",
-                        "schema": {
-                            "$ref": "#/definitions/handlers.DeviceResponse"
-                        }
-                    },
-                    "400": {
-                        "description": "Неверный формат данных",
+                        "description": "ZIP архив",
                         "schema": {
-                            "type": "object",
-                            "additionalProperties": {
-                                "type": "string"
-                            }
+                            "type": "file"
                         }
                     },
                     "401": {
@@ -455,7 +346,7 @@ const docTemplate = `{
                         }
                     },
                     "403": {
-                        "description": "Нет доступа к устройству",
+                        "description": "Нет доступа к экспорту",
                         "schema": {
                             "type": "object",
                             "additionalProperties": {
@@ -464,7 +355,7 @@ const docTemplate = `{
                         }
                     },
                     "404": {
-                        "description": "Устройство не найдено",
+                        "description": "Экспорт не найден или истек",
                         "schema": {
                             "type": "object",
                             "additionalProperties": {
@@ -472,8 +363,8 @@ const docTemplate = `{
                             }
                         }
                     },
-                    "500": {
-                        "description": "Внутренняя ошибка сервера",
+                    "409": {
+                        "description": "Экспорт еще не готов",
                         "schema": {
                             "type": "object",
                             "additionalProperties": {
@@ -482,14 +373,11 @@ const docTemplate = `{
                         }
                     }
                 }
-            },
-            "delete": {
-                "security": [
-                    {
-                        "BearerAuth": []
-                    }
-                ],
-                "description": "Удаляет устройство пользователя",
+            }
+        },
+        "/auth/login": {
+            "post": {
+                "description": "Аутентифицирует пользователя и возвращает JWT токены",
                 "consumes": [
                     "application/json"
                 ],
@@ -497,31 +385,29 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "devices"
+                    "auth"
                 ],
-                "summary": "Удаление устройства",
+                "summary": "Вход в систему",
                 "parameters": [
                     {
-                        "type": "string",
-                        "format": "uuid",
-                        "description": "ID устройства",
-                        "name": "id",
-                        "in": "path",
-                        "required": true
+                        "description": "Данные для входа",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/handlers.LoginRequest"
+                        }
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "Устройство удалено",
+                        "description": "Успешный вход",
                         "schema": {
-                            "type": "object",
-                            "additionalProperties": {
-                                "type": "boolean"
-                            }
+                            "$ref": "#/definitions/handlers.AuthResponse"
                         }
                     },
-                    "401": {
-                        "description": "Не авторизован",
+                    "400": {
+                        "description": "Неверный формат данных",
                         "schema": {
                             "type": "object",
                             "additionalProperties": {
@@ -529,8 +415,8 @@ const docTemplate = `{
                             }
                         }
                     },
-                    "403": {
-                        "description": "Нет доступа к устройству",
+                    "401": {
+                        "description": "Неверный email или пароль",
                         "schema": {
                             "type": "object",
                             "additionalProperties": {
@@ -538,8 +424,8 @@ const docTemplate = `{
                             }
                         }
                     },
-                    "404": {
-                        "description": "Устройство не найдено",
+                    "500": {
+                        "description": "Внутренняя ошибка сервера",
                         "schema": {
                             "type": "object",
                             "additionalProperties": {
@@ -550,9 +436,9 @@ const docTemplate = `{
                 }
             }
         },
-        "/devices/{id}/last-seen": {
+        "/auth/refresh": {
             "post": {
-                "description": "Обновляет время последней активности устройства (не требует аутентификации)",
+                "description": "Обновляет access и refresh токены используя валидный refresh token. Возвращает новую пару токенов.",
                 "consumes": [
                     "application/json"
                 ],
@@ -560,31 +446,32 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "devices"
+                    "auth"
                 ],
-                "summary": "Обновление времени последней активности",
+                "summary": "Обновление токенов",
                 "parameters": [
                     {
-                        "type": "string",
-                        "format": "uuid",
-                        "description": "ID устройства",
-                        "name": "id",
-                        "in": "path",
-                        "required": true
+                        "description": "Refresh token",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/handlers.RefreshRequest"
+                        }
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "Время обновлено",
+                        "description": "Токены успешно обновлены\" example:\"{\\\"access_token\\\":\\\"eyJhbGci...\\\",\\\"refresh_token\\\":\\\"eyJhbGci...\\\"}",
                         "schema": {
                             "type": "object",
                             "additionalProperties": {
-                                "type": "boolean"
+                                "type": "string"
                             }
                         }
                     },
                     "400": {
-                        "description": "Неверный ID устройства",
+                        "description": "Неверный формат данных\" example:\"{\\\"error\\\":\\\"invalid request format\\\"}",
                         "schema": {
                             "type": "object",
                             "additionalProperties": {
@@ -592,61 +479,17 @@ const docTemplate = `{
                             }
                         }
                     },
-                    "404": {
-                        "description": "Устройство не найдено",
+                    "401": {
+                        "description": "Невалидный или истекший refresh token\" example:\"{\\\"error\\\":\\\"invalid or expired refresh token\\\"}",
                         "schema": {
                             "type": "object",
                             "additionalProperties": {
                                 "type": "string"
                             }
                         }
-                    }
-                }
-            }
-        },
-        "/files": {
-            "get": {
-                "security": [
-                    {
-                        "BearerAuth": []
-                    }
-                ],
-                "description": "Возвращает список файлов пользователя с пагинацией",
-                "consumes": [
-                    "application/json"
-                ],
-                "produces": [
-                    "application/json"
-                ],
-                "tags": [
-                    "files"
-                ],
-                "summary": "Список файлов",
-                "parameters": [
-                    {
-                        "type": "integer",
-                        "default": 50,
-                        "description": "Лимит файлов",
-                        "name": "limit",
-                        "in": "query"
-                    },
-                    {
-                        "type": "integer",
-                        "default": 0,
-                        "description": "Смещение",
-                        "name": "offset",
-                        "in": "query"
-                    }
-                ],
-                "responses": {
-                    "200": {
-                        "description": "Список файлов",
-                        "schema": {
-                            "$ref": "#/definitions/handlers.ListFilesResponse"
-                        }
                     },
-                    "401": {
-                        "description": "Не авторизован",
+                    "500": {
+                        "description": "Внутренняя ошибка сервера\" example:\"{\\\"error\\\":\\\"failed to refresh token\\\"}",
                         "schema": {
                             "type": "object",
                             "additionalProperties": {
@@ -655,38 +498,37 @@ const docTemplate = `{
                         }
                     }
                 }
-            },
+            }
+        },
+        "/auth/register": {
             "post": {
-                "security": [
-                    {
-                        "BearerAuth": []
-                    }
-                ],
-                "description": "Загружает файл на сервер через multipart/form-data. Поддерживает потоковую загрузку больших файлов.",
+                "description": "Создает нового пользователя и возвращает JWT токены",
                 "consumes": [
-                    "multipart/form-data"
+                    "application/json"
                 ],
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "files"
+                    "auth"
                 ],
-                "summary": "Загрузка файла",
+                "summary": "Регистрация нового пользователя",
                 "parameters": [
                     {
-                        "type": "file",
-                        "description": "Файл для загрузки",
-                        "name": "file",
-                        "in": "formData",
-                        "required": true
+                        "description": "Данные для регистрации",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/handlers.RegisterRequest"
+                        }
                     }
                 ],
                 "responses": {
                     "201": {
-                        "description": "Файл успешно загружен",
+                        "description": "Пользователь успешно зарегистрирован",
                         "schema": {
-                            "$ref": "#/definitions/handlers.UploadFileResponse"
+                            "$ref": "#/definitions/handlers.AuthResponse"
                         }
                     },
                     "400": {
@@ -698,8 +540,8 @@ const docTemplate = `{
                             }
                         }
                     },
-                    "401": {
-                        "description": "Не авторизован",
+                    "409": {
+                        "description": "Пользователь с таким email уже существует",
                         "schema": {
                             "type": "object",
                             "additionalProperties": {
@@ -719,14 +561,14 @@ const docTemplate = `{
                 }
             }
         },
-        "/files/{id}": {
+        "/devices": {
             "get": {
                 "security": [
                     {
                         "BearerAuth": []
                     }
                 ],
-                "description": "Возвращает метаданные файла",
+                "description": "Возвращает список всех устройств пользователя или устройств организации. Устройства, отключенные из-за неактивности, возвращаются только с include_deactivated=true.",
                 "consumes": [
                     "application/json"
                 ],
@@ -734,24 +576,38 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "files"
+                    "devices"
                 ],
-                "summary": "Метаданные файла",
+                "summary": "Список устройств",
                 "parameters": [
                     {
                         "type": "string",
                         "format": "uuid",
-                        "description": "ID файла",
-                        "name": "id",
-                        "in": "path",
-                        "required": true
+                        "description": "ID организации",
+                        "name": "organization_id",
+                        "in": "query"
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "Включить отключенные устройства",
+                        "name": "include_deactivated",
+                        "in": "query"
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "Метаданные файла",
+                        "description": "Список устройств",
                         "schema": {
-                            "$ref": "#/definitions/handlers.FileResponse"
+                            "$ref": "#/definitions/handlers.ListDevicesResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Неверный ID организации",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
                         }
                     },
                     "401": {
@@ -764,7 +620,7 @@ const docTemplate = `{
                         }
                     },
                     "403": {
-                        "description": "Нет доступа к файлу",
+                        "description": "Пользователь не состоит в организации",
                         "schema": {
                             "type": "object",
                             "additionalProperties": {
@@ -772,8 +628,8 @@ const docTemplate = `{
                             }
                         }
                     },
-                    "404": {
-                        "description": "Файл не найден",
+                    "500": {
+                        "description": "Внутренняя ошибка сервера",
                         "schema": {
                             "type": "object",
                             "additionalProperties": {
@@ -783,13 +639,13 @@ const docTemplate = `{
                     }
                 }
             },
-            "delete": {
+            "post": {
                 "security": [
                     {
                         "BearerAuth": []
                     }
                 ],
-                "description": "Удаляет файл с сервера",
+                "description": "Регистрирует новое устройство для пользователя и возвращает device_token. Токен показывается только один раз. Если указан organization_id, устройство сразу регистрируется в организации. Первое устройство пользователя подтверждается автоматически, остальные ожидают подтверждения доверенным устройством (approval_status=pending).",
                 "consumes": [
                     "application/json"
                 ],
@@ -797,26 +653,33 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "files"
+                    "devices"
                 ],
-                "summary": "Удаление файла",
+                "summary": "Регистрация устройства",
                 "parameters": [
                     {
-                        "type": "string",
-                        "format": "uuid",
-                        "description": "ID файла",
-                        "name": "id",
-                        "in": "path",
-                        "required": true
+                        "description": "Данные устройства",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/handlers.RegisterDeviceRequest"
+                        }
                     }
                 ],
                 "responses": {
-                    "200": {
-                        "description": "Файл удален",
+                    "201": {
+                        "description": "Устройство успешно зарегистрировано",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.RegisterDeviceResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Неверный формат данных",
                         "schema": {
                             "type": "object",
                             "additionalProperties": {
-                                "type": "boolean"
+                                "type": "string"
                             }
                         }
                     },
@@ -830,7 +693,7 @@ const docTemplate = `{
                         }
                     },
                     "403": {
-                        "description": "Нет доступа к файлу",
+                        "description": "Пользователь не состоит в организации",
                         "schema": {
                             "type": "object",
                             "additionalProperties": {
@@ -838,8 +701,8 @@ const docTemplate = `{
                             }
                         }
                     },
-                    "404": {
-                        "description": "Файл не найден",
+                    "500": {
+                        "description": "Внутренняя ошибка сервера",
                         "schema": {
                             "type": "object",
                             "additionalProperties": {
@@ -850,75 +713,33 @@ const docTemplate = `{
                 }
             }
         },
-        "/files/{id}/download": {
+        "/devices/inactivity-policy": {
             "get": {
                 "security": [
                     {
                         "BearerAuth": []
                     }
                 ],
-                "description": "Скачивает файл с сервера (поддерживает Range requests для частичного скачивания). Использует потоковую передачу для больших файлов.",
+                "description": "Возвращает, через сколько дней без подключения устройства пользователя отключаются. За warning_period_seconds до отключения подключенные устройства получают событие device-inactivity-warning, а само устройство - push-уведомление.",
                 "consumes": [
                     "application/json"
                 ],
                 "produces": [
-                    "application/octet-stream"
+                    "application/json"
                 ],
                 "tags": [
-                    "files"
-                ],
-                "summary": "Скачивание файла",
-                "parameters": [
-                    {
-                        "type": "string",
-                        "format": "uuid",
-                        "description": "ID файла",
-                        "name": "id",
-                        "in": "path",
-                        "required": true
-                    },
-                    {
-                        "type": "integer",
-                        "default": 0,
-                        "description": "Смещение в байтах для частичного скачивания",
-                        "name": "offset",
-                        "in": "query"
-                    },
-                    {
-                        "type": "integer",
-                        "default": 0,
-                        "description": "Лимит байт для чтения (0 = весь файл)",
-                        "name": "limit",
-                        "in": "query"
-                    }
+                    "devices"
                 ],
+                "summary": "Политика неактивности устройств",
                 "responses": {
                     "200": {
-                        "description": "Файл (бинарные данные)",
-                        "schema": {
-                            "type": "file"
-                        }
-                    },
-                    "400": {
-                        "description": "Неверный ID файла\" example:\"{\\\"error\\\":\\\"file_id is required\\\"}",
+                        "description": "Политика неактивности",
                         "schema": {
-                            "type": "object",
-                            "additionalProperties": {
-                                "type": "string"
-                            }
+                            "$ref": "#/definitions/handlers.InactivityPolicy"
                         }
                     },
                     "401": {
-                        "description": "Не авторизован\" example:\"{\\\"error\\\":\\\"unauthorized\\\"}",
-                        "schema": {
-                            "type": "object",
-                            "additionalProperties": {
-                                "type": "string"
-                            }
-                        }
-                    },
-                    "403": {
-                        "description": "Нет доступа к файлу\" example:\"{\\\"error\\\":\\\"permission denied\\\"}",
+                        "description": "Не авторизован",
                         "schema": {
                             "type": "object",
                             "additionalProperties": {
@@ -927,7 +748,7 @@ const docTemplate = `{
                         }
                     },
                     "404": {
-                        "description": "Файл не найден\" example:\"{\\\"error\\\":\\\"file not found\\\"}",
+                        "description": "Пользователь не найден",
                         "schema": {
                             "type": "object",
                             "additionalProperties": {
@@ -936,7 +757,7 @@ const docTemplate = `{
                         }
                     },
                     "500": {
-                        "description": "Внутренняя ошибка сервера\" example:\"{\\\"error\\\":\\\"failed to download file\\\"}",
+                        "description": "Внутренняя ошибка сервера",
                         "schema": {
                             "type": "object",
                             "additionalProperties": {
@@ -945,16 +766,14 @@ const docTemplate = `{
                         }
                     }
                 }
-            }
-        },
-        "/webrtc/turn-credentials": {
-            "get": {
+            },
+            "put": {
                 "security": [
                     {
                         "BearerAuth": []
                     }
                 ],
-                "description": "Возвращает TURN серверы и credentials для WebRTC",
+                "description": "Задает порог неактивности в днях (0 - не отключать устройства) или возвращает порог по умолчанию (use_default=true)",
                 "consumes": [
                     "application/json"
                 ],
@@ -962,14 +781,262 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "webrtc"
+                    "devices"
                 ],
-                "summary": "Получение TURN credentials",
-                "responses": {
-                    "200": {
-                        "description": "TURN credentials",
+                "summary": "Изменение политики неактивности устройств",
+                "parameters": [
+                    {
+                        "description": "Порог неактивности",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
                         "schema": {
-                            "$ref": "#/definitions/handlers.TurnCredentialsResponse"
+                            "$ref": "#/definitions/handlers.UpdateInactivityPolicyRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Политика обновлена",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.InactivityPolicy"
+                        }
+                    },
+                    "400": {
+                        "description": "Неверный формат данных",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "401": {
+                        "description": "Не авторизован",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "404": {
+                        "description": "Пользователь не найден",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "500": {
+                        "description": "Внутренняя ошибка сервера",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/devices/pairing": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Авторизованное подтвержденное устройство запрашивает короткий цифровой код (и payload для QR-кода), по которому новое устройство регистрируется без ввода пароля и сразу считается подтвержденным",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "devices"
+                ],
+                "summary": "Код сопряжения устройства",
+                "parameters": [
+                    {
+                        "description": "Устройство, запрашивающее код",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/handlers.CreatePairingCodeRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Код сопряжения",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.PairingCodeResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Неверный формат данных",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "401": {
+                        "description": "Не авторизован",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "403": {
+                        "description": "Нет доступа к устройству",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "404": {
+                        "description": "Устройство не найдено",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "500": {
+                        "description": "Внутренняя ошибка сервера",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/devices/pairing/redeem": {
+            "post": {
+                "description": "Новое устройство погашает код сопряжения и получает device_token для того же пользователя (не требует аутентификации). Число попыток с одного адреса ограничено.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "devices"
+                ],
+                "summary": "Регистрация устройства по коду сопряжения",
+                "parameters": [
+                    {
+                        "description": "Код и данные устройства",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/handlers.RedeemPairingCodeRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Устройство зарегистрировано",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.RegisterDeviceResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Неверный формат данных",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "404": {
+                        "description": "Код не найден или истек",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "429": {
+                        "description": "Слишком много попыток",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "500": {
+                        "description": "Внутренняя ошибка сервера",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/devices/{id}": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Возвращает информацию об устройстве по ID",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "devices"
+                ],
+                "summary": "Получение устройства",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "format": "uuid",
+                        "description": "ID устройства",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Информация об устройстве",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.DeviceResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Неверный ID устройства",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
                         }
                     },
                     "401": {
@@ -980,217 +1047,4141 @@ const docTemplate = `{
                                 "type": "string"
                             }
                         }
+                    },
+                    "403": {
+                        "description": "Нет доступа к устройству",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "404": {
+                        "description": "Устройство не найдено",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
                     }
                 }
+            },
+            "put": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Обновляет информацию об устройстве",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "devices"
+                ],
+                "summary": "Обновление устройства",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "format": "uuid",
+                        "description": "ID устройства",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Данные для обновления",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/handlers.UpdateDeviceRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Устройство обновлено",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.DeviceResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Неверный формат данных",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "401": {
+                        "description": "Не авторизован",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "403": {
+                        "description": "Нет доступа к устройству",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "404": {
+                        "description": "Устройство не найдено",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "500": {
+                        "description": "Внутренняя ошибка сервера",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Удаляет устройство пользователя",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "devices"
+                ],
+                "summary": "Удаление устройства",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "format": "uuid",
+                        "description": "ID устройства",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Устройство удалено",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "boolean"
+                            }
+                        }
+                    },
+                    "401": {
+                        "description": "Не авторизован",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "403": {
+                        "description": "Нет доступа к устройству",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "404": {
+                        "description": "Устройство не найдено",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/devices/{id}/approve": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Подтверждает ожидающее устройство. Решение принимает доверенное (подтвержденное) устройство пользователя, передавая свой device_token. Остальные устройства пользователя получают событие device-approved.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "devices"
+                ],
+                "summary": "Подтверждение устройства",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "format": "uuid",
+                        "description": "ID подтверждаемого устройства",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Токен доверенного устройства",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/handlers.DeviceApprovalRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Устройство подтверждено",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.DeviceResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Неверный формат данных",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "401": {
+                        "description": "Не авторизован",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "403": {
+                        "description": "Устройство не может принимать решение",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "404": {
+                        "description": "Устройство не найдено",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "409": {
+                        "description": "Устройство не ожидает подтверждения",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "500": {
+                        "description": "Внутренняя ошибка сервера",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/devices/{id}/inbox": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Возвращает облачные передачи, которые ждут устройство пользователя. Файл скачивается через /files/{id}/download до подтверждения получения.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "transfers"
+                ],
+                "summary": "Входящие устройства",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "format": "uuid",
+                        "description": "ID устройства",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Входящие устройства",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ListInboxResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Неверный ID устройства",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "401": {
+                        "description": "Не авторизован",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "403": {
+                        "description": "Устройство принадлежит другому пользователю",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "404": {
+                        "description": "Устройство не найдено",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "500": {
+                        "description": "Внутренняя ошибка сервера",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/devices/{id}/inbox/{transfer_id}/ack": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Подтверждает, что устройство скачало файл из входящих, и завершает передачу. Когда файл получили все устройства, он удаляется с сервера по истечении срока хранения.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "transfers"
+                ],
+                "summary": "Подтверждение получения файла",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "format": "uuid",
+                        "description": "ID устройства",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "format": "uuid",
+                        "description": "ID передачи",
+                        "name": "transfer_id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Передача завершена",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.TransferResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Неверный ID",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "401": {
+                        "description": "Не авторизован",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "403": {
+                        "description": "Устройство принадлежит другому пользователю",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "404": {
+                        "description": "Файл не найден во входящих",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "409": {
+                        "description": "Получение уже подтверждено или передача завершена",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "500": {
+                        "description": "Внутренняя ошибка сервера",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/devices/{id}/last-seen": {
+            "post": {
+                "description": "Обновляет время последней активности устройства (не требует аутентификации)",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "devices"
+                ],
+                "summary": "Обновление времени последней активности",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "format": "uuid",
+                        "description": "ID устройства",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Время обновлено",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "boolean"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Неверный ID устройства",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "404": {
+                        "description": "Устройство не найдено",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/devices/{id}/organization": {
+            "put": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Регистрирует устройство в организации пользователя. Пустой organization_id отвязывает устройство от организации.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "devices"
+                ],
+                "summary": "Привязка устройства к организации",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "format": "uuid",
+                        "description": "ID устройства",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "ID организации",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/handlers.SetDeviceOrganizationRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Устройство обновлено",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.DeviceResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Неверный формат данных",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "401": {
+                        "description": "Не авторизован",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "403": {
+                        "description": "Нет доступа к устройству или организации",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "404": {
+                        "description": "Устройство не найдено",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "500": {
+                        "description": "Внутренняя ошибка сервера",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/devices/{id}/prune-exempt": {
+            "put": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Исключает устройство из автоматического отключения по неактивности или возвращает его под действие политики. Устройство организации могут настроить также владелец и администраторы организации.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "devices"
+                ],
+                "summary": "Исключение устройства из отключения по неактивности",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "format": "uuid",
+                        "description": "ID устройства",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Исключение",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/handlers.SetDevicePruneExemptRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Устройство обновлено",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.DeviceResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Неверный формат данных",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "401": {
+                        "description": "Не авторизован",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "403": {
+                        "description": "Нет доступа к устройству",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "404": {
+                        "description": "Устройство не найдено",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "500": {
+                        "description": "Внутренняя ошибка сервера",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/devices/{id}/push-token": {
+            "put": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Сохраняет токен FCM, APNs или подписку Web Push (JSON PushSubscription с HTTPS endpoint push-сервиса браузера). Когда устройство не подключено к signaling серверу, входящие передачи и offer будят приложение push-уведомлением.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "devices"
+                ],
+                "summary": "Регистрация push-токена устройства",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "format": "uuid",
+                        "description": "ID устройства",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Провайдер и токен",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/handlers.SetPushTokenRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Токен сохранен",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.DeviceResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Неверный формат данных",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "401": {
+                        "description": "Не авторизован",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "403": {
+                        "description": "Нет доступа к устройству",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "404": {
+                        "description": "Устройство не найдено",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "500": {
+                        "description": "Внутренняя ошибка сервера",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Удаляет push-токен устройства",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "devices"
+                ],
+                "summary": "Отключение push-уведомлений устройства",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "format": "uuid",
+                        "description": "ID устройства",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Токен удален",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.DeviceResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Неверный ID устройства",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "401": {
+                        "description": "Не авторизован",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "403": {
+                        "description": "Нет доступа к устройству",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "404": {
+                        "description": "Устройство не найдено",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "500": {
+                        "description": "Внутренняя ошибка сервера",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/devices/{id}/reject": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Отклоняет ожидающее устройство: оно не сможет подключаться к signaling серверу и участвовать в передачах. Решение принимает доверенное устройство пользователя, передавая свой device_token.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "devices"
+                ],
+                "summary": "Отклонение устройства",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "format": "uuid",
+                        "description": "ID отклоняемого устройства",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Токен доверенного устройства",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/handlers.DeviceApprovalRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Устройство отклонено",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.DeviceResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Неверный формат данных",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "401": {
+                        "description": "Не авторизован",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "403": {
+                        "description": "Устройство не может принимать решение",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "404": {
+                        "description": "Устройство не найдено",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "409": {
+                        "description": "Устройство не ожидает подтверждения",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "500": {
+                        "description": "Внутренняя ошибка сервера",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/devices/{id}/token/rotate": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Выпускает новый device_token (показывается один раз). Старый токен остается действительным до previous_token_expires_at.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "devices"
+                ],
+                "summary": "Ротация токена устройства",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "format": "uuid",
+                        "description": "ID устройства",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Новый токен устройства",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.RotateDeviceTokenResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Неверный ID устройства",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "401": {
+                        "description": "Не авторизован",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "403": {
+                        "description": "Нет доступа к устройству",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "404": {
+                        "description": "Устройство не найдено",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "409": {
+                        "description": "Устройство деактивировано",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "500": {
+                        "description": "Внутренняя ошибка сервера",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/files": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Возвращает список личных файлов пользователя или файлов организации с пагинацией",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "files"
+                ],
+                "summary": "Список файлов",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "default": 50,
+                        "description": "Лимит файлов",
+                        "name": "limit",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "default": 0,
+                        "description": "Смещение",
+                        "name": "offset",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "format": "uuid",
+                        "description": "ID организации",
+                        "name": "organization_id",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Список файлов",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ListFilesResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Неверный ID организации",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "401": {
+                        "description": "Не авторизован",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "403": {
+                        "description": "Пользователь не состоит в организации",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            },
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Загружает файл на сервер через multipart/form-data. Поддерживает потоковую загрузку больших файлов.",
+                "consumes": [
+                    "multipart/form-data"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "files"
+                ],
+                "summary": "Загрузка файла",
+                "parameters": [
+                    {
+                        "type": "file",
+                        "description": "Файл для загрузки",
+                        "name": "file",
+                        "in": "formData",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "format": "uuid",
+                        "description": "ID организации для загрузки в общее хранилище",
+                        "name": "organization_id",
+                        "in": "formData"
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Файл успешно загружен",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.UploadFileResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Неверный формат данных",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "401": {
+                        "description": "Не авторизован",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "403": {
+                        "description": "Пользователь не состоит в организации",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "404": {
+                        "description": "Организация не найдена",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "413": {
+                        "description": "Превышена квота хранилища организации",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "500": {
+                        "description": "Внутренняя ошибка сервера",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/files/{id}": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Возвращает метаданные файла",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "files"
+                ],
+                "summary": "Метаданные файла",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "format": "uuid",
+                        "description": "ID файла",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Метаданные файла",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.FileResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Не авторизован",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "403": {
+                        "description": "Нет доступа к файлу",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "404": {
+                        "description": "Файл не найден",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Удаляет файл с сервера",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "files"
+                ],
+                "summary": "Удаление файла",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "format": "uuid",
+                        "description": "ID файла",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Файл удален",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "boolean"
+                            }
+                        }
+                    },
+                    "401": {
+                        "description": "Не авторизован",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "403": {
+                        "description": "Нет доступа к файлу",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "404": {
+                        "description": "Файл не найден",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/files/{id}/download": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Скачивает файл с сервера (поддерживает Range requests для частичного скачивания). Использует потоковую передачу для больших файлов.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/octet-stream"
+                ],
+                "tags": [
+                    "files"
+                ],
+                "summary": "Скачивание файла",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "format": "uuid",
+                        "description": "ID файла",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "default": 0,
+                        "description": "Смещение в байтах для частичного скачивания",
+                        "name": "offset",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "default": 0,
+                        "description": "Лимит байт для чтения (0 = весь файл)",
+                        "name": "limit",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Файл (бинарные данные)",
+                        "schema": {
+                            "type": "file"
+                        }
+                    },
+                    "400": {
+                        "description": "Неверный ID файла\" example:\"{\\\"error\\\":\\\"file_id is required\\\"}",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "401": {
+                        "description": "Не авторизован\" example:\"{\\\"error\\\":\\\"unauthorized\\\"}",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "403": {
+                        "description": "Нет доступа к файлу\" example:\"{\\\"error\\\":\\\"permission denied\\\"}",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "404": {
+                        "description": "Файл не найден\" example:\"{\\\"error\\\":\\\"file not found\\\"}",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "500": {
+                        "description": "Внутренняя ошибка сервера\" example:\"{\\\"error\\\":\\\"failed to download file\\\"}",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/organizations": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Возвращает организации, в которых состоит пользователь, с его ролью и использованием хранилища",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "organizations"
+                ],
+                "summary": "Список организаций",
+                "responses": {
+                    "200": {
+                        "description": "Список организаций",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ListOrganizationsResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Не авторизован",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "500": {
+                        "description": "Внутренняя ошибка сервера",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            },
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Создает организацию с общим хранилищем, текущий пользователь становится владельцем. storage_quota_bytes = 0 - без ограничения.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "organizations"
+                ],
+                "summary": "Создание организации",
+                "parameters": [
+                    {
+                        "description": "Данные организации",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/handlers.CreateOrganizationRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Организация создана",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.OrganizationResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Неверный формат данных",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "401": {
+                        "description": "Не авторизован",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "500": {
+                        "description": "Внутренняя ошибка сервера",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/organizations/{id}": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Возвращает информацию об организации, квоте и использовании хранилища",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "organizations"
+                ],
+                "summary": "Получение организации",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "format": "uuid",
+                        "description": "ID организации",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Информация об организации",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.OrganizationResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Неверный ID организации",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "401": {
+                        "description": "Не авторизован",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "403": {
+                        "description": "Пользователь не состоит в организации",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "404": {
+                        "description": "Организация не найдена",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            },
+            "put": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Изменяет название и квоту хранилища организации (владелец или администратор). Не переданные поля не меняются, storage_quota_bytes = 0 снимает ограничение.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "organizations"
+                ],
+                "summary": "Обновление организации",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "format": "uuid",
+                        "description": "ID организации",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Данные для обновления",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/handlers.UpdateOrganizationRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Организация обновлена",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.OrganizationResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Неверный формат данных",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "401": {
+                        "description": "Не авторизован",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "403": {
+                        "description": "Недостаточно прав",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "404": {
+                        "description": "Организация не найдена",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Удаляет организацию вместе с ее файлами (только владелец). Устройства отвязываются от организации.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "organizations"
+                ],
+                "summary": "Удаление организации",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "format": "uuid",
+                        "description": "ID организации",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Организация удалена",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "boolean"
+                            }
+                        }
+                    },
+                    "401": {
+                        "description": "Не авторизован",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "403": {
+                        "description": "Недостаточно прав",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "404": {
+                        "description": "Организация не найдена",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/organizations/{id}/members": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Возвращает список участников организации с их ролями",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "organizations"
+                ],
+                "summary": "Участники организации",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "format": "uuid",
+                        "description": "ID организации",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Список участников",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ListOrganizationMembersResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Не авторизован",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "403": {
+                        "description": "Пользователь не состоит в организации",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "404": {
+                        "description": "Организация не найдена",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            },
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Добавляет зарегистрированного пользователя в организацию по email (владелец или администратор)",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "organizations"
+                ],
+                "summary": "Добавление участника",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "format": "uuid",
+                        "description": "ID организации",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Email и роль участника",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/handlers.AddOrganizationMemberRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Участник добавлен",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.OrganizationMemberResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Неверный формат данных",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "401": {
+                        "description": "Не авторизован",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "403": {
+                        "description": "Недостаточно прав",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "404": {
+                        "description": "Организация или пользователь не найдены",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "409": {
+                        "description": "Пользователь уже состоит в организации",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/organizations/{id}/members/{user_id}": {
+            "put": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Изменяет роль участника. Назначать и снимать владельцев может только владелец; последнего владельца понизить нельзя.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "organizations"
+                ],
+                "summary": "Изменение роли участника",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "format": "uuid",
+                        "description": "ID организации",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "format": "uuid",
+                        "description": "ID пользователя-участника",
+                        "name": "user_id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Новая роль",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/handlers.UpdateOrganizationMemberRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Роль изменена",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.OrganizationMemberResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Неверный формат данных",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "401": {
+                        "description": "Не авторизован",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "403": {
+                        "description": "Недостаточно прав",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "404": {
+                        "description": "Организация или участник не найдены",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "409": {
+                        "description": "Нельзя оставить организацию без владельца",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Исключает участника из организации (владелец или администратор) либо выход из организации самого пользователя. Устройства участника отвязываются от организации.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "organizations"
+                ],
+                "summary": "Удаление участника",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "format": "uuid",
+                        "description": "ID организации",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "format": "uuid",
+                        "description": "ID пользователя-участника",
+                        "name": "user_id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Участник удален",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "boolean"
+                            }
+                        }
+                    },
+                    "401": {
+                        "description": "Не авторизован",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "403": {
+                        "description": "Недостаточно прав",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "404": {
+                        "description": "Организация или участник не найдены",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "409": {
+                        "description": "Нельзя оставить организацию без владельца",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/signaling/ticket": {
+            "post": {
+                "description": "Выпускает короткоживущий билет, которым устройство аутентифицируется на WebSocket signaling сервере вместо device_token (не требует аутентификации пользователя)",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "signaling"
+                ],
+                "summary": "Билет для подключения к signaling серверу",
+                "parameters": [
+                    {
+                        "description": "Устройство и его токен",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/handlers.SignalingTicketRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Билет выпущен",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.SignalingTicketResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Неверный формат данных",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "401": {
+                        "description": "Неверный токен устройства",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "403": {
+                        "description": "Устройство не подтверждено",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "500": {
+                        "description": "Внутренняя ошибка сервера",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/transfers": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Возвращает передачи, в которых участвуют файлы или устройства пользователя. Фильтры device_id (с направлением direction), file_id, group_id и status объединяются через AND.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "transfers"
+                ],
+                "summary": "Список передач",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "format": "uuid",
+                        "description": "ID устройства",
+                        "name": "device_id",
+                        "in": "query"
+                    },
+                    {
+                        "enum": [
+                            "incoming",
+                            "outgoing"
+                        ],
+                        "type": "string",
+                        "description": "Направление относительно устройства",
+                        "name": "direction",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "format": "uuid",
+                        "description": "ID файла",
+                        "name": "file_id",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "format": "uuid",
+                        "description": "ID группы передач",
+                        "name": "group_id",
+                        "in": "query"
+                    },
+                    {
+                        "enum": [
+                            "pending",
+                            "in_progress",
+                            "paused",
+                            "completed",
+                            "failed",
+                            "cancelled",
+                            "rejected",
+                            "expired"
+                        ],
+                        "type": "string",
+                        "description": "Статус",
+                        "name": "status",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "default": 50,
+                        "description": "Лимит передач",
+                        "name": "limit",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "default": 0,
+                        "description": "Смещение",
+                        "name": "offset",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Список передач",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ListTransfersResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Неверные параметры фильтра",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "401": {
+                        "description": "Не авторизован",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "500": {
+                        "description": "Внутренняя ошибка сервера",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            },
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Создает передачу файла с устройства пользователя. Файл должен быть доступен пользователю, устройство получателя - принадлежать ему или его организации.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "transfers"
+                ],
+                "summary": "Создание передачи",
+                "parameters": [
+                    {
+                        "description": "Данные передачи",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/handlers.CreateTransferRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Передача создана",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.TransferResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Неверный формат данных",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "401": {
+                        "description": "Не авторизован",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "403": {
+                        "description": "Нет доступа к файлу или устройству",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "404": {
+                        "description": "Файл или устройство не найдены",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "409": {
+                        "description": "Устройства не подтверждены или не поддерживают передачу",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "500": {
+                        "description": "Внутренняя ошибка сервера",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/transfers/groups": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Создает группу передач: по передаче на каждое устройство из to_device_ids. С all_devices файл отправляется на все устройства пользователя, кроме отправителя; устройства, которые не могут его принять, пропускаются.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "transfers"
+                ],
+                "summary": "Отправка файла нескольким устройствам",
+                "parameters": [
+                    {
+                        "description": "Файл и получатели",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/handlers.CreateTransferGroupRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Группа создана",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.TransferGroupResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Неверный формат данных",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "401": {
+                        "description": "Не авторизован",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "403": {
+                        "description": "Нет доступа к файлу или устройству",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "404": {
+                        "description": "Файл или устройство не найдены",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "409": {
+                        "description": "Ни одно устройство не может принять файл",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "500": {
+                        "description": "Внутренняя ошибка сервера",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/transfers/groups/{id}": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Возвращает передачи получателей и сводное состояние группы: статус, средний прогресс и число завершенных передач. Доступно отправителю.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "transfers"
+                ],
+                "summary": "Получение группы передач",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "format": "uuid",
+                        "description": "ID группы",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Группа передач",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.TransferGroupResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Неверный ID группы",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "401": {
+                        "description": "Не авторизован",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "403": {
+                        "description": "Группа принадлежит другому пользователю",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "404": {
+                        "description": "Группа не найдена",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "500": {
+                        "description": "Внутренняя ошибка сервера",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/transfers/{id}": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Возвращает передачу, в которой участвует устройство или файл пользователя",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "transfers"
+                ],
+                "summary": "Получение передачи",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "format": "uuid",
+                        "description": "ID передачи",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Передача",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.TransferResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Неверный ID передачи",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "401": {
+                        "description": "Не авторизован",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "403": {
+                        "description": "Нет доступа к передаче",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "404": {
+                        "description": "Передача не найдена",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "500": {
+                        "description": "Внутренняя ошибка сервера",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/transfers/{id}/cancel": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Переводит незавершенную передачу в статус cancelled, сохраняя достигнутый прогресс",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "transfers"
+                ],
+                "summary": "Отмена передачи",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "format": "uuid",
+                        "description": "ID передачи",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Причина отмены",
+                        "name": "request",
+                        "in": "body",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.CancelTransferRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Передача отменена",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.TransferResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Неверный формат данных",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "401": {
+                        "description": "Не авторизован",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "403": {
+                        "description": "Нет доступа к передаче",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "404": {
+                        "description": "Передача не найдена",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "409": {
+                        "description": "Передача уже завершена",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "500": {
+                        "description": "Внутренняя ошибка сервера",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/transfers/{id}/history": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Возвращает смены статуса и способа доставки передачи в хронологическом порядке. Событие с одинаковыми from_status и to_status - переход P2P передачи на запасной способ доставки (relay или cloud); delivery_path последнего события завершенной передачи - способ, которым доставлен файл.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "transfers"
+                ],
+                "summary": "История передачи",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "format": "uuid",
+                        "description": "ID передачи",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "История передачи",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.TransferHistoryResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Неверный ID передачи",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "401": {
+                        "description": "Не авторизован",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "403": {
+                        "description": "Нет доступа к передаче",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "404": {
+                        "description": "Передача не найдена",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "500": {
+                        "description": "Внутренняя ошибка сервера",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/transfers/{id}/progress": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Поток событий progress с состоянием передачи: сначала текущее состояние, затем каждое обновление со скоростью и оставшимся временем. Поток закрывается, когда передача переходит в завершающий статус (completed, failed, cancelled, rejected, expired).",
+                "produces": [
+                    "text/event-stream"
+                ],
+                "tags": [
+                    "transfers"
+                ],
+                "summary": "Прогресс передачи (Server-Sent Events)",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "format": "uuid",
+                        "description": "ID передачи",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Поток событий progress",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.TransferProgressResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Неверный ID передачи",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "401": {
+                        "description": "Не авторизован",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "403": {
+                        "description": "Нет доступа к передаче",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "404": {
+                        "description": "Передача не найдена",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "500": {
+                        "description": "Внутренняя ошибка сервера",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/transfers/{id}/status": {
+            "put": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Меняет статус передачи по таблице переходов или, при том же статусе, обновляет прогресс. Если указан bytes_transferred, progress считается по размеру файла.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "transfers"
+                ],
+                "summary": "Обновление статуса передачи",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "format": "uuid",
+                        "description": "ID передачи",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Новый статус и прогресс",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/handlers.UpdateTransferStatusRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Передача обновлена",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.TransferResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Неверный формат данных",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "401": {
+                        "description": "Не авторизован",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "403": {
+                        "description": "Нет доступа к передаче",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "404": {
+                        "description": "Передача не найдена",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "409": {
+                        "description": "Недопустимый переход статуса",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "500": {
+                        "description": "Внутренняя ошибка сервера",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/webrtc/turn-credentials": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Возвращает TURN серверы и credentials для WebRTC",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "webrtc"
+                ],
+                "summary": "Получение TURN credentials",
+                "responses": {
+                    "200": {
+                        "description": "TURN credentials",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.TurnCredentialsResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Не авторизован",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            }
+        }
+    },
+    "definitions": {
+        "handlers.AccountDeletionResponse": {
+            "type": "object",
+            "properties": {
+                "pending": {
+                    "type": "boolean",
+                    "example": true
+                },
+                "requested_at": {
+                    "type": "string",
+                    "example": "2024-01-01T00:00:00Z"
+                },
+                "scheduled_at": {
+                    "type": "string",
+                    "example": "2024-01-31T00:00:00Z"
+                }
+            }
+        },
+        "handlers.AddOrganizationMemberRequest": {
+            "type": "object",
+            "required": [
+                "email"
+            ],
+            "properties": {
+                "email": {
+                    "type": "string",
+                    "example": "colleague@example.com"
+                },
+                "role": {
+                    "type": "string",
+                    "enum": [
+                        "owner",
+                        "admin",
+                        "member"
+                    ],
+                    "example": "member"
+                }
+            }
+        },
+        "handlers.AuthResponse": {
+            "type": "object",
+            "properties": {
+                "access_token": {
+                    "type": "string",
+                    "example": "eyJhbGciOiJIUzI1NiIsInR5cCI6IkpXVCJ9..."
+                },
+                "refresh_token": {
+                    "type": "string",
+                    "example": "eyJhbGciOiJIUzI1NiIsInR5cCI6IkpXVCJ9..."
+                },
+                "user": {
+                    "$ref": "#/definitions/handlers.UserResponse"
+                }
+            }
+        },
+        "handlers.CancelTransferRequest": {
+            "type": "object",
+            "properties": {
+                "reason": {
+                    "type": "string",
+                    "example": "cancelled by user"
+                }
+            }
+        },
+        "handlers.CreateOrganizationRequest": {
+            "type": "object",
+            "required": [
+                "name"
+            ],
+            "properties": {
+                "name": {
+                    "type": "string",
+                    "example": "Acme Inc"
+                },
+                "storage_quota_bytes": {
+                    "type": "integer",
+                    "minimum": 0,
+                    "example": 10737418240
+                }
+            }
+        },
+        "handlers.CreatePairingCodeRequest": {
+            "type": "object",
+            "required": [
+                "device_id"
+            ],
+            "properties": {
+                "device_id": {
+                    "type": "string",
+                    "example": "550e8400-e29b-41d4-a716-446655440000"
+                }
+            }
+        },
+        "handlers.CreateTransferGroupRequest": {
+            "type": "object",
+            "required": [
+                "file_id",
+                "from_device_id",
+                "transfer_type"
+            ],
+            "properties": {
+                "all_devices": {
+                    "description": "на все устройства пользователя, кроме отправителя",
+                    "type": "boolean",
+                    "example": false
+                },
+                "file_id": {
+                    "type": "string",
+                    "example": "550e8400-e29b-41d4-a716-446655440000"
+                },
+                "from_device_id": {
+                    "type": "string",
+                    "example": "550e8400-e29b-41d4-a716-446655440000"
+                },
+                "to_device_ids": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    },
+                    "example": [
+                        "550e8400-e29b-41d4-a716-446655440001",
+                        "550e8400-e29b-41d4-a716-446655440002"
+                    ]
+                },
+                "transfer_type": {
+                    "type": "string",
+                    "enum": [
+                        "p2p",
+                        "cloud"
+                    ],
+                    "example": "cloud"
+                }
+            }
+        },
+        "handlers.CreateTransferRequest": {
+            "type": "object",
+            "required": [
+                "file_id",
+                "from_device_id",
+                "transfer_type"
+            ],
+            "properties": {
+                "file_id": {
+                    "type": "string",
+                    "example": "550e8400-e29b-41d4-a716-446655440000"
+                },
+                "from_device_id": {
+                    "type": "string",
+                    "example": "550e8400-e29b-41d4-a716-446655440000"
+                },
+                "to_device_id": {
+                    "type": "string",
+                    "example": "550e8400-e29b-41d4-a716-446655440000"
+                },
+                "transfer_type": {
+                    "type": "string",
+                    "enum": [
+                        "p2p",
+                        "cloud"
+                    ],
+                    "example": "p2p"
+                }
+            }
+        },
+        "handlers.DataExportResponse": {
+            "type": "object",
+            "properties": {
+                "created_at": {
+                    "type": "string",
+                    "example": "2024-01-01T00:00:00Z"
+                },
+                "error": {
+                    "type": "string",
+                    "example": ""
+                },
+                "expires_at": {
+                    "type": "string",
+                    "example": "2024-01-08T00:00:00Z"
+                },
+                "id": {
+                    "type": "string",
+                    "example": "550e8400-e29b-41d4-a716-446655440000"
+                },
+                "size": {
+                    "type": "integer",
+                    "example": 1024000
+                },
+                "status": {
+                    "type": "string",
+                    "example": "completed"
+                },
+                "updated_at": {
+                    "type": "string",
+                    "example": "2024-01-01T00:00:00Z"
+                },
+                "user_id": {
+                    "type": "string",
+                    "example": "550e8400-e29b-41d4-a716-446655440000"
+                }
+            }
+        },
+        "handlers.DeviceApprovalRequest": {
+            "type": "object",
+            "required": [
+                "device_token"
+            ],
+            "properties": {
+                "device_token": {
+                    "type": "string",
+                    "example": "q9Zb3c0n6l1i4t0k2e8n5s7a3m9p1l0e4x6y2z8w0v5"
+                }
+            }
+        },
+        "handlers.DeviceInfo": {
+            "type": "object",
+            "properties": {
+                "app_version": {
+                    "type": "string",
+                    "example": "1.2.0"
+                },
+                "max_file_size": {
+                    "type": "integer",
+                    "minimum": 1,
+                    "example": 10737418240
+                },
+                "os_version": {
+                    "type": "string",
+                    "example": "14.4"
+                },
+                "platform": {
+                    "type": "string",
+                    "enum": [
+                        "windows",
+                        "macos",
+                        "linux",
+                        "ios",
+                        "android",
+                        "web",
+                        "other"
+                    ],
+                    "example": "macos"
+                },
+                "transfer_protocols": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    },
+                    "example": [
+                        "webrtc",
+                        "relay",
+                        "cloud"
+                    ]
+                }
+            }
+        },
+        "handlers.DeviceResponse": {
+            "type": "object",
+            "properties": {
+                "approval_status": {
+                    "type": "string",
+                    "enum": [
+                        "pending",
+                        "approved",
+                        "rejected"
+                    ],
+                    "example": "approved"
+                },
+                "connected_since": {
+                    "type": "string",
+                    "example": "2024-01-01T00:00:00Z"
+                },
+                "created_at": {
+                    "type": "string",
+                    "example": "2024-01-01T00:00:00Z"
+                },
+                "deactivated_at": {
+                    "type": "string",
+                    "example": "2024-01-01T00:00:00Z"
+                },
+                "device_type": {
+                    "type": "string",
+                    "example": "desktop"
+                },
+                "id": {
+                    "type": "string",
+                    "example": "550e8400-e29b-41d4-a716-446655440000"
+                },
+                "info": {
+                    "$ref": "#/definitions/handlers.DeviceInfo"
+                },
+                "last_seen_at": {
+                    "type": "string",
+                    "example": "2024-01-01T00:00:00Z"
+                },
+                "name": {
+                    "type": "string",
+                    "example": "My Desktop"
+                },
+                "organization_id": {
+                    "type": "string",
+                    "example": "550e8400-e29b-41d4-a716-446655440000"
+                },
+                "presence_status": {
+                    "type": "string",
+                    "enum": [
+                        "online",
+                        "away",
+                        "offline"
+                    ],
+                    "example": "online"
+                },
+                "prune_exempt": {
+                    "type": "boolean",
+                    "example": false
+                },
+                "push_provider": {
+                    "type": "string",
+                    "enum": [
+                        "fcm",
+                        "apns",
+                        "webpush"
+                    ],
+                    "example": "fcm"
+                },
+                "updated_at": {
+                    "type": "string",
+                    "example": "2024-01-01T00:00:00Z"
+                },
+                "user_id": {
+                    "type": "string",
+                    "example": "550e8400-e29b-41d4-a716-446655440000"
+                }
+            }
+        },
+        "handlers.FileResponse": {
+            "type": "object",
+            "properties": {
+                "created_at": {
+                    "type": "string",
+                    "example": "2024-01-01T00:00:00Z"
+                },
+                "expires_at": {
+                    "type": "string",
+                    "example": "2024-02-01T00:00:00Z"
+                },
+                "id": {
+                    "type": "string",
+                    "example": "550e8400-e29b-41d4-a716-446655440000"
+                },
+                "mime_type": {
+                    "type": "string",
+                    "example": "application/pdf"
+                },
+                "name": {
+                    "type": "string",
+                    "example": "document.pdf"
+                },
+                "organization_id": {
+                    "type": "string",
+                    "example": "550e8400-e29b-41d4-a716-446655440000"
+                },
+                "size": {
+                    "type": "integer",
+                    "example": 1024000
+                },
+                "storage_path": {
+                    "type": "string",
+                    "example": "users/550e8400-e29b-41d4-a716-446655440000/files/..."
+                },
+                "storage_type": {
+                    "type": "string",
+                    "example": "local"
+                },
+                "updated_at": {
+                    "type": "string",
+                    "example": "2024-01-01T00:00:00Z"
+                },
+                "user_id": {
+                    "type": "string",
+                    "example": "550e8400-e29b-41d4-a716-446655440000"
+                }
+            }
+        },
+        "handlers.InactivityPolicy": {
+            "type": "object",
+            "properties": {
+                "inactivity_days": {
+                    "description": "0 - устройства не отключаются",
+                    "type": "integer",
+                    "example": 90
+                },
+                "is_default": {
+                    "type": "boolean",
+                    "example": true
+                },
+                "warning_period_seconds": {
+                    "type": "integer",
+                    "example": 604800
+                }
+            }
+        },
+        "handlers.InboxItemResponse": {
+            "type": "object",
+            "properties": {
+                "created_at": {
+                    "type": "string",
+                    "example": "2024-01-01T00:00:00Z"
+                },
+                "device_id": {
+                    "type": "string",
+                    "example": "550e8400-e29b-41d4-a716-446655440000"
+                },
+                "file_id": {
+                    "type": "string",
+                    "example": "550e8400-e29b-41d4-a716-446655440000"
+                },
+                "file_name": {
+                    "type": "string",
+                    "example": "document.pdf"
+                },
+                "file_size": {
+                    "type": "integer",
+                    "example": 1048576
+                },
+                "from_device_id": {
+                    "type": "string",
+                    "example": "550e8400-e29b-41d4-a716-446655440000"
+                },
+                "id": {
+                    "type": "string",
+                    "example": "550e8400-e29b-41d4-a716-446655440000"
+                },
+                "mime_type": {
+                    "type": "string",
+                    "example": "application/pdf"
+                },
+                "transfer_id": {
+                    "type": "string",
+                    "example": "550e8400-e29b-41d4-a716-446655440000"
+                }
+            }
+        },
+        "handlers.ListDevicesResponse": {
+            "type": "object",
+            "properties": {
+                "devices": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/handlers.DeviceResponse"
+                    }
+                },
+                "total": {
+                    "type": "integer",
+                    "example": 5
+                }
+            }
+        },
+        "handlers.ListFilesResponse": {
+            "type": "object",
+            "properties": {
+                "files": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/handlers.FileResponse"
+                    }
+                },
+                "total": {
+                    "type": "integer",
+                    "example": 10
+                }
+            }
+        },
+        "handlers.ListInboxResponse": {
+            "type": "object",
+            "properties": {
+                "items": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/handlers.InboxItemResponse"
+                    }
+                },
+                "total": {
+                    "type": "integer",
+                    "example": 1
+                }
+            }
+        },
+        "handlers.ListOrganizationMembersResponse": {
+            "type": "object",
+            "properties": {
+                "members": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/handlers.OrganizationMemberResponse"
+                    }
+                },
+                "total": {
+                    "type": "integer",
+                    "example": 3
+                }
+            }
+        },
+        "handlers.ListOrganizationsResponse": {
+            "type": "object",
+            "properties": {
+                "organizations": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/handlers.OrganizationResponse"
+                    }
+                },
+                "total": {
+                    "type": "integer",
+                    "example": 2
+                }
+            }
+        },
+        "handlers.ListTransfersResponse": {
+            "type": "object",
+            "properties": {
+                "total": {
+                    "type": "integer",
+                    "example": 5
+                },
+                "transfers": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/handlers.TransferResponse"
+                    }
+                }
+            }
+        },
+        "handlers.LoginRequest": {
+            "type": "object",
+            "required": [
+                "email",
+                "password"
+            ],
+            "properties": {
+                "email": {
+                    "type": "string",
+                    "example": "user@example.com"
+                },
+                "password": {
+                    "type": "string",
+                    "example": "securePassword123"
+                }
+            }
+        },
+        "handlers.OrganizationMemberResponse": {
+            "type": "object",
+            "properties": {
+                "created_at": {
+                    "type": "string",
+                    "example": "2024-01-01T00:00:00Z"
+                },
+                "email": {
+                    "type": "string",
+                    "example": "colleague@example.com"
+                },
+                "organization_id": {
+                    "type": "string",
+                    "example": "550e8400-e29b-41d4-a716-446655440000"
+                },
+                "role": {
+                    "type": "string",
+                    "example": "member"
+                },
+                "updated_at": {
+                    "type": "string",
+                    "example": "2024-01-01T00:00:00Z"
+                },
+                "user_id": {
+                    "type": "string",
+                    "example": "550e8400-e29b-41d4-a716-446655440000"
+                }
+            }
+        },
+        "handlers.OrganizationResponse": {
+            "type": "object",
+            "properties": {
+                "created_at": {
+                    "type": "string",
+                    "example": "2024-01-01T00:00:00Z"
+                },
+                "id": {
+                    "type": "string",
+                    "example": "550e8400-e29b-41d4-a716-446655440000"
+                },
+                "name": {
+                    "type": "string",
+                    "example": "Acme Inc"
+                },
+                "role": {
+                    "type": "string",
+                    "example": "owner"
+                },
+                "storage_quota_bytes": {
+                    "type": "integer",
+                    "example": 10737418240
+                },
+                "storage_used_bytes": {
+                    "type": "integer",
+                    "example": 1024000
+                },
+                "updated_at": {
+                    "type": "string",
+                    "example": "2024-01-01T00:00:00Z"
+                }
+            }
+        },
+        "handlers.PairingCodeResponse": {
+            "type": "object",
+            "properties": {
+                "code": {
+                    "type": "string",
+                    "example": "48291375"
+                },
+                "expires_at": {
+                    "type": "string",
+                    "example": "2024-01-01T00:05:00Z"
+                },
+                "qr_payload": {
+                    "type": "string",
+                    "example": "flow://pair?code=48291375"
+                }
+            }
+        },
+        "handlers.RedeemPairingCodeRequest": {
+            "type": "object",
+            "required": [
+                "code",
+                "device_type",
+                "name"
+            ],
+            "properties": {
+                "code": {
+                    "type": "string",
+                    "example": "48291375"
+                },
+                "device_type": {
+                    "type": "string",
+                    "enum": [
+                        "desktop",
+                        "mobile",
+                        "tablet",
+                        "web",
+                        "cli",
+                        "nas"
+                    ],
+                    "example": "mobile"
+                },
+                "info": {
+                    "$ref": "#/definitions/handlers.DeviceInfo"
+                },
+                "name": {
+                    "type": "string",
+                    "example": "My Phone"
+                }
+            }
+        },
+        "handlers.RefreshRequest": {
+            "type": "object",
+            "required": [
+                "refresh_token"
+            ],
+            "properties": {
+                "refresh_token": {
+                    "type": "string",
+                    "example": "eyJhbGciOiJIUzI1NiIsInR5cCI6IkpXVCJ9..."
+                }
+            }
+        },
+        "handlers.RegisterDeviceRequest": {
+            "type": "object",
+            "required": [
+                "device_type",
+                "name"
+            ],
+            "properties": {
+                "device_type": {
+                    "type": "string",
+                    "enum": [
+                        "desktop",
+                        "mobile",
+                        "tablet",
+                        "web",
+                        "cli",
+                        "nas"
+                    ],
+                    "example": "desktop"
+                },
+                "info": {
+                    "$ref": "#/definitions/handlers.DeviceInfo"
+                },
+                "name": {
+                    "type": "string",
+                    "example": "My Desktop"
+                },
+                "organization_id": {
+                    "type": "string",
+                    "example": "550e8400-e29b-41d4-a716-446655440000"
+                }
+            }
+        },
+        "handlers.RegisterDeviceResponse": {
+            "type": "object",
+            "properties": {
+                "device": {
+                    "$ref": "#/definitions/handlers.DeviceResponse"
+                },
+                "device_token": {
+                    "type": "string",
+                    "example": "550e8400-e29b-41d4-a716-446655440000"
+                }
+            }
+        },
+        "handlers.RegisterRequest": {
+            "type": "object",
+            "required": [
+                "email",
+                "password"
+            ],
+            "properties": {
+                "email": {
+                    "type": "string",
+                    "example": "user@example.com"
+                },
+                "password": {
+                    "type": "string",
+                    "minLength": 8,
+                    "example": "securePassword123"
+                }
+            }
+        },
+        "handlers.RequestAccountDeletionRequest": {
+            "type": "object",
+            "required": [
+                "password"
+            ],
+            "properties": {
+                "password": {
+                    "type": "string",
+                    "example": "securePassword123"
+                }
+            }
+        },
+        "handlers.RotateDeviceTokenResponse": {
+            "type": "object",
+            "properties": {
+                "device_token": {
+                    "type": "string",
+                    "example": "q9Zb3c0n6l1i4t0k2e8n5s7a3m9p1l0e4x6y2z8w0v5"
+                },
+                "previous_token_expires_at": {
+                    "type": "string",
+                    "example": "2024-01-02T00:00:00Z"
+                }
+            }
+        },
+        "handlers.SetDeviceOrganizationRequest": {
+            "type": "object",
+            "properties": {
+                "organization_id": {
+                    "type": "string",
+                    "example": "550e8400-e29b-41d4-a716-446655440000"
+                }
             }
-        }
-    },
-    "definitions": {
-        "handlers.AuthResponse": {
+        },
+        "handlers.SetDevicePruneExemptRequest": {
             "type": "object",
             "properties": {
-                "access_token": {
+                "exempt": {
+                    "type": "boolean",
+                    "example": true
+                }
+            }
+        },
+        "handlers.SetPushTokenRequest": {
+            "type": "object",
+            "required": [
+                "provider",
+                "token"
+            ],
+            "properties": {
+                "provider": {
                     "type": "string",
-                    "example": "eyJhbGciOiJIUzI1NiIsInR5cCI6IkpXVCJ9..."
+                    "enum": [
+                        "fcm",
+                        "apns",
+                        "webpush"
+                    ],
+                    "example": "fcm"
                 },
-                "refresh_token": {
+                "token": {
+                    "description": "для webpush - JSON PushSubscription",
                     "type": "string",
-                    "example": "eyJhbGciOiJIUzI1NiIsInR5cCI6IkpXVCJ9..."
-                },
-                "user": {
-                    "$ref": "#/definitions/handlers.UserResponse"
+                    "example": "fcm-registration-token"
                 }
             }
         },
-        "handlers.DeviceResponse": {
+        "handlers.SignalingTicketRequest": {
             "type": "object",
+            "required": [
+                "device_id",
+                "device_token"
+            ],
             "properties": {
-                "created_at": {
+                "device_id": {
                     "type": "string",
-                    "example": "2024-01-01T00:00:00Z"
+                    "example": "550e8400-e29b-41d4-a716-446655440000"
                 },
                 "device_token": {
                     "type": "string",
-                    "example": "550e8400-e29b-41d4-a716-446655440000"
-                },
-                "device_type": {
+                    "example": "q9Zb3c0n6l1i4t0k2e8n5s7a3m9p1l0e4x6y2z8w0v5"
+                }
+            }
+        },
+        "handlers.SignalingTicketResponse": {
+            "type": "object",
+            "properties": {
+                "expires_at": {
                     "type": "string",
-                    "example": "desktop"
+                    "example": "2024-01-01T00:01:00Z"
                 },
-                "id": {
+                "ticket": {
                     "type": "string",
-                    "example": "550e8400-e29b-41d4-a716-446655440000"
-                },
-                "last_seen_at": {
+                    "example": "eyJhbGciOiJIUzI1NiIsInR5cCI6IkpXVCJ9..."
+                }
+            }
+        },
+        "handlers.TransferEventResponse": {
+            "type": "object",
+            "properties": {
+                "created_at": {
                     "type": "string",
                     "example": "2024-01-01T00:00:00Z"
                 },
-                "name": {
+                "delivery_path": {
+                    "description": "from_status == to_status - смена способа доставки",
                     "type": "string",
-                    "example": "My Desktop"
+                    "example": "relay"
                 },
-                "updated_at": {
+                "from_status": {
+                    "description": "пусто - создание передачи",
                     "type": "string",
-                    "example": "2024-01-01T00:00:00Z"
+                    "example": "pending"
                 },
-                "user_id": {
+                "reason": {
                     "type": "string",
-                    "example": "550e8400-e29b-41d4-a716-446655440000"
+                    "example": "ice_failed"
+                },
+                "to_status": {
+                    "type": "string",
+                    "example": "in_progress"
                 }
             }
         },
-        "handlers.FileResponse": {
+        "handlers.TransferGroupResponse": {
             "type": "object",
             "properties": {
+                "completed": {
+                    "type": "integer",
+                    "example": 2
+                },
                 "created_at": {
                     "type": "string",
                     "example": "2024-01-01T00:00:00Z"
                 },
-                "expires_at": {
-                    "type": "string",
-                    "example": "2024-02-01T00:00:00Z"
+                "failed": {
+                    "type": "integer",
+                    "example": 0
                 },
-                "id": {
+                "file_id": {
                     "type": "string",
                     "example": "550e8400-e29b-41d4-a716-446655440000"
                 },
-                "mime_type": {
+                "from_device_id": {
                     "type": "string",
-                    "example": "application/pdf"
+                    "example": "550e8400-e29b-41d4-a716-446655440000"
                 },
-                "name": {
+                "id": {
                     "type": "string",
-                    "example": "document.pdf"
+                    "example": "550e8400-e29b-41d4-a716-446655440000"
                 },
-                "size": {
+                "progress": {
+                    "description": "средний прогресс получателей",
                     "type": "integer",
-                    "example": 1024000
+                    "example": 60
                 },
-                "storage_path": {
+                "status": {
                     "type": "string",
-                    "example": "users/550e8400-e29b-41d4-a716-446655440000/files/..."
+                    "enum": [
+                        "pending",
+                        "in_progress",
+                        "completed",
+                        "partial",
+                        "failed"
+                    ],
+                    "example": "in_progress"
                 },
-                "storage_type": {
-                    "type": "string",
-                    "example": "local"
+                "total": {
+                    "type": "integer",
+                    "example": 4
                 },
-                "updated_at": {
+                "transfer_type": {
                     "type": "string",
-                    "example": "2024-01-01T00:00:00Z"
+                    "enum": [
+                        "p2p",
+                        "cloud"
+                    ],
+                    "example": "cloud"
                 },
-                "user_id": {
-                    "type": "string",
-                    "example": "550e8400-e29b-41d4-a716-446655440000"
-                }
-            }
-        },
-        "handlers.ListDevicesResponse": {
-            "type": "object",
-            "properties": {
-                "devices": {
+                "transfers": {
                     "type": "array",
                     "items": {
-                        "$ref": "#/definitions/handlers.DeviceResponse"
+                        "$ref": "#/definitions/handlers.TransferResponse"
                     }
-                },
-                "total": {
-                    "type": "integer",
-                    "example": 5
                 }
             }
         },
-        "handlers.ListFilesResponse": {
+        "handlers.TransferHistoryResponse": {
             "type": "object",
             "properties": {
-                "files": {
+                "events": {
                     "type": "array",
                     "items": {
-                        "$ref": "#/definitions/handlers.FileResponse"
+                        "$ref": "#/definitions/handlers.TransferEventResponse"
                     }
-                },
-                "total": {
-                    "type": "integer",
-                    "example": 10
                 }
             }
         },
-        "handlers.LoginRequest": {
+        "handlers.TransferProgressResponse": {
             "type": "object",
-            "required": [
-                "email",
-                "password"
-            ],
             "properties": {
-                "email": {
+                "bytes_transferred": {
+                    "type": "integer",
+                    "example": 440401920
+                },
+                "eta_seconds": {
+                    "description": "0 - неизвестно",
+                    "type": "integer",
+                    "example": 116
+                },
+                "failure_reason": {
                     "type": "string",
-                    "example": "user@example.com"
+                    "example": ""
                 },
-                "password": {
+                "progress": {
+                    "type": "integer",
+                    "example": 42
+                },
+                "rate": {
+                    "description": "байт в секунду, 0 - неизвестно",
+                    "type": "number",
+                    "example": 5242880
+                },
+                "status": {
                     "type": "string",
-                    "example": "securePassword123"
-                }
-            }
-        },
-        "handlers.RefreshRequest": {
-            "type": "object",
-            "required": [
-                "refresh_token"
-            ],
-            "properties": {
-                "refresh_token": {
+                    "enum": [
+                        "pending",
+                        "in_progress",
+                        "paused",
+                        "completed",
+                        "failed",
+                        "cancelled",
+                        "rejected",
+                        "expired"
+                    ],
+                    "example": "in_progress"
+                },
+                "total_size": {
+                    "type": "integer",
+                    "example": 1048576000
+                },
+                "transfer_id": {
                     "type": "string",
-                    "example": "eyJhbGciOiJIUzI1NiIsInR5cCI6IkpXVCJ9..."
+                    "example": "550e8400-e29b-41d4-a716-446655440000"
+                },
+                "updated_at": {
+                    "type": "string",
+                    "example": "2024-01-01T00:00:00Z"
                 }
             }
         },
-        "handlers.RegisterDeviceRequest": {
+        "handlers.TransferResponse": {
             "type": "object",
-            "required": [
-                "device_type",
-                "name"
-            ],
             "properties": {
-                "device_type": {
+                "bytes_transferred": {
+                    "type": "integer",
+                    "example": 440401920
+                },
+                "created_at": {
+                    "type": "string",
+                    "example": "2024-01-01T00:00:00Z"
+                },
+                "delivery_path": {
                     "type": "string",
                     "enum": [
-                        "desktop",
-                        "mobile"
+                        "webrtc",
+                        "relay",
+                        "cloud"
                     ],
-                    "example": "desktop"
+                    "example": "webrtc"
                 },
-                "name": {
+                "failure_reason": {
                     "type": "string",
-                    "example": "My Desktop"
-                }
-            }
-        },
-        "handlers.RegisterDeviceResponse": {
-            "type": "object",
-            "properties": {
-                "device": {
-                    "$ref": "#/definitions/handlers.DeviceResponse"
+                    "example": ""
                 },
-                "device_token": {
+                "file_id": {
                     "type": "string",
                     "example": "550e8400-e29b-41d4-a716-446655440000"
-                }
-            }
-        },
-        "handlers.RegisterRequest": {
-            "type": "object",
-            "required": [
-                "email",
-                "password"
-            ],
-            "properties": {
-                "email": {
+                },
+                "from_device_id": {
                     "type": "string",
-                    "example": "user@example.com"
+                    "example": "550e8400-e29b-41d4-a716-446655440000"
                 },
-                "password": {
+                "group_id": {
                     "type": "string",
-                    "minLength": 8,
-                    "example": "securePassword123"
+                    "example": "550e8400-e29b-41d4-a716-446655440000"
+                },
+                "id": {
+                    "type": "string",
+                    "example": "550e8400-e29b-41d4-a716-446655440000"
+                },
+                "progress": {
+                    "type": "integer",
+                    "example": 42
+                },
+                "status": {
+                    "type": "string",
+                    "enum": [
+                        "pending",
+                        "in_progress",
+                        "paused",
+                        "completed",
+                        "failed",
+                        "cancelled",
+                        "rejected",
+                        "expired"
+                    ],
+                    "example": "in_progress"
+                },
+                "to_device_id": {
+                    "type": "string",
+                    "example": "550e8400-e29b-41d4-a716-446655440000"
+                },
+                "transfer_type": {
+                    "type": "string",
+                    "enum": [
+                        "p2p",
+                        "cloud"
+                    ],
+                    "example": "p2p"
+                },
+                "updated_at": {
+                    "type": "string",
+                    "example": "2024-01-01T00:00:00Z"
                 }
             }
         },
@@ -1221,7 +5212,11 @@ const docTemplate = `{
                     "type": "string",
                     "enum": [
                         "desktop",
-                        "mobile"
+                        "mobile",
+                        "tablet",
+                        "web",
+                        "cli",
+                        "nas"
                     ],
                     "example": "desktop"
                 },
@@ -1231,6 +5226,88 @@ const docTemplate = `{
                 }
             }
         },
+        "handlers.UpdateInactivityPolicyRequest": {
+            "type": "object",
+            "properties": {
+                "inactivity_days": {
+                    "type": "integer",
+                    "minimum": 0,
+                    "example": 30
+                },
+                "use_default": {
+                    "type": "boolean",
+                    "example": false
+                }
+            }
+        },
+        "handlers.UpdateOrganizationMemberRequest": {
+            "type": "object",
+            "required": [
+                "role"
+            ],
+            "properties": {
+                "role": {
+                    "type": "string",
+                    "enum": [
+                        "owner",
+                        "admin",
+                        "member"
+                    ],
+                    "example": "admin"
+                }
+            }
+        },
+        "handlers.UpdateOrganizationRequest": {
+            "type": "object",
+            "properties": {
+                "name": {
+                    "type": "string",
+                    "example": "Acme Inc"
+                },
+                "storage_quota_bytes": {
+                    "type": "integer",
+                    "minimum": 0,
+                    "example": 10737418240
+                }
+            }
+        },
+        "handlers.UpdateTransferStatusRequest": {
+            "type": "object",
+            "required": [
+                "status"
+            ],
+            "properties": {
+                "bytes_transferred": {
+                    "type": "integer",
+                    "minimum": 0,
+                    "example": 440401920
+                },
+                "failure_reason": {
+                    "type": "string",
+                    "example": ""
+                },
+                "progress": {
+                    "type": "integer",
+                    "maximum": 100,
+                    "minimum": 0,
+                    "example": 42
+                },
+                "status": {
+                    "type": "string",
+                    "enum": [
+                        "pending",
+                        "in_progress",
+                        "paused",
+                        "completed",
+                        "failed",
+                        "cancelled",
+                        "rejected",
+                        "expired"
+                    ],
+                    "example": "in_progress"
+                }
+            }
+        },
         "handlers.UploadFileResponse": {
             "type": "object",
             "properties": {
@@ -48,12 +48,84 @@ type (
 	// DeviceResponse модель устройства
 	DeviceResponse handlers.DeviceResponse
 
+	// DeviceInfo модель платформы и возможностей устройства
+	DeviceInfo handlers.DeviceInfo
+
+	// DeviceApprovalRequest модель подтверждения или отклонения устройства
+	DeviceApprovalRequest handlers.DeviceApprovalRequest
+
+	// SetPushTokenRequest модель регистрации push-токена
+	SetPushTokenRequest handlers.SetPushTokenRequest
+
+	// SetDevicePruneExemptRequest модель исключения устройства из отключения по неактивности
+	SetDevicePruneExemptRequest handlers.SetDevicePruneExemptRequest
+
+	// InactivityPolicy модель политики неактивности устройств
+	InactivityPolicy handlers.InactivityPolicy
+
+	// UpdateInactivityPolicyRequest модель изменения политики неактивности
+	UpdateInactivityPolicyRequest handlers.UpdateInactivityPolicyRequest
+
+	// CreateTransferRequest модель для создания передачи
+	CreateTransferRequest handlers.CreateTransferRequest
+
+	// CreateTransferGroupRequest модель отправки файла нескольким устройствам
+	CreateTransferGroupRequest handlers.CreateTransferGroupRequest
+
+	// TransferGroupResponse модель группы передач
+	TransferGroupResponse handlers.TransferGroupResponse
+
+	// UpdateTransferStatusRequest модель смены статуса передачи
+	UpdateTransferStatusRequest handlers.UpdateTransferStatusRequest
+
+	// CancelTransferRequest модель отмены передачи
+	CancelTransferRequest handlers.CancelTransferRequest
+
+	// TransferResponse модель передачи
+	TransferResponse handlers.TransferResponse
+
+	// ListTransfersResponse модель списка передач
+	ListTransfersResponse handlers.ListTransfersResponse
+
+	// TransferHistoryResponse модель истории передачи
+	TransferHistoryResponse handlers.TransferHistoryResponse
+
+	// TransferProgressResponse модель события прогресса передачи
+	TransferProgressResponse handlers.TransferProgressResponse
+
+	// InboxItemResponse модель файла во входящих устройства
+	InboxItemResponse handlers.InboxItemResponse
+
+	// ListInboxResponse модель входящих устройства
+	ListInboxResponse handlers.ListInboxResponse
+
 	// RegisterDeviceResponse модель ответа регистрации устройства
 	RegisterDeviceResponse handlers.RegisterDeviceResponse
 
 	// UpdateDeviceRequest модель для обновления устройства
 	UpdateDeviceRequest handlers.UpdateDeviceRequest
 
+	// SetDeviceOrganizationRequest модель привязки устройства к организации
+	SetDeviceOrganizationRequest handlers.SetDeviceOrganizationRequest
+
+	// RotateDeviceTokenResponse модель ответа ротации токена устройства
+	RotateDeviceTokenResponse handlers.RotateDeviceTokenResponse
+
+	// CreatePairingCodeRequest модель запроса кода сопряжения
+	CreatePairingCodeRequest handlers.CreatePairingCodeRequest
+
+	// PairingCodeResponse модель кода сопряжения
+	PairingCodeResponse handlers.PairingCodeResponse
+
+	// RedeemPairingCodeRequest модель погашения кода сопряжения
+	RedeemPairingCodeRequest handlers.RedeemPairingCodeRequest
+
+	// SignalingTicketRequest модель запроса билета signaling сервера
+	SignalingTicketRequest handlers.SignalingTicketRequest
+
+	// SignalingTicketResponse модель билета signaling сервера
+	SignalingTicketResponse handlers.SignalingTicketResponse
+
 	// ListDevicesResponse модель списка устройств
 	ListDevicesResponse handlers.ListDevicesResponse
 
@@ -68,4 +140,37 @@ type (
 
 	// TurnCredentialsResponse модель TURN credentials
 	TurnCredentialsResponse handlers.TurnCredentialsResponse
+
+	// DataExportResponse модель экспорта данных
+	DataExportResponse handlers.DataExportResponse
+
+	// RequestAccountDeletionRequest модель запроса удаления аккаунта
+	RequestAccountDeletionRequest handlers.RequestAccountDeletionRequest
+
+	// AccountDeletionResponse модель статуса удаления аккаунта
+	AccountDeletionResponse handlers.AccountDeletionResponse
+
+	// CreateOrganizationRequest модель создания организации
+	CreateOrganizationRequest handlers.CreateOrganizationRequest
+
+	// UpdateOrganizationRequest модель обновления организации
+	UpdateOrganizationRequest handlers.UpdateOrganizationRequest
+
+	// OrganizationResponse модель организации
+	OrganizationResponse handlers.OrganizationResponse
+
+	// ListOrganizationsResponse модель списка организаций
+	ListOrganizationsResponse handlers.ListOrganizationsResponse
+
+	// AddOrganizationMemberRequest модель добавления участника организации
+	AddOrganizationMemberRequest handlers.AddOrganizationMemberRequest
+
+	// UpdateOrganizationMemberRequest модель изменения роли участника
+	UpdateOrganizationMemberRequest handlers.UpdateOrganizationMemberRequest
+
+	// OrganizationMemberResponse модель участника организации
+	OrganizationMemberResponse handlers.OrganizationMemberResponse
+
+	// ListOrganizationMembersResponse модель списка участников организации
+	ListOrganizationMembersResponse handlers.ListOrganizationMembersResponse
 )
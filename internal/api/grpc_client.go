@@ -3,20 +3,24 @@ package api
 import (
 	"fmt"
 
+	accountpb "github.com/backend-app/backend/pkg/proto/account"
 	authpb "github.com/backend-app/backend/pkg/proto/auth"
 	devicepb "github.com/backend-app/backend/pkg/proto/device"
 	filepb "github.com/backend-app/backend/pkg/proto/file"
+	organizationpb "github.com/backend-app/backend/pkg/proto/organization"
 	transferpb "github.com/backend-app/backend/pkg/proto/transfer"
 	"google.golang.org/grpc"
 	"google.golang.org/grpc/credentials/insecure"
 )
 
 type GRPCClients struct {
-	Auth     authpb.AuthServiceClient
-	Device   devicepb.DeviceServiceClient
-	File     filepb.FileServiceClient
-	Transfer transferpb.TransferServiceClient
-	conn     *grpc.ClientConn
+	Auth         authpb.AuthServiceClient
+	Device       devicepb.DeviceServiceClient
+	File         filepb.FileServiceClient
+	Transfer     transferpb.TransferServiceClient
+	Account      accountpb.AccountServiceClient
+	Organization organizationpb.OrganizationServiceClient
+	conn         *grpc.ClientConn
 }
 
 func NewGRPCClients(grpcAddr string) (*GRPCClients, error) {
@@ -26,11 +30,13 @@ func NewGRPCClients(grpcAddr string) (*GRPCClients, error) {
 	}
 
 	return &GRPCClients{
-		Auth:     authpb.NewAuthServiceClient(conn),
-		Device:   devicepb.NewDeviceServiceClient(conn),
-		File:     filepb.NewFileServiceClient(conn),
-		Transfer: transferpb.NewTransferServiceClient(conn),
-		conn:     conn,
+		Auth:         authpb.NewAuthServiceClient(conn),
+		Device:       devicepb.NewDeviceServiceClient(conn),
+		File:         filepb.NewFileServiceClient(conn),
+		Transfer:     transferpb.NewTransferServiceClient(conn),
+		Account:      accountpb.NewAccountServiceClient(conn),
+		Organization: organizationpb.NewOrganizationServiceClient(conn),
+		conn:         conn,
 	}, nil
 }
 
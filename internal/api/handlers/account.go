@@ -0,0 +1,367 @@
+package handlers
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+
+	"github.com/backend-app/backend/internal/api/middleware"
+	accountpb "github.com/backend-app/backend/pkg/proto/account"
+	"github.com/gin-gonic/gin"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+type AccountHandler struct {
+	accountClient accountpb.AccountServiceClient
+}
+
+func NewAccountHandler(accountClient accountpb.AccountServiceClient) *AccountHandler {
+	return &AccountHandler{
+		accountClient: accountClient,
+	}
+}
+
+type DataExportResponse struct {
+	ID        string `json:"id" example:"550e8400-e29b-41d4-a716-446655440000"`
+	UserID    string `json:"user_id" example:"550e8400-e29b-41d4-a716-446655440000"`
+	Status    string `json:"status" example:"completed"`
+	Size      int64  `json:"size" example:"1024000"`
+	Error     string `json:"error,omitempty" example:""`
+	ExpiresAt string `json:"expires_at,omitempty" example:"2024-01-08T00:00:00Z"`
+	CreatedAt string `json:"created_at" example:"2024-01-01T00:00:00Z"`
+	UpdatedAt string `json:"updated_at" example:"2024-01-01T00:00:00Z"`
+}
+
+type RequestAccountDeletionRequest struct {
+	Password string `json:"password" binding:"required" example:"securePassword123"`
+}
+
+type AccountDeletionResponse struct {
+	Pending     bool   `json:"pending" example:"true"`
+	RequestedAt string `json:"requested_at,omitempty" example:"2024-01-01T00:00:00Z"`
+	ScheduledAt string `json:"scheduled_at,omitempty" example:"2024-01-31T00:00:00Z"`
+}
+
+// RequestExport godoc
+// @Summary Запрос экспорта данных
+// @Description Ставит в очередь сборку ZIP архива с профилем, устройствами, историей передач и всеми файлами пользователя
+// @Tags account
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Success 202 {object} DataExportResponse "Экспорт поставлен в очередь"
+// @Failure 401 {object} map[string]string "Не авторизован"
+// @Failure 500 {object} map[string]string "Внутренняя ошибка сервера"
+// @Router /account/export [post]
+func (h *AccountHandler) RequestExport(c *gin.Context) {
+	userID, exists := middleware.GetUserID(c)
+	if !exists {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "unauthorized"})
+		return
+	}
+
+	resp, err := h.accountClient.RequestDataExport(context.Background(), &accountpb.RequestDataExportRequest{
+		UserId: userID.String(),
+	})
+	if err != nil {
+		if st, ok := status.FromError(err); ok {
+			switch st.Code() {
+			case codes.NotFound:
+				c.JSON(http.StatusNotFound, gin.H{"error": st.Message()})
+			default:
+				c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to request data export"})
+			}
+			return
+		}
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to request data export"})
+		return
+	}
+
+	c.JSON(http.StatusAccepted, exportToResponse(resp.Export))
+}
+
+// GetExport godoc
+// @Summary Статус экспорта данных
+// @Description Возвращает состояние экспорта данных пользователя
+// @Tags account
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "ID экспорта" format(uuid)
+// @Success 200 {object} DataExportResponse "Состояние экспорта"
+// @Failure 400 {object} map[string]string "Неверный ID экспорта"
+// @Failure 401 {object} map[string]string "Не авторизован"
+// @Failure 403 {object} map[string]string "Нет доступа к экспорту"
+// @Failure 404 {object} map[string]string "Экспорт не найден"
+// @Router /account/export/{id} [get]
+func (h *AccountHandler) GetExport(c *gin.Context) {
+	userID, exists := middleware.GetUserID(c)
+	if !exists {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "unauthorized"})
+		return
+	}
+
+	exportID := c.Param("id")
+	if exportID == "" {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "export_id is required"})
+		return
+	}
+
+	resp, err := h.accountClient.GetDataExport(context.Background(), &accountpb.GetDataExportRequest{
+		ExportId: exportID,
+		UserId:   userID.String(),
+	})
+	if err != nil {
+		if st, ok := status.FromError(err); ok {
+			switch st.Code() {
+			case codes.InvalidArgument:
+				c.JSON(http.StatusBadRequest, gin.H{"error": st.Message()})
+			case codes.NotFound:
+				c.JSON(http.StatusNotFound, gin.H{"error": st.Message()})
+			case codes.PermissionDenied:
+				c.JSON(http.StatusForbidden, gin.H{"error": st.Message()})
+			default:
+				c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to get data export"})
+			}
+			return
+		}
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to get data export"})
+		return
+	}
+
+	c.JSON(http.StatusOK, exportToResponse(resp.Export))
+}
+
+// DownloadExport godoc
+// @Summary Скачивание экспорта данных
+// @Description Скачивает готовый ZIP архив с данными пользователя
+// @Tags account
+// @Accept json
+// @Produce application/zip
+// @Security BearerAuth
+// @Param id path string true "ID экспорта" format(uuid)
+// @Success 200 {file} file "ZIP архив"
+// @Failure 401 {object} map[string]string "Не авторизован"
+// @Failure 403 {object} map[string]string "Нет доступа к экспорту"
+// @Failure 404 {object} map[string]string "Экспорт не найден или истек"
+// @Failure 409 {object} map[string]string "Экспорт еще не готов"
+// @Router /account/export/{id}/download [get]
+func (h *AccountHandler) DownloadExport(c *gin.Context) {
+	userID, exists := middleware.GetUserID(c)
+	if !exists {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "unauthorized"})
+		return
+	}
+
+	exportID := c.Param("id")
+	if exportID == "" {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "export_id is required"})
+		return
+	}
+
+	stream, err := h.accountClient.DownloadDataExport(context.Background(), &accountpb.DownloadDataExportRequest{
+		ExportId: exportID,
+		UserId:   userID.String(),
+	})
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to download data export"})
+		return
+	}
+
+	// Ошибки сервера в server-streaming RPC приходят с первым Recv
+	first, err := stream.Recv()
+	if err != nil && err != io.EOF {
+		if st, ok := status.FromError(err); ok {
+			switch st.Code() {
+			case codes.InvalidArgument:
+				c.JSON(http.StatusBadRequest, gin.H{"error": st.Message()})
+			case codes.NotFound:
+				c.JSON(http.StatusNotFound, gin.H{"error": st.Message()})
+			case codes.PermissionDenied:
+				c.JSON(http.StatusForbidden, gin.H{"error": st.Message()})
+			case codes.FailedPrecondition:
+				c.JSON(http.StatusConflict, gin.H{"error": st.Message()})
+			default:
+				c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to download data export"})
+			}
+			return
+		}
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to download data export"})
+		return
+	}
+
+	c.Header("Content-Type", "application/zip")
+	c.Header("Content-Disposition", fmt.Sprintf("attachment; filename=\"export-%s.zip\"", exportID))
+	c.Status(http.StatusOK)
+
+	resp := first
+	for resp != nil {
+		if _, err := c.Writer.Write(resp.Data); err != nil {
+			return
+		}
+		c.Writer.Flush()
+
+		if resp.IsLast {
+			break
+		}
+
+		resp, err = stream.Recv()
+		if err != nil {
+			return
+		}
+	}
+}
+
+// RequestDeletion godoc
+// @Summary Запрос удаления аккаунта
+// @Description Планирует удаление аккаунта после периода ожидания. До его окончания удаление можно отменить. Единственный владелец организации должен сначала передать владение.
+// @Tags account
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param request body RequestAccountDeletionRequest true "Подтверждение паролем"
+// @Success 202 {object} AccountDeletionResponse "Удаление запланировано"
+// @Failure 400 {object} map[string]string "Неверный формат данных"
+// @Failure 401 {object} map[string]string "Не авторизован или неверный пароль"
+// @Failure 409 {object} map[string]string "Удаление уже запланировано или пользователь - единственный владелец организации"
+// @Failure 500 {object} map[string]string "Внутренняя ошибка сервера"
+// @Router /account/deletion [post]
+func (h *AccountHandler) RequestDeletion(c *gin.Context) {
+	userID, exists := middleware.GetUserID(c)
+	if !exists {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "unauthorized"})
+		return
+	}
+
+	var req RequestAccountDeletionRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	resp, err := h.accountClient.RequestAccountDeletion(context.Background(), &accountpb.RequestAccountDeletionRequest{
+		UserId:   userID.String(),
+		Password: req.Password,
+	})
+	if err != nil {
+		if st, ok := status.FromError(err); ok {
+			switch st.Code() {
+			case codes.Unauthenticated:
+				c.JSON(http.StatusUnauthorized, gin.H{"error": st.Message()})
+			case codes.AlreadyExists, codes.FailedPrecondition:
+				c.JSON(http.StatusConflict, gin.H{"error": st.Message()})
+			case codes.NotFound:
+				c.JSON(http.StatusNotFound, gin.H{"error": st.Message()})
+			default:
+				c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to request account deletion"})
+			}
+			return
+		}
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to request account deletion"})
+		return
+	}
+
+	c.JSON(http.StatusAccepted, deletionToResponse(resp.Deletion))
+}
+
+// GetDeletion godoc
+// @Summary Статус удаления аккаунта
+// @Description Возвращает информацию о запланированном удалении аккаунта
+// @Tags account
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Success 200 {object} AccountDeletionResponse "Статус удаления"
+// @Failure 401 {object} map[string]string "Не авторизован"
+// @Router /account/deletion [get]
+func (h *AccountHandler) GetDeletion(c *gin.Context) {
+	userID, exists := middleware.GetUserID(c)
+	if !exists {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "unauthorized"})
+		return
+	}
+
+	resp, err := h.accountClient.GetAccountDeletionStatus(context.Background(), &accountpb.GetAccountDeletionStatusRequest{
+		UserId: userID.String(),
+	})
+	if err != nil {
+		if st, ok := status.FromError(err); ok {
+			switch st.Code() {
+			case codes.NotFound:
+				c.JSON(http.StatusNotFound, gin.H{"error": st.Message()})
+			default:
+				c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to get account deletion status"})
+			}
+			return
+		}
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to get account deletion status"})
+		return
+	}
+
+	c.JSON(http.StatusOK, deletionToResponse(resp.Deletion))
+}
+
+// CancelDeletion godoc
+// @Summary Отмена удаления аккаунта
+// @Description Отменяет запланированное удаление аккаунта, пока не истек период ожидания
+// @Tags account
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Success 200 {object} map[string]bool "Удаление отменено"
+// @Failure 401 {object} map[string]string "Не авторизован"
+// @Failure 409 {object} map[string]string "Удаление не запрашивалось"
+// @Router /account/deletion [delete]
+func (h *AccountHandler) CancelDeletion(c *gin.Context) {
+	userID, exists := middleware.GetUserID(c)
+	if !exists {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "unauthorized"})
+		return
+	}
+
+	resp, err := h.accountClient.CancelAccountDeletion(context.Background(), &accountpb.CancelAccountDeletionRequest{
+		UserId: userID.String(),
+	})
+	if err != nil {
+		if st, ok := status.FromError(err); ok {
+			switch st.Code() {
+			case codes.FailedPrecondition:
+				c.JSON(http.StatusConflict, gin.H{"error": st.Message()})
+			case codes.NotFound:
+				c.JSON(http.StatusNotFound, gin.H{"error": st.Message()})
+			default:
+				c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to cancel account deletion"})
+			}
+			return
+		}
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to cancel account deletion"})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"success": resp.Success,
+	})
+}
+
+func exportToResponse(export *accountpb.DataExport) DataExportResponse {
+	return DataExportResponse{
+		ID:        export.Id,
+		UserID:    export.UserId,
+		Status:    export.Status,
+		Size:      export.Size,
+		Error:     export.Error,
+		ExpiresAt: export.ExpiresAt,
+		CreatedAt: export.CreatedAt,
+		UpdatedAt: export.UpdatedAt,
+	}
+}
+
+func deletionToResponse(deletion *accountpb.AccountDeletionStatus) AccountDeletionResponse {
+	return AccountDeletionResponse{
+		Pending:     deletion.Pending,
+		RequestedAt: deletion.RequestedAt,
+		ScheduledAt: deletion.ScheduledAt,
+	}
+}
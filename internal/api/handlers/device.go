@@ -21,20 +21,38 @@ func NewDeviceHandler(deviceClient devicepb.DeviceServiceClient) *DeviceHandler
 	}
 }
 
+// DeviceInfo - платформа, версии клиента и возможности устройства
+type DeviceInfo struct {
+	Platform          string   `json:"platform,omitempty" binding:"omitempty,oneof=windows macos linux ios android web other" example:"macos"`
+	OSVersion         string   `json:"os_version,omitempty" example:"14.4"`
+	AppVersion        string   `json:"app_version,omitempty" example:"1.2.0"`
+	TransferProtocols []string `json:"transfer_protocols,omitempty" binding:"omitempty,dive,oneof=webrtc relay cloud" example:"webrtc,relay,cloud"`
+	MaxFileSize       int64    `json:"max_file_size,omitempty" binding:"omitempty,min=1" example:"10737418240"`
+}
+
 type RegisterDeviceRequest struct {
-	Name       string `json:"name" binding:"required" example:"My Desktop"`
-	DeviceType string `json:"device_type" binding:"required,oneof=desktop mobile" example:"desktop"`
+	Name           string      `json:"name" binding:"required" example:"My Desktop"`
+	DeviceType     string      `json:"device_type" binding:"required,oneof=desktop mobile tablet web cli nas" example:"desktop"`
+	OrganizationID string      `json:"organization_id,omitempty" example:"550e8400-e29b-41d4-a716-446655440000"`
+	Info           *DeviceInfo `json:"info,omitempty"`
 }
 
 type DeviceResponse struct {
-	ID          string `json:"id" example:"550e8400-e29b-41d4-a716-446655440000"`
-	UserID      string `json:"user_id" example:"550e8400-e29b-41d4-a716-446655440000"`
-	Name        string `json:"name" example:"My Desktop"`
-	DeviceType  string `json:"device_type" example:"desktop"`
-	DeviceToken string `json:"device_token" example:"550e8400-e29b-41d4-a716-446655440000"`
-	LastSeenAt  string `json:"last_seen_at" example:"2024-01-01T00:00:00Z"`
-	CreatedAt   string `json:"created_at" example:"2024-01-01T00:00:00Z"`
-	UpdatedAt   string `json:"updated_at" example:"2024-01-01T00:00:00Z"`
+	ID             string     `json:"id" example:"550e8400-e29b-41d4-a716-446655440000"`
+	UserID         string     `json:"user_id" example:"550e8400-e29b-41d4-a716-446655440000"`
+	OrganizationID string     `json:"organization_id,omitempty" example:"550e8400-e29b-41d4-a716-446655440000"`
+	Name           string     `json:"name" example:"My Desktop"`
+	DeviceType     string     `json:"device_type" example:"desktop"`
+	LastSeenAt     string     `json:"last_seen_at" example:"2024-01-01T00:00:00Z"`
+	PresenceStatus string     `json:"presence_status,omitempty" example:"online" enums:"online,away,offline"`
+	ConnectedSince string     `json:"connected_since,omitempty" example:"2024-01-01T00:00:00Z"`
+	Info           DeviceInfo `json:"info"`
+	ApprovalStatus string     `json:"approval_status" example:"approved" enums:"pending,approved,rejected"`
+	PushProvider   string     `json:"push_provider,omitempty" example:"fcm" enums:"fcm,apns,webpush"`
+	PruneExempt    bool       `json:"prune_exempt" example:"false"`
+	DeactivatedAt  string     `json:"deactivated_at,omitempty" example:"2024-01-01T00:00:00Z"`
+	CreatedAt      string     `json:"created_at" example:"2024-01-01T00:00:00Z"`
+	UpdatedAt      string     `json:"updated_at" example:"2024-01-01T00:00:00Z"`
 }
 
 type RegisterDeviceResponse struct {
@@ -44,7 +62,71 @@ type RegisterDeviceResponse struct {
 
 type UpdateDeviceRequest struct {
 	Name       string `json:"name,omitempty" example:"My Updated Desktop"`
-	DeviceType string `json:"device_type,omitempty" binding:"omitempty,oneof=desktop mobile" example:"desktop"`
+	DeviceType string `json:"device_type,omitempty" binding:"omitempty,oneof=desktop mobile tablet web cli nas" example:"desktop"`
+}
+
+type SetDeviceOrganizationRequest struct {
+	OrganizationID string `json:"organization_id" example:"550e8400-e29b-41d4-a716-446655440000"`
+}
+
+type CreatePairingCodeRequest struct {
+	DeviceID string `json:"device_id" binding:"required" example:"550e8400-e29b-41d4-a716-446655440000"`
+}
+
+type PairingCodeResponse struct {
+	Code      string `json:"code" example:"48291375"`
+	QRPayload string `json:"qr_payload" example:"flow://pair?code=48291375"`
+	ExpiresAt string `json:"expires_at" example:"2024-01-01T00:05:00Z"`
+}
+
+type RedeemPairingCodeRequest struct {
+	Code       string      `json:"code" binding:"required" example:"48291375"`
+	Name       string      `json:"name" binding:"required" example:"My Phone"`
+	DeviceType string      `json:"device_type" binding:"required,oneof=desktop mobile tablet web cli nas" example:"mobile"`
+	Info       *DeviceInfo `json:"info,omitempty"`
+}
+
+// DeviceApprovalRequest - решение принимает доверенное устройство, подтверждая себя своим токеном
+type DeviceApprovalRequest struct {
+	DeviceToken string `json:"device_token" binding:"required" example:"q9Zb3c0n6l1i4t0k2e8n5s7a3m9p1l0e4x6y2z8w0v5"`
+}
+
+// SignalingTicketRequest - устройство подтверждает себя своим токеном
+type SignalingTicketRequest struct {
+	DeviceID    string `json:"device_id" binding:"required" example:"550e8400-e29b-41d4-a716-446655440000"`
+	DeviceToken string `json:"device_token" binding:"required" example:"q9Zb3c0n6l1i4t0k2e8n5s7a3m9p1l0e4x6y2z8w0v5"`
+}
+
+type SignalingTicketResponse struct {
+	Ticket    string `json:"ticket" example:"eyJhbGciOiJIUzI1NiIsInR5cCI6IkpXVCJ9..."`
+	ExpiresAt string `json:"expires_at" example:"2024-01-01T00:01:00Z"`
+}
+
+type SetPushTokenRequest struct {
+	Provider string `json:"provider" binding:"required,oneof=fcm apns webpush" example:"fcm"`
+	Token    string `json:"token" binding:"required" example:"fcm-registration-token"` // для webpush - JSON PushSubscription
+}
+
+type SetDevicePruneExemptRequest struct {
+	Exempt bool `json:"exempt" example:"true"`
+}
+
+// InactivityPolicy - через сколько дней без подключения устройства пользователя отключаются
+type InactivityPolicy struct {
+	InactivityDays       int32 `json:"inactivity_days" example:"90"` // 0 - устройства не отключаются
+	IsDefault            bool  `json:"is_default" example:"true"`
+	WarningPeriodSeconds int64 `json:"warning_period_seconds" example:"604800"`
+}
+
+// UpdateInactivityPolicyRequest - use_default возвращает порог сервера, inactivity_days при этом игнорируется
+type UpdateInactivityPolicyRequest struct {
+	InactivityDays int32 `json:"inactivity_days" binding:"min=0" example:"30"`
+	UseDefault     bool  `json:"use_default" example:"false"`
+}
+
+type RotateDeviceTokenResponse struct {
+	DeviceToken            string `json:"device_token" example:"q9Zb3c0n6l1i4t0k2e8n5s7a3m9p1l0e4x6y2z8w0v5"`
+	PreviousTokenExpiresAt string `json:"previous_token_expires_at" example:"2024-01-02T00:00:00Z"`
 }
 
 type ListDevicesResponse struct {
@@ -54,7 +136,7 @@ type ListDevicesResponse struct {
 
 // Register godoc
 // @Summary Регистрация устройства
-// @Description Регистрирует новое устройство для пользователя и возвращает device_token для QR-кода
+// @Description Регистрирует новое устройство для пользователя и возвращает device_token. Токен показывается только один раз. Если указан organization_id, устройство сразу регистрируется в организации. Первое устройство пользователя подтверждается автоматически, остальные ожидают подтверждения доверенным устройством (approval_status=pending).
 // @Tags devices
 // @Accept json
 // @Produce json
@@ -63,6 +145,7 @@ type ListDevicesResponse struct {
 // @Success 201 {object} RegisterDeviceResponse "Устройство успешно зарегистрировано"
 // @Failure 400 {object} map[string]string "Неверный формат данных"
 // @Failure 401 {object} map[string]string "Не авторизован"
+// @Failure 403 {object} map[string]string "Пользователь не состоит в организации"
 // @Failure 500 {object} map[string]string "Внутренняя ошибка сервера"
 // @Router /devices [post]
 func (h *DeviceHandler) Register(c *gin.Context) {
@@ -79,15 +162,19 @@ func (h *DeviceHandler) Register(c *gin.Context) {
 	}
 
 	resp, err := h.deviceClient.RegisterDevice(context.Background(), &devicepb.RegisterDeviceRequest{
-		UserId:     userID.String(),
-		Name:       req.Name,
-		DeviceType: req.DeviceType,
+		UserId:         userID.String(),
+		Name:           req.Name,
+		DeviceType:     req.DeviceType,
+		OrganizationId: req.OrganizationID,
+		Info:           deviceInfoToProto(req.Info),
 	})
 	if err != nil {
 		if st, ok := status.FromError(err); ok {
 			switch st.Code() {
 			case codes.InvalidArgument:
 				c.JSON(http.StatusBadRequest, gin.H{"error": st.Message()})
+			case codes.PermissionDenied:
+				c.JSON(http.StatusForbidden, gin.H{"error": st.Message()})
 			default:
 				c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to register device"})
 			}
@@ -97,17 +184,9 @@ func (h *DeviceHandler) Register(c *gin.Context) {
 		return
 	}
 
+	device := deviceToResponse(resp.Device)
 	c.JSON(http.StatusCreated, RegisterDeviceResponse{
-		Device: &DeviceResponse{
-			ID:          resp.Device.Id,
-			UserID:      resp.Device.UserId,
-			Name:        resp.Device.Name,
-			DeviceType:  resp.Device.DeviceType,
-			DeviceToken: resp.Device.DeviceToken,
-			LastSeenAt:  resp.Device.LastSeenAt,
-			CreatedAt:   resp.Device.CreatedAt,
-			UpdatedAt:   resp.Device.UpdatedAt,
-		},
+		Device:      &device,
 		DeviceToken: resp.DeviceToken,
 	})
 }
@@ -159,27 +238,22 @@ func (h *DeviceHandler) Get(c *gin.Context) {
 		return
 	}
 
-	c.JSON(http.StatusOK, DeviceResponse{
-		ID:          resp.Device.Id,
-		UserID:      resp.Device.UserId,
-		Name:        resp.Device.Name,
-		DeviceType:  resp.Device.DeviceType,
-		DeviceToken: resp.Device.DeviceToken,
-		LastSeenAt:  resp.Device.LastSeenAt,
-		CreatedAt:   resp.Device.CreatedAt,
-		UpdatedAt:   resp.Device.UpdatedAt,
-	})
+	c.JSON(http.StatusOK, deviceToResponse(resp.Device))
 }
 
 // List godoc
 // @Summary Список устройств
-// @Description Возвращает список всех устройств пользователя
+// @Description Возвращает список всех устройств пользователя или устройств организации. Устройства, отключенные из-за неактивности, возвращаются только с include_deactivated=true.
 // @Tags devices
 // @Accept json
 // @Produce json
 // @Security BearerAuth
+// @Param organization_id query string false "ID организации" format(uuid)
+// @Param include_deactivated query bool false "Включить отключенные устройства"
 // @Success 200 {object} ListDevicesResponse "Список устройств"
+// @Failure 400 {object} map[string]string "Неверный ID организации"
 // @Failure 401 {object} map[string]string "Не авторизован"
+// @Failure 403 {object} map[string]string "Пользователь не состоит в организации"
 // @Failure 500 {object} map[string]string "Внутренняя ошибка сервера"
 // @Router /devices [get]
 func (h *DeviceHandler) List(c *gin.Context) {
@@ -190,25 +264,29 @@ func (h *DeviceHandler) List(c *gin.Context) {
 	}
 
 	resp, err := h.deviceClient.ListDevices(context.Background(), &devicepb.ListDevicesRequest{
-		UserId: userID.String(),
+		UserId:             userID.String(),
+		OrganizationId:     c.Query("organization_id"),
+		IncludeDeactivated: c.Query("include_deactivated") == "true",
 	})
 	if err != nil {
+		if st, ok := status.FromError(err); ok {
+			switch st.Code() {
+			case codes.InvalidArgument:
+				c.JSON(http.StatusBadRequest, gin.H{"error": st.Message()})
+			case codes.PermissionDenied:
+				c.JSON(http.StatusForbidden, gin.H{"error": st.Message()})
+			default:
+				c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to list devices"})
+			}
+			return
+		}
 		c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to list devices"})
 		return
 	}
 
 	devices := make([]DeviceResponse, len(resp.Devices))
 	for i, device := range resp.Devices {
-		devices[i] = DeviceResponse{
-			ID:          device.Id,
-			UserID:      device.UserId,
-			Name:        device.Name,
-			DeviceType:  device.DeviceType,
-			DeviceToken: device.DeviceToken,
-			LastSeenAt:  device.LastSeenAt,
-			CreatedAt:   device.CreatedAt,
-			UpdatedAt:   device.UpdatedAt,
-		}
+		devices[i] = deviceToResponse(device)
 	}
 
 	c.JSON(http.StatusOK, ListDevicesResponse{
@@ -276,16 +354,7 @@ func (h *DeviceHandler) Update(c *gin.Context) {
 		return
 	}
 
-	c.JSON(http.StatusOK, DeviceResponse{
-		ID:          resp.Device.Id,
-		UserID:      resp.Device.UserId,
-		Name:        resp.Device.Name,
-		DeviceType:  resp.Device.DeviceType,
-		DeviceToken: resp.Device.DeviceToken,
-		LastSeenAt:  resp.Device.LastSeenAt,
-		CreatedAt:   resp.Device.CreatedAt,
-		UpdatedAt:   resp.Device.UpdatedAt,
-	})
+	c.JSON(http.StatusOK, deviceToResponse(resp.Device))
 }
 
 // Delete godoc
@@ -339,6 +408,621 @@ func (h *DeviceHandler) Delete(c *gin.Context) {
 	})
 }
 
+// SetOrganization godoc
+// @Summary Привязка устройства к организации
+// @Description Регистрирует устройство в организации пользователя. Пустой organization_id отвязывает устройство от организации.
+// @Tags devices
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "ID устройства" format(uuid)
+// @Param request body SetDeviceOrganizationRequest true "ID организации"
+// @Success 200 {object} DeviceResponse "Устройство обновлено"
+// @Failure 400 {object} map[string]string "Неверный формат данных"
+// @Failure 401 {object} map[string]string "Не авторизован"
+// @Failure 403 {object} map[string]string "Нет доступа к устройству или организации"
+// @Failure 404 {object} map[string]string "Устройство не найдено"
+// @Failure 500 {object} map[string]string "Внутренняя ошибка сервера"
+// @Router /devices/{id}/organization [put]
+func (h *DeviceHandler) SetOrganization(c *gin.Context) {
+	userID, exists := middleware.GetUserID(c)
+	if !exists {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "unauthorized"})
+		return
+	}
+
+	deviceID := c.Param("id")
+	if deviceID == "" {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "device_id is required"})
+		return
+	}
+
+	var req SetDeviceOrganizationRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	resp, err := h.deviceClient.SetDeviceOrganization(context.Background(), &devicepb.SetDeviceOrganizationRequest{
+		DeviceId:       deviceID,
+		UserId:         userID.String(),
+		OrganizationId: req.OrganizationID,
+	})
+	if err != nil {
+		if st, ok := status.FromError(err); ok {
+			switch st.Code() {
+			case codes.NotFound:
+				c.JSON(http.StatusNotFound, gin.H{"error": st.Message()})
+			case codes.PermissionDenied:
+				c.JSON(http.StatusForbidden, gin.H{"error": st.Message()})
+			case codes.InvalidArgument:
+				c.JSON(http.StatusBadRequest, gin.H{"error": st.Message()})
+			default:
+				c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to update device organization"})
+			}
+			return
+		}
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to update device organization"})
+		return
+	}
+
+	c.JSON(http.StatusOK, deviceToResponse(resp.Device))
+}
+
+// RotateToken godoc
+// @Summary Ротация токена устройства
+// @Description Выпускает новый device_token (показывается один раз). Старый токен остается действительным до previous_token_expires_at.
+// @Tags devices
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "ID устройства" format(uuid)
+// @Success 200 {object} RotateDeviceTokenResponse "Новый токен устройства"
+// @Failure 400 {object} map[string]string "Неверный ID устройства"
+// @Failure 401 {object} map[string]string "Не авторизован"
+// @Failure 403 {object} map[string]string "Нет доступа к устройству"
+// @Failure 404 {object} map[string]string "Устройство не найдено"
+// @Failure 409 {object} map[string]string "Устройство деактивировано"
+// @Failure 500 {object} map[string]string "Внутренняя ошибка сервера"
+// @Router /devices/{id}/token/rotate [post]
+func (h *DeviceHandler) RotateToken(c *gin.Context) {
+	userID, exists := middleware.GetUserID(c)
+	if !exists {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "unauthorized"})
+		return
+	}
+
+	deviceID := c.Param("id")
+	if deviceID == "" {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "device_id is required"})
+		return
+	}
+
+	resp, err := h.deviceClient.RotateDeviceToken(context.Background(), &devicepb.RotateDeviceTokenRequest{
+		DeviceId: deviceID,
+		UserId:   userID.String(),
+	})
+	if err != nil {
+		if st, ok := status.FromError(err); ok {
+			switch st.Code() {
+			case codes.InvalidArgument:
+				c.JSON(http.StatusBadRequest, gin.H{"error": st.Message()})
+			case codes.NotFound:
+				c.JSON(http.StatusNotFound, gin.H{"error": st.Message()})
+			case codes.PermissionDenied:
+				c.JSON(http.StatusForbidden, gin.H{"error": st.Message()})
+			case codes.FailedPrecondition:
+				c.JSON(http.StatusConflict, gin.H{"error": st.Message()})
+			default:
+				c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to rotate device token"})
+			}
+			return
+		}
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to rotate device token"})
+		return
+	}
+
+	c.JSON(http.StatusOK, RotateDeviceTokenResponse{
+		DeviceToken:            resp.DeviceToken,
+		PreviousTokenExpiresAt: resp.PreviousTokenExpiresAt,
+	})
+}
+
+// Approve godoc
+// @Summary Подтверждение устройства
+// @Description Подтверждает ожидающее устройство. Решение принимает доверенное (подтвержденное) устройство пользователя, передавая свой device_token. Остальные устройства пользователя получают событие device-approved.
+// @Tags devices
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "ID подтверждаемого устройства" format(uuid)
+// @Param request body DeviceApprovalRequest true "Токен доверенного устройства"
+// @Success 200 {object} DeviceResponse "Устройство подтверждено"
+// @Failure 400 {object} map[string]string "Неверный формат данных"
+// @Failure 401 {object} map[string]string "Не авторизован"
+// @Failure 403 {object} map[string]string "Устройство не может принимать решение"
+// @Failure 404 {object} map[string]string "Устройство не найдено"
+// @Failure 409 {object} map[string]string "Устройство не ожидает подтверждения"
+// @Failure 500 {object} map[string]string "Внутренняя ошибка сервера"
+// @Router /devices/{id}/approve [post]
+func (h *DeviceHandler) Approve(c *gin.Context) {
+	h.decideApproval(c, true)
+}
+
+// Reject godoc
+// @Summary Отклонение устройства
+// @Description Отклоняет ожидающее устройство: оно не сможет подключаться к signaling серверу и участвовать в передачах. Решение принимает доверенное устройство пользователя, передавая свой device_token.
+// @Tags devices
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "ID отклоняемого устройства" format(uuid)
+// @Param request body DeviceApprovalRequest true "Токен доверенного устройства"
+// @Success 200 {object} DeviceResponse "Устройство отклонено"
+// @Failure 400 {object} map[string]string "Неверный формат данных"
+// @Failure 401 {object} map[string]string "Не авторизован"
+// @Failure 403 {object} map[string]string "Устройство не может принимать решение"
+// @Failure 404 {object} map[string]string "Устройство не найдено"
+// @Failure 409 {object} map[string]string "Устройство не ожидает подтверждения"
+// @Failure 500 {object} map[string]string "Внутренняя ошибка сервера"
+// @Router /devices/{id}/reject [post]
+func (h *DeviceHandler) Reject(c *gin.Context) {
+	h.decideApproval(c, false)
+}
+
+func (h *DeviceHandler) decideApproval(c *gin.Context, approve bool) {
+	userID, exists := middleware.GetUserID(c)
+	if !exists {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "unauthorized"})
+		return
+	}
+
+	deviceID := c.Param("id")
+	if deviceID == "" {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "device_id is required"})
+		return
+	}
+
+	var req DeviceApprovalRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	var device *devicepb.Device
+	var err error
+	if approve {
+		var resp *devicepb.ApproveDeviceResponse
+		resp, err = h.deviceClient.ApproveDevice(context.Background(), &devicepb.ApproveDeviceRequest{
+			DeviceId:            deviceID,
+			UserId:              userID.String(),
+			ApproverDeviceToken: req.DeviceToken,
+		})
+		if err == nil {
+			device = resp.Device
+		}
+	} else {
+		var resp *devicepb.RejectDeviceResponse
+		resp, err = h.deviceClient.RejectDevice(context.Background(), &devicepb.RejectDeviceRequest{
+			DeviceId:            deviceID,
+			UserId:              userID.String(),
+			ApproverDeviceToken: req.DeviceToken,
+		})
+		if err == nil {
+			device = resp.Device
+		}
+	}
+	if err != nil {
+		if st, ok := status.FromError(err); ok {
+			switch st.Code() {
+			case codes.InvalidArgument:
+				c.JSON(http.StatusBadRequest, gin.H{"error": st.Message()})
+			case codes.NotFound:
+				c.JSON(http.StatusNotFound, gin.H{"error": st.Message()})
+			case codes.PermissionDenied:
+				c.JSON(http.StatusForbidden, gin.H{"error": st.Message()})
+			case codes.FailedPrecondition:
+				c.JSON(http.StatusConflict, gin.H{"error": st.Message()})
+			default:
+				c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to update device approval"})
+			}
+			return
+		}
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to update device approval"})
+		return
+	}
+
+	c.JSON(http.StatusOK, deviceToResponse(device))
+}
+
+// SetPushToken godoc
+// @Summary Регистрация push-токена устройства
+// @Description Сохраняет токен FCM, APNs или подписку Web Push (JSON PushSubscription с HTTPS endpoint push-сервиса браузера). Когда устройство не подключено к signaling серверу, входящие передачи и offer будят приложение push-уведомлением.
+// @Tags devices
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "ID устройства" format(uuid)
+// @Param request body SetPushTokenRequest true "Провайдер и токен"
+// @Success 200 {object} DeviceResponse "Токен сохранен"
+// @Failure 400 {object} map[string]string "Неверный формат данных"
+// @Failure 401 {object} map[string]string "Не авторизован"
+// @Failure 403 {object} map[string]string "Нет доступа к устройству"
+// @Failure 404 {object} map[string]string "Устройство не найдено"
+// @Failure 500 {object} map[string]string "Внутренняя ошибка сервера"
+// @Router /devices/{id}/push-token [put]
+func (h *DeviceHandler) SetPushToken(c *gin.Context) {
+	var req SetPushTokenRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	h.setPushToken(c, req.Provider, req.Token)
+}
+
+// DeletePushToken godoc
+// @Summary Отключение push-уведомлений устройства
+// @Description Удаляет push-токен устройства
+// @Tags devices
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "ID устройства" format(uuid)
+// @Success 200 {object} DeviceResponse "Токен удален"
+// @Failure 400 {object} map[string]string "Неверный ID устройства"
+// @Failure 401 {object} map[string]string "Не авторизован"
+// @Failure 403 {object} map[string]string "Нет доступа к устройству"
+// @Failure 404 {object} map[string]string "Устройство не найдено"
+// @Failure 500 {object} map[string]string "Внутренняя ошибка сервера"
+// @Router /devices/{id}/push-token [delete]
+func (h *DeviceHandler) DeletePushToken(c *gin.Context) {
+	h.setPushToken(c, "", "")
+}
+
+func (h *DeviceHandler) setPushToken(c *gin.Context, provider, token string) {
+	userID, exists := middleware.GetUserID(c)
+	if !exists {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "unauthorized"})
+		return
+	}
+
+	deviceID := c.Param("id")
+	if deviceID == "" {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "device_id is required"})
+		return
+	}
+
+	resp, err := h.deviceClient.SetPushToken(context.Background(), &devicepb.SetPushTokenRequest{
+		DeviceId: deviceID,
+		UserId:   userID.String(),
+		Provider: provider,
+		Token:    token,
+	})
+	if err != nil {
+		if st, ok := status.FromError(err); ok {
+			switch st.Code() {
+			case codes.InvalidArgument:
+				c.JSON(http.StatusBadRequest, gin.H{"error": st.Message()})
+			case codes.NotFound:
+				c.JSON(http.StatusNotFound, gin.H{"error": st.Message()})
+			case codes.PermissionDenied:
+				c.JSON(http.StatusForbidden, gin.H{"error": st.Message()})
+			default:
+				c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to update push token"})
+			}
+			return
+		}
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to update push token"})
+		return
+	}
+
+	c.JSON(http.StatusOK, deviceToResponse(resp.Device))
+}
+
+// SetPruneExempt godoc
+// @Summary Исключение устройства из отключения по неактивности
+// @Description Исключает устройство из автоматического отключения по неактивности или возвращает его под действие политики. Устройство организации могут настроить также владелец и администраторы организации.
+// @Tags devices
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "ID устройства" format(uuid)
+// @Param request body SetDevicePruneExemptRequest true "Исключение"
+// @Success 200 {object} DeviceResponse "Устройство обновлено"
+// @Failure 400 {object} map[string]string "Неверный формат данных"
+// @Failure 401 {object} map[string]string "Не авторизован"
+// @Failure 403 {object} map[string]string "Нет доступа к устройству"
+// @Failure 404 {object} map[string]string "Устройство не найдено"
+// @Failure 500 {object} map[string]string "Внутренняя ошибка сервера"
+// @Router /devices/{id}/prune-exempt [put]
+func (h *DeviceHandler) SetPruneExempt(c *gin.Context) {
+	userID, exists := middleware.GetUserID(c)
+	if !exists {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "unauthorized"})
+		return
+	}
+
+	deviceID := c.Param("id")
+	if deviceID == "" {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "device_id is required"})
+		return
+	}
+
+	var req SetDevicePruneExemptRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	resp, err := h.deviceClient.SetDevicePruneExempt(context.Background(), &devicepb.SetDevicePruneExemptRequest{
+		DeviceId: deviceID,
+		UserId:   userID.String(),
+		Exempt:   req.Exempt,
+	})
+	if err != nil {
+		if st, ok := status.FromError(err); ok {
+			switch st.Code() {
+			case codes.InvalidArgument:
+				c.JSON(http.StatusBadRequest, gin.H{"error": st.Message()})
+			case codes.NotFound:
+				c.JSON(http.StatusNotFound, gin.H{"error": st.Message()})
+			case codes.PermissionDenied:
+				c.JSON(http.StatusForbidden, gin.H{"error": st.Message()})
+			default:
+				c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to update device"})
+			}
+			return
+		}
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to update device"})
+		return
+	}
+
+	c.JSON(http.StatusOK, deviceToResponse(resp.Device))
+}
+
+// GetInactivityPolicy godoc
+// @Summary Политика неактивности устройств
+// @Description Возвращает, через сколько дней без подключения устройства пользователя отключаются. За warning_period_seconds до отключения подключенные устройства получают событие device-inactivity-warning, а само устройство - push-уведомление.
+// @Tags devices
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Success 200 {object} InactivityPolicy "Политика неактивности"
+// @Failure 401 {object} map[string]string "Не авторизован"
+// @Failure 404 {object} map[string]string "Пользователь не найден"
+// @Failure 500 {object} map[string]string "Внутренняя ошибка сервера"
+// @Router /devices/inactivity-policy [get]
+func (h *DeviceHandler) GetInactivityPolicy(c *gin.Context) {
+	userID, exists := middleware.GetUserID(c)
+	if !exists {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "unauthorized"})
+		return
+	}
+
+	resp, err := h.deviceClient.GetInactivityPolicy(context.Background(), &devicepb.GetInactivityPolicyRequest{
+		UserId: userID.String(),
+	})
+	if err != nil {
+		if st, ok := status.FromError(err); ok && st.Code() == codes.NotFound {
+			c.JSON(http.StatusNotFound, gin.H{"error": st.Message()})
+			return
+		}
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to get inactivity policy"})
+		return
+	}
+
+	c.JSON(http.StatusOK, inactivityPolicyToResponse(resp.Policy))
+}
+
+// UpdateInactivityPolicy godoc
+// @Summary Изменение политики неактивности устройств
+// @Description Задает порог неактивности в днях (0 - не отключать устройства) или возвращает порог по умолчанию (use_default=true)
+// @Tags devices
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param request body UpdateInactivityPolicyRequest true "Порог неактивности"
+// @Success 200 {object} InactivityPolicy "Политика обновлена"
+// @Failure 400 {object} map[string]string "Неверный формат данных"
+// @Failure 401 {object} map[string]string "Не авторизован"
+// @Failure 404 {object} map[string]string "Пользователь не найден"
+// @Failure 500 {object} map[string]string "Внутренняя ошибка сервера"
+// @Router /devices/inactivity-policy [put]
+func (h *DeviceHandler) UpdateInactivityPolicy(c *gin.Context) {
+	userID, exists := middleware.GetUserID(c)
+	if !exists {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "unauthorized"})
+		return
+	}
+
+	var req UpdateInactivityPolicyRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	resp, err := h.deviceClient.UpdateInactivityPolicy(context.Background(), &devicepb.UpdateInactivityPolicyRequest{
+		UserId:         userID.String(),
+		InactivityDays: req.InactivityDays,
+		UseDefault:     req.UseDefault,
+	})
+	if err != nil {
+		if st, ok := status.FromError(err); ok {
+			switch st.Code() {
+			case codes.InvalidArgument:
+				c.JSON(http.StatusBadRequest, gin.H{"error": st.Message()})
+			case codes.NotFound:
+				c.JSON(http.StatusNotFound, gin.H{"error": st.Message()})
+			default:
+				c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to update inactivity policy"})
+			}
+			return
+		}
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to update inactivity policy"})
+		return
+	}
+
+	c.JSON(http.StatusOK, inactivityPolicyToResponse(resp.Policy))
+}
+
+// CreatePairingCode godoc
+// @Summary Код сопряжения устройства
+// @Description Авторизованное подтвержденное устройство запрашивает короткий цифровой код (и payload для QR-кода), по которому новое устройство регистрируется без ввода пароля и сразу считается подтвержденным
+// @Tags devices
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param request body CreatePairingCodeRequest true "Устройство, запрашивающее код"
+// @Success 201 {object} PairingCodeResponse "Код сопряжения"
+// @Failure 400 {object} map[string]string "Неверный формат данных"
+// @Failure 401 {object} map[string]string "Не авторизован"
+// @Failure 403 {object} map[string]string "Нет доступа к устройству"
+// @Failure 404 {object} map[string]string "Устройство не найдено"
+// @Failure 500 {object} map[string]string "Внутренняя ошибка сервера"
+// @Router /devices/pairing [post]
+func (h *DeviceHandler) CreatePairingCode(c *gin.Context) {
+	userID, exists := middleware.GetUserID(c)
+	if !exists {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "unauthorized"})
+		return
+	}
+
+	var req CreatePairingCodeRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	resp, err := h.deviceClient.CreatePairingCode(context.Background(), &devicepb.CreatePairingCodeRequest{
+		UserId:   userID.String(),
+		DeviceId: req.DeviceID,
+	})
+	if err != nil {
+		if st, ok := status.FromError(err); ok {
+			switch st.Code() {
+			case codes.InvalidArgument:
+				c.JSON(http.StatusBadRequest, gin.H{"error": st.Message()})
+			case codes.NotFound:
+				c.JSON(http.StatusNotFound, gin.H{"error": st.Message()})
+			case codes.PermissionDenied:
+				c.JSON(http.StatusForbidden, gin.H{"error": st.Message()})
+			default:
+				c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to create pairing code"})
+			}
+			return
+		}
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to create pairing code"})
+		return
+	}
+
+	c.JSON(http.StatusCreated, PairingCodeResponse{
+		Code:      resp.Code,
+		QRPayload: resp.QrPayload,
+		ExpiresAt: resp.ExpiresAt,
+	})
+}
+
+// RedeemPairingCode godoc
+// @Summary Регистрация устройства по коду сопряжения
+// @Description Новое устройство погашает код сопряжения и получает device_token для того же пользователя (не требует аутентификации). Число попыток с одного адреса ограничено.
+// @Tags devices
+// @Accept json
+// @Produce json
+// @Param request body RedeemPairingCodeRequest true "Код и данные устройства"
+// @Success 201 {object} RegisterDeviceResponse "Устройство зарегистрировано"
+// @Failure 400 {object} map[string]string "Неверный формат данных"
+// @Failure 404 {object} map[string]string "Код не найден или истек"
+// @Failure 429 {object} map[string]string "Слишком много попыток"
+// @Failure 500 {object} map[string]string "Внутренняя ошибка сервера"
+// @Router /devices/pairing/redeem [post]
+func (h *DeviceHandler) RedeemPairingCode(c *gin.Context) {
+	var req RedeemPairingCodeRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	resp, err := h.deviceClient.RedeemPairingCode(context.Background(), &devicepb.RedeemPairingCodeRequest{
+		Code:       req.Code,
+		Name:       req.Name,
+		DeviceType: req.DeviceType,
+		ClientIp:   c.ClientIP(),
+		Info:       deviceInfoToProto(req.Info),
+	})
+	if err != nil {
+		if st, ok := status.FromError(err); ok {
+			switch st.Code() {
+			case codes.InvalidArgument:
+				c.JSON(http.StatusBadRequest, gin.H{"error": st.Message()})
+			case codes.NotFound:
+				c.JSON(http.StatusNotFound, gin.H{"error": st.Message()})
+			case codes.ResourceExhausted:
+				c.JSON(http.StatusTooManyRequests, gin.H{"error": st.Message()})
+			default:
+				c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to redeem pairing code"})
+			}
+			return
+		}
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to redeem pairing code"})
+		return
+	}
+
+	device := deviceToResponse(resp.Device)
+	c.JSON(http.StatusCreated, RegisterDeviceResponse{
+		Device:      &device,
+		DeviceToken: resp.DeviceToken,
+	})
+}
+
+// IssueSignalingTicket godoc
+// @Summary Билет для подключения к signaling серверу
+// @Description Выпускает короткоживущий билет, которым устройство аутентифицируется на WebSocket signaling сервере вместо device_token (не требует аутентификации пользователя)
+// @Tags signaling
+// @Accept json
+// @Produce json
+// @Param request body SignalingTicketRequest true "Устройство и его токен"
+// @Success 201 {object} SignalingTicketResponse "Билет выпущен"
+// @Failure 400 {object} map[string]string "Неверный формат данных"
+// @Failure 401 {object} map[string]string "Неверный токен устройства"
+// @Failure 403 {object} map[string]string "Устройство не подтверждено"
+// @Failure 500 {object} map[string]string "Внутренняя ошибка сервера"
+// @Router /signaling/ticket [post]
+func (h *DeviceHandler) IssueSignalingTicket(c *gin.Context) {
+	var req SignalingTicketRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	resp, err := h.deviceClient.IssueSignalingTicket(context.Background(), &devicepb.IssueSignalingTicketRequest{
+		DeviceId:    req.DeviceID,
+		DeviceToken: req.DeviceToken,
+	})
+	if err != nil {
+		if st, ok := status.FromError(err); ok {
+			switch st.Code() {
+			case codes.InvalidArgument:
+				c.JSON(http.StatusBadRequest, gin.H{"error": st.Message()})
+			case codes.Unauthenticated:
+				c.JSON(http.StatusUnauthorized, gin.H{"error": st.Message()})
+			case codes.PermissionDenied:
+				c.JSON(http.StatusForbidden, gin.H{"error": st.Message()})
+			default:
+				c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to issue signaling ticket"})
+			}
+			return
+		}
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to issue signaling ticket"})
+		return
+	}
+
+	c.JSON(http.StatusCreated, SignalingTicketResponse{
+		Ticket:    resp.Ticket,
+		ExpiresAt: resp.ExpiresAt,
+	})
+}
+
 // UpdateLastSeen godoc
 // @Summary Обновление времени последней активности
 // @Description Обновляет время последней активности устройства (не требует аутентификации)
@@ -378,3 +1062,51 @@ func (h *DeviceHandler) UpdateLastSeen(c *gin.Context) {
 		"success": resp.Success,
 	})
 }
+
+func deviceToResponse(device *devicepb.Device) DeviceResponse {
+	return DeviceResponse{
+		ID:             device.Id,
+		UserID:         device.UserId,
+		OrganizationID: device.OrganizationId,
+		Name:           device.Name,
+		DeviceType:     device.DeviceType,
+		LastSeenAt:     device.LastSeenAt,
+		PresenceStatus: device.PresenceStatus,
+		ConnectedSince: device.ConnectedSince,
+		ApprovalStatus: device.ApprovalStatus,
+		PushProvider:   device.PushProvider,
+		PruneExempt:    device.PruneExempt,
+		DeactivatedAt:  device.DeactivatedAt,
+		Info: DeviceInfo{
+			Platform:          device.Info.GetPlatform(),
+			OSVersion:         device.Info.GetOsVersion(),
+			AppVersion:        device.Info.GetAppVersion(),
+			TransferProtocols: device.Info.GetTransferProtocols(),
+			MaxFileSize:       device.Info.GetMaxFileSize(),
+		},
+		CreatedAt: device.CreatedAt,
+		UpdatedAt: device.UpdatedAt,
+	}
+}
+
+func deviceInfoToProto(info *DeviceInfo) *devicepb.DeviceInfo {
+	if info == nil {
+		return nil
+	}
+
+	return &devicepb.DeviceInfo{
+		Platform:          info.Platform,
+		OsVersion:         info.OSVersion,
+		AppVersion:        info.AppVersion,
+		TransferProtocols: info.TransferProtocols,
+		MaxFileSize:       info.MaxFileSize,
+	}
+}
+
+func inactivityPolicyToResponse(policy *devicepb.InactivityPolicy) InactivityPolicy {
+	return InactivityPolicy{
+		InactivityDays:       policy.GetInactivityDays(),
+		IsDefault:            policy.GetIsDefault(),
+		WarningPeriodSeconds: policy.GetWarningPeriodSeconds(),
+	}
+}
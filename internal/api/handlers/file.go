@@ -24,16 +24,17 @@ func NewFileHandler(fileClient filepb.FileServiceClient) *FileHandler {
 }
 
 type FileResponse struct {
-	ID          string `json:"id" example:"550e8400-e29b-41d4-a716-446655440000"`
-	UserID      string `json:"user_id" example:"550e8400-e29b-41d4-a716-446655440000"`
-	Name        string `json:"name" example:"document.pdf"`
-	Size        int64  `json:"size" example:"1024000"`
-	MimeType    string `json:"mime_type" example:"application/pdf"`
-	StoragePath string `json:"storage_path" example:"users/550e8400-e29b-41d4-a716-446655440000/files/..."`
-	StorageType string `json:"storage_type" example:"local"`
-	ExpiresAt   string `json:"expires_at,omitempty" example:"2024-02-01T00:00:00Z"`
-	CreatedAt   string `json:"created_at" example:"2024-01-01T00:00:00Z"`
-	UpdatedAt   string `json:"updated_at" example:"2024-01-01T00:00:00Z"`
+	ID             string `json:"id" example:"550e8400-e29b-41d4-a716-446655440000"`
+	UserID         string `json:"user_id" example:"550e8400-e29b-41d4-a716-446655440000"`
+	OrganizationID string `json:"organization_id,omitempty" example:"550e8400-e29b-41d4-a716-446655440000"`
+	Name           string `json:"name" example:"document.pdf"`
+	Size           int64  `json:"size" example:"1024000"`
+	MimeType       string `json:"mime_type" example:"application/pdf"`
+	StoragePath    string `json:"storage_path" example:"users/550e8400-e29b-41d4-a716-446655440000/files/..."`
+	StorageType    string `json:"storage_type" example:"local"`
+	ExpiresAt      string `json:"expires_at,omitempty" example:"2024-02-01T00:00:00Z"`
+	CreatedAt      string `json:"created_at" example:"2024-01-01T00:00:00Z"`
+	UpdatedAt      string `json:"updated_at" example:"2024-01-01T00:00:00Z"`
 }
 
 type ListFilesResponse struct {
@@ -55,9 +56,13 @@ type UploadFileResponse struct {
 // @Produce json
 // @Security BearerAuth
 // @Param file formData file true "Файл для загрузки"
+// @Param organization_id formData string false "ID организации для загрузки в общее хранилище" format(uuid)
 // @Success 201 {object} UploadFileResponse "Файл успешно загружен"
 // @Failure 400 {object} map[string]string "Неверный формат данных"
 // @Failure 401 {object} map[string]string "Не авторизован"
+// @Failure 403 {object} map[string]string "Пользователь не состоит в организации"
+// @Failure 404 {object} map[string]string "Организация не найдена"
+// @Failure 413 {object} map[string]string "Превышена квота хранилища организации"
 // @Failure 500 {object} map[string]string "Внутренняя ошибка сервера"
 // @Router /files [post]
 func (h *FileHandler) Upload(c *gin.Context) {
@@ -102,10 +107,11 @@ func (h *FileHandler) Upload(c *gin.Context) {
 	err = stream.Send(&filepb.UploadFileRequest{
 		Data: &filepb.UploadFileRequest_Metadata{
 			Metadata: &filepb.FileMetadata{
-				Name:     fileHeader.Filename,
-				Size:     fileSize,
-				MimeType: mimeType,
-				UserId:   userID.String(),
+				Name:           fileHeader.Filename,
+				Size:           fileSize,
+				MimeType:       mimeType,
+				UserId:         userID.String(),
+				OrganizationId: c.PostForm("organization_id"),
 			},
 		},
 	})
@@ -148,6 +154,12 @@ func (h *FileHandler) Upload(c *gin.Context) {
 			switch st.Code() {
 			case codes.InvalidArgument:
 				c.JSON(http.StatusBadRequest, gin.H{"error": st.Message()})
+			case codes.PermissionDenied:
+				c.JSON(http.StatusForbidden, gin.H{"error": st.Message()})
+			case codes.NotFound:
+				c.JSON(http.StatusNotFound, gin.H{"error": st.Message()})
+			case codes.ResourceExhausted:
+				c.JSON(http.StatusRequestEntityTooLarge, gin.H{"error": st.Message()})
 			default:
 				c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to upload file"})
 			}
@@ -295,31 +307,23 @@ func (h *FileHandler) GetMetadata(c *gin.Context) {
 		return
 	}
 
-	c.JSON(http.StatusOK, FileResponse{
-		ID:          resp.File.Id,
-		UserID:      resp.File.UserId,
-		Name:        resp.File.Name,
-		Size:        resp.File.Size,
-		MimeType:    resp.File.MimeType,
-		StoragePath: resp.File.StoragePath,
-		StorageType: resp.File.StorageType,
-		ExpiresAt:   resp.File.ExpiresAt,
-		CreatedAt:   resp.File.CreatedAt,
-		UpdatedAt:   resp.File.UpdatedAt,
-	})
+	c.JSON(http.StatusOK, fileToResponse(resp.File))
 }
 
 // List godoc
 // @Summary Список файлов
-// @Description Возвращает список файлов пользователя с пагинацией
+// @Description Возвращает список личных файлов пользователя или файлов организации с пагинацией
 // @Tags files
 // @Accept json
 // @Produce json
 // @Security BearerAuth
 // @Param limit query int false "Лимит файлов" default(50)
 // @Param offset query int false "Смещение" default(0)
+// @Param organization_id query string false "ID организации" format(uuid)
 // @Success 200 {object} ListFilesResponse "Список файлов"
+// @Failure 400 {object} map[string]string "Неверный ID организации"
 // @Failure 401 {object} map[string]string "Не авторизован"
+// @Failure 403 {object} map[string]string "Пользователь не состоит в организации"
 // @Router /files [get]
 func (h *FileHandler) List(c *gin.Context) {
 	userID, exists := middleware.GetUserID(c)
@@ -343,29 +347,30 @@ func (h *FileHandler) List(c *gin.Context) {
 	}
 
 	resp, err := h.fileClient.ListFiles(context.Background(), &filepb.ListFilesRequest{
-		UserId: userID.String(),
-		Limit:  limit,
-		Offset: offset,
+		UserId:         userID.String(),
+		Limit:          limit,
+		Offset:         offset,
+		OrganizationId: c.Query("organization_id"),
 	})
 	if err != nil {
+		if st, ok := status.FromError(err); ok {
+			switch st.Code() {
+			case codes.InvalidArgument:
+				c.JSON(http.StatusBadRequest, gin.H{"error": st.Message()})
+			case codes.PermissionDenied:
+				c.JSON(http.StatusForbidden, gin.H{"error": st.Message()})
+			default:
+				c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to list files"})
+			}
+			return
+		}
 		c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to list files"})
 		return
 	}
 
 	files := make([]FileResponse, len(resp.Files))
 	for i, file := range resp.Files {
-		files[i] = FileResponse{
-			ID:          file.Id,
-			UserID:      file.UserId,
-			Name:        file.Name,
-			Size:        file.Size,
-			MimeType:    file.MimeType,
-			StoragePath: file.StoragePath,
-			StorageType: file.StorageType,
-			ExpiresAt:   file.ExpiresAt,
-			CreatedAt:   file.CreatedAt,
-			UpdatedAt:   file.UpdatedAt,
-		}
+		files[i] = fileToResponse(file)
 	}
 
 	c.JSON(http.StatusOK, ListFilesResponse{
@@ -424,3 +429,19 @@ func (h *FileHandler) Delete(c *gin.Context) {
 		"success": resp.Success,
 	})
 }
+
+func fileToResponse(file *filepb.FileInfo) FileResponse {
+	return FileResponse{
+		ID:             file.Id,
+		UserID:         file.UserId,
+		OrganizationID: file.OrganizationId,
+		Name:           file.Name,
+		Size:           file.Size,
+		MimeType:       file.MimeType,
+		StoragePath:    file.StoragePath,
+		StorageType:    file.StorageType,
+		ExpiresAt:      file.ExpiresAt,
+		CreatedAt:      file.CreatedAt,
+		UpdatedAt:      file.UpdatedAt,
+	}
+}
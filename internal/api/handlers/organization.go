@@ -0,0 +1,548 @@
+package handlers
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/backend-app/backend/internal/api/middleware"
+	organizationpb "github.com/backend-app/backend/pkg/proto/organization"
+	"github.com/gin-gonic/gin"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+type OrganizationHandler struct {
+	organizationClient organizationpb.OrganizationServiceClient
+}
+
+func NewOrganizationHandler(organizationClient organizationpb.OrganizationServiceClient) *OrganizationHandler {
+	return &OrganizationHandler{
+		organizationClient: organizationClient,
+	}
+}
+
+type CreateOrganizationRequest struct {
+	Name              string `json:"name" binding:"required" example:"Acme Inc"`
+	StorageQuotaBytes int64  `json:"storage_quota_bytes,omitempty" binding:"omitempty,min=0" example:"10737418240"`
+}
+
+type UpdateOrganizationRequest struct {
+	Name              string `json:"name,omitempty" example:"Acme Inc"`
+	StorageQuotaBytes *int64 `json:"storage_quota_bytes,omitempty" binding:"omitempty,min=0" example:"10737418240"`
+}
+
+type OrganizationResponse struct {
+	ID                string `json:"id" example:"550e8400-e29b-41d4-a716-446655440000"`
+	Name              string `json:"name" example:"Acme Inc"`
+	StorageQuotaBytes int64  `json:"storage_quota_bytes" example:"10737418240"`
+	StorageUsedBytes  int64  `json:"storage_used_bytes" example:"1024000"`
+	Role              string `json:"role" example:"owner"`
+	CreatedAt         string `json:"created_at" example:"2024-01-01T00:00:00Z"`
+	UpdatedAt         string `json:"updated_at" example:"2024-01-01T00:00:00Z"`
+}
+
+type ListOrganizationsResponse struct {
+	Organizations []OrganizationResponse `json:"organizations"`
+	Total         int                    `json:"total" example:"2"`
+}
+
+type AddOrganizationMemberRequest struct {
+	Email string `json:"email" binding:"required,email" example:"colleague@example.com"`
+	Role  string `json:"role,omitempty" binding:"omitempty,oneof=owner admin member" example:"member"`
+}
+
+type UpdateOrganizationMemberRequest struct {
+	Role string `json:"role" binding:"required,oneof=owner admin member" example:"admin"`
+}
+
+type OrganizationMemberResponse struct {
+	OrganizationID string `json:"organization_id" example:"550e8400-e29b-41d4-a716-446655440000"`
+	UserID         string `json:"user_id" example:"550e8400-e29b-41d4-a716-446655440000"`
+	Email          string `json:"email" example:"colleague@example.com"`
+	Role           string `json:"role" example:"member"`
+	CreatedAt      string `json:"created_at" example:"2024-01-01T00:00:00Z"`
+	UpdatedAt      string `json:"updated_at" example:"2024-01-01T00:00:00Z"`
+}
+
+type ListOrganizationMembersResponse struct {
+	Members []OrganizationMemberResponse `json:"members"`
+	Total   int                          `json:"total" example:"3"`
+}
+
+// Create godoc
+// @Summary Создание организации
+// @Description Создает организацию с общим хранилищем, текущий пользователь становится владельцем. storage_quota_bytes = 0 - без ограничения.
+// @Tags organizations
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param request body CreateOrganizationRequest true "Данные организации"
+// @Success 201 {object} OrganizationResponse "Организация создана"
+// @Failure 400 {object} map[string]string "Неверный формат данных"
+// @Failure 401 {object} map[string]string "Не авторизован"
+// @Failure 500 {object} map[string]string "Внутренняя ошибка сервера"
+// @Router /organizations [post]
+func (h *OrganizationHandler) Create(c *gin.Context) {
+	userID, exists := middleware.GetUserID(c)
+	if !exists {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "unauthorized"})
+		return
+	}
+
+	var req CreateOrganizationRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	resp, err := h.organizationClient.CreateOrganization(context.Background(), &organizationpb.CreateOrganizationRequest{
+		UserId:            userID.String(),
+		Name:              req.Name,
+		StorageQuotaBytes: req.StorageQuotaBytes,
+	})
+	if err != nil {
+		if st, ok := status.FromError(err); ok {
+			switch st.Code() {
+			case codes.InvalidArgument:
+				c.JSON(http.StatusBadRequest, gin.H{"error": st.Message()})
+			default:
+				c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to create organization"})
+			}
+			return
+		}
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to create organization"})
+		return
+	}
+
+	c.JSON(http.StatusCreated, organizationToResponse(resp.Organization))
+}
+
+// List godoc
+// @Summary Список организаций
+// @Description Возвращает организации, в которых состоит пользователь, с его ролью и использованием хранилища
+// @Tags organizations
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Success 200 {object} ListOrganizationsResponse "Список организаций"
+// @Failure 401 {object} map[string]string "Не авторизован"
+// @Failure 500 {object} map[string]string "Внутренняя ошибка сервера"
+// @Router /organizations [get]
+func (h *OrganizationHandler) List(c *gin.Context) {
+	userID, exists := middleware.GetUserID(c)
+	if !exists {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "unauthorized"})
+		return
+	}
+
+	resp, err := h.organizationClient.ListOrganizations(context.Background(), &organizationpb.ListOrganizationsRequest{
+		UserId: userID.String(),
+	})
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to list organizations"})
+		return
+	}
+
+	organizations := make([]OrganizationResponse, len(resp.Organizations))
+	for i, org := range resp.Organizations {
+		organizations[i] = organizationToResponse(org)
+	}
+
+	c.JSON(http.StatusOK, ListOrganizationsResponse{
+		Organizations: organizations,
+		Total:         len(organizations),
+	})
+}
+
+// Get godoc
+// @Summary Получение организации
+// @Description Возвращает информацию об организации, квоте и использовании хранилища
+// @Tags organizations
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "ID организации" format(uuid)
+// @Success 200 {object} OrganizationResponse "Информация об организации"
+// @Failure 400 {object} map[string]string "Неверный ID организации"
+// @Failure 401 {object} map[string]string "Не авторизован"
+// @Failure 403 {object} map[string]string "Пользователь не состоит в организации"
+// @Failure 404 {object} map[string]string "Организация не найдена"
+// @Router /organizations/{id} [get]
+func (h *OrganizationHandler) Get(c *gin.Context) {
+	userID, exists := middleware.GetUserID(c)
+	if !exists {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "unauthorized"})
+		return
+	}
+
+	resp, err := h.organizationClient.GetOrganization(context.Background(), &organizationpb.GetOrganizationRequest{
+		OrganizationId: c.Param("id"),
+		UserId:         userID.String(),
+	})
+	if err != nil {
+		if st, ok := status.FromError(err); ok {
+			switch st.Code() {
+			case codes.InvalidArgument:
+				c.JSON(http.StatusBadRequest, gin.H{"error": st.Message()})
+			case codes.NotFound:
+				c.JSON(http.StatusNotFound, gin.H{"error": st.Message()})
+			case codes.PermissionDenied:
+				c.JSON(http.StatusForbidden, gin.H{"error": st.Message()})
+			default:
+				c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to get organization"})
+			}
+			return
+		}
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to get organization"})
+		return
+	}
+
+	c.JSON(http.StatusOK, organizationToResponse(resp.Organization))
+}
+
+// Update godoc
+// @Summary Обновление организации
+// @Description Изменяет название и квоту хранилища организации (владелец или администратор). Не переданные поля не меняются, storage_quota_bytes = 0 снимает ограничение.
+// @Tags organizations
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "ID организации" format(uuid)
+// @Param request body UpdateOrganizationRequest true "Данные для обновления"
+// @Success 200 {object} OrganizationResponse "Организация обновлена"
+// @Failure 400 {object} map[string]string "Неверный формат данных"
+// @Failure 401 {object} map[string]string "Не авторизован"
+// @Failure 403 {object} map[string]string "Недостаточно прав"
+// @Failure 404 {object} map[string]string "Организация не найдена"
+// @Router /organizations/{id} [put]
+func (h *OrganizationHandler) Update(c *gin.Context) {
+	userID, exists := middleware.GetUserID(c)
+	if !exists {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "unauthorized"})
+		return
+	}
+
+	var req UpdateOrganizationRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	resp, err := h.organizationClient.UpdateOrganization(context.Background(), &organizationpb.UpdateOrganizationRequest{
+		OrganizationId:    c.Param("id"),
+		UserId:            userID.String(),
+		Name:              req.Name,
+		StorageQuotaBytes: req.StorageQuotaBytes,
+	})
+	if err != nil {
+		if st, ok := status.FromError(err); ok {
+			switch st.Code() {
+			case codes.InvalidArgument:
+				c.JSON(http.StatusBadRequest, gin.H{"error": st.Message()})
+			case codes.NotFound:
+				c.JSON(http.StatusNotFound, gin.H{"error": st.Message()})
+			case codes.PermissionDenied:
+				c.JSON(http.StatusForbidden, gin.H{"error": st.Message()})
+			default:
+				c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to update organization"})
+			}
+			return
+		}
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to update organization"})
+		return
+	}
+
+	c.JSON(http.StatusOK, organizationToResponse(resp.Organization))
+}
+
+// Delete godoc
+// @Summary Удаление организации
+// @Description Удаляет организацию вместе с ее файлами (только владелец). Устройства отвязываются от организации.
+// @Tags organizations
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "ID организации" format(uuid)
+// @Success 200 {object} map[string]bool "Организация удалена"
+// @Failure 401 {object} map[string]string "Не авторизован"
+// @Failure 403 {object} map[string]string "Недостаточно прав"
+// @Failure 404 {object} map[string]string "Организация не найдена"
+// @Router /organizations/{id} [delete]
+func (h *OrganizationHandler) Delete(c *gin.Context) {
+	userID, exists := middleware.GetUserID(c)
+	if !exists {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "unauthorized"})
+		return
+	}
+
+	resp, err := h.organizationClient.DeleteOrganization(context.Background(), &organizationpb.DeleteOrganizationRequest{
+		OrganizationId: c.Param("id"),
+		UserId:         userID.String(),
+	})
+	if err != nil {
+		if st, ok := status.FromError(err); ok {
+			switch st.Code() {
+			case codes.InvalidArgument:
+				c.JSON(http.StatusBadRequest, gin.H{"error": st.Message()})
+			case codes.NotFound:
+				c.JSON(http.StatusNotFound, gin.H{"error": st.Message()})
+			case codes.PermissionDenied:
+				c.JSON(http.StatusForbidden, gin.H{"error": st.Message()})
+			default:
+				c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to delete organization"})
+			}
+			return
+		}
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to delete organization"})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"success": resp.Success,
+	})
+}
+
+// ListMembers godoc
+// @Summary Участники организации
+// @Description Возвращает список участников организации с их ролями
+// @Tags organizations
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "ID организации" format(uuid)
+// @Success 200 {object} ListOrganizationMembersResponse "Список участников"
+// @Failure 401 {object} map[string]string "Не авторизован"
+// @Failure 403 {object} map[string]string "Пользователь не состоит в организации"
+// @Failure 404 {object} map[string]string "Организация не найдена"
+// @Router /organizations/{id}/members [get]
+func (h *OrganizationHandler) ListMembers(c *gin.Context) {
+	userID, exists := middleware.GetUserID(c)
+	if !exists {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "unauthorized"})
+		return
+	}
+
+	resp, err := h.organizationClient.ListMembers(context.Background(), &organizationpb.ListMembersRequest{
+		OrganizationId: c.Param("id"),
+		UserId:         userID.String(),
+	})
+	if err != nil {
+		if st, ok := status.FromError(err); ok {
+			switch st.Code() {
+			case codes.InvalidArgument:
+				c.JSON(http.StatusBadRequest, gin.H{"error": st.Message()})
+			case codes.NotFound:
+				c.JSON(http.StatusNotFound, gin.H{"error": st.Message()})
+			case codes.PermissionDenied:
+				c.JSON(http.StatusForbidden, gin.H{"error": st.Message()})
+			default:
+				c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to list organization members"})
+			}
+			return
+		}
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to list organization members"})
+		return
+	}
+
+	members := make([]OrganizationMemberResponse, len(resp.Members))
+	for i, member := range resp.Members {
+		members[i] = memberToResponse(member)
+	}
+
+	c.JSON(http.StatusOK, ListOrganizationMembersResponse{
+		Members: members,
+		Total:   len(members),
+	})
+}
+
+// AddMember godoc
+// @Summary Добавление участника
+// @Description Добавляет зарегистрированного пользователя в организацию по email (владелец или администратор)
+// @Tags organizations
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "ID организации" format(uuid)
+// @Param request body AddOrganizationMemberRequest true "Email и роль участника"
+// @Success 201 {object} OrganizationMemberResponse "Участник добавлен"
+// @Failure 400 {object} map[string]string "Неверный формат данных"
+// @Failure 401 {object} map[string]string "Не авторизован"
+// @Failure 403 {object} map[string]string "Недостаточно прав"
+// @Failure 404 {object} map[string]string "Организация или пользователь не найдены"
+// @Failure 409 {object} map[string]string "Пользователь уже состоит в организации"
+// @Router /organizations/{id}/members [post]
+func (h *OrganizationHandler) AddMember(c *gin.Context) {
+	userID, exists := middleware.GetUserID(c)
+	if !exists {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "unauthorized"})
+		return
+	}
+
+	var req AddOrganizationMemberRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	resp, err := h.organizationClient.AddMember(context.Background(), &organizationpb.AddMemberRequest{
+		OrganizationId: c.Param("id"),
+		UserId:         userID.String(),
+		Email:          req.Email,
+		Role:           req.Role,
+	})
+	if err != nil {
+		if st, ok := status.FromError(err); ok {
+			switch st.Code() {
+			case codes.InvalidArgument:
+				c.JSON(http.StatusBadRequest, gin.H{"error": st.Message()})
+			case codes.NotFound:
+				c.JSON(http.StatusNotFound, gin.H{"error": st.Message()})
+			case codes.PermissionDenied:
+				c.JSON(http.StatusForbidden, gin.H{"error": st.Message()})
+			case codes.AlreadyExists:
+				c.JSON(http.StatusConflict, gin.H{"error": st.Message()})
+			default:
+				c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to add organization member"})
+			}
+			return
+		}
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to add organization member"})
+		return
+	}
+
+	c.JSON(http.StatusCreated, memberToResponse(resp.Member))
+}
+
+// UpdateMember godoc
+// @Summary Изменение роли участника
+// @Description Изменяет роль участника. Назначать и снимать владельцев может только владелец; последнего владельца понизить нельзя.
+// @Tags organizations
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "ID организации" format(uuid)
+// @Param user_id path string true "ID пользователя-участника" format(uuid)
+// @Param request body UpdateOrganizationMemberRequest true "Новая роль"
+// @Success 200 {object} OrganizationMemberResponse "Роль изменена"
+// @Failure 400 {object} map[string]string "Неверный формат данных"
+// @Failure 401 {object} map[string]string "Не авторизован"
+// @Failure 403 {object} map[string]string "Недостаточно прав"
+// @Failure 404 {object} map[string]string "Организация или участник не найдены"
+// @Failure 409 {object} map[string]string "Нельзя оставить организацию без владельца"
+// @Router /organizations/{id}/members/{user_id} [put]
+func (h *OrganizationHandler) UpdateMember(c *gin.Context) {
+	userID, exists := middleware.GetUserID(c)
+	if !exists {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "unauthorized"})
+		return
+	}
+
+	var req UpdateOrganizationMemberRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	resp, err := h.organizationClient.UpdateMemberRole(context.Background(), &organizationpb.UpdateMemberRoleRequest{
+		OrganizationId: c.Param("id"),
+		UserId:         userID.String(),
+		MemberUserId:   c.Param("user_id"),
+		Role:           req.Role,
+	})
+	if err != nil {
+		if st, ok := status.FromError(err); ok {
+			switch st.Code() {
+			case codes.InvalidArgument:
+				c.JSON(http.StatusBadRequest, gin.H{"error": st.Message()})
+			case codes.NotFound:
+				c.JSON(http.StatusNotFound, gin.H{"error": st.Message()})
+			case codes.PermissionDenied:
+				c.JSON(http.StatusForbidden, gin.H{"error": st.Message()})
+			case codes.FailedPrecondition:
+				c.JSON(http.StatusConflict, gin.H{"error": st.Message()})
+			default:
+				c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to update organization member"})
+			}
+			return
+		}
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to update organization member"})
+		return
+	}
+
+	c.JSON(http.StatusOK, memberToResponse(resp.Member))
+}
+
+// RemoveMember godoc
+// @Summary Удаление участника
+// @Description Исключает участника из организации (владелец или администратор) либо выход из организации самого пользователя. Устройства участника отвязываются от организации.
+// @Tags organizations
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "ID организации" format(uuid)
+// @Param user_id path string true "ID пользователя-участника" format(uuid)
+// @Success 200 {object} map[string]bool "Участник удален"
+// @Failure 401 {object} map[string]string "Не авторизован"
+// @Failure 403 {object} map[string]string "Недостаточно прав"
+// @Failure 404 {object} map[string]string "Организация или участник не найдены"
+// @Failure 409 {object} map[string]string "Нельзя оставить организацию без владельца"
+// @Router /organizations/{id}/members/{user_id} [delete]
+func (h *OrganizationHandler) RemoveMember(c *gin.Context) {
+	userID, exists := middleware.GetUserID(c)
+	if !exists {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "unauthorized"})
+		return
+	}
+
+	resp, err := h.organizationClient.RemoveMember(context.Background(), &organizationpb.RemoveMemberRequest{
+		OrganizationId: c.Param("id"),
+		UserId:         userID.String(),
+		MemberUserId:   c.Param("user_id"),
+	})
+	if err != nil {
+		if st, ok := status.FromError(err); ok {
+			switch st.Code() {
+			case codes.InvalidArgument:
+				c.JSON(http.StatusBadRequest, gin.H{"error": st.Message()})
+			case codes.NotFound:
+				c.JSON(http.StatusNotFound, gin.H{"error": st.Message()})
+			case codes.PermissionDenied:
+				c.JSON(http.StatusForbidden, gin.H{"error": st.Message()})
+			case codes.FailedPrecondition:
+				c.JSON(http.StatusConflict, gin.H{"error": st.Message()})
+			default:
+				c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to remove organization member"})
+			}
+			return
+		}
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to remove organization member"})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"success": resp.Success,
+	})
+}
+
+func organizationToResponse(org *organizationpb.Organization) OrganizationResponse {
+	return OrganizationResponse{
+		ID:                org.Id,
+		Name:              org.Name,
+		StorageQuotaBytes: org.StorageQuotaBytes,
+		StorageUsedBytes:  org.StorageUsedBytes,
+		Role:              org.Role,
+		CreatedAt:         org.CreatedAt,
+		UpdatedAt:         org.UpdatedAt,
+	}
+}
+
+func memberToResponse(member *organizationpb.Member) OrganizationMemberResponse {
+	return OrganizationMemberResponse{
+		OrganizationID: member.OrganizationId,
+		UserID:         member.UserId,
+		Email:          member.Email,
+		Role:           member.Role,
+		CreatedAt:      member.CreatedAt,
+		UpdatedAt:      member.UpdatedAt,
+	}
+}
@@ -0,0 +1,788 @@
+package handlers
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/backend-app/backend/internal/api/middleware"
+	transferpb "github.com/backend-app/backend/pkg/proto/transfer"
+	"github.com/gin-gonic/gin"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// progressKeepAlive - интервал комментариев SSE, чтобы прокси не закрывали простаивающий поток
+const progressKeepAlive = 15 * time.Second
+
+// TransferHandler работает с передачами, в которых участвуют устройства или файлы
+// пользователя. Владение проверяет сервис передач.
+type TransferHandler struct {
+	transferClient transferpb.TransferServiceClient
+}
+
+func NewTransferHandler(transferClient transferpb.TransferServiceClient) *TransferHandler {
+	return &TransferHandler{
+		transferClient: transferClient,
+	}
+}
+
+type CreateTransferRequest struct {
+	FileID       string `json:"file_id" binding:"required" example:"550e8400-e29b-41d4-a716-446655440000"`
+	FromDeviceID string `json:"from_device_id" binding:"required" example:"550e8400-e29b-41d4-a716-446655440000"`
+	ToDeviceID   string `json:"to_device_id,omitempty" example:"550e8400-e29b-41d4-a716-446655440000"`
+	TransferType string `json:"transfer_type" binding:"required,oneof=p2p cloud" example:"p2p"`
+}
+
+type UpdateTransferStatusRequest struct {
+	Status           string `json:"status" binding:"required,oneof=pending in_progress paused completed failed cancelled rejected expired" example:"in_progress"`
+	Progress         int64  `json:"progress,omitempty" binding:"min=0,max=100" example:"42"`
+	BytesTransferred int64  `json:"bytes_transferred,omitempty" binding:"min=0" example:"440401920"`
+	FailureReason    string `json:"failure_reason,omitempty" example:""`
+}
+
+type CreateTransferGroupRequest struct {
+	FileID       string   `json:"file_id" binding:"required" example:"550e8400-e29b-41d4-a716-446655440000"`
+	FromDeviceID string   `json:"from_device_id" binding:"required" example:"550e8400-e29b-41d4-a716-446655440000"`
+	ToDeviceIDs  []string `json:"to_device_ids,omitempty" example:"550e8400-e29b-41d4-a716-446655440001,550e8400-e29b-41d4-a716-446655440002"`
+	AllDevices   bool     `json:"all_devices,omitempty" example:"false"` // на все устройства пользователя, кроме отправителя
+	TransferType string   `json:"transfer_type" binding:"required,oneof=p2p cloud" example:"cloud"`
+}
+
+type CancelTransferRequest struct {
+	Reason string `json:"reason,omitempty" example:"cancelled by user"`
+}
+
+type TransferResponse struct {
+	ID               string `json:"id" example:"550e8400-e29b-41d4-a716-446655440000"`
+	FileID           string `json:"file_id" example:"550e8400-e29b-41d4-a716-446655440000"`
+	FromDeviceID     string `json:"from_device_id,omitempty" example:"550e8400-e29b-41d4-a716-446655440000"`
+	ToDeviceID       string `json:"to_device_id,omitempty" example:"550e8400-e29b-41d4-a716-446655440000"`
+	GroupID          string `json:"group_id,omitempty" example:"550e8400-e29b-41d4-a716-446655440000"`
+	TransferType     string `json:"transfer_type" example:"p2p" enums:"p2p,cloud"`
+	DeliveryPath     string `json:"delivery_path" example:"webrtc" enums:"webrtc,relay,cloud"`
+	Status           string `json:"status" example:"in_progress" enums:"pending,in_progress,paused,completed,failed,cancelled,rejected,expired"`
+	Progress         int64  `json:"progress" example:"42"`
+	BytesTransferred int64  `json:"bytes_transferred" example:"440401920"`
+	FailureReason    string `json:"failure_reason,omitempty" example:""`
+	CreatedAt        string `json:"created_at" example:"2024-01-01T00:00:00Z"`
+	UpdatedAt        string `json:"updated_at" example:"2024-01-01T00:00:00Z"`
+}
+
+type TransferGroupResponse struct {
+	ID           string             `json:"id" example:"550e8400-e29b-41d4-a716-446655440000"`
+	FileID       string             `json:"file_id" example:"550e8400-e29b-41d4-a716-446655440000"`
+	FromDeviceID string             `json:"from_device_id,omitempty" example:"550e8400-e29b-41d4-a716-446655440000"`
+	TransferType string             `json:"transfer_type" example:"cloud" enums:"p2p,cloud"`
+	Status       string             `json:"status" example:"in_progress" enums:"pending,in_progress,completed,partial,failed"`
+	Progress     int64              `json:"progress" example:"60"` // средний прогресс получателей
+	Total        int32              `json:"total" example:"4"`
+	Completed    int32              `json:"completed" example:"2"`
+	Failed       int32              `json:"failed" example:"0"`
+	Transfers    []TransferResponse `json:"transfers"`
+	CreatedAt    string             `json:"created_at" example:"2024-01-01T00:00:00Z"`
+}
+
+type TransferEventResponse struct {
+	FromStatus   string `json:"from_status,omitempty" example:"pending"` // пусто - создание передачи
+	ToStatus     string `json:"to_status" example:"in_progress"`
+	Reason       string `json:"reason,omitempty" example:"ice_failed"`
+	DeliveryPath string `json:"delivery_path,omitempty" example:"relay"` // from_status == to_status - смена способа доставки
+	CreatedAt    string `json:"created_at" example:"2024-01-01T00:00:00Z"`
+}
+
+type TransferHistoryResponse struct {
+	Events []TransferEventResponse `json:"events"`
+}
+
+type ListTransfersResponse struct {
+	Transfers []TransferResponse `json:"transfers"`
+	Total     int                `json:"total" example:"5"`
+}
+
+// Create godoc
+// @Summary Создание передачи
+// @Description Создает передачу файла с устройства пользователя. Файл должен быть доступен пользователю, устройство получателя - принадлежать ему или его организации.
+// @Tags transfers
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param request body CreateTransferRequest true "Данные передачи"
+// @Success 201 {object} TransferResponse "Передача создана"
+// @Failure 400 {object} map[string]string "Неверный формат данных"
+// @Failure 401 {object} map[string]string "Не авторизован"
+// @Failure 403 {object} map[string]string "Нет доступа к файлу или устройству"
+// @Failure 404 {object} map[string]string "Файл или устройство не найдены"
+// @Failure 409 {object} map[string]string "Устройства не подтверждены или не поддерживают передачу"
+// @Failure 500 {object} map[string]string "Внутренняя ошибка сервера"
+// @Router /transfers [post]
+func (h *TransferHandler) Create(c *gin.Context) {
+	userID, exists := middleware.GetUserID(c)
+	if !exists {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "unauthorized"})
+		return
+	}
+
+	var req CreateTransferRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	resp, err := h.transferClient.CreateTransfer(context.Background(), &transferpb.CreateTransferRequest{
+		UserId:       userID.String(),
+		FileId:       req.FileID,
+		FromDeviceId: req.FromDeviceID,
+		ToDeviceId:   req.ToDeviceID,
+		TransferType: req.TransferType,
+	})
+	if err != nil {
+		respondTransferError(c, err, "failed to create transfer")
+		return
+	}
+
+	c.JSON(http.StatusCreated, transferToResponse(resp.Transfer))
+}
+
+// CreateGroup godoc
+// @Summary Отправка файла нескольким устройствам
+// @Description Создает группу передач: по передаче на каждое устройство из to_device_ids. С all_devices файл отправляется на все устройства пользователя, кроме отправителя; устройства, которые не могут его принять, пропускаются.
+// @Tags transfers
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param request body CreateTransferGroupRequest true "Файл и получатели"
+// @Success 201 {object} TransferGroupResponse "Группа создана"
+// @Failure 400 {object} map[string]string "Неверный формат данных"
+// @Failure 401 {object} map[string]string "Не авторизован"
+// @Failure 403 {object} map[string]string "Нет доступа к файлу или устройству"
+// @Failure 404 {object} map[string]string "Файл или устройство не найдены"
+// @Failure 409 {object} map[string]string "Ни одно устройство не может принять файл"
+// @Failure 500 {object} map[string]string "Внутренняя ошибка сервера"
+// @Router /transfers/groups [post]
+func (h *TransferHandler) CreateGroup(c *gin.Context) {
+	userID, exists := middleware.GetUserID(c)
+	if !exists {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "unauthorized"})
+		return
+	}
+
+	var req CreateTransferGroupRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	if len(req.ToDeviceIDs) == 0 && !req.AllDevices {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "to_device_ids or all_devices is required"})
+		return
+	}
+
+	resp, err := h.transferClient.CreateTransferGroup(context.Background(), &transferpb.CreateTransferGroupRequest{
+		UserId:       userID.String(),
+		FileId:       req.FileID,
+		FromDeviceId: req.FromDeviceID,
+		ToDeviceIds:  req.ToDeviceIDs,
+		AllDevices:   req.AllDevices,
+		TransferType: req.TransferType,
+	})
+	if err != nil {
+		respondTransferError(c, err, "failed to create transfer group")
+		return
+	}
+
+	c.JSON(http.StatusCreated, groupToResponse(resp.Group))
+}
+
+// GetGroup godoc
+// @Summary Получение группы передач
+// @Description Возвращает передачи получателей и сводное состояние группы: статус, средний прогресс и число завершенных передач. Доступно отправителю.
+// @Tags transfers
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "ID группы" format(uuid)
+// @Success 200 {object} TransferGroupResponse "Группа передач"
+// @Failure 400 {object} map[string]string "Неверный ID группы"
+// @Failure 401 {object} map[string]string "Не авторизован"
+// @Failure 403 {object} map[string]string "Группа принадлежит другому пользователю"
+// @Failure 404 {object} map[string]string "Группа не найдена"
+// @Failure 500 {object} map[string]string "Внутренняя ошибка сервера"
+// @Router /transfers/groups/{id} [get]
+func (h *TransferHandler) GetGroup(c *gin.Context) {
+	userID, exists := middleware.GetUserID(c)
+	if !exists {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "unauthorized"})
+		return
+	}
+
+	resp, err := h.transferClient.GetTransferGroup(context.Background(), &transferpb.GetTransferGroupRequest{
+		GroupId: c.Param("id"),
+		UserId:  userID.String(),
+	})
+	if err != nil {
+		respondTransferError(c, err, "failed to get transfer group")
+		return
+	}
+
+	c.JSON(http.StatusOK, groupToResponse(resp.Group))
+}
+
+// Get godoc
+// @Summary Получение передачи
+// @Description Возвращает передачу, в которой участвует устройство или файл пользователя
+// @Tags transfers
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "ID передачи" format(uuid)
+// @Success 200 {object} TransferResponse "Передача"
+// @Failure 400 {object} map[string]string "Неверный ID передачи"
+// @Failure 401 {object} map[string]string "Не авторизован"
+// @Failure 403 {object} map[string]string "Нет доступа к передаче"
+// @Failure 404 {object} map[string]string "Передача не найдена"
+// @Failure 500 {object} map[string]string "Внутренняя ошибка сервера"
+// @Router /transfers/{id} [get]
+func (h *TransferHandler) Get(c *gin.Context) {
+	transfer, ok := h.getOwnedTransfer(c)
+	if !ok {
+		return
+	}
+
+	c.JSON(http.StatusOK, transferToResponse(transfer))
+}
+
+// List godoc
+// @Summary Список передач
+// @Description Возвращает передачи, в которых участвуют файлы или устройства пользователя. Фильтры device_id (с направлением direction), file_id, group_id и status объединяются через AND.
+// @Tags transfers
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param device_id query string false "ID устройства" format(uuid)
+// @Param direction query string false "Направление относительно устройства" Enums(incoming, outgoing)
+// @Param file_id query string false "ID файла" format(uuid)
+// @Param group_id query string false "ID группы передач" format(uuid)
+// @Param status query string false "Статус" Enums(pending, in_progress, paused, completed, failed, cancelled, rejected, expired)
+// @Param limit query int false "Лимит передач" default(50)
+// @Param offset query int false "Смещение" default(0)
+// @Success 200 {object} ListTransfersResponse "Список передач"
+// @Failure 400 {object} map[string]string "Неверные параметры фильтра"
+// @Failure 401 {object} map[string]string "Не авторизован"
+// @Failure 500 {object} map[string]string "Внутренняя ошибка сервера"
+// @Router /transfers [get]
+func (h *TransferHandler) List(c *gin.Context) {
+	userID, exists := middleware.GetUserID(c)
+	if !exists {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "unauthorized"})
+		return
+	}
+
+	limit := int32(50)
+	offset := int32(0)
+
+	if limitStr := c.Query("limit"); limitStr != "" {
+		if l, err := strconv.ParseInt(limitStr, 10, 32); err == nil {
+			limit = int32(l)
+		}
+	}
+	if offsetStr := c.Query("offset"); offsetStr != "" {
+		if o, err := strconv.ParseInt(offsetStr, 10, 32); err == nil {
+			offset = int32(o)
+		}
+	}
+
+	resp, err := h.transferClient.ListTransfers(context.Background(), &transferpb.ListTransfersRequest{
+		UserId:    userID.String(),
+		DeviceId:  c.Query("device_id"),
+		Direction: c.Query("direction"),
+		FileId:    c.Query("file_id"),
+		GroupId:   c.Query("group_id"),
+		Status:    c.Query("status"),
+		Limit:     limit,
+		Offset:    offset,
+	})
+	if err != nil {
+		respondTransferError(c, err, "failed to list transfers")
+		return
+	}
+
+	transfers := make([]TransferResponse, len(resp.Transfers))
+	for i, transfer := range resp.Transfers {
+		transfers[i] = transferToResponse(transfer)
+	}
+
+	c.JSON(http.StatusOK, ListTransfersResponse{
+		Transfers: transfers,
+		Total:     len(transfers),
+	})
+}
+
+// History godoc
+// @Summary История передачи
+// @Description Возвращает смены статуса и способа доставки передачи в хронологическом порядке. Событие с одинаковыми from_status и to_status - переход P2P передачи на запасной способ доставки (relay или cloud); delivery_path последнего события завершенной передачи - способ, которым доставлен файл.
+// @Tags transfers
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "ID передачи" format(uuid)
+// @Success 200 {object} TransferHistoryResponse "История передачи"
+// @Failure 400 {object} map[string]string "Неверный ID передачи"
+// @Failure 401 {object} map[string]string "Не авторизован"
+// @Failure 403 {object} map[string]string "Нет доступа к передаче"
+// @Failure 404 {object} map[string]string "Передача не найдена"
+// @Failure 500 {object} map[string]string "Внутренняя ошибка сервера"
+// @Router /transfers/{id}/history [get]
+func (h *TransferHandler) History(c *gin.Context) {
+	userID, exists := middleware.GetUserID(c)
+	if !exists {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "unauthorized"})
+		return
+	}
+
+	resp, err := h.transferClient.GetTransferHistory(context.Background(), &transferpb.GetTransferHistoryRequest{
+		TransferId: c.Param("id"),
+		UserId:     userID.String(),
+	})
+	if err != nil {
+		respondTransferError(c, err, "failed to get transfer history")
+		return
+	}
+
+	events := make([]TransferEventResponse, len(resp.Events))
+	for i, event := range resp.Events {
+		events[i] = TransferEventResponse{
+			FromStatus:   event.FromStatus,
+			ToStatus:     event.ToStatus,
+			Reason:       event.Reason,
+			DeliveryPath: event.DeliveryPath,
+			CreatedAt:    event.CreatedAt,
+		}
+	}
+
+	c.JSON(http.StatusOK, TransferHistoryResponse{
+		Events: events,
+	})
+}
+
+// UpdateStatus godoc
+// @Summary Обновление статуса передачи
+// @Description Меняет статус передачи по таблице переходов или, при том же статусе, обновляет прогресс. Если указан bytes_transferred, progress считается по размеру файла.
+// @Tags transfers
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "ID передачи" format(uuid)
+// @Param request body UpdateTransferStatusRequest true "Новый статус и прогресс"
+// @Success 200 {object} TransferResponse "Передача обновлена"
+// @Failure 400 {object} map[string]string "Неверный формат данных"
+// @Failure 401 {object} map[string]string "Не авторизован"
+// @Failure 403 {object} map[string]string "Нет доступа к передаче"
+// @Failure 404 {object} map[string]string "Передача не найдена"
+// @Failure 409 {object} map[string]string "Недопустимый переход статуса"
+// @Failure 500 {object} map[string]string "Внутренняя ошибка сервера"
+// @Router /transfers/{id}/status [put]
+func (h *TransferHandler) UpdateStatus(c *gin.Context) {
+	var req UpdateTransferStatusRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	transfer, ok := h.getOwnedTransfer(c)
+	if !ok {
+		return
+	}
+
+	h.updateStatus(c, &transferpb.UpdateTransferStatusRequest{
+		TransferId:       transfer.Id,
+		Status:           req.Status,
+		Progress:         req.Progress,
+		BytesTransferred: req.BytesTransferred,
+		FailureReason:    req.FailureReason,
+	})
+}
+
+// Cancel godoc
+// @Summary Отмена передачи
+// @Description Переводит незавершенную передачу в статус cancelled, сохраняя достигнутый прогресс
+// @Tags transfers
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "ID передачи" format(uuid)
+// @Param request body CancelTransferRequest false "Причина отмены"
+// @Success 200 {object} TransferResponse "Передача отменена"
+// @Failure 400 {object} map[string]string "Неверный формат данных"
+// @Failure 401 {object} map[string]string "Не авторизован"
+// @Failure 403 {object} map[string]string "Нет доступа к передаче"
+// @Failure 404 {object} map[string]string "Передача не найдена"
+// @Failure 409 {object} map[string]string "Передача уже завершена"
+// @Failure 500 {object} map[string]string "Внутренняя ошибка сервера"
+// @Router /transfers/{id}/cancel [post]
+func (h *TransferHandler) Cancel(c *gin.Context) {
+	var req CancelTransferRequest
+	if c.Request.ContentLength > 0 {
+		if err := c.ShouldBindJSON(&req); err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
+	}
+
+	transfer, ok := h.getOwnedTransfer(c)
+	if !ok {
+		return
+	}
+
+	h.updateStatus(c, &transferpb.UpdateTransferStatusRequest{
+		TransferId:       transfer.Id,
+		Status:           "cancelled",
+		Progress:         transfer.Progress,
+		BytesTransferred: transfer.BytesTransferred,
+		FailureReason:    req.Reason,
+	})
+}
+
+func (h *TransferHandler) updateStatus(c *gin.Context, req *transferpb.UpdateTransferStatusRequest) {
+	userID, _ := middleware.GetUserID(c)
+	req.UserId = userID.String()
+
+	resp, err := h.transferClient.UpdateTransferStatus(context.Background(), req)
+	if err != nil {
+		respondTransferError(c, err, "failed to update transfer status")
+		return
+	}
+
+	c.JSON(http.StatusOK, transferToResponse(resp.Transfer))
+}
+
+// getOwnedTransfer загружает передачу из пути запроса. Сервис передач проверяет,
+// что в ней участвует устройство или файл пользователя. При ошибке ответ уже отправлен.
+func (h *TransferHandler) getOwnedTransfer(c *gin.Context) (*transferpb.Transfer, bool) {
+	userID, exists := middleware.GetUserID(c)
+	if !exists {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "unauthorized"})
+		return nil, false
+	}
+
+	transferID := c.Param("id")
+	if transferID == "" {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "transfer_id is required"})
+		return nil, false
+	}
+
+	resp, err := h.transferClient.GetTransfer(context.Background(), &transferpb.GetTransferRequest{
+		TransferId: transferID,
+		UserId:     userID.String(),
+	})
+	if err != nil {
+		respondTransferError(c, err, "failed to get transfer")
+		return nil, false
+	}
+
+	return resp.Transfer, true
+}
+
+// respondTransferError переводит ошибку gRPC сервисов в HTTP ответ
+func respondTransferError(c *gin.Context, err error, message string) {
+	if st, ok := status.FromError(err); ok {
+		switch st.Code() {
+		case codes.InvalidArgument:
+			c.JSON(http.StatusBadRequest, gin.H{"error": st.Message()})
+		case codes.NotFound:
+			c.JSON(http.StatusNotFound, gin.H{"error": st.Message()})
+		case codes.PermissionDenied:
+			c.JSON(http.StatusForbidden, gin.H{"error": st.Message()})
+		case codes.FailedPrecondition, codes.Aborted:
+			c.JSON(http.StatusConflict, gin.H{"error": st.Message()})
+		default:
+			c.JSON(http.StatusInternalServerError, gin.H{"error": message})
+		}
+		return
+	}
+	c.JSON(http.StatusInternalServerError, gin.H{"error": message})
+}
+
+type TransferProgressResponse struct {
+	TransferID       string  `json:"transfer_id" example:"550e8400-e29b-41d4-a716-446655440000"`
+	Status           string  `json:"status" example:"in_progress" enums:"pending,in_progress,paused,completed,failed,cancelled,rejected,expired"`
+	Progress         int64   `json:"progress" example:"42"`
+	BytesTransferred int64   `json:"bytes_transferred" example:"440401920"`
+	TotalSize        int64   `json:"total_size" example:"1048576000"`
+	Rate             float64 `json:"rate" example:"5242880"`    // байт в секунду, 0 - неизвестно
+	ETASeconds       int64   `json:"eta_seconds" example:"116"` // 0 - неизвестно
+	FailureReason    string  `json:"failure_reason,omitempty" example:""`
+	UpdatedAt        string  `json:"updated_at" example:"2024-01-01T00:00:00Z"`
+}
+
+// StreamProgress godoc
+// @Summary Прогресс передачи (Server-Sent Events)
+// @Description Поток событий progress с состоянием передачи: сначала текущее состояние, затем каждое обновление со скоростью и оставшимся временем. Поток закрывается, когда передача переходит в завершающий статус (completed, failed, cancelled, rejected, expired).
+// @Tags transfers
+// @Produce text/event-stream
+// @Security BearerAuth
+// @Param id path string true "ID передачи" format(uuid)
+// @Success 200 {object} TransferProgressResponse "Поток событий progress"
+// @Failure 400 {object} map[string]string "Неверный ID передачи"
+// @Failure 401 {object} map[string]string "Не авторизован"
+// @Failure 403 {object} map[string]string "Нет доступа к передаче"
+// @Failure 404 {object} map[string]string "Передача не найдена"
+// @Failure 500 {object} map[string]string "Внутренняя ошибка сервера"
+// @Router /transfers/{id}/progress [get]
+func (h *TransferHandler) StreamProgress(c *gin.Context) {
+	transfer, ok := h.getOwnedTransfer(c)
+	if !ok {
+		return
+	}
+
+	// Поток завершается вместе с запросом клиента
+	userID, _ := middleware.GetUserID(c)
+	stream, err := h.transferClient.StreamTransferProgress(c.Request.Context(), &transferpb.StreamTransferProgressRequest{
+		TransferId: transfer.Id,
+		UserId:     userID.String(),
+	})
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to stream transfer progress"})
+		return
+	}
+
+	// Ошибки до первого обновления возвращаются обычным JSON ответом
+	update, err := stream.Recv()
+	if err != nil {
+		if st, ok := status.FromError(err); ok {
+			switch st.Code() {
+			case codes.InvalidArgument:
+				c.JSON(http.StatusBadRequest, gin.H{"error": st.Message()})
+			case codes.NotFound:
+				c.JSON(http.StatusNotFound, gin.H{"error": st.Message()})
+			default:
+				c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to stream transfer progress"})
+			}
+			return
+		}
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to stream transfer progress"})
+		return
+	}
+
+	// WriteTimeout сервера не должен обрывать долгий поток
+	http.NewResponseController(c.Writer).SetWriteDeadline(time.Time{})
+
+	c.Header("Cache-Control", "no-cache")
+	c.Header("Connection", "keep-alive")
+	c.Header("X-Accel-Buffering", "no")
+
+	c.SSEvent("progress", progressToResponse(update))
+	c.Writer.Flush()
+
+	updates := make(chan *transferpb.TransferProgressUpdate)
+	streamErr := make(chan error, 1)
+	go func() {
+		defer close(updates)
+		for {
+			update, err := stream.Recv()
+			if err != nil {
+				streamErr <- err
+				return
+			}
+
+			select {
+			case updates <- update:
+			case <-c.Request.Context().Done():
+				return
+			}
+		}
+	}()
+
+	keepAlive := time.NewTicker(progressKeepAlive)
+	defer keepAlive.Stop()
+
+	for {
+		select {
+		case <-c.Request.Context().Done():
+			return
+		case <-keepAlive.C:
+			if _, err := io.WriteString(c.Writer, ": keep-alive\n\n"); err != nil {
+				return
+			}
+			c.Writer.Flush()
+		case update, ok := <-updates:
+			if !ok {
+				if err := <-streamErr; err != io.EOF {
+					c.SSEvent("error", gin.H{"error": "transfer progress stream interrupted"})
+					c.Writer.Flush()
+				}
+				return
+			}
+
+			c.SSEvent("progress", progressToResponse(update))
+			c.Writer.Flush()
+		}
+	}
+}
+
+type InboxItemResponse struct {
+	ID           string `json:"id" example:"550e8400-e29b-41d4-a716-446655440000"`
+	DeviceID     string `json:"device_id" example:"550e8400-e29b-41d4-a716-446655440000"`
+	TransferID   string `json:"transfer_id" example:"550e8400-e29b-41d4-a716-446655440000"`
+	FileID       string `json:"file_id" example:"550e8400-e29b-41d4-a716-446655440000"`
+	FromDeviceID string `json:"from_device_id,omitempty" example:"550e8400-e29b-41d4-a716-446655440000"`
+	FileName     string `json:"file_name" example:"document.pdf"`
+	FileSize     int64  `json:"file_size" example:"1048576"`
+	MimeType     string `json:"mime_type" example:"application/pdf"`
+	CreatedAt    string `json:"created_at" example:"2024-01-01T00:00:00Z"`
+}
+
+type ListInboxResponse struct {
+	Items []InboxItemResponse `json:"items"`
+	Total int                 `json:"total" example:"1"`
+}
+
+// ListInbox godoc
+// @Summary Входящие устройства
+// @Description Возвращает облачные передачи, которые ждут устройство пользователя. Файл скачивается через /files/{id}/download до подтверждения получения.
+// @Tags transfers
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "ID устройства" format(uuid)
+// @Success 200 {object} ListInboxResponse "Входящие устройства"
+// @Failure 400 {object} map[string]string "Неверный ID устройства"
+// @Failure 401 {object} map[string]string "Не авторизован"
+// @Failure 403 {object} map[string]string "Устройство принадлежит другому пользователю"
+// @Failure 404 {object} map[string]string "Устройство не найдено"
+// @Failure 500 {object} map[string]string "Внутренняя ошибка сервера"
+// @Router /devices/{id}/inbox [get]
+func (h *TransferHandler) ListInbox(c *gin.Context) {
+	userID, exists := middleware.GetUserID(c)
+	if !exists {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "unauthorized"})
+		return
+	}
+
+	deviceID := c.Param("id")
+	if deviceID == "" {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "device_id is required"})
+		return
+	}
+
+	resp, err := h.transferClient.ListInbox(context.Background(), &transferpb.ListInboxRequest{
+		DeviceId: deviceID,
+		UserId:   userID.String(),
+	})
+	if err != nil {
+		respondTransferError(c, err, "failed to get inbox")
+		return
+	}
+
+	items := make([]InboxItemResponse, len(resp.Items))
+	for i, item := range resp.Items {
+		items[i] = InboxItemResponse{
+			ID:           item.Id,
+			DeviceID:     item.DeviceId,
+			TransferID:   item.TransferId,
+			FileID:       item.FileId,
+			FromDeviceID: item.FromDeviceId,
+			FileName:     item.FileName,
+			FileSize:     item.FileSize,
+			MimeType:     item.MimeType,
+			CreatedAt:    item.CreatedAt,
+		}
+	}
+
+	c.JSON(http.StatusOK, ListInboxResponse{
+		Items: items,
+		Total: len(items),
+	})
+}
+
+// AcknowledgeInboxItem godoc
+// @Summary Подтверждение получения файла
+// @Description Подтверждает, что устройство скачало файл из входящих, и завершает передачу. Когда файл получили все устройства, он удаляется с сервера по истечении срока хранения.
+// @Tags transfers
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "ID устройства" format(uuid)
+// @Param transfer_id path string true "ID передачи" format(uuid)
+// @Success 200 {object} TransferResponse "Передача завершена"
+// @Failure 400 {object} map[string]string "Неверный ID"
+// @Failure 401 {object} map[string]string "Не авторизован"
+// @Failure 403 {object} map[string]string "Устройство принадлежит другому пользователю"
+// @Failure 404 {object} map[string]string "Файл не найден во входящих"
+// @Failure 409 {object} map[string]string "Получение уже подтверждено или передача завершена"
+// @Failure 500 {object} map[string]string "Внутренняя ошибка сервера"
+// @Router /devices/{id}/inbox/{transfer_id}/ack [post]
+func (h *TransferHandler) AcknowledgeInboxItem(c *gin.Context) {
+	userID, exists := middleware.GetUserID(c)
+	if !exists {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "unauthorized"})
+		return
+	}
+
+	resp, err := h.transferClient.AcknowledgeInboxItem(context.Background(), &transferpb.AcknowledgeInboxItemRequest{
+		DeviceId:   c.Param("id"),
+		TransferId: c.Param("transfer_id"),
+		UserId:     userID.String(),
+	})
+	if err != nil {
+		respondTransferError(c, err, "failed to acknowledge inbox item")
+		return
+	}
+
+	c.JSON(http.StatusOK, transferToResponse(resp.Transfer))
+}
+
+func transferToResponse(transfer *transferpb.Transfer) TransferResponse {
+	return TransferResponse{
+		ID:               transfer.Id,
+		FileID:           transfer.FileId,
+		FromDeviceID:     transfer.FromDeviceId,
+		ToDeviceID:       transfer.ToDeviceId,
+		GroupID:          transfer.GroupId,
+		TransferType:     transfer.TransferType,
+		DeliveryPath:     transfer.DeliveryPath,
+		Status:           transfer.Status,
+		Progress:         transfer.Progress,
+		BytesTransferred: transfer.BytesTransferred,
+		FailureReason:    transfer.FailureReason,
+		CreatedAt:        transfer.CreatedAt,
+		UpdatedAt:        transfer.UpdatedAt,
+	}
+}
+
+func groupToResponse(group *transferpb.TransferGroup) TransferGroupResponse {
+	transfers := make([]TransferResponse, len(group.Transfers))
+	for i, transfer := range group.Transfers {
+		transfers[i] = transferToResponse(transfer)
+	}
+
+	return TransferGroupResponse{
+		ID:           group.Id,
+		FileID:       group.FileId,
+		FromDeviceID: group.FromDeviceId,
+		TransferType: group.TransferType,
+		Status:       group.Status,
+		Progress:     group.Progress,
+		Total:        group.Total,
+		Completed:    group.Completed,
+		Failed:       group.Failed,
+		Transfers:    transfers,
+		CreatedAt:    group.CreatedAt,
+	}
+}
+
+func progressToResponse(update *transferpb.TransferProgressUpdate) TransferProgressResponse {
+	return TransferProgressResponse{
+		TransferID:       update.TransferId,
+		Status:           update.Status,
+		Progress:         update.Progress,
+		BytesTransferred: update.BytesTransferred,
+		TotalSize:        update.TotalSize,
+		Rate:             update.Rate,
+		ETASeconds:       update.EtaSeconds,
+		FailureReason:    update.FailureReason,
+		UpdatedAt:        update.UpdatedAt,
+	}
+}
@@ -33,6 +33,12 @@ func NewServer(cfg *config.Config, db *sql.DB, redisClient *redis.Client, grpcCl
 
 	router := gin.Default()
 
+	// Без доверенных прокси ClientIP возвращает адрес соединения: X-Forwarded-For
+	// от клиента не должен влиять на ограничение попыток
+	if err := router.SetTrustedProxies(cfg.Server.TrustedProxies); err != nil {
+		panic(fmt.Sprintf("invalid trusted proxies: %v", err))
+	}
+
 	router.Use(corsMiddleware())
 	router.Use(loggingMiddleware())
 
@@ -43,6 +49,9 @@ func NewServer(cfg *config.Config, db *sql.DB, redisClient *redis.Client, grpcCl
 	authHandler := handlers.NewAuthHandler(grpcClients.Auth)
 	deviceHandler := handlers.NewDeviceHandler(grpcClients.Device)
 	fileHandler := handlers.NewFileHandler(grpcClients.File)
+	transferHandler := handlers.NewTransferHandler(grpcClients.Transfer)
+	accountHandler := handlers.NewAccountHandler(grpcClients.Account)
+	organizationHandler := handlers.NewOrganizationHandler(grpcClients.Organization)
 	var webrtcHandler *handlers.WebRTCHandler
 	if turnServer != nil {
 		webrtcHandler = handlers.NewWebRTCHandler(turnServer)
@@ -57,6 +66,9 @@ func NewServer(cfg *config.Config, db *sql.DB, redisClient *redis.Client, grpcCl
 			auth.POST("/refresh", authHandler.Refresh)
 		}
 
+		api.POST("/devices/pairing/redeem", deviceHandler.RedeemPairingCode)
+		api.POST("/signaling/ticket", deviceHandler.IssueSignalingTicket)
+
 		protected := api.Group("")
 		protected.Use(middleware.AuthMiddleware(grpcClients.Auth))
 		{
@@ -64,10 +76,22 @@ func NewServer(cfg *config.Config, db *sql.DB, redisClient *redis.Client, grpcCl
 			{
 				devices.POST("", deviceHandler.Register)
 				devices.GET("", deviceHandler.List)
+				devices.POST("/pairing", deviceHandler.CreatePairingCode)
+				devices.GET("/inactivity-policy", deviceHandler.GetInactivityPolicy)
+				devices.PUT("/inactivity-policy", deviceHandler.UpdateInactivityPolicy)
 				devices.GET("/:id", deviceHandler.Get)
 				devices.PUT("/:id", deviceHandler.Update)
 				devices.DELETE("/:id", deviceHandler.Delete)
+				devices.PUT("/:id/organization", deviceHandler.SetOrganization)
+				devices.POST("/:id/token/rotate", deviceHandler.RotateToken)
+				devices.POST("/:id/approve", deviceHandler.Approve)
+				devices.POST("/:id/reject", deviceHandler.Reject)
+				devices.PUT("/:id/push-token", deviceHandler.SetPushToken)
+				devices.DELETE("/:id/push-token", deviceHandler.DeletePushToken)
+				devices.PUT("/:id/prune-exempt", deviceHandler.SetPruneExempt)
 				devices.POST("/:id/last-seen", deviceHandler.UpdateLastSeen)
+				devices.GET("/:id/inbox", transferHandler.ListInbox)
+				devices.POST("/:id/inbox/:transfer_id/ack", transferHandler.AcknowledgeInboxItem)
 			}
 
 			files := protected.Group("/files")
@@ -79,6 +103,42 @@ func NewServer(cfg *config.Config, db *sql.DB, redisClient *redis.Client, grpcCl
 				files.DELETE("/:id", fileHandler.Delete)
 			}
 
+			transfers := protected.Group("/transfers")
+			{
+				transfers.POST("", transferHandler.Create)
+				transfers.GET("", transferHandler.List)
+				transfers.POST("/groups", transferHandler.CreateGroup)
+				transfers.GET("/groups/:id", transferHandler.GetGroup)
+				transfers.GET("/:id", transferHandler.Get)
+				transfers.PUT("/:id/status", transferHandler.UpdateStatus)
+				transfers.POST("/:id/cancel", transferHandler.Cancel)
+				transfers.GET("/:id/progress", transferHandler.StreamProgress)
+				transfers.GET("/:id/history", transferHandler.History)
+			}
+
+			organizations := protected.Group("/organizations")
+			{
+				organizations.POST("", organizationHandler.Create)
+				organizations.GET("", organizationHandler.List)
+				organizations.GET("/:id", organizationHandler.Get)
+				organizations.PUT("/:id", organizationHandler.Update)
+				organizations.DELETE("/:id", organizationHandler.Delete)
+				organizations.GET("/:id/members", organizationHandler.ListMembers)
+				organizations.POST("/:id/members", organizationHandler.AddMember)
+				organizations.PUT("/:id/members/:user_id", organizationHandler.UpdateMember)
+				organizations.DELETE("/:id/members/:user_id", organizationHandler.RemoveMember)
+			}
+
+			account := protected.Group("/account")
+			{
+				account.POST("/export", accountHandler.RequestExport)
+				account.GET("/export/:id", accountHandler.GetExport)
+				account.GET("/export/:id/download", accountHandler.DownloadExport)
+				account.POST("/deletion", accountHandler.RequestDeletion)
+				account.GET("/deletion", accountHandler.GetDeletion)
+				account.DELETE("/deletion", accountHandler.CancelDeletion)
+			}
+
 			if webrtcHandler != nil {
 				webrtc := protected.Group("/webrtc")
 				{
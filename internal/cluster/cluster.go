@@ -0,0 +1,244 @@
+package cluster
+
+import (
+	"context"
+	"strconv"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/redis/go-redis/v9"
+)
+
+const (
+	nodesKey         = "signaling:nodes"     // узлы, которые регистрировали устройства
+	broadcastChannel = "signaling:broadcast" // сообщения всем узлам
+	deviceKeyPrefix  = "signaling:device:"   // узлы с сессиями устройства: node_id -> срок действия
+	nodeKeyPrefix    = "signaling:node:"     // пульс узла, ключи устройств узла и его канал
+	reapLockSuffix   = ":reap"               // блокировка очистки упавшего узла
+	devicesSuffix    = ":devices"            // device_id -> user_id устройств узла
+	channelSuffix    = ":messages"           // сообщения устройствам узла
+)
+
+// releaseScript убирает узел ARGV[1] из узлов устройства вместе с истекшими (срок
+// меньше ARGV[2]) и возвращает число оставшихся узлов
+var releaseScript = redis.NewScript(`
+redis.call("ZREM", KEYS[1], ARGV[1])
+redis.call("ZREMRANGEBYSCORE", KEYS[1], "-inf", "(" .. ARGV[2])
+return redis.call("ZCARD", KEYS[1])
+`)
+
+// Registry хранит в Redis, к каким узлам signaling подключены сессии устройства, и
+// доставляет сообщения между узлами через pub/sub. Записи узла живут ttl и продлеваются
+// им, поэтому устройства упавшего узла освобождаются автоматически.
+type Registry struct {
+	redis  *redis.Client
+	nodeID string
+	ttl    time.Duration
+}
+
+func NewRegistry(redisClient *redis.Client, nodeID string, ttl time.Duration) *Registry {
+	return &Registry{
+		redis:  redisClient,
+		nodeID: nodeID,
+		ttl:    ttl,
+	}
+}
+
+// NodeID возвращает идентификатор текущего узла
+func (r *Registry) NodeID() string {
+	return r.nodeID
+}
+
+// Register записывает, что к текущему узлу подключена сессия устройства пользователя
+func (r *Registry) Register(ctx context.Context, deviceID, userID uuid.UUID) error {
+	pipe := r.redis.TxPipeline()
+	pipe.ZAdd(ctx, deviceKey(deviceID), redis.Z{Score: r.expiry(), Member: r.nodeID})
+	pipe.PExpire(ctx, deviceKey(deviceID), r.ttl)
+	pipe.HSet(ctx, devicesKey(r.nodeID), deviceID.String(), userID.String())
+	pipe.SAdd(ctx, nodesKey, r.nodeID)
+	pipe.Set(ctx, nodeKey(r.nodeID), time.Now().Unix(), r.ttl)
+
+	_, err := pipe.Exec(ctx)
+	return err
+}
+
+// Unregister освобождает устройство, у которого не осталось сессий на текущем узле.
+// Возвращает true, если устройство не подключено ни к одному узлу.
+func (r *Registry) Unregister(ctx context.Context, deviceID uuid.UUID) (bool, error) {
+	if err := r.redis.HDel(ctx, devicesKey(r.nodeID), deviceID.String()).Err(); err != nil {
+		return false, err
+	}
+
+	remaining, err := r.release(ctx, deviceID, r.nodeID)
+	if err != nil {
+		return false, err
+	}
+
+	return remaining == 0, nil
+}
+
+// Heartbeat продлевает пульс узла и записи его устройств. Возвращает true, если пульс
+// истек или узел уже очищен как упавший: тогда записи устройств могли пропасть, и их
+// нужно зарегистрировать заново через Register.
+func (r *Registry) Heartbeat(ctx context.Context, deviceIDs []uuid.UUID) (bool, error) {
+	expiry := r.expiry()
+
+	pipe := r.redis.Pipeline()
+	alive := pipe.Exists(ctx, nodeKey(r.nodeID))
+	added := pipe.SAdd(ctx, nodesKey, r.nodeID)
+	pipe.Set(ctx, nodeKey(r.nodeID), time.Now().Unix(), r.ttl)
+	for _, deviceID := range deviceIDs {
+		// XX: запись, удаленная при очистке узла, восстанавливает Register
+		pipe.ZAddXX(ctx, deviceKey(deviceID), redis.Z{Score: expiry, Member: r.nodeID})
+		pipe.PExpire(ctx, deviceKey(deviceID), r.ttl)
+	}
+
+	if _, err := pipe.Exec(ctx); err != nil {
+		return false, err
+	}
+
+	return alive.Val() == 0 || added.Val() > 0, nil
+}
+
+// Nodes возвращает узлы, к которым подключены сессии устройства
+func (r *Registry) Nodes(ctx context.Context, deviceID uuid.UUID) ([]string, error) {
+	return r.redis.ZRangeByScore(ctx, deviceKey(deviceID), &redis.ZRangeBy{
+		Min: strconv.FormatInt(time.Now().UnixMilli(), 10),
+		Max: "+inf",
+	}).Result()
+}
+
+// Send отправляет сообщение узлу nodeID
+func (r *Registry) Send(ctx context.Context, nodeID string, payload []byte) error {
+	return r.redis.Publish(ctx, nodeKey(nodeID)+channelSuffix, payload).Err()
+}
+
+// Broadcast отправляет сообщение всем узлам, включая текущий
+func (r *Registry) Broadcast(ctx context.Context, payload []byte) error {
+	return r.redis.Publish(ctx, broadcastChannel, payload).Err()
+}
+
+// Subscribe вызывает handler для сообщений текущему узлу и всем узлам, пока не отменен ctx
+func (r *Registry) Subscribe(ctx context.Context, handler func(payload []byte)) error {
+	pubsub := r.redis.Subscribe(ctx, nodeKey(r.nodeID)+channelSuffix, broadcastChannel)
+	defer pubsub.Close()
+
+	// Дожидаемся подтверждения подписки на оба канала, чтобы не потерять первые сообщения
+	for i := 0; i < 2; i++ {
+		if _, err := pubsub.Receive(ctx); err != nil {
+			return err
+		}
+	}
+
+	messages := pubsub.Channel()
+	for {
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case msg, ok := <-messages:
+			if !ok {
+				return nil
+			}
+
+			handler([]byte(msg.Payload))
+		}
+	}
+}
+
+// ReapDeadNodes освобождает устройства узлов, пульс которых истек, и возвращает те из
+// них, что больше не подключены ни к одному узлу (device_id -> user_id), чтобы вызывающий
+// перевел их в offline. Каждый упавший узел очищает только один из живых узлов.
+func (r *Registry) ReapDeadNodes(ctx context.Context) (map[uuid.UUID]uuid.UUID, error) {
+	nodes, err := r.redis.SMembers(ctx, nodesKey).Result()
+	if err != nil {
+		return nil, err
+	}
+
+	reaped := make(map[uuid.UUID]uuid.UUID)
+	for _, node := range nodes {
+		if node == r.nodeID {
+			continue
+		}
+
+		alive, err := r.redis.Exists(ctx, nodeKey(node)).Result()
+		if err != nil {
+			return reaped, err
+		}
+		if alive > 0 {
+			continue
+		}
+
+		locked, err := r.redis.SetNX(ctx, nodeKey(node)+reapLockSuffix, r.nodeID, r.ttl).Result()
+		if err != nil {
+			return reaped, err
+		}
+		if !locked {
+			continue
+		}
+
+		devices, err := r.redis.HGetAll(ctx, devicesKey(node)).Result()
+		if err != nil {
+			return reaped, err
+		}
+
+		for device, user := range devices {
+			deviceID, err := uuid.Parse(device)
+			if err != nil {
+				continue
+			}
+			userID, err := uuid.Parse(user)
+			if err != nil {
+				continue
+			}
+
+			remaining, err := r.release(ctx, deviceID, node)
+			if err != nil {
+				return reaped, err
+			}
+
+			if remaining == 0 {
+				reaped[deviceID] = userID
+			}
+		}
+
+		pipe := r.redis.TxPipeline()
+		pipe.Del(ctx, devicesKey(node))
+		pipe.SRem(ctx, nodesKey, node)
+		if _, err := pipe.Exec(ctx); err != nil {
+			return reaped, err
+		}
+	}
+
+	return reaped, nil
+}
+
+// Leave удаляет текущий узел из кластера при штатной остановке, после того как
+// освобождены его устройства
+func (r *Registry) Leave(ctx context.Context) error {
+	pipe := r.redis.TxPipeline()
+	pipe.Del(ctx, devicesKey(r.nodeID), nodeKey(r.nodeID))
+	pipe.SRem(ctx, nodesKey, r.nodeID)
+	_, err := pipe.Exec(ctx)
+	return err
+}
+
+func (r *Registry) release(ctx context.Context, deviceID uuid.UUID, nodeID string) (int64, error) {
+	return releaseScript.Run(ctx, r.redis, []string{deviceKey(deviceID)}, nodeID, time.Now().UnixMilli()).Int64()
+}
+
+// expiry - срок действия записи узла в миллисекундах Unix
+func (r *Registry) expiry() float64 {
+	return float64(time.Now().Add(r.ttl).UnixMilli())
+}
+
+func deviceKey(deviceID uuid.UUID) string {
+	return deviceKeyPrefix + deviceID.String()
+}
+
+func nodeKey(nodeID string) string {
+	return nodeKeyPrefix + nodeID
+}
+
+func devicesKey(nodeID string) string {
+	return nodeKeyPrefix + nodeID + devicesSuffix
+}
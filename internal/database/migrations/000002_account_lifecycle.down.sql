@@ -0,0 +1,7 @@
+-- Откат миграции: удаление таблиц и колонок жизненного цикла аккаунта
+DROP TABLE IF EXISTS account_deletion_audit;
+DROP TABLE IF EXISTS data_exports;
+
+DROP INDEX IF EXISTS idx_users_deletion_scheduled_at;
+ALTER TABLE users DROP COLUMN IF EXISTS deletion_scheduled_at;
+ALTER TABLE users DROP COLUMN IF EXISTS deletion_requested_at;
@@ -0,0 +1,36 @@
+-- Запрос на удаление аккаунта с периодом ожидания
+ALTER TABLE users ADD COLUMN IF NOT EXISTS deletion_requested_at TIMESTAMP;
+ALTER TABLE users ADD COLUMN IF NOT EXISTS deletion_scheduled_at TIMESTAMP;
+
+CREATE INDEX idx_users_deletion_scheduled_at ON users(deletion_scheduled_at);
+
+-- Создание таблицы data_exports (выгрузка персональных данных)
+CREATE TABLE IF NOT EXISTS data_exports (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    user_id UUID NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    status VARCHAR(50) NOT NULL DEFAULT 'pending', -- 'pending', 'processing', 'completed', 'failed'
+    storage_path VARCHAR(500),
+    size BIGINT NOT NULL DEFAULT 0,
+    error TEXT,
+    expires_at TIMESTAMP,
+    created_at TIMESTAMP NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMP NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX idx_data_exports_user_id ON data_exports(user_id);
+CREATE INDEX idx_data_exports_status ON data_exports(status);
+
+-- Журнал удаления аккаунтов. Внешнего ключа нет: запись должна пережить пользователя
+CREATE TABLE IF NOT EXISTS account_deletion_audit (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    user_id UUID NOT NULL,
+    email_hash VARCHAR(64) NOT NULL,
+    files_deleted INTEGER NOT NULL DEFAULT 0,
+    bytes_deleted BIGINT NOT NULL DEFAULT 0,
+    devices_deleted INTEGER NOT NULL DEFAULT 0,
+    transfers_deleted INTEGER NOT NULL DEFAULT 0,
+    requested_at TIMESTAMP,
+    purged_at TIMESTAMP NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX idx_account_deletion_audit_user_id ON account_deletion_audit(user_id);
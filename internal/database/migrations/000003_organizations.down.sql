@@ -0,0 +1,9 @@
+-- Откат миграции: удаление организаций
+DROP INDEX IF EXISTS idx_devices_organization_id;
+DROP INDEX IF EXISTS idx_files_organization_id;
+
+ALTER TABLE devices DROP COLUMN IF EXISTS organization_id;
+ALTER TABLE files DROP COLUMN IF EXISTS organization_id;
+
+DROP TABLE IF EXISTS organization_members;
+DROP TABLE IF EXISTS organizations;
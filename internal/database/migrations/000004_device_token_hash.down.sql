@@ -0,0 +1,13 @@
+-- Исходные токены восстановить невозможно: устройствам выдаются новые случайные токены,
+-- и их потребуется зарегистрировать заново
+ALTER TABLE devices ADD COLUMN IF NOT EXISTS device_token VARCHAR(255);
+UPDATE devices SET device_token = gen_random_uuid()::text WHERE device_token IS NULL;
+ALTER TABLE devices ALTER COLUMN device_token SET NOT NULL;
+ALTER TABLE devices ADD CONSTRAINT devices_device_token_key UNIQUE (device_token);
+CREATE INDEX IF NOT EXISTS idx_devices_token ON devices(device_token);
+
+DROP INDEX IF EXISTS idx_devices_previous_token_hash;
+DROP INDEX IF EXISTS idx_devices_token_hash;
+ALTER TABLE devices DROP COLUMN IF EXISTS previous_token_expires_at;
+ALTER TABLE devices DROP COLUMN IF EXISTS previous_token_hash;
+ALTER TABLE devices DROP COLUMN IF EXISTS device_token_hash;
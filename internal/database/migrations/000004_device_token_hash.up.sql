@@ -0,0 +1,16 @@
+-- Токены устройств хранятся только в виде SHA-256 хеша
+ALTER TABLE devices ADD COLUMN IF NOT EXISTS device_token_hash VARCHAR(64);
+ALTER TABLE devices ADD COLUMN IF NOT EXISTS previous_token_hash VARCHAR(64);
+ALTER TABLE devices ADD COLUMN IF NOT EXISTS previous_token_expires_at TIMESTAMP;
+
+-- Перевод существующих токенов в хеши
+UPDATE devices SET device_token_hash = encode(sha256(convert_to(device_token, 'UTF8')), 'hex')
+WHERE device_token_hash IS NULL;
+
+ALTER TABLE devices ALTER COLUMN device_token_hash SET NOT NULL;
+
+DROP INDEX IF EXISTS idx_devices_token;
+ALTER TABLE devices DROP COLUMN IF EXISTS device_token;
+
+CREATE UNIQUE INDEX idx_devices_token_hash ON devices(device_token_hash);
+CREATE INDEX idx_devices_previous_token_hash ON devices(previous_token_hash) WHERE previous_token_hash IS NOT NULL;
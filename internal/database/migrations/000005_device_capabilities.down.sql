@@ -0,0 +1,11 @@
+-- Откат миграции: новые типы устройств сводятся к desktop и mobile
+UPDATE devices SET device_type = 'mobile' WHERE device_type = 'tablet';
+UPDATE devices SET device_type = 'desktop' WHERE device_type NOT IN ('desktop', 'mobile');
+
+COMMENT ON COLUMN devices.device_type IS NULL;
+
+ALTER TABLE devices DROP COLUMN IF EXISTS max_file_size;
+ALTER TABLE devices DROP COLUMN IF EXISTS transfer_protocols;
+ALTER TABLE devices DROP COLUMN IF EXISTS app_version;
+ALTER TABLE devices DROP COLUMN IF EXISTS os_version;
+ALTER TABLE devices DROP COLUMN IF EXISTS platform;
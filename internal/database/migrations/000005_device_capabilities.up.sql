@@ -0,0 +1,9 @@
+-- Платформа, версии клиента и возможности устройства для выбора способа передачи
+ALTER TABLE devices ADD COLUMN IF NOT EXISTS platform VARCHAR(50) NOT NULL DEFAULT '';
+ALTER TABLE devices ADD COLUMN IF NOT EXISTS os_version VARCHAR(100) NOT NULL DEFAULT '';
+ALTER TABLE devices ADD COLUMN IF NOT EXISTS app_version VARCHAR(50) NOT NULL DEFAULT '';
+-- Существующие клиенты поддерживают все способы передачи
+ALTER TABLE devices ADD COLUMN IF NOT EXISTS transfer_protocols TEXT[] NOT NULL DEFAULT '{webrtc,relay,cloud}';
+ALTER TABLE devices ADD COLUMN IF NOT EXISTS max_file_size BIGINT; -- NULL - без ограничения
+
+COMMENT ON COLUMN devices.device_type IS 'desktop, mobile, tablet, web, cli, nas';
@@ -0,0 +1,5 @@
+-- Откат миграции: подтверждение устройств
+DROP INDEX IF EXISTS idx_devices_user_approval;
+
+ALTER TABLE devices DROP COLUMN IF EXISTS approved_by_device_id;
+ALTER TABLE devices DROP COLUMN IF EXISTS approval_status;
@@ -0,0 +1,7 @@
+-- Новые устройства ожидают подтверждения доверенным устройством пользователя.
+-- Уже зарегистрированные устройства считаются подтвержденными.
+ALTER TABLE devices ADD COLUMN IF NOT EXISTS approval_status VARCHAR(20) NOT NULL DEFAULT 'approved';
+ALTER TABLE devices ALTER COLUMN approval_status SET DEFAULT 'pending';
+ALTER TABLE devices ADD COLUMN IF NOT EXISTS approved_by_device_id UUID REFERENCES devices(id) ON DELETE SET NULL;
+
+CREATE INDEX idx_devices_user_approval ON devices(user_id, approval_status);
@@ -0,0 +1,3 @@
+-- Откат миграции: токены push-уведомлений
+ALTER TABLE devices DROP COLUMN IF EXISTS push_token;
+ALTER TABLE devices DROP COLUMN IF EXISTS push_provider;
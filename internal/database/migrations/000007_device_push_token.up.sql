@@ -0,0 +1,3 @@
+-- Токены push-уведомлений для пробуждения приложения на неподключенных устройствах
+ALTER TABLE devices ADD COLUMN IF NOT EXISTS push_provider VARCHAR(20) NOT NULL DEFAULT ''; -- '', 'fcm', 'apns', 'webpush'
+ALTER TABLE devices ADD COLUMN IF NOT EXISTS push_token TEXT NOT NULL DEFAULT '';
@@ -0,0 +1,8 @@
+-- Откат миграции: отключение неактивных устройств
+DROP INDEX IF EXISTS idx_devices_last_seen_active;
+
+ALTER TABLE devices DROP COLUMN IF EXISTS deactivated_at;
+ALTER TABLE devices DROP COLUMN IF EXISTS inactivity_warned_at;
+ALTER TABLE devices DROP COLUMN IF EXISTS prune_exempt;
+
+ALTER TABLE users DROP COLUMN IF EXISTS device_inactivity_days;
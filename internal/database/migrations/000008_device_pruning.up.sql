@@ -0,0 +1,9 @@
+-- Отключение давно неактивных устройств
+-- NULL - порог по умолчанию из конфигурации, 0 - не отключать устройства пользователя
+ALTER TABLE users ADD COLUMN IF NOT EXISTS device_inactivity_days INTEGER;
+
+ALTER TABLE devices ADD COLUMN IF NOT EXISTS prune_exempt BOOLEAN NOT NULL DEFAULT FALSE;
+ALTER TABLE devices ADD COLUMN IF NOT EXISTS inactivity_warned_at TIMESTAMP;
+ALTER TABLE devices ADD COLUMN IF NOT EXISTS deactivated_at TIMESTAMP;
+
+CREATE INDEX idx_devices_last_seen_active ON devices(last_seen_at) WHERE deactivated_at IS NULL AND NOT prune_exempt;
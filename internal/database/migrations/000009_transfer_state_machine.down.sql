@@ -0,0 +1,8 @@
+-- Откат миграции: конечный автомат статусов передачи
+DROP TABLE IF EXISTS transfer_events;
+
+-- Новые статусы не поддерживаются старой схемой
+UPDATE transfers SET status = 'failed' WHERE status IN ('cancelled', 'rejected', 'expired');
+UPDATE transfers SET status = 'in_progress' WHERE status = 'paused';
+
+ALTER TABLE transfers DROP COLUMN IF EXISTS failure_reason;
@@ -0,0 +1,18 @@
+-- Конечный автомат статусов передачи и история переходов
+-- Новые статусы: 'paused', 'cancelled', 'rejected', 'expired'
+ALTER TABLE transfers ADD COLUMN IF NOT EXISTS failure_reason VARCHAR(255) NOT NULL DEFAULT '';
+
+CREATE TABLE IF NOT EXISTS transfer_events (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    transfer_id UUID NOT NULL REFERENCES transfers(id) ON DELETE CASCADE,
+    from_status VARCHAR(50), -- NULL - создание передачи
+    to_status VARCHAR(50) NOT NULL,
+    reason VARCHAR(255) NOT NULL DEFAULT '',
+    created_at TIMESTAMP NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX idx_transfer_events_transfer_id ON transfer_events(transfer_id, created_at);
+
+-- У существующих передач история начинается с текущего статуса
+INSERT INTO transfer_events (transfer_id, from_status, to_status, created_at)
+SELECT id, NULL, status, updated_at FROM transfers;
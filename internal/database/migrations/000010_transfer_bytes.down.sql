@@ -0,0 +1,2 @@
+-- Откат миграции: переданный объем передачи
+ALTER TABLE transfers DROP COLUMN IF EXISTS bytes_transferred;
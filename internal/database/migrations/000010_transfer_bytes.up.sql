@@ -0,0 +1,2 @@
+-- Переданный объем для расчета скорости и оставшегося времени передачи
+ALTER TABLE transfers ADD COLUMN IF NOT EXISTS bytes_transferred BIGINT NOT NULL DEFAULT 0;
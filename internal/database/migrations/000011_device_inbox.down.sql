@@ -0,0 +1,2 @@
+-- Откат миграции: входящие устройства
+DROP TABLE IF EXISTS device_inbox;
@@ -0,0 +1,13 @@
+-- Входящие устройства для облачных передач: файл загружается один раз и ждет
+-- получателя, пока тот не подтвердит получение
+CREATE TABLE IF NOT EXISTS device_inbox (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    device_id UUID NOT NULL REFERENCES devices(id) ON DELETE CASCADE,
+    transfer_id UUID NOT NULL UNIQUE REFERENCES transfers(id) ON DELETE CASCADE,
+    file_id UUID NOT NULL REFERENCES files(id) ON DELETE CASCADE,
+    acknowledged_at TIMESTAMP, -- NULL - файл еще не получен устройством
+    created_at TIMESTAMP NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX idx_device_inbox_device_id ON device_inbox(device_id, created_at) WHERE acknowledged_at IS NULL;
+CREATE INDEX idx_device_inbox_file_id ON device_inbox(file_id) WHERE acknowledged_at IS NULL;
@@ -0,0 +1,4 @@
+-- Откат миграции: группы передач
+DROP INDEX IF EXISTS idx_transfers_group_id;
+ALTER TABLE transfers DROP COLUMN IF EXISTS group_id;
+DROP TABLE IF EXISTS transfer_groups;
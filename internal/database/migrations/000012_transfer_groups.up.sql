@@ -0,0 +1,16 @@
+-- Группы передач: один файл нескольким устройствам. У каждого получателя своя
+-- передача со статусом и прогрессом, группа объединяет их.
+CREATE TABLE IF NOT EXISTS transfer_groups (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    user_id UUID NOT NULL REFERENCES users(id) ON DELETE CASCADE, -- отправитель
+    file_id UUID NOT NULL REFERENCES files(id) ON DELETE CASCADE,
+    from_device_id UUID REFERENCES devices(id) ON DELETE SET NULL,
+    transfer_type VARCHAR(50) NOT NULL,
+    created_at TIMESTAMP NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX idx_transfer_groups_user_id ON transfer_groups(user_id, created_at);
+
+ALTER TABLE transfers ADD COLUMN IF NOT EXISTS group_id UUID REFERENCES transfer_groups(id) ON DELETE CASCADE;
+
+CREATE INDEX idx_transfers_group_id ON transfers(group_id) WHERE group_id IS NOT NULL;
@@ -0,0 +1,3 @@
+-- Откат миграции: способ доставки передачи
+ALTER TABLE transfer_events DROP COLUMN IF EXISTS delivery_path;
+ALTER TABLE transfers DROP COLUMN IF EXISTS delivery_path;
@@ -0,0 +1,9 @@
+-- Способ, которым передача доставляется сейчас: webrtc, relay или cloud. P2P
+-- передача переходит на relay или cloud, если ICE соединение не установилось.
+ALTER TABLE transfers ADD COLUMN IF NOT EXISTS delivery_path VARCHAR(20) NOT NULL DEFAULT 'webrtc';
+UPDATE transfers SET delivery_path = 'cloud' WHERE transfer_type = 'cloud';
+
+-- Способ доставки на момент записи истории: смена способа записывается как событие
+-- без смены статуса
+ALTER TABLE transfer_events ADD COLUMN IF NOT EXISTS delivery_path VARCHAR(20) NOT NULL DEFAULT '';
+UPDATE transfer_events e SET delivery_path = t.delivery_path FROM transfers t WHERE t.id = e.transfer_id;
@@ -0,0 +1,135 @@
+package events
+
+import (
+	"context"
+	"encoding/json"
+	"time"
+
+	"github.com/backend-app/backend/internal/models"
+	"github.com/google/uuid"
+	"github.com/redis/go-redis/v9"
+)
+
+// channel - Redis канал, через который сервисы уведомляют signaling хаб
+const channel = "events"
+
+const (
+	TypeDeviceApprovalRequested = "device-approval-requested"
+	TypeDeviceApproved          = "device-approved"
+	TypeDeviceRejected          = "device-rejected"
+	TypeDeviceInactivityWarning = "device-inactivity-warning"
+	TypeDeviceDeactivated       = "device-deactivated"
+	TypeInbox                   = "inbox"
+	TypeTransferTimedOut        = "transfer-timed-out"
+)
+
+// Event - событие для подключенных устройств пользователя. Если указан DeviceID,
+// событие получает только это устройство.
+type Event struct {
+	Type     string          `json:"type"`
+	UserID   uuid.UUID       `json:"user_id"`
+	DeviceID *uuid.UUID      `json:"device_id,omitempty"`
+	Data     json.RawMessage `json:"data,omitempty"`
+}
+
+// Bus публикует события и доставляет их подписчикам через Redis pub/sub
+type Bus struct {
+	redis *redis.Client
+}
+
+func NewBus(redisClient *redis.Client) *Bus {
+	return &Bus{redis: redisClient}
+}
+
+// Publish отправляет событие с данными data всем подписчикам
+func (b *Bus) Publish(ctx context.Context, eventType string, userID uuid.UUID, data interface{}) error {
+	return b.publish(ctx, eventType, userID, nil, data)
+}
+
+// PublishToDevice отправляет событие одному устройству пользователя
+func (b *Bus) PublishToDevice(ctx context.Context, eventType string, userID, deviceID uuid.UUID, data interface{}) error {
+	return b.publish(ctx, eventType, userID, &deviceID, data)
+}
+
+func (b *Bus) publish(ctx context.Context, eventType string, userID uuid.UUID, deviceID *uuid.UUID, data interface{}) error {
+	payload, err := json.Marshal(data)
+	if err != nil {
+		return err
+	}
+
+	message, err := json.Marshal(&Event{
+		Type:     eventType,
+		UserID:   userID,
+		DeviceID: deviceID,
+		Data:     payload,
+	})
+	if err != nil {
+		return err
+	}
+
+	return b.redis.Publish(ctx, channel, message).Err()
+}
+
+// Subscribe вызывает handler для каждого события, пока не отменен ctx.
+// Некорректные сообщения пропускаются.
+func (b *Bus) Subscribe(ctx context.Context, handler func(*Event)) error {
+	pubsub := b.redis.Subscribe(ctx, channel)
+	defer pubsub.Close()
+
+	// Дожидаемся подтверждения подписки, чтобы не потерять первые события
+	if _, err := pubsub.Receive(ctx); err != nil {
+		return err
+	}
+
+	messages := pubsub.Channel()
+	for {
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case msg, ok := <-messages:
+			if !ok {
+				return nil
+			}
+
+			event := &Event{}
+			if err := json.Unmarshal([]byte(msg.Payload), event); err != nil {
+				continue
+			}
+
+			handler(event)
+		}
+	}
+}
+
+// DeviceApproval - данные событий подтверждения устройства
+type DeviceApproval struct {
+	DeviceID          uuid.UUID  `json:"device_id"`
+	Name              string     `json:"name"`
+	DeviceType        string     `json:"device_type"`
+	ApprovalStatus    string     `json:"approval_status"`
+	DecidedByDeviceID *uuid.UUID `json:"decided_by_device_id,omitempty"`
+}
+
+// DeviceInactivity - данные событий отключения неактивного устройства
+type DeviceInactivity struct {
+	DeviceID      uuid.UUID  `json:"device_id"`
+	Name          string     `json:"name"`
+	LastSeenAt    time.Time  `json:"last_seen_at"`
+	DeactivatesAt *time.Time `json:"deactivates_at,omitempty"`
+}
+
+// Inbox - данные события о файлах, которые ждут устройство во входящих
+type Inbox struct {
+	Items []*models.InboxItem `json:"items"`
+}
+
+// TransferTimeout - данные события о передаче, завершенной сервером: зависшей
+// в процессе (failed) или так и не принятой (expired)
+type TransferTimeout struct {
+	TransferID   uuid.UUID  `json:"transfer_id"`
+	FileID       uuid.UUID  `json:"file_id"`
+	FromDeviceID *uuid.UUID `json:"from_device_id,omitempty"`
+	ToDeviceID   *uuid.UUID `json:"to_device_id,omitempty"`
+	Status       string     `json:"status"`
+	Reason       string     `json:"reason"`
+}
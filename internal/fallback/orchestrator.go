@@ -0,0 +1,280 @@
+package fallback
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/backend-app/backend/internal/models"
+	"github.com/backend-app/backend/internal/progress"
+	"github.com/backend-app/backend/internal/repository"
+	"github.com/backend-app/backend/pkg/config"
+	"github.com/backend-app/backend/pkg/logger"
+	"github.com/google/uuid"
+	"github.com/rs/zerolog"
+)
+
+// ICEState - результат ICE согласования, о котором сообщает клиент
+type ICEState string
+
+const (
+	ICEStateChecking  ICEState = "checking"  // согласование началось
+	ICEStateConnected ICEState = "connected" // соединение установлено
+	ICEStateFailed    ICEState = "failed"    // соединение не установилось
+)
+
+// Report - сообщение клиента о состоянии ICE соединения для передачи.
+// Path - способ, которым устанавливается (или установлено) соединение:
+// webrtc или relay; пусто - текущий способ передачи.
+type Report struct {
+	TransferID uuid.UUID
+	State      ICEState
+	Path       models.TransferProtocol
+}
+
+// ErrTransferNotFound - передачи нет или устройство в ней не участвует
+var ErrTransferNotFound = errors.New("transfer not found")
+
+// Notifier сообщает участникам передачи о смене способа доставки или о том,
+// что запасных способов не осталось (передача в статусе failed)
+type Notifier func(transfer *models.Transfer, reason string)
+
+// Orchestrator переводит P2P передачу на запасной способ доставки
+// (webrtc -> relay -> cloud), если клиент сообщил о неудачном ICE согласовании
+// или соединение не установилось за ICETimeout. Передача сохраняет свой ID,
+// каждая смена способа записывается в ее историю.
+type Orchestrator struct {
+	config       *config.TransferConfig
+	transferRepo *repository.TransferRepo
+	deviceRepo   *repository.DeviceRepo
+	fileRepo     *repository.FileRepo
+	progress     *progress.Broker
+	notify       Notifier
+
+	mu     sync.Mutex
+	timers map[uuid.UUID]*time.Timer // transfer_id -> ожидание соединения
+
+	log zerolog.Logger
+}
+
+func NewOrchestrator(
+	cfg *config.TransferConfig,
+	transferRepo *repository.TransferRepo,
+	deviceRepo *repository.DeviceRepo,
+	fileRepo *repository.FileRepo,
+	progressBroker *progress.Broker,
+	notify Notifier,
+) *Orchestrator {
+	return &Orchestrator{
+		config:       cfg,
+		transferRepo: transferRepo,
+		deviceRepo:   deviceRepo,
+		fileRepo:     fileRepo,
+		progress:     progressBroker,
+		notify:       notify,
+		timers:       make(map[uuid.UUID]*time.Timer),
+		log:          logger.Get(),
+	}
+}
+
+// Report обрабатывает сообщение устройства deviceID о состоянии ICE и возвращает
+// передачу, чтобы сообщение можно было переслать второму участнику
+func (o *Orchestrator) Report(deviceID uuid.UUID, report Report) (*models.Transfer, error) {
+	transfer, err := o.transferRepo.GetByID(report.TransferID)
+	if err != nil {
+		return nil, errors.New("failed to get transfer")
+	}
+	if transfer == nil || !transfer.HasDevice(deviceID) {
+		return nil, ErrTransferNotFound
+	}
+	if transfer.Status.IsTerminal() {
+		return nil, fmt.Errorf("transfer is already %s", transfer.Status)
+	}
+	if transfer.DeliveryPath == models.TransferProtocolCloud {
+		return nil, errors.New("transfer is delivered through cloud storage")
+	}
+
+	path := report.Path
+	if path == "" {
+		path = transfer.DeliveryPath
+	}
+	if path != models.TransferProtocolWebRTC && path != models.TransferProtocolRelay {
+		return nil, errors.New("path must be webrtc or relay")
+	}
+
+	switch report.State {
+	case ICEStateChecking:
+		o.watch(transfer.ID, path)
+	case ICEStateConnected:
+		o.stopWatch(transfer.ID)
+		o.connected(transfer, path)
+	case ICEStateFailed:
+		o.stopWatch(transfer.ID)
+		o.fallback(transfer.ID, path, models.TransferReasonICEFailed)
+	default:
+		return nil, errors.New("state must be checking, connected or failed")
+	}
+
+	return transfer, nil
+}
+
+// watch запускает (или перезапускает) ожидание соединения способом path
+func (o *Orchestrator) watch(transferID uuid.UUID, path models.TransferProtocol) {
+	o.mu.Lock()
+	defer o.mu.Unlock()
+
+	if timer, ok := o.timers[transferID]; ok {
+		timer.Stop()
+	}
+
+	var timer *time.Timer
+	timer = time.AfterFunc(o.config.ICETimeout, func() {
+		o.mu.Lock()
+		if o.timers[transferID] == timer {
+			delete(o.timers, transferID)
+		}
+		o.mu.Unlock()
+
+		o.fallback(transferID, path, models.TransferReasonICETimeout)
+	})
+	o.timers[transferID] = timer
+}
+
+func (o *Orchestrator) stopWatch(transferID uuid.UUID) {
+	o.mu.Lock()
+	defer o.mu.Unlock()
+
+	if timer, ok := o.timers[transferID]; ok {
+		timer.Stop()
+		delete(o.timers, transferID)
+	}
+}
+
+// Stop отменяет все ожидания соединения
+func (o *Orchestrator) Stop() {
+	o.mu.Lock()
+	defer o.mu.Unlock()
+
+	for transferID, timer := range o.timers {
+		timer.Stop()
+		delete(o.timers, transferID)
+	}
+}
+
+// connected записывает способ, которым установлено соединение, если ICE выбрал
+// не тот, что записан у передачи (например, TURN вместо прямого соединения)
+func (o *Orchestrator) connected(transfer *models.Transfer, path models.TransferProtocol) {
+	if transfer.DeliveryPath == path {
+		return
+	}
+
+	from := transfer.DeliveryPath
+	transfer.DeliveryPath = path
+	transfer.UpdatedAt = time.Now()
+
+	if err := o.transferRepo.ChangeDeliveryPath(transfer, from, ""); err != nil && err != sql.ErrNoRows {
+		o.log.Error().Err(err).Str("transfer_id", transfer.ID.String()).Msg("Failed to record delivery path")
+	}
+}
+
+// fallback переводит передачу со способа failedPath на следующий. Если передача
+// уже завершена или перешла на другой способ, ничего не делает. Если запасных
+// способов нет, передача завершается со статусом failed.
+func (o *Orchestrator) fallback(transferID uuid.UUID, failedPath models.TransferProtocol, reason string) {
+	transfer, err := o.transferRepo.GetByID(transferID)
+	if err != nil {
+		o.log.Error().Err(err).Str("transfer_id", transferID.String()).Msg("Failed to get transfer for fallback")
+		return
+	}
+	if transfer == nil || transfer.Status.IsTerminal() || transfer.DeliveryPath != failedPath {
+		return
+	}
+
+	next, ok, err := o.nextPath(transfer)
+	if err != nil {
+		o.log.Error().Err(err).Str("transfer_id", transferID.String()).Msg("Failed to choose fallback path")
+		return
+	}
+
+	if !ok {
+		o.fail(transfer, reason)
+		return
+	}
+
+	transfer.DeliveryPath = next
+	transfer.UpdatedAt = time.Now()
+
+	err = o.transferRepo.ChangeDeliveryPath(transfer, failedPath, reason)
+	if err == sql.ErrNoRows {
+		return
+	}
+	if err != nil {
+		o.log.Error().Err(err).Str("transfer_id", transferID.String()).Msg("Failed to change delivery path")
+		return
+	}
+
+	o.log.Info().
+		Str("transfer_id", transferID.String()).
+		Str("from", string(failedPath)).
+		Str("to", string(next)).
+		Str("reason", reason).
+		Msg("Transfer switched to fallback delivery path")
+
+	o.notify(transfer, reason)
+}
+
+func (o *Orchestrator) nextPath(transfer *models.Transfer) (models.TransferProtocol, bool, error) {
+	if transfer.ToDeviceID == nil {
+		return "", false, nil
+	}
+
+	toDevice, err := o.deviceRepo.GetByID(*transfer.ToDeviceID)
+	if err != nil {
+		return "", false, err
+	}
+	if toDevice == nil || !toDevice.IsActive() {
+		return "", false, nil
+	}
+
+	var fromDevice *models.Device
+	if transfer.FromDeviceID != nil {
+		fromDevice, err = o.deviceRepo.GetByID(*transfer.FromDeviceID)
+		if err != nil {
+			return "", false, err
+		}
+	}
+
+	next, ok := models.NextDeliveryPath(transfer.DeliveryPath, fromDevice, toDevice)
+	return next, ok, nil
+}
+
+// fail завершает передачу, для которой не осталось способов доставки
+func (o *Orchestrator) fail(transfer *models.Transfer, reason string) {
+	previousStatus := transfer.Status
+	previousBytes := transfer.BytesTransferred
+	previousAt := transfer.UpdatedAt
+
+	if err := transfer.TransitionTo(models.TransferStatusFailed, reason); err != nil {
+		return
+	}
+
+	err := o.transferRepo.Transition(transfer, previousStatus)
+	if err == sql.ErrNoRows {
+		return
+	}
+	if err != nil {
+		o.log.Error().Err(err).Str("transfer_id", transfer.ID.String()).Msg("Failed to fail transfer")
+		return
+	}
+
+	var totalSize int64
+	if file, err := o.fileRepo.GetByID(transfer.FileID); err == nil && file != nil {
+		totalSize = file.Size
+	}
+
+	o.progress.Publish(context.Background(), progress.NewUpdate(transfer, totalSize, previousBytes, previousAt))
+	o.notify(transfer, reason)
+}
@@ -5,13 +5,21 @@ import (
 	"fmt"
 	"net"
 
+	"github.com/backend-app/backend/internal/events"
 	"github.com/backend-app/backend/internal/grpc/services"
+	"github.com/backend-app/backend/internal/presence"
+	"github.com/backend-app/backend/internal/progress"
+	"github.com/backend-app/backend/internal/push"
 	"github.com/backend-app/backend/internal/repository"
 	"github.com/backend-app/backend/internal/storage"
+	"github.com/backend-app/backend/internal/websocket"
 	"github.com/backend-app/backend/pkg/config"
+	accountpb "github.com/backend-app/backend/pkg/proto/account"
 	authpb "github.com/backend-app/backend/pkg/proto/auth"
 	devicepb "github.com/backend-app/backend/pkg/proto/device"
 	filepb "github.com/backend-app/backend/pkg/proto/file"
+	organizationpb "github.com/backend-app/backend/pkg/proto/organization"
+	signalingpb "github.com/backend-app/backend/pkg/proto/signaling"
 	transferpb "github.com/backend-app/backend/pkg/proto/transfer"
 	"github.com/redis/go-redis/v9"
 	"google.golang.org/grpc"
@@ -22,13 +30,19 @@ type Server struct {
 	config     *config.Config
 }
 
-func NewServer(cfg *config.Config, db *sql.DB, redisClient *redis.Client) *Server {
+func NewServer(cfg *config.Config, db *sql.DB, redisClient *redis.Client, pushService *push.Service, signalingHub *websocket.Hub) *Server {
 	grpcServer := grpc.NewServer()
 
 	userRepo := repository.NewUserRepo(db)
 	deviceRepo := repository.NewDeviceRepo(db)
 	fileRepo := repository.NewFileRepo(db)
 	transferRepo := repository.NewTransferRepo(db)
+	accountRepo := repository.NewAccountRepo(db)
+	orgRepo := repository.NewOrganizationRepo(db)
+	inboxRepo := repository.NewInboxRepo(db)
+	pairingRepo := repository.NewPairingRepo(redisClient)
+	presenceStore := presence.NewStore(redisClient, cfg.Presence.TTL)
+	eventBus := events.NewBus(redisClient)
 
 	localStorage, err := storage.NewLocalStorage(cfg.Storage.LocalPath)
 	if err != nil {
@@ -36,9 +50,12 @@ func NewServer(cfg *config.Config, db *sql.DB, redisClient *redis.Client) *Serve
 	}
 
 	authpb.RegisterAuthServiceServer(grpcServer, services.NewAuthService(userRepo, cfg.Server.JWTSecret))
-	devicepb.RegisterDeviceServiceServer(grpcServer, services.NewDeviceService(deviceRepo))
-	filepb.RegisterFileServiceServer(grpcServer, services.NewFileService(fileRepo, localStorage))
-	transferpb.RegisterTransferServiceServer(grpcServer, services.NewTransferService(transferRepo))
+	devicepb.RegisterDeviceServiceServer(grpcServer, services.NewDeviceService(deviceRepo, userRepo, orgRepo, pairingRepo, &cfg.Pairing, &cfg.Device, &cfg.Signaling, cfg.Server.JWTSecret, presenceStore, eventBus))
+	filepb.RegisterFileServiceServer(grpcServer, services.NewFileService(fileRepo, orgRepo, inboxRepo, localStorage))
+	transferpb.RegisterTransferServiceServer(grpcServer, services.NewTransferService(transferRepo, deviceRepo, fileRepo, orgRepo, inboxRepo, &cfg.Transfer, presenceStore, eventBus, pushService, progress.NewBroker(redisClient)))
+	accountpb.RegisterAccountServiceServer(grpcServer, services.NewAccountService(userRepo, accountRepo, orgRepo, localStorage, cfg.Account.DeletionGracePeriod))
+	organizationpb.RegisterOrganizationServiceServer(grpcServer, services.NewOrganizationService(orgRepo, userRepo, fileRepo, localStorage))
+	signalingpb.RegisterSignalingServiceServer(grpcServer, services.NewSignalingService(signalingHub))
 
 	return &Server{
 		grpcServer: grpcServer,
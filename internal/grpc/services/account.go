@@ -0,0 +1,284 @@
+package services
+
+import (
+	"context"
+	"io"
+	"time"
+
+	"github.com/backend-app/backend/internal/models"
+	"github.com/backend-app/backend/internal/repository"
+	"github.com/backend-app/backend/internal/storage"
+	accountpb "github.com/backend-app/backend/pkg/proto/account"
+	"github.com/google/uuid"
+	"golang.org/x/crypto/bcrypt"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+type AccountService struct {
+	accountpb.UnimplementedAccountServiceServer
+	userRepo            *repository.UserRepo
+	accountRepo         *repository.AccountRepo
+	orgRepo             *repository.OrganizationRepo
+	storage             *storage.LocalStorage
+	deletionGracePeriod time.Duration
+	chunkSize           int64
+}
+
+func NewAccountService(userRepo *repository.UserRepo, accountRepo *repository.AccountRepo, orgRepo *repository.OrganizationRepo, storage *storage.LocalStorage, deletionGracePeriod time.Duration) *AccountService {
+	return &AccountService{
+		userRepo:            userRepo,
+		accountRepo:         accountRepo,
+		orgRepo:             orgRepo,
+		storage:             storage,
+		deletionGracePeriod: deletionGracePeriod,
+		chunkSize:           64 * 1024,
+	}
+}
+
+func (s *AccountService) RequestDataExport(ctx context.Context, req *accountpb.RequestDataExportRequest) (*accountpb.RequestDataExportResponse, error) {
+	userID, err := uuid.Parse(req.UserId)
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, "invalid user_id")
+	}
+
+	user, err := s.userRepo.GetByID(userID)
+	if err != nil {
+		return nil, status.Error(codes.Internal, "failed to get user")
+	}
+	if user == nil {
+		return nil, status.Error(codes.NotFound, "user not found")
+	}
+
+	export := &models.DataExport{
+		UserID: userID,
+		Status: models.DataExportStatusPending,
+	}
+
+	if err := export.Validate(); err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+
+	if err := s.accountRepo.CreateExport(export); err != nil {
+		return nil, status.Error(codes.Internal, "failed to create data export")
+	}
+
+	return &accountpb.RequestDataExportResponse{
+		Export: s.exportToProto(export),
+	}, nil
+}
+
+func (s *AccountService) GetDataExport(ctx context.Context, req *accountpb.GetDataExportRequest) (*accountpb.GetDataExportResponse, error) {
+	export, err := s.getOwnedExport(req.ExportId, req.UserId)
+	if err != nil {
+		return nil, err
+	}
+
+	return &accountpb.GetDataExportResponse{
+		Export: s.exportToProto(export),
+	}, nil
+}
+
+func (s *AccountService) DownloadDataExport(req *accountpb.DownloadDataExportRequest, stream accountpb.AccountService_DownloadDataExportServer) error {
+	export, err := s.getOwnedExport(req.ExportId, req.UserId)
+	if err != nil {
+		return err
+	}
+
+	if export.Status != models.DataExportStatusCompleted {
+		return status.Error(codes.FailedPrecondition, "data export is not ready")
+	}
+
+	if export.IsExpired() {
+		return status.Error(codes.NotFound, "data export expired")
+	}
+
+	reader, fileSize, err := s.storage.ReadFile(export.StoragePath, 0, 0)
+	if err != nil {
+		return status.Error(codes.Internal, "failed to read data export: "+err.Error())
+	}
+	defer reader.Close()
+
+	buffer := make([]byte, s.chunkSize)
+	totalSent := int64(0)
+
+	for {
+		n, err := reader.Read(buffer)
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return status.Error(codes.Internal, "failed to read chunk: "+err.Error())
+		}
+
+		isLast := totalSent+int64(n) >= fileSize
+
+		if err := stream.Send(&accountpb.DownloadDataExportResponse{
+			Data:      buffer[:n],
+			ChunkSize: int64(n),
+			IsLast:    isLast,
+		}); err != nil {
+			return status.Error(codes.Internal, "failed to send chunk: "+err.Error())
+		}
+
+		totalSent += int64(n)
+		if isLast {
+			break
+		}
+	}
+
+	return nil
+}
+
+func (s *AccountService) RequestAccountDeletion(ctx context.Context, req *accountpb.RequestAccountDeletionRequest) (*accountpb.RequestAccountDeletionResponse, error) {
+	userID, err := uuid.Parse(req.UserId)
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, "invalid user_id")
+	}
+
+	user, err := s.userRepo.GetByID(userID)
+	if err != nil {
+		return nil, status.Error(codes.Internal, "failed to get user")
+	}
+	if user == nil {
+		return nil, status.Error(codes.NotFound, "user not found")
+	}
+
+	err = bcrypt.CompareHashAndPassword([]byte(user.PasswordHash), []byte(req.Password))
+	if err != nil {
+		return nil, status.Error(codes.Unauthenticated, "invalid password")
+	}
+
+	if user.IsDeletionPending() {
+		return nil, status.Error(codes.AlreadyExists, "account deletion already requested")
+	}
+
+	// Организация не должна остаться без владельца
+	soleOwned, err := s.orgRepo.GetSoleOwnedByUserID(userID)
+	if err != nil {
+		return nil, status.Error(codes.Internal, "failed to get owned organizations")
+	}
+	if len(soleOwned) > 0 {
+		return nil, status.Errorf(codes.FailedPrecondition, "transfer ownership of organization %q before deleting the account", soleOwned[0].Name)
+	}
+
+	scheduledAt := time.Now().Add(s.deletionGracePeriod)
+	if err := s.userRepo.ScheduleDeletion(userID, scheduledAt); err != nil {
+		return nil, status.Error(codes.Internal, "failed to schedule account deletion")
+	}
+
+	user, err = s.userRepo.GetByID(userID)
+	if err != nil || user == nil {
+		return nil, status.Error(codes.Internal, "failed to get user")
+	}
+
+	return &accountpb.RequestAccountDeletionResponse{
+		Deletion: s.deletionToProto(user),
+	}, nil
+}
+
+func (s *AccountService) CancelAccountDeletion(ctx context.Context, req *accountpb.CancelAccountDeletionRequest) (*accountpb.CancelAccountDeletionResponse, error) {
+	userID, err := uuid.Parse(req.UserId)
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, "invalid user_id")
+	}
+
+	user, err := s.userRepo.GetByID(userID)
+	if err != nil {
+		return nil, status.Error(codes.Internal, "failed to get user")
+	}
+	if user == nil {
+		return nil, status.Error(codes.NotFound, "user not found")
+	}
+
+	if !user.IsDeletionPending() {
+		return nil, status.Error(codes.FailedPrecondition, "account deletion is not requested")
+	}
+
+	if err := s.userRepo.CancelDeletion(userID); err != nil {
+		return nil, status.Error(codes.Internal, "failed to cancel account deletion")
+	}
+
+	return &accountpb.CancelAccountDeletionResponse{
+		Success: true,
+	}, nil
+}
+
+func (s *AccountService) GetAccountDeletionStatus(ctx context.Context, req *accountpb.GetAccountDeletionStatusRequest) (*accountpb.GetAccountDeletionStatusResponse, error) {
+	userID, err := uuid.Parse(req.UserId)
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, "invalid user_id")
+	}
+
+	user, err := s.userRepo.GetByID(userID)
+	if err != nil {
+		return nil, status.Error(codes.Internal, "failed to get user")
+	}
+	if user == nil {
+		return nil, status.Error(codes.NotFound, "user not found")
+	}
+
+	return &accountpb.GetAccountDeletionStatusResponse{
+		Deletion: s.deletionToProto(user),
+	}, nil
+}
+
+func (s *AccountService) getOwnedExport(exportIDStr, userIDStr string) (*models.DataExport, error) {
+	exportID, err := uuid.Parse(exportIDStr)
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, "invalid export_id")
+	}
+
+	userID, err := uuid.Parse(userIDStr)
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, "invalid user_id")
+	}
+
+	export, err := s.accountRepo.GetExportByID(exportID)
+	if err != nil {
+		return nil, status.Error(codes.Internal, "failed to get data export")
+	}
+	if export == nil {
+		return nil, status.Error(codes.NotFound, "data export not found")
+	}
+
+	if export.UserID != userID {
+		return nil, status.Error(codes.PermissionDenied, "data export belongs to another user")
+	}
+
+	return export, nil
+}
+
+func (s *AccountService) exportToProto(export *models.DataExport) *accountpb.DataExport {
+	pbExport := &accountpb.DataExport{
+		Id:        export.ID.String(),
+		UserId:    export.UserID.String(),
+		Status:    string(export.Status),
+		Size:      export.Size,
+		Error:     export.Error,
+		CreatedAt: export.CreatedAt.Format(time.RFC3339),
+		UpdatedAt: export.UpdatedAt.Format(time.RFC3339),
+	}
+
+	if export.ExpiresAt != nil {
+		pbExport.ExpiresAt = export.ExpiresAt.Format(time.RFC3339)
+	}
+
+	return pbExport
+}
+
+func (s *AccountService) deletionToProto(user *models.User) *accountpb.AccountDeletionStatus {
+	deletion := &accountpb.AccountDeletionStatus{
+		Pending: user.IsDeletionPending(),
+	}
+
+	if user.DeletionRequestedAt != nil {
+		deletion.RequestedAt = user.DeletionRequestedAt.Format(time.RFC3339)
+	}
+
+	if user.DeletionScheduledAt != nil {
+		deletion.ScheduledAt = user.DeletionScheduledAt.Format(time.RFC3339)
+	}
+
+	return deletion
+}
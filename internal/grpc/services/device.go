@@ -2,10 +2,20 @@ package services
 
 import (
 	"context"
+	"crypto/rand"
+	"database/sql"
+	"fmt"
+	"math/big"
 	"time"
 
+	"github.com/backend-app/backend/internal/events"
 	"github.com/backend-app/backend/internal/models"
+	"github.com/backend-app/backend/internal/presence"
+	"github.com/backend-app/backend/internal/push"
 	"github.com/backend-app/backend/internal/repository"
+	"github.com/backend-app/backend/pkg/config"
+	"github.com/backend-app/backend/pkg/devicetoken"
+	"github.com/backend-app/backend/pkg/jwt"
 	devicepb "github.com/backend-app/backend/pkg/proto/device"
 	"github.com/google/uuid"
 	"google.golang.org/grpc/codes"
@@ -14,12 +24,30 @@ import (
 
 type DeviceService struct {
 	devicepb.UnimplementedDeviceServiceServer
-	deviceRepo *repository.DeviceRepo
+	deviceRepo   *repository.DeviceRepo
+	userRepo     *repository.UserRepo
+	orgRepo      *repository.OrganizationRepo
+	pairingRepo  *repository.PairingRepo
+	pairingCfg   *config.PairingConfig
+	deviceCfg    *config.DeviceConfig
+	signalingCfg *config.SignalingConfig
+	jwtSecret    string
+	presence     *presence.Store
+	events       *events.Bus
 }
 
-func NewDeviceService(deviceRepo *repository.DeviceRepo) *DeviceService {
+func NewDeviceService(deviceRepo *repository.DeviceRepo, userRepo *repository.UserRepo, orgRepo *repository.OrganizationRepo, pairingRepo *repository.PairingRepo, pairingCfg *config.PairingConfig, deviceCfg *config.DeviceConfig, signalingCfg *config.SignalingConfig, jwtSecret string, presenceStore *presence.Store, eventBus *events.Bus) *DeviceService {
 	return &DeviceService{
-		deviceRepo: deviceRepo,
+		deviceRepo:   deviceRepo,
+		userRepo:     userRepo,
+		orgRepo:      orgRepo,
+		pairingRepo:  pairingRepo,
+		pairingCfg:   pairingCfg,
+		deviceCfg:    deviceCfg,
+		signalingCfg: signalingCfg,
+		jwtSecret:    jwtSecret,
+		presence:     presenceStore,
+		events:       eventBus,
 	}
 }
 
@@ -30,37 +58,54 @@ func (s *DeviceService) RegisterDevice(ctx context.Context, req *devicepb.Regist
 	}
 
 	deviceType := models.DeviceType(req.DeviceType)
-	if deviceType != models.DeviceTypeDesktop && deviceType != models.DeviceTypeMobile {
+	if !deviceType.IsValid() {
 		return nil, status.Error(codes.InvalidArgument, "invalid device_type")
 	}
 
+	organizationID, err := s.parseOrganizationID(req.OrganizationId, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	deviceToken, err := devicetoken.Generate()
+	if err != nil {
+		return nil, status.Error(codes.Internal, "failed to generate device token")
+	}
+
 	device := &models.Device{
-		UserID:      userID,
-		Name:        req.Name,
-		DeviceType:  deviceType,
-		DeviceToken: uuid.New().String(),
+		UserID:          userID,
+		OrganizationID:  organizationID,
+		Name:            req.Name,
+		DeviceType:      deviceType,
+		ApprovalStatus:  models.DeviceApprovalPending,
+		DeviceTokenHash: devicetoken.Hash(deviceToken),
 	}
+	applyDeviceInfo(device, req.Info)
 
 	if err := device.Validate(); err != nil {
 		return nil, status.Error(codes.InvalidArgument, err.Error())
 	}
 
+	// Первое устройство подтверждать некому, поэтому оно доверенное сразу
+	hasApproved, err := s.deviceRepo.HasApprovedDevices(userID)
+	if err != nil {
+		return nil, status.Error(codes.Internal, "failed to check devices")
+	}
+	if !hasApproved {
+		device.ApprovalStatus = models.DeviceApprovalApproved
+	}
+
 	if err := s.deviceRepo.Create(device); err != nil {
 		return nil, status.Error(codes.Internal, "failed to create device")
 	}
 
+	if device.ApprovalStatus == models.DeviceApprovalPending {
+		s.publishApproval(ctx, events.TypeDeviceApprovalRequested, device)
+	}
+
 	return &devicepb.RegisterDeviceResponse{
-		Device: &devicepb.Device{
-			Id:          device.ID.String(),
-			UserId:      device.UserID.String(),
-			Name:        device.Name,
-			DeviceType:  string(device.DeviceType),
-			DeviceToken: device.DeviceToken,
-			LastSeenAt:  device.LastSeenAt.Format(time.RFC3339),
-			CreatedAt:   device.CreatedAt.Format(time.RFC3339),
-			UpdatedAt:   device.UpdatedAt.Format(time.RFC3339),
-		},
-		DeviceToken: device.DeviceToken,
+		Device:      s.deviceToProto(device),
+		DeviceToken: deviceToken,
 	}, nil
 }
 
@@ -80,20 +125,17 @@ func (s *DeviceService) GetDevice(ctx context.Context, req *devicepb.GetDeviceRe
 
 	userID, err := uuid.Parse(req.UserId)
 	if err == nil && device.UserID != userID {
-		return nil, status.Error(codes.PermissionDenied, "device belongs to another user")
+		// Устройства организации видны всем ее участникам
+		if !s.isOrganizationMember(device.OrganizationID, userID) {
+			return nil, status.Error(codes.PermissionDenied, "device belongs to another user")
+		}
 	}
 
+	pbDevice := s.deviceToProto(device)
+	s.applyPresence(ctx, []*devicepb.Device{pbDevice}, []*models.Device{device})
+
 	return &devicepb.GetDeviceResponse{
-		Device: &devicepb.Device{
-			Id:          device.ID.String(),
-			UserId:      device.UserID.String(),
-			Name:        device.Name,
-			DeviceType:  string(device.DeviceType),
-			DeviceToken: device.DeviceToken,
-			LastSeenAt:  device.LastSeenAt.Format(time.RFC3339),
-			CreatedAt:   device.CreatedAt.Format(time.RFC3339),
-			UpdatedAt:   device.UpdatedAt.Format(time.RFC3339),
-		},
+		Device: pbDevice,
 	}, nil
 }
 
@@ -103,24 +145,37 @@ func (s *DeviceService) ListDevices(ctx context.Context, req *devicepb.ListDevic
 		return nil, status.Error(codes.InvalidArgument, "invalid user_id")
 	}
 
-	devices, err := s.deviceRepo.GetByUserID(userID)
+	var devices []*models.Device
+	if req.OrganizationId != "" {
+		organizationID, err := s.parseOrganizationID(req.OrganizationId, userID)
+		if err != nil {
+			return nil, err
+		}
+
+		devices, err = s.deviceRepo.GetByOrganizationID(*organizationID)
+	} else {
+		devices, err = s.deviceRepo.GetByUserID(userID)
+	}
 	if err != nil {
 		return nil, status.Error(codes.Internal, "failed to list devices")
 	}
 
+	// Отключенные по неактивности устройства скрыты, если их не запросили явно
+	if !req.IncludeDeactivated {
+		active := devices[:0]
+		for _, device := range devices {
+			if device.IsActive() {
+				active = append(active, device)
+			}
+		}
+		devices = active
+	}
+
 	pbDevices := make([]*devicepb.Device, len(devices))
 	for i, device := range devices {
-		pbDevices[i] = &devicepb.Device{
-			Id:          device.ID.String(),
-			UserId:      device.UserID.String(),
-			Name:        device.Name,
-			DeviceType:  string(device.DeviceType),
-			DeviceToken: device.DeviceToken,
-			LastSeenAt:  device.LastSeenAt.Format(time.RFC3339),
-			CreatedAt:   device.CreatedAt.Format(time.RFC3339),
-			UpdatedAt:   device.UpdatedAt.Format(time.RFC3339),
-		}
+		pbDevices[i] = s.deviceToProto(device)
 	}
+	s.applyPresence(ctx, pbDevices, devices)
 
 	return &devicepb.ListDevicesResponse{
 		Devices: pbDevices,
@@ -164,16 +219,7 @@ func (s *DeviceService) UpdateDevice(ctx context.Context, req *devicepb.UpdateDe
 	}
 
 	return &devicepb.UpdateDeviceResponse{
-		Device: &devicepb.Device{
-			Id:          device.ID.String(),
-			UserId:      device.UserID.String(),
-			Name:        device.Name,
-			DeviceType:  string(device.DeviceType),
-			DeviceToken: device.DeviceToken,
-			LastSeenAt:  device.LastSeenAt.Format(time.RFC3339),
-			CreatedAt:   device.CreatedAt.Format(time.RFC3339),
-			UpdatedAt:   device.UpdatedAt.Format(time.RFC3339),
-		},
+		Device: s.deviceToProto(device),
 	}, nil
 }
 
@@ -223,3 +269,650 @@ func (s *DeviceService) UpdateLastSeen(ctx context.Context, req *devicepb.Update
 		Success: true,
 	}, nil
 }
+
+func (s *DeviceService) SetDeviceOrganization(ctx context.Context, req *devicepb.SetDeviceOrganizationRequest) (*devicepb.SetDeviceOrganizationResponse, error) {
+	deviceID, err := uuid.Parse(req.DeviceId)
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, "invalid device_id")
+	}
+
+	userID, err := uuid.Parse(req.UserId)
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, "invalid user_id")
+	}
+
+	device, err := s.deviceRepo.GetByID(deviceID)
+	if err != nil {
+		return nil, status.Error(codes.Internal, "failed to get device")
+	}
+	if device == nil {
+		return nil, status.Error(codes.NotFound, "device not found")
+	}
+
+	if device.UserID != userID {
+		return nil, status.Error(codes.PermissionDenied, "device belongs to another user")
+	}
+
+	organizationID, err := s.parseOrganizationID(req.OrganizationId, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	device.OrganizationID = organizationID
+	if err := s.deviceRepo.Update(device); err != nil {
+		return nil, status.Error(codes.Internal, "failed to update device")
+	}
+
+	return &devicepb.SetDeviceOrganizationResponse{
+		Device: s.deviceToProto(device),
+	}, nil
+}
+
+func (s *DeviceService) CreatePairingCode(ctx context.Context, req *devicepb.CreatePairingCodeRequest) (*devicepb.CreatePairingCodeResponse, error) {
+	userID, err := uuid.Parse(req.UserId)
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, "invalid user_id")
+	}
+
+	deviceID, err := uuid.Parse(req.DeviceId)
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, "invalid device_id")
+	}
+
+	device, err := s.deviceRepo.GetByID(deviceID)
+	if err != nil {
+		return nil, status.Error(codes.Internal, "failed to get device")
+	}
+	if device == nil {
+		return nil, status.Error(codes.NotFound, "device not found")
+	}
+
+	if device.UserID != userID {
+		return nil, status.Error(codes.PermissionDenied, "device belongs to another user")
+	}
+
+	// Устройство, созданное по коду, подтверждается автоматически,
+	// поэтому выдавать коды могут только доверенные устройства
+	if !device.IsApproved() {
+		return nil, status.Error(codes.PermissionDenied, "device is not approved")
+	}
+
+	// Повторяем генерацию при редкой коллизии с уже выданным кодом
+	for i := 0; i < 5; i++ {
+		code, err := generatePairingCode(s.pairingCfg.CodeLength)
+		if err != nil {
+			return nil, status.Error(codes.Internal, "failed to generate pairing code")
+		}
+
+		pairing := &models.DevicePairing{
+			Code:     code,
+			UserID:   userID,
+			DeviceID: deviceID,
+		}
+
+		created, err := s.pairingRepo.Create(ctx, pairing, s.pairingCfg.CodeTTL)
+		if err != nil {
+			return nil, status.Error(codes.Internal, "failed to create pairing code")
+		}
+		if !created {
+			continue
+		}
+
+		return &devicepb.CreatePairingCodeResponse{
+			Code:      pairing.Code,
+			QrPayload: fmt.Sprintf("flow://pair?code=%s", pairing.Code),
+			ExpiresAt: pairing.ExpiresAt.Format(time.RFC3339),
+		}, nil
+	}
+
+	return nil, status.Error(codes.Unavailable, "failed to allocate pairing code, try again")
+}
+
+func (s *DeviceService) RedeemPairingCode(ctx context.Context, req *devicepb.RedeemPairingCodeRequest) (*devicepb.RedeemPairingCodeResponse, error) {
+	if req.Code == "" {
+		return nil, status.Error(codes.InvalidArgument, "code is required")
+	}
+
+	deviceType := models.DeviceType(req.DeviceType)
+	if !deviceType.IsValid() {
+		return nil, status.Error(codes.InvalidArgument, "invalid device_type")
+	}
+
+	deviceToken, err := devicetoken.Generate()
+	if err != nil {
+		return nil, status.Error(codes.Internal, "failed to generate device token")
+	}
+
+	device := &models.Device{
+		Name:            req.Name,
+		DeviceType:      deviceType,
+		ApprovalStatus:  models.DeviceApprovalApproved,
+		DeviceTokenHash: devicetoken.Hash(deviceToken),
+	}
+	applyDeviceInfo(device, req.Info)
+
+	// Проверяем данные до погашения кода, чтобы ошибка клиента не сжигала код
+	if err := device.Validate(); err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+
+	attemptKey := req.ClientIp
+	if attemptKey == "" {
+		attemptKey = "unknown"
+	}
+
+	attempts, err := s.pairingRepo.RegisterAttempt(ctx, attemptKey, s.pairingCfg.AttemptWindow)
+	if err != nil {
+		return nil, status.Error(codes.Internal, "failed to check pairing attempts")
+	}
+	if attempts > int64(s.pairingCfg.MaxAttempts) {
+		return nil, status.Error(codes.ResourceExhausted, "too many pairing attempts, try again later")
+	}
+
+	// Общий лимит неверных кодов не обходится сменой адреса
+	failed, err := s.pairingRepo.FailedAttempts(ctx)
+	if err != nil {
+		return nil, status.Error(codes.Internal, "failed to check pairing attempts")
+	}
+	if failed >= int64(s.pairingCfg.MaxFailedAttempts) {
+		return nil, status.Error(codes.ResourceExhausted, "too many pairing attempts, try again later")
+	}
+
+	pairing, err := s.pairingRepo.Redeem(ctx, req.Code)
+	if err != nil {
+		return nil, status.Error(codes.Internal, "failed to redeem pairing code")
+	}
+	if pairing == nil {
+		if err := s.pairingRepo.RegisterFailedAttempt(ctx, s.pairingCfg.AttemptWindow); err != nil {
+			return nil, status.Error(codes.Internal, "failed to register pairing attempt")
+		}
+		return nil, status.Error(codes.NotFound, "invalid or expired pairing code")
+	}
+
+	device.UserID = pairing.UserID
+	device.ApprovedByDeviceID = &pairing.DeviceID
+
+	if err := s.deviceRepo.Create(device); err != nil {
+		return nil, status.Error(codes.Internal, "failed to create device")
+	}
+
+	return &devicepb.RedeemPairingCodeResponse{
+		Device:      s.deviceToProto(device),
+		DeviceToken: deviceToken,
+	}, nil
+}
+
+func (s *DeviceService) ApproveDevice(ctx context.Context, req *devicepb.ApproveDeviceRequest) (*devicepb.ApproveDeviceResponse, error) {
+	device, err := s.decideApproval(ctx, req.DeviceId, req.UserId, req.ApproverDeviceToken, models.DeviceApprovalApproved)
+	if err != nil {
+		return nil, err
+	}
+
+	return &devicepb.ApproveDeviceResponse{
+		Device: s.deviceToProto(device),
+	}, nil
+}
+
+func (s *DeviceService) RejectDevice(ctx context.Context, req *devicepb.RejectDeviceRequest) (*devicepb.RejectDeviceResponse, error) {
+	device, err := s.decideApproval(ctx, req.DeviceId, req.UserId, req.ApproverDeviceToken, models.DeviceApprovalRejected)
+	if err != nil {
+		return nil, err
+	}
+
+	return &devicepb.RejectDeviceResponse{
+		Device: s.deviceToProto(device),
+	}, nil
+}
+
+// decideApproval подтверждает или отклоняет ожидающее устройство. Решение принимает
+// доверенное устройство пользователя, которое подтверждает себя своим токеном.
+func (s *DeviceService) decideApproval(ctx context.Context, deviceIDStr, userIDStr, approverToken string, approvalStatus models.DeviceApprovalStatus) (*models.Device, error) {
+	deviceID, err := uuid.Parse(deviceIDStr)
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, "invalid device_id")
+	}
+
+	userID, err := uuid.Parse(userIDStr)
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, "invalid user_id")
+	}
+
+	if approverToken == "" {
+		return nil, status.Error(codes.InvalidArgument, "approver_device_token is required")
+	}
+
+	device, err := s.deviceRepo.GetByID(deviceID)
+	if err != nil {
+		return nil, status.Error(codes.Internal, "failed to get device")
+	}
+	if device == nil {
+		return nil, status.Error(codes.NotFound, "device not found")
+	}
+
+	if device.UserID != userID {
+		return nil, status.Error(codes.PermissionDenied, "device belongs to another user")
+	}
+
+	approver, err := s.deviceRepo.GetByTokenHash(devicetoken.Hash(approverToken))
+	if err != nil {
+		return nil, status.Error(codes.Internal, "failed to get approver device")
+	}
+	if approver == nil || approver.UserID != userID {
+		return nil, status.Error(codes.PermissionDenied, "invalid approver_device_token")
+	}
+
+	if device.ApprovalStatus != models.DeviceApprovalPending {
+		return nil, status.Error(codes.FailedPrecondition, "device is not pending approval")
+	}
+
+	if err := approver.CanDecideApproval(device); err != nil {
+		return nil, status.Error(codes.PermissionDenied, err.Error())
+	}
+
+	if err := s.deviceRepo.DecideApproval(device, approvalStatus, approver.ID); err != nil {
+		if err == sql.ErrNoRows {
+			return nil, status.Error(codes.FailedPrecondition, "device is not pending approval")
+		}
+		return nil, status.Error(codes.Internal, "failed to update device")
+	}
+
+	eventType := events.TypeDeviceApproved
+	if approvalStatus == models.DeviceApprovalRejected {
+		eventType = events.TypeDeviceRejected
+	}
+	s.publishApproval(ctx, eventType, device)
+
+	return device, nil
+}
+
+// publishApproval уведомляет подключенные устройства пользователя об изменении подтверждения
+func (s *DeviceService) publishApproval(ctx context.Context, eventType string, device *models.Device) {
+	s.events.Publish(ctx, eventType, device.UserID, &events.DeviceApproval{
+		DeviceID:          device.ID,
+		Name:              device.Name,
+		DeviceType:        string(device.DeviceType),
+		ApprovalStatus:    string(device.ApprovalStatus),
+		DecidedByDeviceID: device.ApprovedByDeviceID,
+	})
+}
+
+// SetPushToken сохраняет токен push-уведомлений, которым сервер будит приложение,
+// когда устройство не подключено к signaling серверу
+func (s *DeviceService) SetPushToken(ctx context.Context, req *devicepb.SetPushTokenRequest) (*devicepb.SetPushTokenResponse, error) {
+	deviceID, err := uuid.Parse(req.DeviceId)
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, "invalid device_id")
+	}
+
+	userID, err := uuid.Parse(req.UserId)
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, "invalid user_id")
+	}
+
+	provider := models.PushProvider(req.Provider)
+	if (provider == "") != (req.Token == "") || (provider != "" && !provider.IsValid()) {
+		return nil, status.Error(codes.InvalidArgument, "invalid push provider or token")
+	}
+
+	if provider == models.PushProviderWebPush {
+		if err := push.ValidateWebPushToken(req.Token); err != nil {
+			return nil, status.Error(codes.InvalidArgument, err.Error())
+		}
+	}
+
+	device, err := s.deviceRepo.GetByID(deviceID)
+	if err != nil {
+		return nil, status.Error(codes.Internal, "failed to get device")
+	}
+	if device == nil {
+		return nil, status.Error(codes.NotFound, "device not found")
+	}
+
+	if device.UserID != userID {
+		return nil, status.Error(codes.PermissionDenied, "device belongs to another user")
+	}
+
+	if err := s.deviceRepo.SetPushToken(device, provider, req.Token); err != nil {
+		return nil, status.Error(codes.Internal, "failed to update push token")
+	}
+
+	return &devicepb.SetPushTokenResponse{
+		Device: s.deviceToProto(device),
+	}, nil
+}
+
+// SetDevicePruneExempt исключает устройство из отключения по неактивности или
+// возвращает его под действие политики. Устройство организации может настроить
+// и владелец или администратор организации.
+func (s *DeviceService) SetDevicePruneExempt(ctx context.Context, req *devicepb.SetDevicePruneExemptRequest) (*devicepb.SetDevicePruneExemptResponse, error) {
+	deviceID, err := uuid.Parse(req.DeviceId)
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, "invalid device_id")
+	}
+
+	userID, err := uuid.Parse(req.UserId)
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, "invalid user_id")
+	}
+
+	device, err := s.deviceRepo.GetByID(deviceID)
+	if err != nil {
+		return nil, status.Error(codes.Internal, "failed to get device")
+	}
+	if device == nil {
+		return nil, status.Error(codes.NotFound, "device not found")
+	}
+
+	if device.UserID != userID {
+		if device.OrganizationID == nil {
+			return nil, status.Error(codes.PermissionDenied, "device belongs to another user")
+		}
+
+		member, err := s.orgRepo.GetMember(*device.OrganizationID, userID)
+		if err != nil {
+			return nil, status.Error(codes.Internal, "failed to get organization member")
+		}
+		if member == nil || !member.Role.CanManage() {
+			return nil, status.Error(codes.PermissionDenied, "only organization owners and admins can manage other members' devices")
+		}
+	}
+
+	if err := s.deviceRepo.SetPruneExempt(device, req.Exempt); err != nil {
+		return nil, status.Error(codes.Internal, "failed to update device")
+	}
+
+	return &devicepb.SetDevicePruneExemptResponse{
+		Device: s.deviceToProto(device),
+	}, nil
+}
+
+func (s *DeviceService) GetInactivityPolicy(ctx context.Context, req *devicepb.GetInactivityPolicyRequest) (*devicepb.GetInactivityPolicyResponse, error) {
+	userID, err := uuid.Parse(req.UserId)
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, "invalid user_id")
+	}
+
+	days, err := s.userRepo.GetDeviceInactivityDays(userID)
+	if err == sql.ErrNoRows {
+		return nil, status.Error(codes.NotFound, "user not found")
+	}
+	if err != nil {
+		return nil, status.Error(codes.Internal, "failed to get inactivity policy")
+	}
+
+	return &devicepb.GetInactivityPolicyResponse{
+		Policy: s.inactivityPolicyToProto(days),
+	}, nil
+}
+
+func (s *DeviceService) UpdateInactivityPolicy(ctx context.Context, req *devicepb.UpdateInactivityPolicyRequest) (*devicepb.UpdateInactivityPolicyResponse, error) {
+	userID, err := uuid.Parse(req.UserId)
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, "invalid user_id")
+	}
+
+	var days *int
+	if !req.UseDefault {
+		if req.InactivityDays < 0 {
+			return nil, status.Error(codes.InvalidArgument, "inactivity_days must not be negative")
+		}
+
+		value := int(req.InactivityDays)
+		days = &value
+	}
+
+	err = s.userRepo.SetDeviceInactivityDays(userID, days)
+	if err == sql.ErrNoRows {
+		return nil, status.Error(codes.NotFound, "user not found")
+	}
+	if err != nil {
+		return nil, status.Error(codes.Internal, "failed to update inactivity policy")
+	}
+
+	return &devicepb.UpdateInactivityPolicyResponse{
+		Policy: s.inactivityPolicyToProto(days),
+	}, nil
+}
+
+// inactivityPolicyToProto подставляет порог сервера, если у пользователя нет своего
+func (s *DeviceService) inactivityPolicyToProto(days *int) *devicepb.InactivityPolicy {
+	policy := &devicepb.InactivityPolicy{
+		InactivityDays:       int32(s.deviceCfg.InactivityDays),
+		IsDefault:            days == nil,
+		WarningPeriodSeconds: int64(s.deviceCfg.InactivityWarningPeriod.Seconds()),
+	}
+
+	if days != nil {
+		policy.InactivityDays = int32(*days)
+	}
+
+	return policy
+}
+
+// RotateDeviceToken выпускает новый токен устройства. Старый токен принимается
+// еще DEVICE_TOKEN_GRACE_PERIOD, чтобы клиент успел переключиться.
+func (s *DeviceService) RotateDeviceToken(ctx context.Context, req *devicepb.RotateDeviceTokenRequest) (*devicepb.RotateDeviceTokenResponse, error) {
+	deviceID, err := uuid.Parse(req.DeviceId)
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, "invalid device_id")
+	}
+
+	userID, err := uuid.Parse(req.UserId)
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, "invalid user_id")
+	}
+
+	device, err := s.deviceRepo.GetByID(deviceID)
+	if err != nil {
+		return nil, status.Error(codes.Internal, "failed to get device")
+	}
+	if device == nil {
+		return nil, status.Error(codes.NotFound, "device not found")
+	}
+
+	if device.UserID != userID {
+		return nil, status.Error(codes.PermissionDenied, "device belongs to another user")
+	}
+
+	if !device.IsActive() {
+		return nil, status.Error(codes.FailedPrecondition, "device is deactivated")
+	}
+
+	deviceToken, err := devicetoken.Generate()
+	if err != nil {
+		return nil, status.Error(codes.Internal, "failed to generate device token")
+	}
+
+	previousExpiresAt := time.Now().Add(s.deviceCfg.TokenGracePeriod)
+	if err := s.deviceRepo.RotateToken(device, devicetoken.Hash(deviceToken), previousExpiresAt); err != nil {
+		if err == sql.ErrNoRows {
+			return nil, status.Error(codes.FailedPrecondition, "device is deactivated")
+		}
+		return nil, status.Error(codes.Internal, "failed to rotate device token")
+	}
+
+	return &devicepb.RotateDeviceTokenResponse{
+		DeviceToken:            deviceToken,
+		PreviousTokenExpiresAt: previousExpiresAt.Format(time.RFC3339),
+	}, nil
+}
+
+// generatePairingCode генерирует случайный цифровой код длиной 6-8 символов
+func generatePairingCode(length int) (string, error) {
+	if length < 6 {
+		length = 6
+	}
+	if length > 8 {
+		length = 8
+	}
+
+	max := new(big.Int).Exp(big.NewInt(10), big.NewInt(int64(length)), nil)
+	n, err := rand.Int(rand.Reader, max)
+	if err != nil {
+		return "", err
+	}
+
+	return fmt.Sprintf("%0*d", length, n), nil
+}
+
+// parseOrganizationID разбирает organization_id и проверяет членство пользователя.
+// Пустая строка означает, что устройство не привязано к организации.
+func (s *DeviceService) parseOrganizationID(value string, userID uuid.UUID) (*uuid.UUID, error) {
+	if value == "" {
+		return nil, nil
+	}
+
+	organizationID, err := uuid.Parse(value)
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, "invalid organization_id")
+	}
+
+	isMember, err := s.orgRepo.IsMember(organizationID, userID)
+	if err != nil {
+		return nil, status.Error(codes.Internal, "failed to check organization membership")
+	}
+	if !isMember {
+		return nil, status.Error(codes.PermissionDenied, "user is not a member of the organization")
+	}
+
+	return &organizationID, nil
+}
+
+func (s *DeviceService) isOrganizationMember(organizationID *uuid.UUID, userID uuid.UUID) bool {
+	if organizationID == nil {
+		return false
+	}
+
+	isMember, err := s.orgRepo.IsMember(*organizationID, userID)
+	return err == nil && isMember
+}
+
+func (s *DeviceService) deviceToProto(device *models.Device) *devicepb.Device {
+	var organizationID string
+	if device.OrganizationID != nil {
+		organizationID = device.OrganizationID.String()
+	}
+
+	protocols := make([]string, len(device.TransferProtocols))
+	for i, protocol := range device.TransferProtocols {
+		protocols[i] = string(protocol)
+	}
+
+	var maxFileSize int64
+	if device.MaxFileSize != nil {
+		maxFileSize = *device.MaxFileSize
+	}
+
+	var deactivatedAt string
+	if device.DeactivatedAt != nil {
+		deactivatedAt = device.DeactivatedAt.Format(time.RFC3339)
+	}
+
+	return &devicepb.Device{
+		Id:             device.ID.String(),
+		UserId:         device.UserID.String(),
+		OrganizationId: organizationID,
+		Name:           device.Name,
+		DeviceType:     string(device.DeviceType),
+		LastSeenAt:     device.LastSeenAt.Format(time.RFC3339),
+		CreatedAt:      device.CreatedAt.Format(time.RFC3339),
+		UpdatedAt:      device.UpdatedAt.Format(time.RFC3339),
+		ApprovalStatus: string(device.ApprovalStatus),
+		PushProvider:   string(device.PushProvider),
+		PruneExempt:    device.PruneExempt,
+		DeactivatedAt:  deactivatedAt,
+		Info: &devicepb.DeviceInfo{
+			Platform:          string(device.Platform),
+			OsVersion:         device.OSVersion,
+			AppVersion:        device.AppVersion,
+			TransferProtocols: protocols,
+			MaxFileSize:       maxFileSize,
+		},
+	}
+}
+
+// applyDeviceInfo переносит сведения о клиенте из запроса в устройство.
+// Клиенты, не сообщившие протоколы, считаются поддерживающими все.
+func applyDeviceInfo(device *models.Device, info *devicepb.DeviceInfo) {
+	device.TransferProtocols = models.DefaultTransferProtocols
+	if info == nil {
+		return
+	}
+
+	device.Platform = models.DevicePlatform(info.Platform)
+	device.OSVersion = info.OsVersion
+	device.AppVersion = info.AppVersion
+
+	if len(info.TransferProtocols) > 0 {
+		device.TransferProtocols = make([]models.TransferProtocol, len(info.TransferProtocols))
+		for i, protocol := range info.TransferProtocols {
+			device.TransferProtocols[i] = models.TransferProtocol(protocol)
+		}
+	}
+
+	if info.MaxFileSize != 0 {
+		maxFileSize := info.MaxFileSize
+		device.MaxFileSize = &maxFileSize
+	}
+}
+
+// applyPresence дополняет устройства текущим присутствием из signaling хаба.
+// Если Redis недоступен, поля присутствия остаются пустыми.
+func (s *DeviceService) applyPresence(ctx context.Context, pbDevices []*devicepb.Device, devices []*models.Device) {
+	deviceIDs := make([]uuid.UUID, len(devices))
+	for i, device := range devices {
+		deviceIDs[i] = device.ID
+	}
+
+	presences, err := s.presence.GetMany(ctx, deviceIDs)
+	if err != nil {
+		return
+	}
+
+	for i, device := range devices {
+		p, ok := presences[device.ID]
+		if !ok {
+			continue
+		}
+
+		pbDevices[i].PresenceStatus = string(p.Status)
+		if p.ConnectedSince != nil {
+			pbDevices[i].ConnectedSince = p.ConnectedSince.Format(time.RFC3339)
+		}
+	}
+}
+
+// IssueSignalingTicket выпускает короткоживущий билет для подключения к signaling
+// серверу, чтобы токен устройства не передавался при подключении
+func (s *DeviceService) IssueSignalingTicket(ctx context.Context, req *devicepb.IssueSignalingTicketRequest) (*devicepb.IssueSignalingTicketResponse, error) {
+	deviceID, err := uuid.Parse(req.DeviceId)
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, "invalid device_id")
+	}
+
+	if req.DeviceToken == "" {
+		return nil, status.Error(codes.InvalidArgument, "device_token is required")
+	}
+
+	device, err := s.deviceRepo.GetByTokenHash(devicetoken.Hash(req.DeviceToken))
+	if err != nil {
+		return nil, status.Error(codes.Internal, "failed to get device")
+	}
+	if device == nil || device.ID != deviceID {
+		return nil, status.Error(codes.Unauthenticated, "invalid device_token")
+	}
+
+	if !device.IsApproved() {
+		return nil, status.Error(codes.PermissionDenied, "device is not approved")
+	}
+
+	ticket, expiresAt, err := jwt.GenerateSignalingTicket(device.UserID, device.ID, s.jwtSecret, s.signalingCfg.TicketTTL)
+	if err != nil {
+		return nil, status.Error(codes.Internal, "failed to issue ticket")
+	}
+
+	return &devicepb.IssueSignalingTicketResponse{
+		Ticket:    ticket,
+		ExpiresAt: expiresAt.Format(time.RFC3339),
+	}, nil
+}
@@ -17,13 +17,17 @@ import (
 type FileService struct {
 	filepb.UnimplementedFileServiceServer
 	fileRepo  *repository.FileRepo
+	orgRepo   *repository.OrganizationRepo
+	inboxRepo *repository.InboxRepo
 	storage   *storage.LocalStorage
 	chunkSize int64
 }
 
-func NewFileService(fileRepo *repository.FileRepo, storage *storage.LocalStorage) *FileService {
+func NewFileService(fileRepo *repository.FileRepo, orgRepo *repository.OrganizationRepo, inboxRepo *repository.InboxRepo, storage *storage.LocalStorage) *FileService {
 	return &FileService{
 		fileRepo:  fileRepo,
+		orgRepo:   orgRepo,
+		inboxRepo: inboxRepo,
 		storage:   storage,
 		chunkSize: 64 * 1024,
 	}
@@ -65,12 +69,27 @@ func (s *FileService) UploadFile(stream filepb.FileService_UploadFileServer) err
 		return status.Error(codes.InvalidArgument, "file size mismatch")
 	}
 
+	var organizationID *uuid.UUID
+	if metadata.OrganizationId != "" {
+		orgID, err := uuid.Parse(metadata.OrganizationId)
+		if err != nil {
+			return status.Error(codes.InvalidArgument, "invalid organization_id")
+		}
+
+		if err := s.checkOrganizationQuota(orgID, userID, metadata.Size); err != nil {
+			return err
+		}
+
+		organizationID = &orgID
+	}
+
 	file := &models.File{
-		UserID:      userID,
-		Name:        metadata.Name,
-		Size:        metadata.Size,
-		MimeType:    metadata.MimeType,
-		StorageType: models.StorageTypeLocal,
+		UserID:         userID,
+		OrganizationID: organizationID,
+		Name:           metadata.Name,
+		Size:           metadata.Size,
+		MimeType:       metadata.MimeType,
+		StorageType:    models.StorageTypeLocal,
 	}
 
 	if err := file.Validate(); err != nil {
@@ -81,7 +100,12 @@ func (s *FileService) UploadFile(stream filepb.FileService_UploadFileServer) err
 		return status.Error(codes.Internal, "failed to create file record")
 	}
 
-	storagePath, err := s.storage.SaveFile(userID, file.ID, metadata.Name, chunks)
+	var storagePath string
+	if organizationID != nil {
+		storagePath, err = s.storage.SaveOrganizationFile(*organizationID, file.ID, metadata.Name, chunks)
+	} else {
+		storagePath, err = s.storage.SaveFile(userID, file.ID, metadata.Name, chunks)
+	}
 	if err != nil {
 		s.fileRepo.Delete(file.ID)
 		return status.Error(codes.Internal, "failed to save file: "+err.Error())
@@ -116,8 +140,10 @@ func (s *FileService) DownloadFile(req *filepb.DownloadFileRequest, stream filep
 	}
 
 	userID, err := uuid.Parse(req.UserId)
-	if err == nil && file.UserID != userID {
-		return status.Error(codes.PermissionDenied, "file belongs to another user")
+	if err == nil {
+		if err := s.checkFileAccess(file, userID); err != nil {
+			return err
+		}
 	}
 
 	if file.StoragePath == "" {
@@ -191,28 +217,14 @@ func (s *FileService) GetFileMetadata(ctx context.Context, req *filepb.GetFileMe
 	}
 
 	userID, err := uuid.Parse(req.UserId)
-	if err == nil && file.UserID != userID {
-		return nil, status.Error(codes.PermissionDenied, "file belongs to another user")
-	}
-
-	var expiresAt string
-	if file.ExpiresAt != nil {
-		expiresAt = file.ExpiresAt.Format(time.RFC3339)
+	if err == nil {
+		if err := s.checkFileAccess(file, userID); err != nil {
+			return nil, err
+		}
 	}
 
 	return &filepb.GetFileMetadataResponse{
-		File: &filepb.FileInfo{
-			Id:          file.ID.String(),
-			UserId:      file.UserID.String(),
-			Name:        file.Name,
-			Size:        file.Size,
-			MimeType:    file.MimeType,
-			StoragePath: file.StoragePath,
-			StorageType: string(file.StorageType),
-			ExpiresAt:   expiresAt,
-			CreatedAt:   file.CreatedAt.Format(time.RFC3339),
-			UpdatedAt:   file.UpdatedAt.Format(time.RFC3339),
-		},
+		File: s.fileToProto(file),
 	}, nil
 }
 
@@ -222,30 +234,28 @@ func (s *FileService) ListFiles(ctx context.Context, req *filepb.ListFilesReques
 		return nil, status.Error(codes.InvalidArgument, "invalid user_id")
 	}
 
-	files, err := s.fileRepo.GetByUserID(userID, int(req.Limit), int(req.Offset))
+	var files []*models.File
+	if req.OrganizationId != "" {
+		organizationID, err := uuid.Parse(req.OrganizationId)
+		if err != nil {
+			return nil, status.Error(codes.InvalidArgument, "invalid organization_id")
+		}
+
+		if _, err := s.getMembership(organizationID, userID); err != nil {
+			return nil, err
+		}
+
+		files, err = s.fileRepo.GetByOrganizationID(organizationID, int(req.Limit), int(req.Offset))
+	} else {
+		files, err = s.fileRepo.GetByUserID(userID, int(req.Limit), int(req.Offset))
+	}
 	if err != nil {
 		return nil, status.Error(codes.Internal, "failed to list files")
 	}
 
 	pbFiles := make([]*filepb.FileInfo, len(files))
 	for i, file := range files {
-		var expiresAt string
-		if file.ExpiresAt != nil {
-			expiresAt = file.ExpiresAt.Format(time.RFC3339)
-		}
-
-		pbFiles[i] = &filepb.FileInfo{
-			Id:          file.ID.String(),
-			UserId:      file.UserID.String(),
-			Name:        file.Name,
-			Size:        file.Size,
-			MimeType:    file.MimeType,
-			StoragePath: file.StoragePath,
-			StorageType: string(file.StorageType),
-			ExpiresAt:   expiresAt,
-			CreatedAt:   file.CreatedAt.Format(time.RFC3339),
-			UpdatedAt:   file.UpdatedAt.Format(time.RFC3339),
-		}
+		pbFiles[i] = s.fileToProto(file)
 	}
 
 	return &filepb.ListFilesResponse{
@@ -274,7 +284,19 @@ func (s *FileService) DeleteFile(ctx context.Context, req *filepb.DeleteFileRequ
 	}
 
 	if file.UserID != userID {
-		return nil, status.Error(codes.PermissionDenied, "file belongs to another user")
+		// Чужой файл организации могут удалить только владелец или администратор
+		if file.OrganizationID == nil {
+			return nil, status.Error(codes.PermissionDenied, "file belongs to another user")
+		}
+
+		member, err := s.getMembership(*file.OrganizationID, userID)
+		if err != nil {
+			return nil, err
+		}
+
+		if !member.Role.CanManage() {
+			return nil, status.Error(codes.PermissionDenied, "only organization owners and admins can delete other members' files")
+		}
 	}
 
 	if file.StoragePath != "" {
@@ -290,3 +312,90 @@ func (s *FileService) DeleteFile(ctx context.Context, req *filepb.DeleteFileRequ
 		Success: true,
 	}, nil
 }
+
+// checkFileAccess проверяет, что пользователь - владелец файла, участник организации,
+// которой принадлежит файл, или получатель облачной передачи файла
+func (s *FileService) checkFileAccess(file *models.File, userID uuid.UUID) error {
+	if file.UserID == userID {
+		return nil
+	}
+
+	// Файл ждет устройство пользователя во входящих
+	inInbox, err := s.inboxRepo.HasPendingForUser(file.ID, userID)
+	if err != nil {
+		return status.Error(codes.Internal, "failed to check inbox")
+	}
+	if inInbox {
+		return nil
+	}
+
+	if file.OrganizationID == nil {
+		return status.Error(codes.PermissionDenied, "file belongs to another user")
+	}
+
+	_, err = s.getMembership(*file.OrganizationID, userID)
+	return err
+}
+
+func (s *FileService) getMembership(organizationID, userID uuid.UUID) (*models.OrganizationMember, error) {
+	member, err := s.orgRepo.GetMember(organizationID, userID)
+	if err != nil {
+		return nil, status.Error(codes.Internal, "failed to get organization member")
+	}
+	if member == nil {
+		return nil, status.Error(codes.PermissionDenied, "user is not a member of the organization")
+	}
+
+	return member, nil
+}
+
+func (s *FileService) checkOrganizationQuota(organizationID, userID uuid.UUID, size int64) error {
+	org, err := s.orgRepo.GetByID(organizationID)
+	if err != nil {
+		return status.Error(codes.Internal, "failed to get organization")
+	}
+	if org == nil {
+		return status.Error(codes.NotFound, "organization not found")
+	}
+
+	if _, err := s.getMembership(organizationID, userID); err != nil {
+		return err
+	}
+
+	used, err := s.orgRepo.GetStorageUsage(organizationID)
+	if err != nil {
+		return status.Error(codes.Internal, "failed to get organization storage usage")
+	}
+
+	if !org.HasQuotaFor(used, size) {
+		return status.Error(codes.ResourceExhausted, "organization storage quota exceeded")
+	}
+
+	return nil
+}
+
+func (s *FileService) fileToProto(file *models.File) *filepb.FileInfo {
+	var expiresAt string
+	if file.ExpiresAt != nil {
+		expiresAt = file.ExpiresAt.Format(time.RFC3339)
+	}
+
+	var organizationID string
+	if file.OrganizationID != nil {
+		organizationID = file.OrganizationID.String()
+	}
+
+	return &filepb.FileInfo{
+		Id:             file.ID.String(),
+		UserId:         file.UserID.String(),
+		OrganizationId: organizationID,
+		Name:           file.Name,
+		Size:           file.Size,
+		MimeType:       file.MimeType,
+		StoragePath:    file.StoragePath,
+		StorageType:    string(file.StorageType),
+		ExpiresAt:      expiresAt,
+		CreatedAt:      file.CreatedAt.Format(time.RFC3339),
+		UpdatedAt:      file.UpdatedAt.Format(time.RFC3339),
+	}
+}
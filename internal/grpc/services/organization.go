@@ -0,0 +1,419 @@
+package services
+
+import (
+	"context"
+	"time"
+
+	"github.com/backend-app/backend/internal/models"
+	"github.com/backend-app/backend/internal/repository"
+	"github.com/backend-app/backend/internal/storage"
+	organizationpb "github.com/backend-app/backend/pkg/proto/organization"
+	"github.com/google/uuid"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+type OrganizationService struct {
+	organizationpb.UnimplementedOrganizationServiceServer
+	orgRepo  *repository.OrganizationRepo
+	userRepo *repository.UserRepo
+	fileRepo *repository.FileRepo
+	storage  *storage.LocalStorage
+}
+
+func NewOrganizationService(orgRepo *repository.OrganizationRepo, userRepo *repository.UserRepo, fileRepo *repository.FileRepo, storage *storage.LocalStorage) *OrganizationService {
+	return &OrganizationService{
+		orgRepo:  orgRepo,
+		userRepo: userRepo,
+		fileRepo: fileRepo,
+		storage:  storage,
+	}
+}
+
+func (s *OrganizationService) CreateOrganization(ctx context.Context, req *organizationpb.CreateOrganizationRequest) (*organizationpb.CreateOrganizationResponse, error) {
+	userID, err := uuid.Parse(req.UserId)
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, "invalid user_id")
+	}
+
+	org := &models.Organization{
+		Name:              req.Name,
+		StorageQuotaBytes: quotaFromProto(req.StorageQuotaBytes),
+	}
+
+	if err := org.Validate(); err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+
+	if err := s.orgRepo.Create(org, userID); err != nil {
+		return nil, status.Error(codes.Internal, "failed to create organization")
+	}
+
+	return &organizationpb.CreateOrganizationResponse{
+		Organization: s.organizationToProto(org, models.OrganizationRoleOwner, 0),
+	}, nil
+}
+
+func (s *OrganizationService) GetOrganization(ctx context.Context, req *organizationpb.GetOrganizationRequest) (*organizationpb.GetOrganizationResponse, error) {
+	org, member, err := s.getOrganizationForMember(req.OrganizationId, req.UserId)
+	if err != nil {
+		return nil, err
+	}
+
+	used, err := s.orgRepo.GetStorageUsage(org.ID)
+	if err != nil {
+		return nil, status.Error(codes.Internal, "failed to get organization storage usage")
+	}
+
+	return &organizationpb.GetOrganizationResponse{
+		Organization: s.organizationToProto(org, member.Role, used),
+	}, nil
+}
+
+func (s *OrganizationService) ListOrganizations(ctx context.Context, req *organizationpb.ListOrganizationsRequest) (*organizationpb.ListOrganizationsResponse, error) {
+	userID, err := uuid.Parse(req.UserId)
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, "invalid user_id")
+	}
+
+	orgs, err := s.orgRepo.GetByUserID(userID)
+	if err != nil {
+		return nil, status.Error(codes.Internal, "failed to list organizations")
+	}
+
+	pbOrgs := make([]*organizationpb.Organization, 0, len(orgs))
+	for _, org := range orgs {
+		member, err := s.orgRepo.GetMember(org.ID, userID)
+		if err != nil || member == nil {
+			return nil, status.Error(codes.Internal, "failed to get organization member")
+		}
+
+		used, err := s.orgRepo.GetStorageUsage(org.ID)
+		if err != nil {
+			return nil, status.Error(codes.Internal, "failed to get organization storage usage")
+		}
+
+		pbOrgs = append(pbOrgs, s.organizationToProto(org, member.Role, used))
+	}
+
+	return &organizationpb.ListOrganizationsResponse{
+		Organizations: pbOrgs,
+	}, nil
+}
+
+func (s *OrganizationService) UpdateOrganization(ctx context.Context, req *organizationpb.UpdateOrganizationRequest) (*organizationpb.UpdateOrganizationResponse, error) {
+	org, member, err := s.getOrganizationForMember(req.OrganizationId, req.UserId)
+	if err != nil {
+		return nil, err
+	}
+
+	if !member.Role.CanManage() {
+		return nil, status.Error(codes.PermissionDenied, "only organization owners and admins can update the organization")
+	}
+
+	if req.Name != "" {
+		org.Name = req.Name
+	}
+	if req.StorageQuotaBytes != nil {
+		org.StorageQuotaBytes = quotaFromProto(*req.StorageQuotaBytes)
+	}
+
+	if err := org.Validate(); err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+
+	if err := s.orgRepo.Update(org); err != nil {
+		return nil, status.Error(codes.Internal, "failed to update organization")
+	}
+
+	used, err := s.orgRepo.GetStorageUsage(org.ID)
+	if err != nil {
+		return nil, status.Error(codes.Internal, "failed to get organization storage usage")
+	}
+
+	return &organizationpb.UpdateOrganizationResponse{
+		Organization: s.organizationToProto(org, member.Role, used),
+	}, nil
+}
+
+func (s *OrganizationService) DeleteOrganization(ctx context.Context, req *organizationpb.DeleteOrganizationRequest) (*organizationpb.DeleteOrganizationResponse, error) {
+	org, member, err := s.getOrganizationForMember(req.OrganizationId, req.UserId)
+	if err != nil {
+		return nil, err
+	}
+
+	if member.Role != models.OrganizationRoleOwner {
+		return nil, status.Error(codes.PermissionDenied, "only organization owners can delete the organization")
+	}
+
+	files, err := s.fileRepo.GetAllByOrganizationID(org.ID)
+	if err != nil {
+		return nil, status.Error(codes.Internal, "failed to list organization files")
+	}
+
+	// Записи файлов удаляются каскадно, устройства отвязываются от организации
+	if err := s.orgRepo.Delete(org.ID); err != nil {
+		return nil, status.Error(codes.Internal, "failed to delete organization")
+	}
+
+	for _, file := range files {
+		if file.StoragePath != "" {
+			s.storage.DeleteFile(file.StoragePath)
+		}
+	}
+	s.storage.DeleteOrganizationData(org.ID)
+
+	return &organizationpb.DeleteOrganizationResponse{
+		Success: true,
+	}, nil
+}
+
+func (s *OrganizationService) AddMember(ctx context.Context, req *organizationpb.AddMemberRequest) (*organizationpb.AddMemberResponse, error) {
+	org, member, err := s.getOrganizationForMember(req.OrganizationId, req.UserId)
+	if err != nil {
+		return nil, err
+	}
+
+	if !member.Role.CanManage() {
+		return nil, status.Error(codes.PermissionDenied, "only organization owners and admins can add members")
+	}
+
+	role := models.OrganizationRole(req.Role)
+	if role == "" {
+		role = models.OrganizationRoleMember
+	}
+
+	if role == models.OrganizationRoleOwner && member.Role != models.OrganizationRoleOwner {
+		return nil, status.Error(codes.PermissionDenied, "only organization owners can add owners")
+	}
+
+	user, err := s.userRepo.GetByEmail(req.Email)
+	if err != nil {
+		return nil, status.Error(codes.Internal, "failed to get user")
+	}
+	if user == nil {
+		return nil, status.Error(codes.NotFound, "user not found")
+	}
+
+	existing, err := s.orgRepo.GetMember(org.ID, user.ID)
+	if err != nil {
+		return nil, status.Error(codes.Internal, "failed to get organization member")
+	}
+	if existing != nil {
+		return nil, status.Error(codes.AlreadyExists, "user is already a member of the organization")
+	}
+
+	newMember := &models.OrganizationMember{
+		OrganizationID: org.ID,
+		UserID:         user.ID,
+		Email:          user.Email,
+		Role:           role,
+	}
+
+	if err := newMember.Validate(); err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+
+	if err := s.orgRepo.AddMember(newMember); err != nil {
+		return nil, status.Error(codes.Internal, "failed to add organization member")
+	}
+
+	return &organizationpb.AddMemberResponse{
+		Member: s.memberToProto(newMember),
+	}, nil
+}
+
+func (s *OrganizationService) ListMembers(ctx context.Context, req *organizationpb.ListMembersRequest) (*organizationpb.ListMembersResponse, error) {
+	org, _, err := s.getOrganizationForMember(req.OrganizationId, req.UserId)
+	if err != nil {
+		return nil, err
+	}
+
+	members, err := s.orgRepo.ListMembers(org.ID)
+	if err != nil {
+		return nil, status.Error(codes.Internal, "failed to list organization members")
+	}
+
+	pbMembers := make([]*organizationpb.Member, len(members))
+	for i, member := range members {
+		pbMembers[i] = s.memberToProto(member)
+	}
+
+	return &organizationpb.ListMembersResponse{
+		Members: pbMembers,
+	}, nil
+}
+
+func (s *OrganizationService) UpdateMemberRole(ctx context.Context, req *organizationpb.UpdateMemberRoleRequest) (*organizationpb.UpdateMemberRoleResponse, error) {
+	org, member, err := s.getOrganizationForMember(req.OrganizationId, req.UserId)
+	if err != nil {
+		return nil, err
+	}
+
+	target, err := s.getTargetMember(org.ID, req.MemberUserId)
+	if err != nil {
+		return nil, err
+	}
+
+	role := models.OrganizationRole(req.Role)
+	if !role.IsValid() {
+		return nil, status.Error(codes.InvalidArgument, "invalid organization role")
+	}
+
+	// Назначать и снимать владельцев может только владелец, администратор управляет остальными
+	if member.Role != models.OrganizationRoleOwner {
+		if !member.Role.CanManage() || role == models.OrganizationRoleOwner || target.Role == models.OrganizationRoleOwner {
+			return nil, status.Error(codes.PermissionDenied, "insufficient organization role")
+		}
+	}
+
+	if target.Role == models.OrganizationRoleOwner && role != models.OrganizationRoleOwner {
+		if err := s.ensureAnotherOwner(org.ID); err != nil {
+			return nil, err
+		}
+	}
+
+	if err := s.orgRepo.UpdateMemberRole(org.ID, target.UserID, role); err != nil {
+		return nil, status.Error(codes.Internal, "failed to update organization member")
+	}
+
+	target.Role = role
+	target.UpdatedAt = time.Now()
+
+	return &organizationpb.UpdateMemberRoleResponse{
+		Member: s.memberToProto(target),
+	}, nil
+}
+
+func (s *OrganizationService) RemoveMember(ctx context.Context, req *organizationpb.RemoveMemberRequest) (*organizationpb.RemoveMemberResponse, error) {
+	org, member, err := s.getOrganizationForMember(req.OrganizationId, req.UserId)
+	if err != nil {
+		return nil, err
+	}
+
+	target, err := s.getTargetMember(org.ID, req.MemberUserId)
+	if err != nil {
+		return nil, err
+	}
+
+	// Любой участник может выйти из организации сам
+	if target.UserID != member.UserID {
+		if !member.Role.CanManage() {
+			return nil, status.Error(codes.PermissionDenied, "only organization owners and admins can remove members")
+		}
+		if target.Role == models.OrganizationRoleOwner && member.Role != models.OrganizationRoleOwner {
+			return nil, status.Error(codes.PermissionDenied, "only organization owners can remove owners")
+		}
+	}
+
+	if target.Role == models.OrganizationRoleOwner {
+		if err := s.ensureAnotherOwner(org.ID); err != nil {
+			return nil, err
+		}
+	}
+
+	if err := s.orgRepo.RemoveMember(org.ID, target.UserID); err != nil {
+		return nil, status.Error(codes.Internal, "failed to remove organization member")
+	}
+
+	return &organizationpb.RemoveMemberResponse{
+		Success: true,
+	}, nil
+}
+
+func (s *OrganizationService) getOrganizationForMember(organizationIDStr, userIDStr string) (*models.Organization, *models.OrganizationMember, error) {
+	organizationID, err := uuid.Parse(organizationIDStr)
+	if err != nil {
+		return nil, nil, status.Error(codes.InvalidArgument, "invalid organization_id")
+	}
+
+	userID, err := uuid.Parse(userIDStr)
+	if err != nil {
+		return nil, nil, status.Error(codes.InvalidArgument, "invalid user_id")
+	}
+
+	org, err := s.orgRepo.GetByID(organizationID)
+	if err != nil {
+		return nil, nil, status.Error(codes.Internal, "failed to get organization")
+	}
+	if org == nil {
+		return nil, nil, status.Error(codes.NotFound, "organization not found")
+	}
+
+	member, err := s.orgRepo.GetMember(organizationID, userID)
+	if err != nil {
+		return nil, nil, status.Error(codes.Internal, "failed to get organization member")
+	}
+	if member == nil {
+		return nil, nil, status.Error(codes.PermissionDenied, "user is not a member of the organization")
+	}
+
+	return org, member, nil
+}
+
+func (s *OrganizationService) getTargetMember(organizationID uuid.UUID, memberUserIDStr string) (*models.OrganizationMember, error) {
+	memberUserID, err := uuid.Parse(memberUserIDStr)
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, "invalid member_user_id")
+	}
+
+	target, err := s.orgRepo.GetMember(organizationID, memberUserID)
+	if err != nil {
+		return nil, status.Error(codes.Internal, "failed to get organization member")
+	}
+	if target == nil {
+		return nil, status.Error(codes.NotFound, "organization member not found")
+	}
+
+	return target, nil
+}
+
+// ensureAnotherOwner не позволяет оставить организацию без владельца
+func (s *OrganizationService) ensureAnotherOwner(organizationID uuid.UUID) error {
+	owners, err := s.orgRepo.CountOwners(organizationID)
+	if err != nil {
+		return status.Error(codes.Internal, "failed to count organization owners")
+	}
+
+	if owners <= 1 {
+		return status.Error(codes.FailedPrecondition, "organization must have at least one owner")
+	}
+
+	return nil
+}
+
+// quotaFromProto преобразует квоту из proto: 0 означает отсутствие ограничения
+func quotaFromProto(quota int64) *int64 {
+	if quota == 0 {
+		return nil
+	}
+	return &quota
+}
+
+func (s *OrganizationService) organizationToProto(org *models.Organization, role models.OrganizationRole, used int64) *organizationpb.Organization {
+	var quota int64
+	if org.StorageQuotaBytes != nil {
+		quota = *org.StorageQuotaBytes
+	}
+
+	return &organizationpb.Organization{
+		Id:                org.ID.String(),
+		Name:              org.Name,
+		StorageQuotaBytes: quota,
+		StorageUsedBytes:  used,
+		Role:              string(role),
+		CreatedAt:         org.CreatedAt.Format(time.RFC3339),
+		UpdatedAt:         org.UpdatedAt.Format(time.RFC3339),
+	}
+}
+
+func (s *OrganizationService) memberToProto(member *models.OrganizationMember) *organizationpb.Member {
+	return &organizationpb.Member{
+		OrganizationId: member.OrganizationID.String(),
+		UserId:         member.UserID.String(),
+		Email:          member.Email,
+		Role:           string(member.Role),
+		CreatedAt:      member.CreatedAt.Format(time.RFC3339),
+		UpdatedAt:      member.UpdatedAt.Format(time.RFC3339),
+	}
+}
@@ -0,0 +1,23 @@
+package services
+
+import (
+	"github.com/backend-app/backend/internal/websocket"
+	signalingpb "github.com/backend-app/backend/pkg/proto/signaling"
+)
+
+// SignalingService - signaling в потоке gRPC. Сессии регистрируются в том же хабе,
+// что и соединения WebSocket сервера.
+type SignalingService struct {
+	signalingpb.UnimplementedSignalingServiceServer
+	hub *websocket.Hub
+}
+
+func NewSignalingService(hub *websocket.Hub) *SignalingService {
+	return &SignalingService{
+		hub: hub,
+	}
+}
+
+func (s *SignalingService) Connect(stream signalingpb.SignalingService_ConnectServer) error {
+	return s.hub.ServeStream(stream)
+}
@@ -2,28 +2,75 @@ package services
 
 import (
 	"context"
+	"database/sql"
+	"errors"
 	"time"
 
+	"github.com/backend-app/backend/internal/events"
 	"github.com/backend-app/backend/internal/models"
+	"github.com/backend-app/backend/internal/presence"
+	"github.com/backend-app/backend/internal/progress"
+	"github.com/backend-app/backend/internal/push"
 	"github.com/backend-app/backend/internal/repository"
+	"github.com/backend-app/backend/pkg/config"
 	transferpb "github.com/backend-app/backend/pkg/proto/transfer"
 	"github.com/google/uuid"
 	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/status"
 )
 
+// maxGroupRecipients - сколько устройств можно указать в одной группе передач
+const maxGroupRecipients = 50
+
 type TransferService struct {
 	transferpb.UnimplementedTransferServiceServer
 	transferRepo *repository.TransferRepo
+	deviceRepo   *repository.DeviceRepo
+	fileRepo     *repository.FileRepo
+	orgRepo      *repository.OrganizationRepo
+	inboxRepo    *repository.InboxRepo
+	transferCfg  *config.TransferConfig
+	presence     *presence.Store
+	events       *events.Bus
+	push         *push.Service
+	progress     *progress.Broker
 }
 
-func NewTransferService(transferRepo *repository.TransferRepo) *TransferService {
+func NewTransferService(
+	transferRepo *repository.TransferRepo,
+	deviceRepo *repository.DeviceRepo,
+	fileRepo *repository.FileRepo,
+	orgRepo *repository.OrganizationRepo,
+	inboxRepo *repository.InboxRepo,
+	transferCfg *config.TransferConfig,
+	presenceStore *presence.Store,
+	eventBus *events.Bus,
+	pushService *push.Service,
+	progressBroker *progress.Broker,
+) *TransferService {
 	return &TransferService{
 		transferRepo: transferRepo,
+		deviceRepo:   deviceRepo,
+		fileRepo:     fileRepo,
+		orgRepo:      orgRepo,
+		inboxRepo:    inboxRepo,
+		transferCfg:  transferCfg,
+		presence:     presenceStore,
+		events:       eventBus,
+		push:         pushService,
+		progress:     progressBroker,
 	}
 }
 
+// CreateTransfer создает передачу от имени пользователя: файл должен быть ему
+// доступен, устройство отправителя - принадлежать ему, а получатель - ему или
+// его организации
 func (s *TransferService) CreateTransfer(ctx context.Context, req *transferpb.CreateTransferRequest) (*transferpb.CreateTransferResponse, error) {
+	userID, err := uuid.Parse(req.UserId)
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, "invalid user_id")
+	}
+
 	fileID, err := uuid.Parse(req.FileId)
 	if err != nil {
 		return nil, status.Error(codes.InvalidArgument, "invalid file_id")
@@ -37,6 +84,7 @@ func (s *TransferService) CreateTransfer(ctx context.Context, req *transferpb.Cr
 	transfer := &models.Transfer{
 		FileID:       fileID,
 		TransferType: transferType,
+		DeliveryPath: models.DefaultDeliveryPath(transferType),
 		Status:       models.TransferStatusPending,
 		Progress:     0,
 	}
@@ -61,27 +109,202 @@ func (s *TransferService) CreateTransfer(ctx context.Context, req *transferpb.Cr
 		return nil, status.Error(codes.InvalidArgument, err.Error())
 	}
 
+	file, err := s.fileRepo.GetByID(fileID)
+	if err != nil {
+		return nil, status.Error(codes.Internal, "failed to get file")
+	}
+	if file == nil {
+		return nil, status.Error(codes.NotFound, "file not found")
+	}
+
+	if file.UserID != userID && !s.isOrganizationMember(file.OrganizationID, userID) {
+		return nil, status.Error(codes.PermissionDenied, "file belongs to another user")
+	}
+
+	toDevice, err := s.checkDevices(transfer, file, userID)
+	if err != nil {
+		return nil, err
+	}
+
 	if err := s.transferRepo.Create(transfer); err != nil {
 		return nil, status.Error(codes.Internal, "failed to create transfer")
 	}
 
+	if toDevice != nil {
+		s.notifyRecipient(ctx, transfer, toDevice)
+	}
+
+	if transfer.IsStoreAndForward() {
+		s.publishInbox(ctx, toDevice)
+	}
+
 	return &transferpb.CreateTransferResponse{
 		Transfer: s.transferToProto(transfer),
 	}, nil
 }
 
-func (s *TransferService) GetTransfer(ctx context.Context, req *transferpb.GetTransferRequest) (*transferpb.GetTransferResponse, error) {
-	transferID, err := uuid.Parse(req.TransferId)
+// CreateTransferGroup отправляет файл нескольким устройствам: для каждого получателя
+// создается своя передача с общим group_id. При all_devices файл отправляется на все
+// устройства пользователя, кроме отправителя; устройства, которые не могут его
+// принять, пропускаются.
+func (s *TransferService) CreateTransferGroup(ctx context.Context, req *transferpb.CreateTransferGroupRequest) (*transferpb.CreateTransferGroupResponse, error) {
+	userID, err := uuid.Parse(req.UserId)
 	if err != nil {
-		return nil, status.Error(codes.InvalidArgument, "invalid transfer_id")
+		return nil, status.Error(codes.InvalidArgument, "invalid user_id")
 	}
 
-	transfer, err := s.transferRepo.GetByID(transferID)
+	fileID, err := uuid.Parse(req.FileId)
 	if err != nil {
-		return nil, status.Error(codes.Internal, "failed to get transfer")
+		return nil, status.Error(codes.InvalidArgument, "invalid file_id")
 	}
-	if transfer == nil {
-		return nil, status.Error(codes.NotFound, "transfer not found")
+
+	transferType := models.TransferType(req.TransferType)
+	if transferType != models.TransferTypeP2P && transferType != models.TransferTypeCloud {
+		return nil, status.Error(codes.InvalidArgument, "invalid transfer_type")
+	}
+
+	group := &models.TransferGroup{
+		UserID:       userID,
+		FileID:       fileID,
+		TransferType: transferType,
+	}
+
+	if req.FromDeviceId != "" {
+		fromDeviceID, err := uuid.Parse(req.FromDeviceId)
+		if err != nil {
+			return nil, status.Error(codes.InvalidArgument, "invalid from_device_id")
+		}
+		group.FromDeviceID = &fromDeviceID
+	}
+
+	var toDeviceIDs []uuid.UUID
+	if !req.AllDevices {
+		if len(req.ToDeviceIds) == 0 {
+			return nil, status.Error(codes.InvalidArgument, "to_device_ids or all_devices is required")
+		}
+		if len(req.ToDeviceIds) > maxGroupRecipients {
+			return nil, status.Errorf(codes.InvalidArgument, "at most %d recipients are allowed", maxGroupRecipients)
+		}
+
+		seen := make(map[uuid.UUID]bool, len(req.ToDeviceIds))
+		for _, id := range req.ToDeviceIds {
+			toDeviceID, err := uuid.Parse(id)
+			if err != nil {
+				return nil, status.Error(codes.InvalidArgument, "invalid to_device_ids")
+			}
+			if !seen[toDeviceID] {
+				seen[toDeviceID] = true
+				toDeviceIDs = append(toDeviceIDs, toDeviceID)
+			}
+		}
+	}
+
+	file, err := s.fileRepo.GetByID(fileID)
+	if err != nil {
+		return nil, status.Error(codes.Internal, "failed to get file")
+	}
+	if file == nil {
+		return nil, status.Error(codes.NotFound, "file not found")
+	}
+
+	if file.UserID != userID && !s.isOrganizationMember(file.OrganizationID, userID) {
+		return nil, status.Error(codes.PermissionDenied, "file belongs to another user")
+	}
+
+	// Сначала проверяем отправителя, чтобы его ошибка не выглядела как отсутствие получателей
+	if _, err := s.checkDevices(&models.Transfer{FromDeviceID: group.FromDeviceID, TransferType: transferType}, file, userID); err != nil {
+		return nil, err
+	}
+
+	if req.AllDevices {
+		devices, err := s.deviceRepo.GetByUserID(userID)
+		if err != nil {
+			return nil, status.Error(codes.Internal, "failed to get devices")
+		}
+
+		for _, device := range devices {
+			if group.FromDeviceID != nil && device.ID == *group.FromDeviceID {
+				continue
+			}
+			toDeviceIDs = append(toDeviceIDs, device.ID)
+		}
+	}
+
+	var recipients []*models.Device
+	for _, toDeviceID := range toDeviceIDs {
+		transfer := &models.Transfer{
+			FileID:       fileID,
+			FromDeviceID: group.FromDeviceID,
+			ToDeviceID:   &toDeviceID,
+			TransferType: transferType,
+			DeliveryPath: models.DefaultDeliveryPath(transferType),
+			Status:       models.TransferStatusPending,
+		}
+
+		toDevice, err := s.checkDevices(transfer, file, userID)
+		if err != nil {
+			if req.AllDevices && status.Code(err) == codes.FailedPrecondition {
+				continue
+			}
+			return nil, err
+		}
+
+		group.Transfers = append(group.Transfers, transfer)
+		recipients = append(recipients, toDevice)
+	}
+
+	if len(group.Transfers) == 0 {
+		return nil, status.Error(codes.FailedPrecondition, "no devices can receive the file")
+	}
+
+	if err := s.transferRepo.CreateGroup(group); err != nil {
+		return nil, status.Error(codes.Internal, "failed to create transfer group")
+	}
+
+	for i, transfer := range group.Transfers {
+		s.notifyRecipient(ctx, transfer, recipients[i])
+		if transfer.IsStoreAndForward() {
+			s.publishInbox(ctx, recipients[i])
+		}
+	}
+
+	return &transferpb.CreateTransferGroupResponse{
+		Group: s.groupToProto(group),
+	}, nil
+}
+
+// GetTransferGroup возвращает передачи группы и ее сводное состояние отправителю
+func (s *TransferService) GetTransferGroup(ctx context.Context, req *transferpb.GetTransferGroupRequest) (*transferpb.GetTransferGroupResponse, error) {
+	groupID, err := uuid.Parse(req.GroupId)
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, "invalid group_id")
+	}
+
+	userID, err := uuid.Parse(req.UserId)
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, "invalid user_id")
+	}
+
+	group, err := s.transferRepo.GetGroup(groupID)
+	if err != nil {
+		return nil, status.Error(codes.Internal, "failed to get transfer group")
+	}
+	if group == nil {
+		return nil, status.Error(codes.NotFound, "transfer group not found")
+	}
+	if group.UserID != userID {
+		return nil, status.Error(codes.PermissionDenied, "transfer group belongs to another user")
+	}
+
+	return &transferpb.GetTransferGroupResponse{
+		Group: s.groupToProto(group),
+	}, nil
+}
+
+func (s *TransferService) GetTransfer(ctx context.Context, req *transferpb.GetTransferRequest) (*transferpb.GetTransferResponse, error) {
+	transfer, err := s.getParticipantTransfer(req.TransferId, req.UserId)
+	if err != nil {
+		return nil, err
 	}
 
 	return &transferpb.GetTransferResponse{
@@ -90,26 +313,66 @@ func (s *TransferService) GetTransfer(ctx context.Context, req *transferpb.GetTr
 }
 
 func (s *TransferService) UpdateTransferStatus(ctx context.Context, req *transferpb.UpdateTransferStatusRequest) (*transferpb.UpdateTransferStatusResponse, error) {
-	transferID, err := uuid.Parse(req.TransferId)
+	transferStatus := models.TransferStatus(req.Status)
+	if !transferStatus.IsValid() {
+		return nil, status.Error(codes.InvalidArgument, "invalid status")
+	}
+
+	if req.Progress < 0 || req.Progress > 100 {
+		return nil, status.Error(codes.InvalidArgument, "progress must be between 0 and 100")
+	}
+	if req.BytesTransferred < 0 {
+		return nil, status.Error(codes.InvalidArgument, "bytes_transferred cannot be negative")
+	}
+
+	transfer, err := s.getParticipantTransfer(req.TransferId, req.UserId)
 	if err != nil {
-		return nil, status.Error(codes.InvalidArgument, "invalid transfer_id")
+		return nil, err
 	}
 
-	transferStatus := models.TransferStatus(req.Status)
-	if transferStatus != models.TransferStatusPending &&
-		transferStatus != models.TransferStatusInProgress &&
-		transferStatus != models.TransferStatusCompleted &&
-		transferStatus != models.TransferStatusFailed {
-		return nil, status.Error(codes.InvalidArgument, "invalid status")
+	totalSize, err := s.fileSize(transfer.FileID)
+	if err != nil {
+		return nil, err
 	}
 
-	if err := s.transferRepo.UpdateStatus(transferID, transferStatus, req.Progress); err != nil {
-		return nil, status.Error(codes.Internal, "failed to update transfer status")
+	previousStatus := transfer.Status
+	previousBytes := transfer.BytesTransferred
+	previousAt := transfer.UpdatedAt
+
+	transfer.Progress = req.Progress
+	if req.BytesTransferred > 0 {
+		if totalSize > 0 && req.BytesTransferred > totalSize {
+			return nil, status.Error(codes.InvalidArgument, "bytes_transferred exceeds file size")
+		}
+
+		transfer.BytesTransferred = req.BytesTransferred
+		if totalSize > 0 {
+			transfer.Progress = req.BytesTransferred * 100 / totalSize
+		}
 	}
 
-	transfer, err := s.transferRepo.GetByID(transferID)
+	// Повтор текущего статуса незавершенной передачи обновляет только прогресс
+	if transferStatus == previousStatus && !previousStatus.IsTerminal() {
+		transfer.UpdatedAt = time.Now()
+		err = s.transferRepo.UpdateProgress(transfer)
+	} else {
+		if err := transfer.TransitionTo(transferStatus, req.FailureReason); err != nil {
+			return nil, status.Error(codes.FailedPrecondition, err.Error())
+		}
+		err = s.transferRepo.Transition(transfer, previousStatus)
+	}
+
+	if err == sql.ErrNoRows {
+		return nil, status.Error(codes.Aborted, "transfer status changed concurrently")
+	}
 	if err != nil {
-		return nil, status.Error(codes.Internal, "failed to get transfer")
+		return nil, status.Error(codes.Internal, "failed to update transfer status")
+	}
+
+	s.progress.Publish(ctx, progress.NewUpdate(transfer, totalSize, previousBytes, previousAt))
+
+	if transfer.IsStoreAndForward() && transfer.Status.IsTerminal() {
+		s.releaseDeliveredFile(transfer.FileID)
 	}
 
 	return &transferpb.UpdateTransferStatusResponse{
@@ -117,23 +380,60 @@ func (s *TransferService) UpdateTransferStatus(ctx context.Context, req *transfe
 	}, nil
 }
 
+// ListTransfers возвращает только передачи, в которых участвуют файлы или устройства пользователя
 func (s *TransferService) ListTransfers(ctx context.Context, req *transferpb.ListTransfersRequest) (*transferpb.ListTransfersResponse, error) {
-	var transfers []*models.Transfer
-	var err error
+	userID, err := uuid.Parse(req.UserId)
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, "invalid user_id")
+	}
+
+	filter := repository.TransferFilter{
+		UserID: &userID,
+		Status: models.TransferStatus(req.Status),
+		Limit:  int(req.Limit),
+		Offset: int(req.Offset),
+	}
 
 	if req.FileId != "" {
 		fileID, err := uuid.Parse(req.FileId)
 		if err != nil {
 			return nil, status.Error(codes.InvalidArgument, "invalid file_id")
 		}
-		transfers, err = s.transferRepo.GetByFileID(fileID)
-	} else if req.Status != "" {
-		transferStatus := models.TransferStatus(req.Status)
-		transfers, err = s.transferRepo.GetByStatus(transferStatus)
-	} else {
-		return nil, status.Error(codes.InvalidArgument, "file_id or status must be provided")
+		filter.FileID = &fileID
 	}
 
+	if req.GroupId != "" {
+		groupID, err := uuid.Parse(req.GroupId)
+		if err != nil {
+			return nil, status.Error(codes.InvalidArgument, "invalid group_id")
+		}
+		filter.GroupID = &groupID
+	}
+
+	if req.DeviceId != "" {
+		deviceID, err := uuid.Parse(req.DeviceId)
+		if err != nil {
+			return nil, status.Error(codes.InvalidArgument, "invalid device_id")
+		}
+		filter.DeviceID = &deviceID
+	}
+
+	if req.Direction != "" {
+		filter.Direction = models.TransferDirection(req.Direction)
+		if !filter.Direction.IsValid() || filter.DeviceID == nil {
+			return nil, status.Error(codes.InvalidArgument, "direction requires device_id and must be incoming or outgoing")
+		}
+	}
+
+	if filter.Status != "" && !filter.Status.IsValid() {
+		return nil, status.Error(codes.InvalidArgument, "invalid status")
+	}
+
+	if filter.Limit < 0 || filter.Offset < 0 {
+		return nil, status.Error(codes.InvalidArgument, "limit and offset cannot be negative")
+	}
+
+	transfers, err := s.transferRepo.List(filter)
 	if err != nil {
 		return nil, status.Error(codes.Internal, "failed to list transfers")
 	}
@@ -148,13 +448,24 @@ func (s *TransferService) ListTransfers(ctx context.Context, req *transferpb.Lis
 	}, nil
 }
 
+// StreamTransferProgress отправляет текущее состояние передачи, затем каждое
+// обновление, пока передача не завершится или клиент не отключится
 func (s *TransferService) StreamTransferProgress(req *transferpb.StreamTransferProgressRequest, stream transferpb.TransferService_StreamTransferProgressServer) error {
-	transferID, err := uuid.Parse(req.TransferId)
+	transfer, err := s.getParticipantTransfer(req.TransferId, req.UserId)
 	if err != nil {
-		return status.Error(codes.InvalidArgument, "invalid transfer_id")
+		return err
 	}
 
-	transfer, err := s.transferRepo.GetByID(transferID)
+	ctx := stream.Context()
+
+	// Подписка до чтения снимка, чтобы не потерять обновление между ними
+	sub, err := s.progress.Subscribe(ctx, transfer.ID)
+	if err != nil {
+		return status.Error(codes.Unavailable, "failed to subscribe to transfer progress")
+	}
+	defer sub.Close()
+
+	transfer, err = s.transferRepo.GetByID(transfer.ID)
 	if err != nil {
 		return status.Error(codes.Internal, "failed to get transfer")
 	}
@@ -162,23 +473,235 @@ func (s *TransferService) StreamTransferProgress(req *transferpb.StreamTransferP
 		return status.Error(codes.NotFound, "transfer not found")
 	}
 
-	return stream.Send(&transferpb.TransferProgressUpdate{
-		TransferId: transfer.ID.String(),
-		Status:     string(transfer.Status),
-		Progress:   transfer.Progress,
-		TotalSize:  0, // TODO: получить из файла
+	totalSize, err := s.fileSize(transfer.FileID)
+	if err != nil {
+		return err
+	}
+
+	snapshot := progress.NewUpdate(transfer, totalSize, transfer.BytesTransferred, transfer.UpdatedAt)
+	if err := stream.Send(progressUpdateToProto(snapshot)); err != nil {
+		return err
+	}
+
+	if transfer.Status.IsTerminal() {
+		return nil
+	}
+
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+		case update, ok := <-sub.Updates():
+			if !ok {
+				return status.Error(codes.Unavailable, "transfer progress subscription closed")
+			}
+
+			// Обновление, опубликованное до снимка, уже учтено в нем
+			if update.UpdatedAt.Before(snapshot.UpdatedAt) {
+				continue
+			}
+
+			if err := stream.Send(progressUpdateToProto(update)); err != nil {
+				return err
+			}
+
+			if update.Status.IsTerminal() {
+				return nil
+			}
+		}
+	}
+}
+
+// fileSize возвращает размер файла передачи, 0 - если файл уже удален
+func (s *TransferService) fileSize(fileID uuid.UUID) (int64, error) {
+	file, err := s.fileRepo.GetByID(fileID)
+	if err != nil {
+		return 0, status.Error(codes.Internal, "failed to get file")
+	}
+	if file == nil {
+		return 0, nil
+	}
+
+	return file.Size, nil
+}
+
+func progressUpdateToProto(update *progress.Update) *transferpb.TransferProgressUpdate {
+	return &transferpb.TransferProgressUpdate{
+		TransferId:       update.TransferID.String(),
+		Status:           string(update.Status),
+		Progress:         update.Progress,
+		TotalSize:        update.TotalSize,
+		BytesTransferred: update.BytesTransferred,
+		Rate:             update.Rate,
+		EtaSeconds:       update.ETASeconds,
+		FailureReason:    update.FailureReason,
+		UpdatedAt:        update.UpdatedAt.Format(time.RFC3339),
+	}
+}
+
+// GetTransferHistory возвращает смены статуса передачи в хронологическом порядке
+func (s *TransferService) GetTransferHistory(ctx context.Context, req *transferpb.GetTransferHistoryRequest) (*transferpb.GetTransferHistoryResponse, error) {
+	transfer, err := s.getParticipantTransfer(req.TransferId, req.UserId)
+	if err != nil {
+		return nil, err
+	}
+
+	events, err := s.transferRepo.GetEvents(transfer.ID)
+	if err != nil {
+		return nil, status.Error(codes.Internal, "failed to get transfer history")
+	}
+
+	pbEvents := make([]*transferpb.TransferEvent, len(events))
+	for i, event := range events {
+		pbEvents[i] = &transferpb.TransferEvent{
+			ToStatus:     string(event.ToStatus),
+			Reason:       event.Reason,
+			DeliveryPath: string(event.DeliveryPath),
+			CreatedAt:    event.CreatedAt.Format(time.RFC3339),
+		}
+		if event.FromStatus != nil {
+			pbEvents[i].FromStatus = string(*event.FromStatus)
+		}
+	}
+
+	return &transferpb.GetTransferHistoryResponse{
+		Events: pbEvents,
+	}, nil
+}
+
+// ListInbox возвращает облачные передачи, которые ждут устройство пользователя
+func (s *TransferService) ListInbox(ctx context.Context, req *transferpb.ListInboxRequest) (*transferpb.ListInboxResponse, error) {
+	device, err := s.getOwnedDevice(req.DeviceId, req.UserId)
+	if err != nil {
+		return nil, err
+	}
+
+	items, err := s.inboxRepo.GetPendingByDeviceID(device.ID)
+	if err != nil {
+		return nil, status.Error(codes.Internal, "failed to get inbox")
+	}
+
+	pbItems := make([]*transferpb.InboxItem, len(items))
+	for i, item := range items {
+		pbItems[i] = inboxItemToProto(item)
+	}
+
+	return &transferpb.ListInboxResponse{
+		Items: pbItems,
+	}, nil
+}
+
+// AcknowledgeInboxItem подтверждает, что устройство получило файл из входящих:
+// передача завершается, а файл, который больше никто не ждет, получает срок хранения
+func (s *TransferService) AcknowledgeInboxItem(ctx context.Context, req *transferpb.AcknowledgeInboxItemRequest) (*transferpb.AcknowledgeInboxItemResponse, error) {
+	device, err := s.getOwnedDevice(req.DeviceId, req.UserId)
+	if err != nil {
+		return nil, err
+	}
+
+	transferID, err := uuid.Parse(req.TransferId)
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, "invalid transfer_id")
+	}
+
+	transfer, err := s.transferRepo.GetByID(transferID)
+	if err != nil {
+		return nil, status.Error(codes.Internal, "failed to get transfer")
+	}
+	if transfer == nil || !transfer.IsStoreAndForward() || *transfer.ToDeviceID != device.ID {
+		return nil, status.Error(codes.NotFound, "inbox item not found")
+	}
+	if transfer.Status.IsTerminal() {
+		return nil, status.Errorf(codes.FailedPrecondition, "transfer is already %s", transfer.Status)
+	}
+
+	totalSize, err := s.fileSize(transfer.FileID)
+	if err != nil {
+		return nil, err
+	}
+
+	previousBytes := transfer.BytesTransferred
+	previousAt := transfer.UpdatedAt
+
+	// Передача, которую устройство не отмечало начатой, проходит через in_progress,
+	// чтобы история соответствовала таблице переходов
+	var steps []models.TransferStatus
+	if transfer.Status == models.TransferStatusPending {
+		steps = append(steps, models.TransferStatusInProgress)
+	}
+	steps = append(steps, models.TransferStatusCompleted)
+
+	transfer.Progress = 100
+	transfer.BytesTransferred = totalSize
+
+	err = s.inboxRepo.Acknowledge(device.ID, transfer, steps)
+	if errors.Is(err, models.ErrInvalidTransition) {
+		return nil, status.Error(codes.FailedPrecondition, err.Error())
+	}
+	if err == sql.ErrNoRows {
+		return nil, status.Error(codes.Aborted, "inbox item already acknowledged or transfer changed concurrently")
+	}
+	if err != nil {
+		return nil, status.Error(codes.Internal, "failed to acknowledge inbox item")
+	}
+
+	s.progress.Publish(ctx, progress.NewUpdate(transfer, totalSize, previousBytes, previousAt))
+	s.releaseDeliveredFile(transfer.FileID)
+
+	return &transferpb.AcknowledgeInboxItemResponse{
+		Transfer: s.transferToProto(transfer),
+	}, nil
+}
+
+// publishInbox отправляет подключенному устройству актуальный список входящих
+func (s *TransferService) publishInbox(ctx context.Context, device *models.Device) {
+	items, err := s.inboxRepo.GetPendingByDeviceID(device.ID)
+	if err != nil {
+		return
+	}
+
+	s.events.PublishToDevice(ctx, events.TypeInbox, device.UserID, device.ID, &events.Inbox{
+		Items: items,
 	})
 }
 
+// releaseDeliveredFile назначает срок хранения файлу, который больше не ждет ни
+// одно устройство. Файл удаляет задача очистки.
+func (s *TransferService) releaseDeliveredFile(fileID uuid.UUID) {
+	s.inboxRepo.ExpireFileIfDelivered(fileID, time.Now().Add(s.transferCfg.DeliveredFileTTL))
+}
+
+func inboxItemToProto(item *models.InboxItem) *transferpb.InboxItem {
+	pbItem := &transferpb.InboxItem{
+		Id:         item.ID.String(),
+		DeviceId:   item.DeviceID.String(),
+		TransferId: item.TransferID.String(),
+		FileId:     item.FileID.String(),
+		FileName:   item.FileName,
+		FileSize:   item.FileSize,
+		MimeType:   item.MimeType,
+		CreatedAt:  item.CreatedAt.Format(time.RFC3339),
+	}
+
+	if item.FromDeviceID != nil {
+		pbItem.FromDeviceId = item.FromDeviceID.String()
+	}
+
+	return pbItem
+}
+
 func (s *TransferService) transferToProto(transfer *models.Transfer) *transferpb.Transfer {
 	pbTransfer := &transferpb.Transfer{
-		Id:           transfer.ID.String(),
-		FileId:       transfer.FileID.String(),
-		TransferType: string(transfer.TransferType),
-		Status:       string(transfer.Status),
-		Progress:     transfer.Progress,
-		CreatedAt:    transfer.CreatedAt.Format(time.RFC3339),
-		UpdatedAt:    transfer.UpdatedAt.Format(time.RFC3339),
+		Id:               transfer.ID.String(),
+		FileId:           transfer.FileID.String(),
+		TransferType:     string(transfer.TransferType),
+		DeliveryPath:     string(transfer.DeliveryPath),
+		Status:           string(transfer.Status),
+		Progress:         transfer.Progress,
+		FailureReason:    transfer.FailureReason,
+		BytesTransferred: transfer.BytesTransferred,
+		CreatedAt:        transfer.CreatedAt.Format(time.RFC3339),
+		UpdatedAt:        transfer.UpdatedAt.Format(time.RFC3339),
 	}
 
 	if transfer.FromDeviceID != nil {
@@ -189,5 +712,203 @@ func (s *TransferService) transferToProto(transfer *models.Transfer) *transferpb
 		pbTransfer.ToDeviceId = transfer.ToDeviceID.String()
 	}
 
+	if transfer.GroupID != nil {
+		pbTransfer.GroupId = transfer.GroupID.String()
+	}
+
 	return pbTransfer
 }
+
+func (s *TransferService) groupToProto(group *models.TransferGroup) *transferpb.TransferGroup {
+	summary := group.Summary()
+
+	pbGroup := &transferpb.TransferGroup{
+		Id:           group.ID.String(),
+		FileId:       group.FileID.String(),
+		TransferType: string(group.TransferType),
+		Status:       string(summary.Status),
+		Progress:     summary.Progress,
+		Total:        int32(summary.Total),
+		Completed:    int32(summary.Completed),
+		Failed:       int32(summary.Failed),
+		Transfers:    make([]*transferpb.Transfer, len(group.Transfers)),
+		CreatedAt:    group.CreatedAt.Format(time.RFC3339),
+	}
+
+	if group.FromDeviceID != nil {
+		pbGroup.FromDeviceId = group.FromDeviceID.String()
+	}
+
+	for i, transfer := range group.Transfers {
+		pbGroup.Transfers[i] = s.transferToProto(transfer)
+	}
+
+	return pbGroup
+}
+
+// getParticipantTransfer загружает передачу и проверяет, что пользователь владеет
+// ее файлом, устройством отправителя или устройством получателя
+func (s *TransferService) getParticipantTransfer(transferIDStr, userIDStr string) (*models.Transfer, error) {
+	transferID, err := uuid.Parse(transferIDStr)
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, "invalid transfer_id")
+	}
+
+	userID, err := uuid.Parse(userIDStr)
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, "invalid user_id")
+	}
+
+	transfer, err := s.transferRepo.GetByID(transferID)
+	if err != nil {
+		return nil, status.Error(codes.Internal, "failed to get transfer")
+	}
+	if transfer == nil {
+		return nil, status.Error(codes.NotFound, "transfer not found")
+	}
+
+	for _, deviceID := range []*uuid.UUID{transfer.FromDeviceID, transfer.ToDeviceID} {
+		if deviceID == nil {
+			continue
+		}
+
+		device, err := s.deviceRepo.GetByID(*deviceID)
+		if err != nil {
+			return nil, status.Error(codes.Internal, "failed to get device")
+		}
+		if device != nil && device.UserID == userID {
+			return transfer, nil
+		}
+	}
+
+	file, err := s.fileRepo.GetByID(transfer.FileID)
+	if err != nil {
+		return nil, status.Error(codes.Internal, "failed to get file")
+	}
+	if file != nil && file.UserID == userID {
+		return transfer, nil
+	}
+
+	return nil, status.Error(codes.PermissionDenied, "transfer belongs to another user")
+}
+
+// getOwnedDevice загружает устройство и проверяет, что оно принадлежит пользователю
+func (s *TransferService) getOwnedDevice(deviceIDStr, userIDStr string) (*models.Device, error) {
+	deviceID, err := uuid.Parse(deviceIDStr)
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, "invalid device_id")
+	}
+
+	userID, err := uuid.Parse(userIDStr)
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, "invalid user_id")
+	}
+
+	device, err := s.deviceRepo.GetByID(deviceID)
+	if err != nil {
+		return nil, status.Error(codes.Internal, "failed to get device")
+	}
+	if device == nil {
+		return nil, status.Error(codes.NotFound, "device not found")
+	}
+	if device.UserID != userID {
+		return nil, status.Error(codes.PermissionDenied, "device belongs to another user")
+	}
+
+	return device, nil
+}
+
+func (s *TransferService) isOrganizationMember(organizationID *uuid.UUID, userID uuid.UUID) bool {
+	if organizationID == nil {
+		return false
+	}
+
+	isMember, err := s.orgRepo.IsMember(*organizationID, userID)
+	return err == nil && isMember
+}
+
+// checkDevices проверяет, что отправитель - устройство пользователя, получатель
+// принадлежит ему или его организации, оба устройства подтверждены, получатель
+// поддерживает выбранный тип передачи и готов принять файл такого размера.
+// Возвращает устройство получателя, если оно указано.
+func (s *TransferService) checkDevices(transfer *models.Transfer, file *models.File, userID uuid.UUID) (*models.Device, error) {
+	var fromDevice *models.Device
+	if transfer.FromDeviceID != nil {
+		var err error
+		fromDevice, err = s.deviceRepo.GetByID(*transfer.FromDeviceID)
+		if err != nil {
+			return nil, status.Error(codes.Internal, "failed to get device")
+		}
+		if fromDevice == nil {
+			return nil, status.Error(codes.NotFound, "source device not found")
+		}
+		if fromDevice.UserID != userID {
+			return nil, status.Error(codes.PermissionDenied, "source device belongs to another user")
+		}
+		if !fromDevice.IsApproved() {
+			return nil, status.Error(codes.FailedPrecondition, "source device is not approved")
+		}
+		if !fromDevice.IsActive() {
+			return nil, status.Error(codes.FailedPrecondition, "source device is deactivated")
+		}
+	}
+
+	if transfer.ToDeviceID == nil {
+		return nil, nil
+	}
+
+	toDevice, err := s.deviceRepo.GetByID(*transfer.ToDeviceID)
+	if err != nil {
+		return nil, status.Error(codes.Internal, "failed to get device")
+	}
+	if toDevice == nil {
+		return nil, status.Error(codes.NotFound, "target device not found")
+	}
+	if toDevice.UserID != userID && !s.isOrganizationMember(toDevice.OrganizationID, userID) {
+		return nil, status.Error(codes.PermissionDenied, "target device belongs to another user")
+	}
+	if !toDevice.IsApproved() {
+		return nil, status.Error(codes.FailedPrecondition, "target device is not approved")
+	}
+	if !toDevice.IsActive() {
+		return nil, status.Error(codes.FailedPrecondition, "target device is deactivated")
+	}
+
+	if !models.SupportsTransferType(transfer.TransferType, fromDevice, toDevice) {
+		return nil, status.Errorf(codes.FailedPrecondition, "devices do not support %s transfers", transfer.TransferType)
+	}
+
+	if !toDevice.CanReceive(file.Size) {
+		return nil, status.Error(codes.FailedPrecondition, "file exceeds max file size of target device")
+	}
+
+	return toDevice, nil
+}
+
+// notifyRecipient будит приложение получателя push-уведомлением, если устройство
+// не подключено к signaling серверу
+func (s *TransferService) notifyRecipient(ctx context.Context, transfer *models.Transfer, toDevice *models.Device) {
+	if !toDevice.HasPushToken() {
+		return
+	}
+
+	p, err := s.presence.Get(ctx, toDevice.ID)
+	if err == nil && p.Status == presence.StatusOnline {
+		return
+	}
+
+	data := map[string]string{
+		"transfer_id": transfer.ID.String(),
+		"file_id":     transfer.FileID.String(),
+	}
+	if transfer.FromDeviceID != nil {
+		data["from_device_id"] = transfer.FromDeviceID.String()
+	}
+
+	s.push.NotifyAsync(toDevice, &push.Notification{
+		Type:  push.TypeIncomingTransfer,
+		Title: "Incoming file",
+		Body:  "A file is waiting to be received",
+		Data:  data,
+	})
+}
@@ -0,0 +1,182 @@
+package jobs
+
+import (
+	"context"
+	"crypto/sha256"
+	"database/sql"
+	"encoding/hex"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/backend-app/backend/internal/models"
+	"github.com/backend-app/backend/internal/repository"
+	"github.com/backend-app/backend/internal/storage"
+	"github.com/backend-app/backend/pkg/config"
+	"github.com/backend-app/backend/pkg/logger"
+	"github.com/google/uuid"
+	"github.com/rs/zerolog"
+)
+
+const purgeBatchSize = 50
+
+// AccountPurger удаляет аккаунты, у которых истек период ожидания удаления.
+// Строка пользователя блокируется, затем удаляются файлы из хранилища, строки в БД
+// и пишется запись аудита.
+type AccountPurger struct {
+	config       *config.AccountConfig
+	userRepo     *repository.UserRepo
+	deviceRepo   *repository.DeviceRepo
+	fileRepo     *repository.FileRepo
+	transferRepo *repository.TransferRepo
+	accountRepo  *repository.AccountRepo
+	storage      *storage.LocalStorage
+	log          zerolog.Logger
+}
+
+// NewAccountPurger создает задачу удаления аккаунтов
+func NewAccountPurger(
+	cfg *config.AccountConfig,
+	userRepo *repository.UserRepo,
+	deviceRepo *repository.DeviceRepo,
+	fileRepo *repository.FileRepo,
+	transferRepo *repository.TransferRepo,
+	accountRepo *repository.AccountRepo,
+	storage *storage.LocalStorage,
+) *AccountPurger {
+	return &AccountPurger{
+		config:       cfg,
+		userRepo:     userRepo,
+		deviceRepo:   deviceRepo,
+		fileRepo:     fileRepo,
+		transferRepo: transferRepo,
+		accountRepo:  accountRepo,
+		storage:      storage,
+		log:          logger.Get(),
+	}
+}
+
+// Run периодически удаляет аккаунты до отмены контекста (блокирующий вызов)
+func (p *AccountPurger) Run(ctx context.Context) {
+	ticker := time.NewTicker(p.config.JobInterval)
+	defer ticker.Stop()
+
+	for {
+		p.purgeDue()
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+func (p *AccountPurger) purgeDue() {
+	users, err := p.userRepo.GetDueForDeletion(time.Now(), purgeBatchSize)
+	if err != nil {
+		p.log.Error().Err(err).Msg("Failed to get accounts due for deletion")
+		return
+	}
+
+	for _, user := range users {
+		if err := p.purge(user); err != nil {
+			p.log.Error().
+				Err(err).
+				Str("user_id", user.ID.String()).
+				Msg("Failed to purge account")
+			continue
+		}
+	}
+}
+
+// purge удаляет аккаунт, если его удаление не отменено, пока задача работала
+func (p *AccountPurger) purge(user *models.User) error {
+	audit, err := p.accountRepo.PurgeUser(user.ID, time.Now(), func() (*models.AccountDeletionAudit, error) {
+		return p.deleteData(user)
+	})
+	if err == sql.ErrNoRows {
+		p.log.Info().Str("user_id", user.ID.String()).Msg("Account deletion canceled, skipping purge")
+		return nil
+	}
+	if err == repository.ErrSoleOrganizationOwner {
+		// Владение могли передать после запроса удаления: повторим на следующем запуске
+		p.log.Warn().Str("user_id", user.ID.String()).Msg("Account is the sole owner of an organization, skipping purge")
+		return nil
+	}
+	if err != nil {
+		return fmt.Errorf("failed to delete user: %w", err)
+	}
+
+	p.log.Info().
+		Str("user_id", user.ID.String()).
+		Int("files", audit.FilesDeleted).
+		Int64("bytes", audit.BytesDeleted).
+		Int("devices", audit.DevicesDeleted).
+		Int("transfers", audit.TransfersDeleted).
+		Msg("Account purged")
+
+	return nil
+}
+
+// deleteData удаляет файлы пользователя из хранилища и возвращает запись аудита.
+// Вызывается, когда строка пользователя уже заблокирована для удаления.
+func (p *AccountPurger) deleteData(user *models.User) (*models.AccountDeletionAudit, error) {
+	files, err := p.fileRepo.GetAllByUserID(user.ID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get files: %w", err)
+	}
+
+	devices, err := p.deviceRepo.GetByUserID(user.ID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get devices: %w", err)
+	}
+
+	transfers, err := p.transferRepo.GetByUserID(user.ID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get transfers: %w", err)
+	}
+
+	// Файлы организаций остаются в общем хранилище и переходят к другому владельцу
+	fileIDs := make(map[uuid.UUID]bool, len(files))
+	var bytesDeleted int64
+	for _, file := range files {
+		if file.OrganizationID != nil {
+			continue
+		}
+
+		fileIDs[file.ID] = true
+		if file.StoragePath != "" {
+			if err := p.storage.DeleteFile(file.StoragePath); err != nil {
+				return nil, err
+			}
+		}
+		bytesDeleted += file.Size
+	}
+
+	// Удаляем каталог пользователя целиком: архивы экспорта и возможные остатки загрузок
+	if err := p.storage.DeleteUserData(user.ID); err != nil {
+		return nil, err
+	}
+
+	// Каскадно удаляются только передачи файлов пользователя; передачи на его
+	// устройства из чужих файлов остаются с обнуленным to_device_id
+	transfersDeleted := 0
+	for _, transfer := range transfers {
+		if fileIDs[transfer.FileID] {
+			transfersDeleted++
+		}
+	}
+
+	emailHash := sha256.Sum256([]byte(strings.ToLower(user.Email)))
+
+	return &models.AccountDeletionAudit{
+		UserID:           user.ID,
+		EmailHash:        hex.EncodeToString(emailHash[:]),
+		FilesDeleted:     len(fileIDs),
+		BytesDeleted:     bytesDeleted,
+		DevicesDeleted:   len(devices),
+		TransfersDeleted: transfersDeleted,
+		RequestedAt:      user.DeletionRequestedAt,
+	}, nil
+}
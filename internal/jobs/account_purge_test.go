@@ -0,0 +1,197 @@
+package jobs
+
+import (
+	"database/sql"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/backend-app/backend/internal/models"
+	"github.com/backend-app/backend/internal/repository"
+	"github.com/backend-app/backend/internal/storage"
+	"github.com/backend-app/backend/pkg/config"
+	"github.com/google/uuid"
+)
+
+const (
+	claimUserQuery      = "FOR UPDATE"
+	soleOwnerQuery      = "SELECT EXISTS"
+	reassignFilesQuery  = "UPDATE files f"
+	purgeFileColumnsSQL = "id, user_id, organization_id, name, size, mime_type, storage_path, storage_type, expires_at, created_at, updated_at"
+)
+
+var purgeFileColumns = strings.Split(purgeFileColumnsSQL, ", ")
+
+type purgeFixture struct {
+	purger  *AccountPurger
+	db      sqlmock.Sqlmock
+	storage *storage.LocalStorage
+	dir     string
+}
+
+func newPurgeFixture(t *testing.T) *purgeFixture {
+	t.Helper()
+
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("sqlmock: %v", err)
+	}
+	t.Cleanup(func() { db.Close() })
+
+	dir := t.TempDir()
+	localStorage, err := storage.NewLocalStorage(dir)
+	if err != nil {
+		t.Fatalf("storage: %v", err)
+	}
+
+	purger := NewAccountPurger(
+		&config.AccountConfig{},
+		repository.NewUserRepo(db),
+		repository.NewDeviceRepo(db),
+		repository.NewFileRepo(db),
+		repository.NewTransferRepo(db),
+		repository.NewAccountRepo(db),
+		localStorage,
+	)
+
+	return &purgeFixture{purger: purger, db: mock, storage: localStorage, dir: dir}
+}
+
+func dueUser() *models.User {
+	scheduledAt := time.Now().Add(-time.Hour)
+	return &models.User{
+		ID:                  uuid.New(),
+		Email:               "user@example.com",
+		DeletionScheduledAt: &scheduledAt,
+	}
+}
+
+// saveUserFile сохраняет файл в каталоге пользователя и возвращает его полный путь
+func (f *purgeFixture) saveUserFile(t *testing.T, userID uuid.UUID) string {
+	t.Helper()
+
+	storagePath, err := f.storage.SaveFile(userID, uuid.New(), "notes.txt", [][]byte{[]byte("data")})
+	if err != nil {
+		t.Fatalf("save file: %v", err)
+	}
+	return filepath.Join(f.dir, storagePath)
+}
+
+// expectClaim ожидает блокировку строки пользователя, проверку владения организациями
+// и передачу файлов организаций другому владельцу
+func (f *purgeFixture) expectClaim(userID uuid.UUID, soleOwner bool) {
+	f.db.ExpectBegin()
+	f.db.ExpectQuery(claimUserQuery).
+		WithArgs(userID, sqlmock.AnyArg()).
+		WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(userID))
+	f.db.ExpectQuery(soleOwnerQuery).
+		WithArgs(userID, models.OrganizationRoleOwner).
+		WillReturnRows(sqlmock.NewRows([]string{"exists"}).AddRow(soleOwner))
+	if soleOwner {
+		f.db.ExpectRollback()
+		return
+	}
+	f.db.ExpectExec(reassignFilesQuery).
+		WithArgs(userID, models.OrganizationRoleOwner, sqlmock.AnyArg()).
+		WillReturnResult(sqlmock.NewResult(0, 0))
+}
+
+// fileRow возвращает строку files для sqlmock
+func fileRow(rows *sqlmock.Rows, file *models.File) *sqlmock.Rows {
+	return rows.AddRow(file.ID, file.UserID, file.OrganizationID, file.Name, file.Size, "text/plain",
+		file.StoragePath, "local", nil, time.Now(), time.Now())
+}
+
+func TestPurgeSkipsCanceledDeletion(t *testing.T) {
+	f := newPurgeFixture(t)
+	user := dueUser()
+	path := f.saveUserFile(t, user.ID)
+
+	// Пользователь отменил удаление после выборки: строка больше не подходит
+	f.db.ExpectBegin()
+	f.db.ExpectQuery(claimUserQuery).
+		WithArgs(user.ID, sqlmock.AnyArg()).
+		WillReturnError(sql.ErrNoRows)
+	f.db.ExpectRollback()
+
+	if err := f.purger.purge(user); err != nil {
+		t.Fatalf("purge: %v", err)
+	}
+
+	if _, err := os.Stat(path); err != nil {
+		t.Errorf("file of a canceled deletion was removed: %v", err)
+	}
+
+	if err := f.db.ExpectationsWereMet(); err != nil {
+		t.Error(err)
+	}
+}
+
+func TestPurgeDeletesClaimedUser(t *testing.T) {
+	f := newPurgeFixture(t)
+	user := dueUser()
+	path := f.saveUserFile(t, user.ID)
+
+	organizationID := uuid.New()
+	orgStoragePath, err := f.storage.SaveOrganizationFile(organizationID, uuid.New(), "shared.txt", [][]byte{[]byte("shared")})
+	if err != nil {
+		t.Fatalf("save organization file: %v", err)
+	}
+
+	f.expectClaim(user.ID, false)
+	f.db.ExpectQuery(regexp.QuoteMeta("FROM files")).
+		WillReturnRows(fileRow(fileRow(sqlmock.NewRows(purgeFileColumns),
+			&models.File{ID: uuid.New(), UserID: user.ID, Name: "notes.txt", Size: 4, StoragePath: strings.TrimPrefix(path, f.dir+"/")}),
+			&models.File{ID: uuid.New(), UserID: user.ID, OrganizationID: &organizationID, Name: "shared.txt", Size: 6, StoragePath: orgStoragePath}))
+	f.db.ExpectQuery(regexp.QuoteMeta("FROM devices")).
+		WillReturnRows(sqlmock.NewRows(nil))
+	f.db.ExpectQuery(regexp.QuoteMeta("FROM transfers")).
+		WillReturnRows(sqlmock.NewRows(nil))
+	f.db.ExpectExec(regexp.QuoteMeta("DELETE FROM users WHERE id = $1")).
+		WithArgs(user.ID).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	// В аудите учитывается только личный файл
+	f.db.ExpectExec(regexp.QuoteMeta("INSERT INTO account_deletion_audit")).
+		WithArgs(sqlmock.AnyArg(), user.ID, sqlmock.AnyArg(), 1, int64(4), 0, 0, sqlmock.AnyArg(), sqlmock.AnyArg()).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	f.db.ExpectCommit()
+
+	if err := f.purger.purge(user); err != nil {
+		t.Fatalf("purge: %v", err)
+	}
+
+	if _, err := os.Stat(path); !os.IsNotExist(err) {
+		t.Errorf("user file still exists: %v", err)
+	}
+	if !f.storage.FileExists(orgStoragePath) {
+		t.Error("organization file was removed")
+	}
+
+	if err := f.db.ExpectationsWereMet(); err != nil {
+		t.Error(err)
+	}
+}
+
+func TestPurgeSkipsSoleOrganizationOwner(t *testing.T) {
+	f := newPurgeFixture(t)
+	user := dueUser()
+	path := f.saveUserFile(t, user.ID)
+
+	f.expectClaim(user.ID, true)
+
+	if err := f.purger.purge(user); err != nil {
+		t.Fatalf("purge: %v", err)
+	}
+
+	if _, err := os.Stat(path); err != nil {
+		t.Errorf("file of the sole owner was removed: %v", err)
+	}
+
+	if err := f.db.ExpectationsWereMet(); err != nil {
+		t.Error(err)
+	}
+}
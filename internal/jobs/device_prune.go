@@ -0,0 +1,179 @@
+package jobs
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/backend-app/backend/internal/events"
+	"github.com/backend-app/backend/internal/models"
+	"github.com/backend-app/backend/internal/presence"
+	"github.com/backend-app/backend/internal/push"
+	"github.com/backend-app/backend/internal/repository"
+	"github.com/backend-app/backend/pkg/config"
+	"github.com/backend-app/backend/pkg/devicetoken"
+	"github.com/backend-app/backend/pkg/logger"
+	"github.com/rs/zerolog"
+)
+
+const pruneBatchSize = 100
+
+// DevicePruner отключает устройства, которые не подключались дольше порога
+// неактивности пользователя. За InactivityWarningPeriod до отключения пользователь
+// получает предупреждение; подключение устройства сбрасывает его.
+type DevicePruner struct {
+	config     *config.DeviceConfig
+	deviceRepo *repository.DeviceRepo
+	presence   *presence.Store
+	events     *events.Bus
+	push       *push.Service
+	log        zerolog.Logger
+}
+
+// NewDevicePruner создает задачу отключения неактивных устройств
+func NewDevicePruner(
+	cfg *config.DeviceConfig,
+	deviceRepo *repository.DeviceRepo,
+	presenceStore *presence.Store,
+	eventBus *events.Bus,
+	pushService *push.Service,
+) *DevicePruner {
+	return &DevicePruner{
+		config:     cfg,
+		deviceRepo: deviceRepo,
+		presence:   presenceStore,
+		events:     eventBus,
+		push:       pushService,
+		log:        logger.Get(),
+	}
+}
+
+// Run периодически предупреждает и отключает неактивные устройства до отмены контекста (блокирующий вызов)
+func (p *DevicePruner) Run(ctx context.Context) {
+	ticker := time.NewTicker(p.config.PruneInterval)
+	defer ticker.Stop()
+
+	for {
+		p.warnDue(ctx)
+		p.deactivateDue(ctx)
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+func (p *DevicePruner) warnDue(ctx context.Context) {
+	now := time.Now()
+
+	devices, err := p.deviceRepo.GetDueForInactivityWarning(p.config.InactivityDays, now, p.config.InactivityWarningPeriod, pruneBatchSize)
+	if err != nil {
+		p.log.Error().Err(err).Msg("Failed to get devices due for inactivity warning")
+		return
+	}
+
+	for _, device := range devices {
+		if p.isConnected(ctx, device) {
+			continue
+		}
+
+		if err := p.deviceRepo.MarkInactivityWarned(device, now); err != nil {
+			p.log.Error().
+				Err(err).
+				Str("device_id", device.ID.String()).
+				Msg("Failed to mark device as warned")
+			continue
+		}
+
+		// Отключение не раньше, чем через период предупреждения
+		deactivatesAt := now.Add(p.config.InactivityWarningPeriod)
+
+		p.events.Publish(ctx, events.TypeDeviceInactivityWarning, device.UserID, &events.DeviceInactivity{
+			DeviceID:      device.ID,
+			Name:          device.Name,
+			LastSeenAt:    device.LastSeenAt,
+			DeactivatesAt: &deactivatesAt,
+		})
+
+		p.push.NotifyAsync(device, &push.Notification{
+			Type:  push.TypeDeviceInactivityWarning,
+			Title: "Device will be deactivated",
+			Body:  fmt.Sprintf("Open the app on %q to keep it connected", device.Name),
+			Data: map[string]string{
+				"device_id":      device.ID.String(),
+				"deactivates_at": deactivatesAt.UTC().Format(time.RFC3339),
+			},
+		})
+	}
+}
+
+func (p *DevicePruner) deactivateDue(ctx context.Context) {
+	devices, err := p.deviceRepo.GetDueForDeactivation(p.config.InactivityDays, time.Now(), p.config.InactivityWarningPeriod, pruneBatchSize)
+	if err != nil {
+		p.log.Error().Err(err).Msg("Failed to get devices due for deactivation")
+		return
+	}
+
+	for _, device := range devices {
+		if p.isConnected(ctx, device) {
+			continue
+		}
+
+		if err := p.deactivate(ctx, device); err != nil {
+			p.log.Error().
+				Err(err).
+				Str("device_id", device.ID.String()).
+				Msg("Failed to deactivate device")
+			continue
+		}
+	}
+}
+
+// isConnected проверяет, подключено ли устройство к signaling серверу. last_seen_at
+// обновляется только при подключении и отключении, поэтому у долго подключенного
+// устройства он сдвигается здесь.
+func (p *DevicePruner) isConnected(ctx context.Context, device *models.Device) bool {
+	state, err := p.presence.Get(ctx, device.ID)
+	if err != nil || state.Status == presence.StatusOffline {
+		return false
+	}
+
+	if err := p.deviceRepo.UpdateLastSeen(device.ID); err != nil {
+		p.log.Error().
+			Err(err).
+			Str("device_id", device.ID.String()).
+			Msg("Failed to update last seen")
+	}
+
+	return true
+}
+
+func (p *DevicePruner) deactivate(ctx context.Context, device *models.Device) error {
+	// Хеш случайного токена, который никому не выдается: старый токен перестает действовать
+	revokedToken, err := devicetoken.Generate()
+	if err != nil {
+		return fmt.Errorf("failed to generate token: %w", err)
+	}
+
+	failedTransfers, err := p.deviceRepo.Deactivate(device, devicetoken.Hash(revokedToken))
+	if err != nil {
+		return fmt.Errorf("failed to deactivate device: %w", err)
+	}
+
+	p.events.Publish(ctx, events.TypeDeviceDeactivated, device.UserID, &events.DeviceInactivity{
+		DeviceID:   device.ID,
+		Name:       device.Name,
+		LastSeenAt: device.LastSeenAt,
+	})
+
+	p.log.Info().
+		Str("device_id", device.ID.String()).
+		Str("user_id", device.UserID.String()).
+		Time("last_seen_at", device.LastSeenAt).
+		Int64("failed_transfers", failedTransfers).
+		Msg("Inactive device deactivated")
+
+	return nil
+}
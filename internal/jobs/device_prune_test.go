@@ -0,0 +1,168 @@
+package jobs
+
+import (
+	"context"
+	"regexp"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/alicebob/miniredis/v2"
+	"github.com/backend-app/backend/internal/events"
+	"github.com/backend-app/backend/internal/models"
+	"github.com/backend-app/backend/internal/presence"
+	"github.com/backend-app/backend/internal/push"
+	"github.com/backend-app/backend/internal/repository"
+	"github.com/backend-app/backend/pkg/config"
+	"github.com/google/uuid"
+	"github.com/redis/go-redis/v9"
+)
+
+var deviceColumns = []string{
+	"id", "user_id", "organization_id", "name", "device_type", "platform", "os_version", "app_version",
+	"transfer_protocols", "max_file_size", "approval_status", "approved_by_device_id", "push_provider",
+	"push_token", "prune_exempt", "inactivity_warned_at", "deactivated_at", "device_token_hash",
+	"previous_token_hash", "previous_token_expires_at", "last_seen_at", "created_at", "updated_at",
+}
+
+type pruneFixture struct {
+	pruner   *DevicePruner
+	db       sqlmock.Sqlmock
+	presence *presence.Store
+	notifier *push.LogNotifier
+}
+
+func newPruneFixture(t *testing.T) *pruneFixture {
+	t.Helper()
+
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("sqlmock: %v", err)
+	}
+	t.Cleanup(func() { db.Close() })
+
+	redisServer := miniredis.RunT(t)
+	redisClient := redis.NewClient(&redis.Options{Addr: redisServer.Addr()})
+	t.Cleanup(func() { redisClient.Close() })
+
+	deviceRepo := repository.NewDeviceRepo(db)
+	notifier := push.NewLogNotifier()
+	pushService := push.NewService(map[models.PushProvider]push.Notifier{
+		models.PushProviderFCM: notifier,
+	}, deviceRepo, time.Second)
+	presenceStore := presence.NewStore(redisClient, time.Minute)
+
+	cfg := &config.DeviceConfig{
+		InactivityDays:          90,
+		InactivityWarningPeriod: 7 * 24 * time.Hour,
+		PruneInterval:           time.Hour,
+	}
+
+	return &pruneFixture{
+		pruner:   NewDevicePruner(cfg, deviceRepo, presenceStore, events.NewBus(redisClient), pushService),
+		db:       mock,
+		presence: presenceStore,
+		notifier: notifier,
+	}
+}
+
+func inactiveDevice(lastSeen time.Time) *models.Device {
+	return &models.Device{
+		ID:             uuid.New(),
+		UserID:         uuid.New(),
+		Name:           "Laptop",
+		DeviceType:     "desktop",
+		Platform:       "linux",
+		ApprovalStatus: models.DeviceApprovalApproved,
+		PushProvider:   models.PushProviderFCM,
+		PushToken:      "fcm-token",
+		LastSeenAt:     lastSeen,
+	}
+}
+
+func deviceRows(devices ...*models.Device) *sqlmock.Rows {
+	rows := sqlmock.NewRows(deviceColumns)
+	for _, d := range devices {
+		rows.AddRow(
+			d.ID, d.UserID, nil, d.Name, d.DeviceType, d.Platform, "", "",
+			[]byte("{}"), nil, d.ApprovalStatus, nil, d.PushProvider,
+			d.PushToken, false, nil, nil, "hash",
+			nil, nil, d.LastSeenAt, d.LastSeenAt, d.LastSeenAt,
+		)
+	}
+	return rows
+}
+
+// waitSent ждет n уведомлений не дольше timeout: NotifyAsync отправляет их в фоне
+func waitSent(t *testing.T, notifier *push.LogNotifier, n int, timeout time.Duration) []push.SentNotification {
+	t.Helper()
+
+	deadline := time.Now().Add(timeout)
+	for {
+		sent := notifier.Sent()
+		if len(sent) >= n || time.Now().After(deadline) {
+			return sent
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+}
+
+func TestWarnDueSendsInactivityPush(t *testing.T) {
+	f := newPruneFixture(t)
+	device := inactiveDevice(time.Now().Add(-90 * 24 * time.Hour))
+
+	f.db.ExpectQuery(regexp.QuoteMeta("AND d.inactivity_warned_at IS NULL")).
+		WillReturnRows(deviceRows(device))
+	f.db.ExpectExec(regexp.QuoteMeta("SET inactivity_warned_at = $1")).
+		WithArgs(sqlmock.AnyArg(), device.ID).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+
+	f.pruner.warnDue(context.Background())
+
+	sent := waitSent(t, f.notifier, 1, 2*time.Second)
+	if len(sent) != 1 {
+		t.Fatalf("sent %d notifications, want 1", len(sent))
+	}
+	if sent[0].Token != device.PushToken {
+		t.Errorf("token = %q, want %q", sent[0].Token, device.PushToken)
+	}
+	if sent[0].Notification.Type != push.TypeDeviceInactivityWarning {
+		t.Errorf("type = %q, want %q", sent[0].Notification.Type, push.TypeDeviceInactivityWarning)
+	}
+	if got := sent[0].Notification.Data["device_id"]; got != device.ID.String() {
+		t.Errorf("device_id = %q, want %q", got, device.ID)
+	}
+
+	if err := f.db.ExpectationsWereMet(); err != nil {
+		t.Error(err)
+	}
+}
+
+func TestWarnDueSkipsConnectedDevice(t *testing.T) {
+	f := newPruneFixture(t)
+	device := inactiveDevice(time.Now().Add(-90 * 24 * time.Hour))
+
+	err := f.presence.Set(context.Background(), &presence.Presence{
+		DeviceID: device.ID,
+		Status:   presence.StatusOnline,
+	})
+	if err != nil {
+		t.Fatalf("set presence: %v", err)
+	}
+
+	f.db.ExpectQuery(regexp.QuoteMeta("AND d.inactivity_warned_at IS NULL")).
+		WillReturnRows(deviceRows(device))
+	f.db.ExpectExec(regexp.QuoteMeta("SET last_seen_at")).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+
+	f.pruner.warnDue(context.Background())
+
+	// NotifyAsync отправляет в своей горутине: ошибочная отправка появилась бы не сразу
+	if sent := waitSent(t, f.notifier, 1, 200*time.Millisecond); len(sent) != 0 {
+		t.Fatalf("sent %d notifications to a connected device, want 0", len(sent))
+	}
+
+	if err := f.db.ExpectationsWereMet(); err != nil {
+		t.Error(err)
+	}
+}
@@ -0,0 +1,332 @@
+package jobs
+
+import (
+	"archive/zip"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"path/filepath"
+	"time"
+
+	"github.com/backend-app/backend/internal/models"
+	"github.com/backend-app/backend/internal/repository"
+	"github.com/backend-app/backend/internal/storage"
+	"github.com/backend-app/backend/pkg/config"
+	"github.com/backend-app/backend/pkg/logger"
+	"github.com/rs/zerolog"
+)
+
+// ExportWorker собирает ZIP архивы с персональными данными пользователей
+type ExportWorker struct {
+	config       *config.AccountConfig
+	userRepo     *repository.UserRepo
+	deviceRepo   *repository.DeviceRepo
+	fileRepo     *repository.FileRepo
+	transferRepo *repository.TransferRepo
+	accountRepo  *repository.AccountRepo
+	storage      *storage.LocalStorage
+	log          zerolog.Logger
+}
+
+// NewExportWorker создает воркер экспорта данных
+func NewExportWorker(
+	cfg *config.AccountConfig,
+	userRepo *repository.UserRepo,
+	deviceRepo *repository.DeviceRepo,
+	fileRepo *repository.FileRepo,
+	transferRepo *repository.TransferRepo,
+	accountRepo *repository.AccountRepo,
+	storage *storage.LocalStorage,
+) *ExportWorker {
+	return &ExportWorker{
+		config:       cfg,
+		userRepo:     userRepo,
+		deviceRepo:   deviceRepo,
+		fileRepo:     fileRepo,
+		transferRepo: transferRepo,
+		accountRepo:  accountRepo,
+		storage:      storage,
+		log:          logger.Get(),
+	}
+}
+
+// Run обрабатывает очередь экспортов до отмены контекста (блокирующий вызов)
+func (w *ExportWorker) Run(ctx context.Context) {
+	ticker := time.NewTicker(w.config.JobInterval)
+	defer ticker.Stop()
+
+	for {
+		w.processPending()
+		w.cleanupExpired()
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// processPending собирает все ожидающие экспорты
+func (w *ExportWorker) processPending() {
+	for {
+		export, err := w.accountRepo.ClaimPendingExport()
+		if err != nil {
+			w.log.Error().Err(err).Msg("Failed to claim pending export")
+			return
+		}
+		if export == nil {
+			return
+		}
+
+		if err := w.build(export); err != nil {
+			w.log.Error().
+				Err(err).
+				Str("export_id", export.ID.String()).
+				Msg("Failed to build data export")
+
+			if export.StoragePath != "" {
+				w.storage.DeleteFile(export.StoragePath)
+			}
+			export.Status = models.DataExportStatusFailed
+			export.StoragePath = ""
+			export.Size = 0
+			export.Error = err.Error()
+		}
+
+		if err := w.accountRepo.UpdateExport(export); err != nil {
+			w.log.Error().
+				Err(err).
+				Str("export_id", export.ID.String()).
+				Msg("Failed to update data export")
+		}
+	}
+}
+
+// exportProfile - профиль пользователя в архиве
+type exportProfile struct {
+	ID                  string     `json:"id"`
+	Email               string     `json:"email"`
+	DeletionScheduledAt *time.Time `json:"deletion_scheduled_at,omitempty"`
+	CreatedAt           time.Time  `json:"created_at"`
+	UpdatedAt           time.Time  `json:"updated_at"`
+}
+
+// exportDevice - устройство в архиве (без секретов)
+type exportDevice struct {
+	ID         string    `json:"id"`
+	Name       string    `json:"name"`
+	DeviceType string    `json:"device_type"`
+	Platform   string    `json:"platform,omitempty"`
+	OSVersion  string    `json:"os_version,omitempty"`
+	AppVersion string    `json:"app_version,omitempty"`
+	LastSeenAt time.Time `json:"last_seen_at"`
+	CreatedAt  time.Time `json:"created_at"`
+}
+
+// exportFile - метаданные файла в архиве
+type exportFile struct {
+	ID          string     `json:"id"`
+	Name        string     `json:"name"`
+	Size        int64      `json:"size"`
+	MimeType    string     `json:"mime_type"`
+	ArchivePath string     `json:"archive_path,omitempty"`
+	ExpiresAt   *time.Time `json:"expires_at,omitempty"`
+	CreatedAt   time.Time  `json:"created_at"`
+}
+
+// build записывает архив экспорта и заполняет поля готового экспорта
+func (w *ExportWorker) build(export *models.DataExport) error {
+	user, err := w.userRepo.GetByID(export.UserID)
+	if err != nil {
+		return fmt.Errorf("failed to get user: %w", err)
+	}
+	if user == nil {
+		return fmt.Errorf("user not found")
+	}
+
+	devices, err := w.deviceRepo.GetByUserID(user.ID)
+	if err != nil {
+		return fmt.Errorf("failed to get devices: %w", err)
+	}
+
+	files, err := w.fileRepo.GetAllByUserID(user.ID)
+	if err != nil {
+		return fmt.Errorf("failed to get files: %w", err)
+	}
+
+	transfers, err := w.transferRepo.GetByUserID(user.ID)
+	if err != nil {
+		return fmt.Errorf("failed to get transfers: %w", err)
+	}
+
+	out, storagePath, err := w.storage.CreateExportFile(user.ID, export.ID)
+	if err != nil {
+		return err
+	}
+	export.StoragePath = storagePath
+
+	counter := &countingWriter{w: out}
+	archive := zip.NewWriter(counter)
+
+	if err := writeJSON(archive, "profile.json", exportProfile{
+		ID:                  user.ID.String(),
+		Email:               user.Email,
+		DeletionScheduledAt: user.DeletionScheduledAt,
+		CreatedAt:           user.CreatedAt,
+		UpdatedAt:           user.UpdatedAt,
+	}); err != nil {
+		out.Close()
+		return err
+	}
+
+	exportDevices := make([]exportDevice, len(devices))
+	for i, device := range devices {
+		exportDevices[i] = exportDevice{
+			ID:         device.ID.String(),
+			Name:       device.Name,
+			DeviceType: string(device.DeviceType),
+			Platform:   string(device.Platform),
+			OSVersion:  device.OSVersion,
+			AppVersion: device.AppVersion,
+			LastSeenAt: device.LastSeenAt,
+			CreatedAt:  device.CreatedAt,
+		}
+	}
+	if err := writeJSON(archive, "devices.json", exportDevices); err != nil {
+		out.Close()
+		return err
+	}
+
+	if transfers == nil {
+		transfers = []*models.Transfer{}
+	}
+	if err := writeJSON(archive, "transfers.json", transfers); err != nil {
+		out.Close()
+		return err
+	}
+
+	exportFiles := make([]exportFile, len(files))
+	for i, file := range files {
+		exportFiles[i] = exportFile{
+			ID:        file.ID.String(),
+			Name:      file.Name,
+			Size:      file.Size,
+			MimeType:  file.MimeType,
+			ExpiresAt: file.ExpiresAt,
+			CreatedAt: file.CreatedAt,
+		}
+
+		if file.StoragePath == "" || !w.storage.FileExists(file.StoragePath) {
+			continue
+		}
+
+		archivePath := fmt.Sprintf("files/%s_%s", file.ID.String(), filepath.Base(file.Name))
+		if err := w.copyFile(archive, archivePath, file); err != nil {
+			out.Close()
+			return err
+		}
+		exportFiles[i].ArchivePath = archivePath
+	}
+	if err := writeJSON(archive, "files.json", exportFiles); err != nil {
+		out.Close()
+		return err
+	}
+
+	if err := archive.Close(); err != nil {
+		out.Close()
+		return fmt.Errorf("failed to finalize archive: %w", err)
+	}
+	if err := out.Close(); err != nil {
+		return fmt.Errorf("failed to close archive: %w", err)
+	}
+
+	expiresAt := time.Now().Add(w.config.ExportTTL)
+	export.Status = models.DataExportStatusCompleted
+	export.Size = counter.n
+	export.Error = ""
+	export.ExpiresAt = &expiresAt
+
+	w.log.Info().
+		Str("export_id", export.ID.String()).
+		Str("user_id", user.ID.String()).
+		Int64("size", export.Size).
+		Msg("Data export completed")
+
+	return nil
+}
+
+func (w *ExportWorker) copyFile(archive *zip.Writer, archivePath string, file *models.File) error {
+	reader, _, err := w.storage.ReadFile(file.StoragePath, 0, 0)
+	if err != nil {
+		return fmt.Errorf("failed to read file %s: %w", file.ID, err)
+	}
+	defer reader.Close()
+
+	entry, err := archive.Create(archivePath)
+	if err != nil {
+		return fmt.Errorf("failed to create archive entry: %w", err)
+	}
+
+	if _, err := io.Copy(entry, reader); err != nil {
+		return fmt.Errorf("failed to copy file %s: %w", file.ID, err)
+	}
+
+	return nil
+}
+
+// cleanupExpired удаляет просроченные архивы экспорта
+func (w *ExportWorker) cleanupExpired() {
+	exports, err := w.accountRepo.GetExpiredExports()
+	if err != nil {
+		w.log.Error().Err(err).Msg("Failed to get expired exports")
+		return
+	}
+
+	for _, export := range exports {
+		if export.StoragePath != "" {
+			if err := w.storage.DeleteFile(export.StoragePath); err != nil {
+				w.log.Error().
+					Err(err).
+					Str("export_id", export.ID.String()).
+					Msg("Failed to delete expired export")
+				continue
+			}
+		}
+
+		if err := w.accountRepo.DeleteExport(export.ID); err != nil {
+			w.log.Error().
+				Err(err).
+				Str("export_id", export.ID.String()).
+				Msg("Failed to delete expired export record")
+		}
+	}
+}
+
+func writeJSON(archive *zip.Writer, name string, v interface{}) error {
+	entry, err := archive.Create(name)
+	if err != nil {
+		return fmt.Errorf("failed to create archive entry: %w", err)
+	}
+
+	encoder := json.NewEncoder(entry)
+	encoder.SetIndent("", "  ")
+	if err := encoder.Encode(v); err != nil {
+		return fmt.Errorf("failed to write %s: %w", name, err)
+	}
+
+	return nil
+}
+
+// countingWriter считает количество записанных байт
+type countingWriter struct {
+	w io.Writer
+	n int64
+}
+
+func (c *countingWriter) Write(p []byte) (int, error) {
+	n, err := c.w.Write(p)
+	c.n += int64(n)
+	return n, err
+}
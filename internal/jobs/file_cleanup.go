@@ -0,0 +1,82 @@
+package jobs
+
+import (
+	"context"
+	"time"
+
+	"github.com/backend-app/backend/internal/repository"
+	"github.com/backend-app/backend/internal/storage"
+	"github.com/backend-app/backend/pkg/config"
+	"github.com/backend-app/backend/pkg/logger"
+	"github.com/rs/zerolog"
+)
+
+// FileCleaner удаляет файлы с истекшим сроком хранения: сначала из хранилища,
+// затем строку в БД. Срок назначается, например, файлам облачных передач после
+// получения всеми устройствами.
+type FileCleaner struct {
+	config   *config.TransferConfig
+	fileRepo *repository.FileRepo
+	storage  *storage.LocalStorage
+	log      zerolog.Logger
+}
+
+// NewFileCleaner создает задачу удаления файлов с истекшим сроком хранения
+func NewFileCleaner(cfg *config.TransferConfig, fileRepo *repository.FileRepo, storage *storage.LocalStorage) *FileCleaner {
+	return &FileCleaner{
+		config:   cfg,
+		fileRepo: fileRepo,
+		storage:  storage,
+		log:      logger.Get(),
+	}
+}
+
+// Run периодически удаляет файлы с истекшим сроком до отмены контекста (блокирующий вызов)
+func (c *FileCleaner) Run(ctx context.Context) {
+	ticker := time.NewTicker(c.config.CleanupInterval)
+	defer ticker.Stop()
+
+	for {
+		c.deleteExpired()
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+func (c *FileCleaner) deleteExpired() {
+	files, err := c.fileRepo.GetExpiredFiles()
+	if err != nil {
+		c.log.Error().Err(err).Msg("Failed to get expired files")
+		return
+	}
+
+	for _, file := range files {
+		if file.StoragePath != "" {
+			if err := c.storage.DeleteFile(file.StoragePath); err != nil {
+				c.log.Error().
+					Err(err).
+					Str("file_id", file.ID.String()).
+					Msg("Failed to delete expired file from storage")
+				continue
+			}
+		}
+
+		// Передачи файла и элементы входящих удаляются каскадно
+		if err := c.fileRepo.Delete(file.ID); err != nil {
+			c.log.Error().
+				Err(err).
+				Str("file_id", file.ID.String()).
+				Msg("Failed to delete expired file")
+			continue
+		}
+
+		c.log.Info().
+			Str("file_id", file.ID.String()).
+			Int64("size", file.Size).
+			Msg("Expired file deleted")
+	}
+}
@@ -0,0 +1,128 @@
+package jobs
+
+import (
+	"context"
+	"time"
+
+	"github.com/backend-app/backend/internal/events"
+	"github.com/backend-app/backend/internal/models"
+	"github.com/backend-app/backend/internal/progress"
+	"github.com/backend-app/backend/internal/repository"
+	"github.com/backend-app/backend/pkg/config"
+	"github.com/backend-app/backend/pkg/logger"
+	"github.com/google/uuid"
+	"github.com/rs/zerolog"
+)
+
+const watchdogBatchSize = 100
+
+// TransferWatchdog завершает зависшие передачи: в процессе без обновлений дольше
+// StalledTimeout - со статусом failed, не принятые за PendingTTL - со статусом
+// expired. Оба устройства передачи получают событие через signaling.
+type TransferWatchdog struct {
+	config       *config.TransferConfig
+	transferRepo *repository.TransferRepo
+	deviceRepo   *repository.DeviceRepo
+	fileRepo     *repository.FileRepo
+	events       *events.Bus
+	progress     *progress.Broker
+	log          zerolog.Logger
+}
+
+// NewTransferWatchdog создает задачу завершения зависших передач
+func NewTransferWatchdog(
+	cfg *config.TransferConfig,
+	transferRepo *repository.TransferRepo,
+	deviceRepo *repository.DeviceRepo,
+	fileRepo *repository.FileRepo,
+	eventBus *events.Bus,
+	progressBroker *progress.Broker,
+) *TransferWatchdog {
+	return &TransferWatchdog{
+		config:       cfg,
+		transferRepo: transferRepo,
+		deviceRepo:   deviceRepo,
+		fileRepo:     fileRepo,
+		events:       eventBus,
+		progress:     progressBroker,
+		log:          logger.Get(),
+	}
+}
+
+// Run периодически завершает зависшие передачи до отмены контекста (блокирующий вызов)
+func (w *TransferWatchdog) Run(ctx context.Context) {
+	ticker := time.NewTicker(w.config.WatchdogInterval)
+	defer ticker.Stop()
+
+	for {
+		w.timeOut(ctx, models.TransferStatusInProgress, models.TransferStatusFailed, models.TransferReasonTimeout, w.config.StalledTimeout)
+		w.timeOut(ctx, models.TransferStatusPending, models.TransferStatusExpired, models.TransferReasonNotAccepted, w.config.PendingTTL)
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// timeOut переводит передачи в статусе from, не обновлявшиеся дольше timeout, в статус to
+func (w *TransferWatchdog) timeOut(ctx context.Context, from, to models.TransferStatus, reason string, timeout time.Duration) {
+	for {
+		transfers, err := w.transferRepo.TimeOutStale(from, to, reason, time.Now().Add(-timeout), watchdogBatchSize)
+		if err != nil {
+			w.log.Error().
+				Err(err).
+				Str("status", string(from)).
+				Msg("Failed to time out stale transfers")
+			return
+		}
+
+		for _, transfer := range transfers {
+			w.log.Info().
+				Str("transfer_id", transfer.ID.String()).
+				Str("from", string(from)).
+				Str("to", string(to)).
+				Msg("Stale transfer timed out")
+
+			w.notify(ctx, transfer)
+		}
+
+		if len(transfers) < watchdogBatchSize || ctx.Err() != nil {
+			return
+		}
+	}
+}
+
+// notify закрывает потоки прогресса передачи и сообщает о ней обоим устройствам
+func (w *TransferWatchdog) notify(ctx context.Context, transfer *models.Transfer) {
+	var totalSize int64
+	if file, err := w.fileRepo.GetByID(transfer.FileID); err == nil && file != nil {
+		totalSize = file.Size
+	}
+
+	w.progress.Publish(ctx, progress.NewUpdate(transfer, totalSize, transfer.BytesTransferred, transfer.UpdatedAt))
+
+	data := &events.TransferTimeout{
+		TransferID:   transfer.ID,
+		FileID:       transfer.FileID,
+		FromDeviceID: transfer.FromDeviceID,
+		ToDeviceID:   transfer.ToDeviceID,
+		Status:       string(transfer.Status),
+		Reason:       transfer.FailureReason,
+	}
+
+	for _, deviceID := range []*uuid.UUID{transfer.FromDeviceID, transfer.ToDeviceID} {
+		if deviceID == nil {
+			continue
+		}
+
+		// Устройства могут принадлежать разным пользователям, событие адресуется владельцу
+		device, err := w.deviceRepo.GetByID(*deviceID)
+		if err != nil || device == nil {
+			continue
+		}
+
+		w.events.PublishToDevice(ctx, events.TypeTransferTimedOut, device.UserID, device.ID, data)
+	}
+}
@@ -0,0 +1,172 @@
+package mailbox
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/redis/go-redis/v9"
+)
+
+const (
+	devicesKey = "signaling:mailbox:devices" // устройства с непустой очередью
+	keyPrefix  = "signaling:mailbox:device:" // очередь сообщений устройства
+)
+
+// ErrFull возвращается, если очередь устройства заполнена
+var ErrFull = errors.New("mailbox is full")
+
+// pushScript добавляет сообщение в конец очереди KEYS[1], если в ней меньше ARGV[2]
+// сообщений, и отмечает устройство ARGV[3] в KEYS[2]. Возвращает 0 для полной очереди.
+var pushScript = redis.NewScript(`
+if redis.call("LLEN", KEYS[1]) >= tonumber(ARGV[2]) then
+	return 0
+end
+redis.call("RPUSH", KEYS[1], ARGV[1])
+redis.call("PEXPIRE", KEYS[1], ARGV[4])
+redis.call("SADD", KEYS[2], ARGV[3])
+return 1
+`)
+
+// drainScript забирает всю очередь KEYS[1] и снимает отметку устройства ARGV[1]
+var drainScript = redis.NewScript(`
+local entries = redis.call("LRANGE", KEYS[1], 0, -1)
+redis.call("DEL", KEYS[1])
+redis.call("SREM", KEYS[2], ARGV[1])
+return entries
+`)
+
+// expireScript забирает из начала очереди KEYS[1] сообщения со сроком не позже
+// ARGV[2] (срок растет от начала к концу) и снимает отметку опустевшей очереди
+var expireScript = redis.NewScript(`
+local expired = {}
+while true do
+	local entry = redis.call("LINDEX", KEYS[1], 0)
+	if not entry then
+		break
+	end
+	if cjson.decode(entry).expires_at > tonumber(ARGV[2]) then
+		break
+	end
+	table.insert(expired, redis.call("LPOP", KEYS[1]))
+end
+if redis.call("LLEN", KEYS[1]) == 0 then
+	redis.call("SREM", KEYS[2], ARGV[1])
+end
+return expired
+`)
+
+// entry - сообщение в очереди со сроком доставки в миллисекундах Unix
+type entry struct {
+	ExpiresAt int64           `json:"expires_at"`
+	Message   json.RawMessage `json:"message"`
+}
+
+// Mailbox - короткоживущие очереди signaling сообщений для устройств, у которых
+// сейчас нет ни одной сессии. Очередь отдается устройству при подключении; сообщения,
+// не доставленные за ttl, возвращаются Expired.
+type Mailbox struct {
+	redis   *redis.Client
+	ttl     time.Duration
+	maxSize int
+}
+
+func New(redisClient *redis.Client, ttl time.Duration, maxSize int) *Mailbox {
+	return &Mailbox{
+		redis:   redisClient,
+		ttl:     ttl,
+		maxSize: maxSize,
+	}
+}
+
+// Push ставит сообщение в очередь устройства. Для заполненной очереди возвращает ErrFull.
+func (m *Mailbox) Push(ctx context.Context, deviceID uuid.UUID, message []byte) error {
+	data, err := json.Marshal(&entry{
+		ExpiresAt: time.Now().Add(m.ttl).UnixMilli(),
+		Message:   message,
+	})
+	if err != nil {
+		return err
+	}
+
+	// Ключ живет дольше сообщений, чтобы Expired успел вернуть просроченные
+	keyTTL := 2 * m.ttl
+
+	pushed, err := pushScript.Run(ctx, m.redis, []string{key(deviceID), devicesKey},
+		data, m.maxSize, deviceID.String(), keyTTL.Milliseconds()).Int()
+	if err != nil {
+		return err
+	}
+
+	if pushed == 0 {
+		return ErrFull
+	}
+
+	return nil
+}
+
+// Drain забирает очередь устройства: сообщения, которые еще можно доставить, в
+// порядке постановки, и просроченные
+func (m *Mailbox) Drain(ctx context.Context, deviceID uuid.UUID) (pending, expired [][]byte, err error) {
+	values, err := drainScript.Run(ctx, m.redis, []string{key(deviceID), devicesKey}, deviceID.String()).StringSlice()
+	if err != nil {
+		return nil, nil, err
+	}
+
+	now := time.Now().UnixMilli()
+	for _, value := range values {
+		e := &entry{}
+		if err := json.Unmarshal([]byte(value), e); err != nil {
+			continue
+		}
+
+		if e.ExpiresAt > now {
+			pending = append(pending, e.Message)
+		} else {
+			expired = append(expired, e.Message)
+		}
+	}
+
+	return pending, expired, nil
+}
+
+// Expired забирает из всех очередей сообщения, срок доставки которых истек. Каждое
+// сообщение возвращается только одному вызывающему, даже на разных узлах.
+func (m *Mailbox) Expired(ctx context.Context) ([][]byte, error) {
+	devices, err := m.redis.SMembers(ctx, devicesKey).Result()
+	if err != nil {
+		return nil, err
+	}
+
+	now := time.Now().UnixMilli()
+
+	var expired [][]byte
+	for _, device := range devices {
+		deviceID, err := uuid.Parse(device)
+		if err != nil {
+			continue
+		}
+
+		values, err := expireScript.Run(ctx, m.redis, []string{key(deviceID), devicesKey}, device, now).StringSlice()
+		if err != nil {
+			return expired, err
+		}
+
+		for _, value := range values {
+			e := &entry{}
+			if err := json.Unmarshal([]byte(value), e); err != nil {
+				continue
+			}
+
+			expired = append(expired, e.Message)
+		}
+	}
+
+	return expired, nil
+}
+
+func key(deviceID uuid.UUID) string {
+	return keyPrefix + deviceID.String()
+}
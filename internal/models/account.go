@@ -0,0 +1,60 @@
+package models
+
+import (
+	"errors"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type DataExportStatus string
+
+const (
+	DataExportStatusPending    DataExportStatus = "pending"
+	DataExportStatusProcessing DataExportStatus = "processing"
+	DataExportStatusCompleted  DataExportStatus = "completed"
+	DataExportStatusFailed     DataExportStatus = "failed"
+)
+
+type DataExport struct {
+	ID          uuid.UUID        `json:"id" db:"id"`
+	UserID      uuid.UUID        `json:"user_id" db:"user_id"`
+	Status      DataExportStatus `json:"status" db:"status"`
+	StoragePath string           `json:"-" db:"storage_path"`
+	Size        int64            `json:"size" db:"size"`
+	Error       string           `json:"error,omitempty" db:"error"`
+	ExpiresAt   *time.Time       `json:"expires_at,omitempty" db:"expires_at"`
+	CreatedAt   time.Time        `json:"created_at" db:"created_at"`
+	UpdatedAt   time.Time        `json:"updated_at" db:"updated_at"`
+}
+
+func (e *DataExport) Validate() error {
+	if e.Status != DataExportStatusPending &&
+		e.Status != DataExportStatusProcessing &&
+		e.Status != DataExportStatusCompleted &&
+		e.Status != DataExportStatusFailed {
+		return errors.New("invalid export status")
+	}
+	return nil
+}
+
+func (e *DataExport) IsExpired() bool {
+	if e.ExpiresAt == nil {
+		return false
+	}
+	return time.Now().After(*e.ExpiresAt)
+}
+
+// AccountDeletionAudit - запись о физическом удалении аккаунта.
+// Хранит только обезличенные данные, поэтому переживает самого пользователя.
+type AccountDeletionAudit struct {
+	ID               uuid.UUID  `json:"id" db:"id"`
+	UserID           uuid.UUID  `json:"user_id" db:"user_id"`
+	EmailHash        string     `json:"email_hash" db:"email_hash"`
+	FilesDeleted     int        `json:"files_deleted" db:"files_deleted"`
+	BytesDeleted     int64      `json:"bytes_deleted" db:"bytes_deleted"`
+	DevicesDeleted   int        `json:"devices_deleted" db:"devices_deleted"`
+	TransfersDeleted int        `json:"transfers_deleted" db:"transfers_deleted"`
+	RequestedAt      *time.Time `json:"requested_at,omitempty" db:"requested_at"`
+	PurgedAt         time.Time  `json:"purged_at" db:"purged_at"`
+}
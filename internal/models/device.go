@@ -12,28 +12,228 @@ type DeviceType string
 const (
 	DeviceTypeDesktop DeviceType = "desktop"
 	DeviceTypeMobile  DeviceType = "mobile"
+	DeviceTypeTablet  DeviceType = "tablet"
+	DeviceTypeWeb     DeviceType = "web"
+	DeviceTypeCLI     DeviceType = "cli"
+	DeviceTypeNAS     DeviceType = "nas"
 )
 
+func (t DeviceType) IsValid() bool {
+	switch t {
+	case DeviceTypeDesktop, DeviceTypeMobile, DeviceTypeTablet, DeviceTypeWeb, DeviceTypeCLI, DeviceTypeNAS:
+		return true
+	}
+	return false
+}
+
+type DevicePlatform string
+
+const (
+	DevicePlatformWindows DevicePlatform = "windows"
+	DevicePlatformMacOS   DevicePlatform = "macos"
+	DevicePlatformLinux   DevicePlatform = "linux"
+	DevicePlatformIOS     DevicePlatform = "ios"
+	DevicePlatformAndroid DevicePlatform = "android"
+	DevicePlatformWeb     DevicePlatform = "web"
+	DevicePlatformOther   DevicePlatform = "other"
+)
+
+func (p DevicePlatform) IsValid() bool {
+	switch p {
+	case DevicePlatformWindows, DevicePlatformMacOS, DevicePlatformLinux, DevicePlatformIOS,
+		DevicePlatformAndroid, DevicePlatformWeb, DevicePlatformOther:
+		return true
+	}
+	return false
+}
+
+// TransferProtocol - способ доставки файла, который поддерживает клиент
+type TransferProtocol string
+
+const (
+	TransferProtocolWebRTC TransferProtocol = "webrtc" // прямое P2P соединение
+	TransferProtocolRelay  TransferProtocol = "relay"  // WebRTC через TURN
+	TransferProtocolCloud  TransferProtocol = "cloud"  // загрузка в облачное хранилище
+)
+
+func (p TransferProtocol) IsValid() bool {
+	switch p {
+	case TransferProtocolWebRTC, TransferProtocolRelay, TransferProtocolCloud:
+		return true
+	}
+	return false
+}
+
+// DefaultTransferProtocols - протоколы в порядке предпочтения. Используются для
+// клиентов, которые не сообщили свой список.
+var DefaultTransferProtocols = []TransferProtocol{
+	TransferProtocolWebRTC,
+	TransferProtocolRelay,
+	TransferProtocolCloud,
+}
+
+// DeviceApprovalStatus - состояние подтверждения нового устройства доверенным устройством
+type DeviceApprovalStatus string
+
+const (
+	DeviceApprovalPending  DeviceApprovalStatus = "pending"
+	DeviceApprovalApproved DeviceApprovalStatus = "approved"
+	DeviceApprovalRejected DeviceApprovalStatus = "rejected"
+)
+
+// PushProvider - сервис доставки push-уведомлений на устройство
+type PushProvider string
+
+const (
+	PushProviderFCM     PushProvider = "fcm"
+	PushProviderAPNs    PushProvider = "apns"
+	PushProviderWebPush PushProvider = "webpush" // токен - JSON подписки PushSubscription
+)
+
+func (p PushProvider) IsValid() bool {
+	switch p {
+	case PushProviderFCM, PushProviderAPNs, PushProviderWebPush:
+		return true
+	}
+	return false
+}
+
+// Device - устройство пользователя. Токен устройства выдается клиенту один раз,
+// в базе хранится только его SHA-256 хеш. После ротации предыдущий токен
+// действует до PreviousTokenExpiresAt. Пока устройство не подтверждено,
+// оно не может подключиться к signaling серверу и участвовать в передачах.
 type Device struct {
-	ID          uuid.UUID  `json:"id" db:"id"`
-	UserID      uuid.UUID  `json:"user_id" db:"user_id"`
-	Name        string     `json:"name" db:"name"`
-	DeviceType  DeviceType `json:"device_type" db:"device_type"`
-	DeviceToken string     `json:"device_token" db:"device_token"`
-	LastSeenAt  time.Time  `json:"last_seen_at" db:"last_seen_at"`
-	CreatedAt   time.Time  `json:"created_at" db:"created_at"`
-	UpdatedAt   time.Time  `json:"updated_at" db:"updated_at"`
+	ID                     uuid.UUID            `json:"id" db:"id"`
+	UserID                 uuid.UUID            `json:"user_id" db:"user_id"`
+	OrganizationID         *uuid.UUID           `json:"organization_id,omitempty" db:"organization_id"`
+	Name                   string               `json:"name" db:"name"`
+	DeviceType             DeviceType           `json:"device_type" db:"device_type"`
+	Platform               DevicePlatform       `json:"platform,omitempty" db:"platform"`
+	OSVersion              string               `json:"os_version,omitempty" db:"os_version"`
+	AppVersion             string               `json:"app_version,omitempty" db:"app_version"`
+	TransferProtocols      []TransferProtocol   `json:"transfer_protocols" db:"transfer_protocols"`
+	MaxFileSize            *int64               `json:"max_file_size,omitempty" db:"max_file_size"` // nil - без ограничения
+	ApprovalStatus         DeviceApprovalStatus `json:"approval_status" db:"approval_status"`
+	ApprovedByDeviceID     *uuid.UUID           `json:"approved_by_device_id,omitempty" db:"approved_by_device_id"`
+	PushProvider           PushProvider         `json:"push_provider,omitempty" db:"push_provider"`
+	PushToken              string               `json:"-" db:"push_token"`
+	PruneExempt            bool                 `json:"prune_exempt" db:"prune_exempt"` // не отключать по неактивности
+	InactivityWarnedAt     *time.Time           `json:"-" db:"inactivity_warned_at"`
+	DeactivatedAt          *time.Time           `json:"deactivated_at,omitempty" db:"deactivated_at"`
+	DeviceTokenHash        string               `json:"-" db:"device_token_hash"`
+	PreviousTokenHash      *string              `json:"-" db:"previous_token_hash"`
+	PreviousTokenExpiresAt *time.Time           `json:"-" db:"previous_token_expires_at"`
+	LastSeenAt             time.Time            `json:"last_seen_at" db:"last_seen_at"`
+	CreatedAt              time.Time            `json:"created_at" db:"created_at"`
+	UpdatedAt              time.Time            `json:"updated_at" db:"updated_at"`
 }
 
 func (d *Device) Validate() error {
 	if d.Name == "" {
 		return errors.New("device name is required")
 	}
-	if d.DeviceType != DeviceTypeDesktop && d.DeviceType != DeviceTypeMobile {
+	if !d.DeviceType.IsValid() {
 		return errors.New("invalid device type")
 	}
-	if d.DeviceToken == "" {
+	if d.Platform != "" && !d.Platform.IsValid() {
+		return errors.New("invalid platform")
+	}
+	if len(d.TransferProtocols) == 0 {
+		return errors.New("at least one transfer protocol is required")
+	}
+	for _, protocol := range d.TransferProtocols {
+		if !protocol.IsValid() {
+			return errors.New("invalid transfer protocol")
+		}
+	}
+	if d.MaxFileSize != nil && *d.MaxFileSize <= 0 {
+		return errors.New("max file size must be positive")
+	}
+	if d.PushProvider != "" && (!d.PushProvider.IsValid() || d.PushToken == "") {
+		return errors.New("invalid push token")
+	}
+	if d.DeviceTokenHash == "" {
 		return errors.New("device token is required")
 	}
 	return nil
 }
+
+// HasPushToken проверяет, что устройство может получать push-уведомления
+func (d *Device) HasPushToken() bool {
+	return d.PushProvider != "" && d.PushToken != ""
+}
+
+// IsActive проверяет, что устройство не отключено из-за неактивности
+func (d *Device) IsActive() bool {
+	return d.DeactivatedAt == nil
+}
+
+func (d *Device) IsApproved() bool {
+	return d.ApprovalStatus == DeviceApprovalApproved
+}
+
+// CanDecideApproval проверяет, что устройство может подтвердить или отклонить target:
+// только подтвержденное устройство того же пользователя и только ожидающее устройство
+func (d *Device) CanDecideApproval(target *Device) error {
+	if d.UserID != target.UserID {
+		return errors.New("device belongs to another user")
+	}
+	if !d.IsApproved() {
+		return errors.New("only approved devices can approve other devices")
+	}
+	if d.ID == target.ID {
+		return errors.New("device cannot approve itself")
+	}
+	if target.ApprovalStatus != DeviceApprovalPending {
+		return errors.New("device is not pending approval")
+	}
+	return nil
+}
+
+func (d *Device) SupportsProtocol(protocol TransferProtocol) bool {
+	for _, p := range d.TransferProtocols {
+		if p == protocol {
+			return true
+		}
+	}
+	return false
+}
+
+// CanReceive проверяет, что устройство готово принять файл такого размера
+func (d *Device) CanReceive(size int64) bool {
+	return d.MaxFileSize == nil || size <= *d.MaxFileSize
+}
+
+// CompatibleProtocols возвращает протоколы, которые поддерживают оба устройства,
+// в порядке предпочтения
+func CompatibleProtocols(from, to *Device) []TransferProtocol {
+	var protocols []TransferProtocol
+	for _, protocol := range DefaultTransferProtocols {
+		if from.SupportsProtocol(protocol) && to.SupportsProtocol(protocol) {
+			protocols = append(protocols, protocol)
+		}
+	}
+	return protocols
+}
+
+// SupportsTransferType проверяет, можно ли передать файл между устройствами выбранным
+// типом передачи. P2P требует общего WebRTC протокола (напрямую или через TURN),
+// облачной передаче достаточно поддержки cloud у получателя. from может быть nil,
+// если файл отправлен не с устройства.
+func SupportsTransferType(transferType TransferType, from, to *Device) bool {
+	switch transferType {
+	case TransferTypeP2P:
+		if from == nil {
+			return false
+		}
+		for _, protocol := range CompatibleProtocols(from, to) {
+			if protocol == TransferProtocolWebRTC || protocol == TransferProtocolRelay {
+				return true
+			}
+		}
+		return false
+	case TransferTypeCloud:
+		return to.SupportsProtocol(TransferProtocolCloud)
+	}
+	return false
+}
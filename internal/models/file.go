@@ -15,16 +15,17 @@ const (
 )
 
 type File struct {
-	ID          uuid.UUID   `json:"id" db:"id"`
-	UserID      uuid.UUID   `json:"user_id" db:"user_id"`
-	Name        string      `json:"name" db:"name"`
-	Size        int64       `json:"size" db:"size"`
-	MimeType    string      `json:"mime_type" db:"mime_type"`
-	StoragePath string      `json:"storage_path" db:"storage_path"`
-	StorageType StorageType `json:"storage_type" db:"storage_type"`
-	ExpiresAt   *time.Time  `json:"expires_at,omitempty" db:"expires_at"`
-	CreatedAt   time.Time   `json:"created_at" db:"created_at"`
-	UpdatedAt   time.Time   `json:"updated_at" db:"updated_at"`
+	ID             uuid.UUID   `json:"id" db:"id"`
+	UserID         uuid.UUID   `json:"user_id" db:"user_id"`
+	OrganizationID *uuid.UUID  `json:"organization_id,omitempty" db:"organization_id"`
+	Name           string      `json:"name" db:"name"`
+	Size           int64       `json:"size" db:"size"`
+	MimeType       string      `json:"mime_type" db:"mime_type"`
+	StoragePath    string      `json:"storage_path" db:"storage_path"`
+	StorageType    StorageType `json:"storage_type" db:"storage_type"`
+	ExpiresAt      *time.Time  `json:"expires_at,omitempty" db:"expires_at"`
+	CreatedAt      time.Time   `json:"created_at" db:"created_at"`
+	UpdatedAt      time.Time   `json:"updated_at" db:"updated_at"`
 }
 
 func (f *File) Validate() error {
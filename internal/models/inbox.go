@@ -0,0 +1,22 @@
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// InboxItem - файл облачной передачи, который ждет устройство-получатель во входящих.
+// FromDeviceID и сведения о файле заполняются из передачи и файла.
+type InboxItem struct {
+	ID             uuid.UUID  `json:"id" db:"id"`
+	DeviceID       uuid.UUID  `json:"device_id" db:"device_id"`
+	TransferID     uuid.UUID  `json:"transfer_id" db:"transfer_id"`
+	FileID         uuid.UUID  `json:"file_id" db:"file_id"`
+	FromDeviceID   *uuid.UUID `json:"from_device_id,omitempty"`
+	FileName       string     `json:"file_name"`
+	FileSize       int64      `json:"file_size"`
+	MimeType       string     `json:"mime_type"`
+	AcknowledgedAt *time.Time `json:"acknowledged_at,omitempty" db:"acknowledged_at"`
+	CreatedAt      time.Time  `json:"created_at" db:"created_at"`
+}
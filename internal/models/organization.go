@@ -0,0 +1,67 @@
+package models
+
+import (
+	"errors"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type OrganizationRole string
+
+const (
+	OrganizationRoleOwner  OrganizationRole = "owner"
+	OrganizationRoleAdmin  OrganizationRole = "admin"
+	OrganizationRoleMember OrganizationRole = "member"
+)
+
+func (r OrganizationRole) IsValid() bool {
+	return r == OrganizationRoleOwner || r == OrganizationRoleAdmin || r == OrganizationRoleMember
+}
+
+// CanManage - может ли роль управлять участниками, квотой и файлами организации
+func (r OrganizationRole) CanManage() bool {
+	return r == OrganizationRoleOwner || r == OrganizationRoleAdmin
+}
+
+type Organization struct {
+	ID                uuid.UUID `json:"id" db:"id"`
+	Name              string    `json:"name" db:"name"`
+	StorageQuotaBytes *int64    `json:"storage_quota_bytes,omitempty" db:"storage_quota_bytes"`
+	CreatedAt         time.Time `json:"created_at" db:"created_at"`
+	UpdatedAt         time.Time `json:"updated_at" db:"updated_at"`
+}
+
+func (o *Organization) Validate() error {
+	if o.Name == "" {
+		return errors.New("organization name is required")
+	}
+	if o.StorageQuotaBytes != nil && *o.StorageQuotaBytes < 0 {
+		return errors.New("storage quota cannot be negative")
+	}
+	return nil
+}
+
+// HasQuotaFor проверяет, помещается ли size байт в квоту при текущем использовании used
+func (o *Organization) HasQuotaFor(used, size int64) bool {
+	if o.StorageQuotaBytes == nil {
+		return true
+	}
+	return used+size <= *o.StorageQuotaBytes
+}
+
+type OrganizationMember struct {
+	OrganizationID uuid.UUID        `json:"organization_id" db:"organization_id"`
+	UserID         uuid.UUID        `json:"user_id" db:"user_id"`
+	Email          string           `json:"email,omitempty" db:"email"`
+	Role           OrganizationRole `json:"role" db:"role"`
+	CreatedAt      time.Time        `json:"created_at" db:"created_at"`
+	UpdatedAt      time.Time        `json:"updated_at" db:"updated_at"`
+}
+
+func (m *OrganizationMember) Validate() error {
+	if !m.Role.IsValid() {
+		return errors.New("invalid organization role")
+	}
+	return nil
+}
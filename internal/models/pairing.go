@@ -0,0 +1,21 @@
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// DevicePairing - ожидающее сопряжение, созданное уже авторизованным устройством.
+// Хранится в Redis до истечения TTL или до погашения кода.
+type DevicePairing struct {
+	Code      string    `json:"code"`
+	UserID    uuid.UUID `json:"user_id"`
+	DeviceID  uuid.UUID `json:"device_id"` // устройство, запросившее код
+	ExpiresAt time.Time `json:"expires_at"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+func (p *DevicePairing) IsExpired() bool {
+	return time.Now().After(p.ExpiresAt)
+}
@@ -2,6 +2,7 @@ package models
 
 import (
 	"errors"
+	"fmt"
 	"time"
 
 	"github.com/google/uuid"
@@ -14,47 +15,210 @@ const (
 	TransferTypeCloud TransferType = "cloud"
 )
 
+// TransferDirection - направление передачи относительно устройства
+type TransferDirection string
+
+const (
+	TransferDirectionIncoming TransferDirection = "incoming"
+	TransferDirectionOutgoing TransferDirection = "outgoing"
+)
+
+func (d TransferDirection) IsValid() bool {
+	return d == TransferDirectionIncoming || d == TransferDirectionOutgoing
+}
+
 type TransferStatus string
 
 const (
 	TransferStatusPending    TransferStatus = "pending"
 	TransferStatusInProgress TransferStatus = "in_progress"
+	TransferStatusPaused     TransferStatus = "paused"
 	TransferStatusCompleted  TransferStatus = "completed"
 	TransferStatusFailed     TransferStatus = "failed"
+	TransferStatusCancelled  TransferStatus = "cancelled"
+	TransferStatusRejected   TransferStatus = "rejected"
+	TransferStatusExpired    TransferStatus = "expired"
 )
 
+// Причины завершения передачи и смены способа доставки, которые выставляет сервер
+const (
+	TransferReasonDeviceDeactivated = "device_deactivated"
+	TransferReasonICEFailed         = "ice_failed"   // клиент сообщил, что ICE соединение не установилось
+	TransferReasonICETimeout        = "ice_timeout"  // ICE соединение не установилось за отведенное время
+	TransferReasonTimeout           = "timeout"      // передача долго не обновлялась
+	TransferReasonNotAccepted       = "not_accepted" // передачу не приняли за отведенное время
+)
+
+// transferTransitions - допустимые переходы между статусами передачи.
+// Из завершающих статусов переходов нет.
+var transferTransitions = map[TransferStatus][]TransferStatus{
+	TransferStatusPending: {
+		TransferStatusInProgress,
+		TransferStatusCancelled,
+		TransferStatusRejected,
+		TransferStatusExpired,
+		TransferStatusFailed,
+	},
+	TransferStatusInProgress: {
+		TransferStatusPaused,
+		TransferStatusCompleted,
+		TransferStatusFailed,
+		TransferStatusCancelled,
+	},
+	TransferStatusPaused: {
+		TransferStatusInProgress,
+		TransferStatusCancelled,
+		TransferStatusExpired,
+		TransferStatusFailed,
+	},
+	TransferStatusCompleted: nil,
+	TransferStatusFailed:    nil,
+	TransferStatusCancelled: nil,
+	TransferStatusRejected:  nil,
+	TransferStatusExpired:   nil,
+}
+
+// ErrInvalidTransition возвращается при недопустимой смене статуса передачи
+var ErrInvalidTransition = errors.New("invalid transfer status transition")
+
+func (s TransferStatus) IsValid() bool {
+	_, ok := transferTransitions[s]
+	return ok
+}
+
+// IsTerminal проверяет, что передача завершена и ее статус больше не меняется
+func (s TransferStatus) IsTerminal() bool {
+	return s.IsValid() && len(transferTransitions[s]) == 0
+}
+
+// CanTransitionTo проверяет, разрешен ли переход в статус next
+func (s TransferStatus) CanTransitionTo(next TransferStatus) bool {
+	for _, allowed := range transferTransitions[s] {
+		if allowed == next {
+			return true
+		}
+	}
+	return false
+}
+
+// hasReason - статусы, для которых сохраняется причина
+func (s TransferStatus) hasReason() bool {
+	return s == TransferStatusFailed ||
+		s == TransferStatusCancelled ||
+		s == TransferStatusRejected ||
+		s == TransferStatusExpired
+}
+
 type Transfer struct {
-	ID           uuid.UUID      `json:"id" db:"id"`
-	FileID       uuid.UUID      `json:"file_id" db:"file_id"`
-	FromDeviceID *uuid.UUID     `json:"from_device_id,omitempty" db:"from_device_id"`
-	ToDeviceID   *uuid.UUID     `json:"to_device_id,omitempty" db:"to_device_id"`
-	TransferType TransferType   `json:"transfer_type" db:"transfer_type"`
-	Status       TransferStatus `json:"status" db:"status"`
-	Progress     int64          `json:"progress" db:"progress"`
-	CreatedAt    time.Time      `json:"created_at" db:"created_at"`
-	UpdatedAt    time.Time      `json:"updated_at" db:"updated_at"`
+	ID               uuid.UUID        `json:"id" db:"id"`
+	FileID           uuid.UUID        `json:"file_id" db:"file_id"`
+	FromDeviceID     *uuid.UUID       `json:"from_device_id,omitempty" db:"from_device_id"`
+	ToDeviceID       *uuid.UUID       `json:"to_device_id,omitempty" db:"to_device_id"`
+	GroupID          *uuid.UUID       `json:"group_id,omitempty" db:"group_id"`
+	TransferType     TransferType     `json:"transfer_type" db:"transfer_type"`
+	DeliveryPath     TransferProtocol `json:"delivery_path" db:"delivery_path"` // у завершенной передачи - способ, которым доставлен файл
+	Status           TransferStatus   `json:"status" db:"status"`
+	Progress         int64            `json:"progress" db:"progress"`
+	BytesTransferred int64            `json:"bytes_transferred" db:"bytes_transferred"`
+	FailureReason    string           `json:"failure_reason,omitempty" db:"failure_reason"`
+	CreatedAt        time.Time        `json:"created_at" db:"created_at"`
+	UpdatedAt        time.Time        `json:"updated_at" db:"updated_at"`
+}
+
+// TransferEvent - запись истории смены статусов передачи
+type TransferEvent struct {
+	ID           uuid.UUID        `json:"id" db:"id"`
+	TransferID   uuid.UUID        `json:"transfer_id" db:"transfer_id"`
+	FromStatus   *TransferStatus  `json:"from_status,omitempty" db:"from_status"` // nil - создание передачи
+	ToStatus     TransferStatus   `json:"to_status" db:"to_status"`
+	Reason       string           `json:"reason,omitempty" db:"reason"`
+	DeliveryPath TransferProtocol `json:"delivery_path,omitempty" db:"delivery_path"` // FromStatus == ToStatus - смена способа доставки
+	CreatedAt    time.Time        `json:"created_at" db:"created_at"`
+}
+
+// DefaultDeliveryPath - способ доставки новой передачи: P2P начинается с прямого
+// WebRTC соединения, облачная передача идет через хранилище
+func DefaultDeliveryPath(transferType TransferType) TransferProtocol {
+	if transferType == TransferTypeCloud {
+		return TransferProtocolCloud
+	}
+	return TransferProtocolWebRTC
+}
+
+// NextDeliveryPath возвращает запасной способ доставки после неудачного current в
+// порядке webrtc -> relay -> cloud, пропуская способы, которые не поддерживают
+// устройства. false - запасных способов нет.
+func NextDeliveryPath(current TransferProtocol, from, to *Device) (TransferProtocol, bool) {
+	passed := false
+	for _, protocol := range DefaultTransferProtocols {
+		if protocol == current {
+			passed = true
+			continue
+		}
+		if !passed {
+			continue
+		}
+
+		switch protocol {
+		case TransferProtocolRelay:
+			if from != nil && from.SupportsProtocol(protocol) && to.SupportsProtocol(protocol) {
+				return protocol, true
+			}
+		case TransferProtocolCloud:
+			// Файл передачи уже в хранилище, получателю достаточно скачать его
+			if to.SupportsProtocol(protocol) {
+				return protocol, true
+			}
+		}
+	}
+
+	return "", false
+}
+
+// IsStoreAndForward проверяет, что передача идет через хранилище и адресована
+// устройству: файл ждет во входящих получателя до подтверждения получения
+func (t *Transfer) IsStoreAndForward() bool {
+	return t.DeliveryPath == TransferProtocolCloud && t.ToDeviceID != nil
+}
+
+// HasDevice проверяет, что устройство - отправитель или получатель передачи
+func (t *Transfer) HasDevice(deviceID uuid.UUID) bool {
+	return (t.FromDeviceID != nil && *t.FromDeviceID == deviceID) ||
+		(t.ToDeviceID != nil && *t.ToDeviceID == deviceID)
 }
 
 func (t *Transfer) Validate() error {
 	if t.TransferType != TransferTypeP2P && t.TransferType != TransferTypeCloud {
 		return errors.New("invalid transfer type")
 	}
-	if t.Status != TransferStatusPending &&
-		t.Status != TransferStatusInProgress &&
-		t.Status != TransferStatusCompleted &&
-		t.Status != TransferStatusFailed {
+	if !t.Status.IsValid() {
 		return errors.New("invalid transfer status")
 	}
+	if !t.DeliveryPath.IsValid() {
+		return errors.New("invalid delivery path")
+	}
 	if t.Progress < 0 {
 		return errors.New("progress cannot be negative")
 	}
+	if t.BytesTransferred < 0 {
+		return errors.New("bytes transferred cannot be negative")
+	}
 	return nil
 }
 
-func (t *Transfer) UpdateProgress(progress int64) {
-	t.Progress = progress
-	t.UpdatedAt = time.Now()
-	if progress >= 100 {
-		t.Status = TransferStatusCompleted
+// TransitionTo переводит передачу в статус next по таблице переходов. reason
+// сохраняется только для failed, cancelled, rejected и expired.
+func (t *Transfer) TransitionTo(next TransferStatus, reason string) error {
+	if !t.Status.CanTransitionTo(next) {
+		return fmt.Errorf("%w: %s -> %s", ErrInvalidTransition, t.Status, next)
 	}
+
+	t.Status = next
+	t.FailureReason = ""
+	if next.hasReason() {
+		t.FailureReason = reason
+	}
+	t.UpdatedAt = time.Now()
+
+	return nil
 }
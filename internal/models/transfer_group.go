@@ -0,0 +1,86 @@
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// TransferGroupStatus - сводный статус передач группы
+type TransferGroupStatus string
+
+const (
+	TransferGroupStatusPending    TransferGroupStatus = "pending"     // ни один получатель не начал прием
+	TransferGroupStatusInProgress TransferGroupStatus = "in_progress" // есть незавершенные передачи
+	TransferGroupStatusCompleted  TransferGroupStatus = "completed"   // файл получили все устройства
+	TransferGroupStatusPartial    TransferGroupStatus = "partial"     // все завершены, но получили не все
+	TransferGroupStatusFailed     TransferGroupStatus = "failed"      // все завершены, никто не получил
+)
+
+// TransferGroup - отправка одного файла нескольким устройствам. Transfers - по
+// одной передаче на получателя.
+type TransferGroup struct {
+	ID           uuid.UUID    `json:"id" db:"id"`
+	UserID       uuid.UUID    `json:"user_id" db:"user_id"`
+	FileID       uuid.UUID    `json:"file_id" db:"file_id"`
+	FromDeviceID *uuid.UUID   `json:"from_device_id,omitempty" db:"from_device_id"`
+	TransferType TransferType `json:"transfer_type" db:"transfer_type"`
+	CreatedAt    time.Time    `json:"created_at" db:"created_at"`
+	Transfers    []*Transfer  `json:"transfers"`
+}
+
+// TransferGroupSummary - сводное состояние группы
+type TransferGroupSummary struct {
+	Status    TransferGroupStatus `json:"status"`
+	Progress  int64               `json:"progress"` // средний прогресс получателей
+	Total     int                 `json:"total"`
+	Completed int                 `json:"completed"`
+	Failed    int                 `json:"failed"` // завершены без получения: failed, cancelled, rejected, expired
+}
+
+// Summary считает сводный статус и прогресс по передачам группы
+func (g *TransferGroup) Summary() TransferGroupSummary {
+	summary := TransferGroupSummary{
+		Total: len(g.Transfers),
+	}
+	if summary.Total == 0 {
+		summary.Status = TransferGroupStatusPending
+		return summary
+	}
+
+	var progress int64
+	pending := 0
+	for _, transfer := range g.Transfers {
+		switch {
+		case transfer.Status == TransferStatusCompleted:
+			summary.Completed++
+			progress += 100
+		case transfer.Status.IsTerminal():
+			summary.Failed++
+			progress += transfer.Progress
+		default:
+			if transfer.Status == TransferStatusPending {
+				pending++
+			}
+			progress += transfer.Progress
+		}
+	}
+	summary.Progress = progress / int64(summary.Total)
+
+	switch {
+	case summary.Completed == summary.Total:
+		summary.Status = TransferGroupStatusCompleted
+	case summary.Completed+summary.Failed < summary.Total:
+		if pending == summary.Total {
+			summary.Status = TransferGroupStatusPending
+		} else {
+			summary.Status = TransferGroupStatusInProgress
+		}
+	case summary.Completed > 0:
+		summary.Status = TransferGroupStatusPartial
+	default:
+		summary.Status = TransferGroupStatusFailed
+	}
+
+	return summary
+}
@@ -0,0 +1,126 @@
+package presence
+
+import (
+	"context"
+	"encoding/json"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/redis/go-redis/v9"
+)
+
+type Status string
+
+const (
+	StatusOnline  Status = "online"
+	StatusAway    Status = "away"
+	StatusOffline Status = "offline"
+)
+
+const keyPrefix = "presence:device:"
+
+// Presence - текущее состояние подключения устройства к signaling серверу
+type Presence struct {
+	DeviceID       uuid.UUID  `json:"device_id"`
+	Status         Status     `json:"status"`
+	ConnectedSince *time.Time `json:"connected_since,omitempty"`
+	UpdatedAt      time.Time  `json:"updated_at"`
+}
+
+// Store хранит присутствие устройств в Redis. Записи живут ttl и продлеваются хабом,
+// поэтому устройства упавшего узла автоматически становятся offline.
+type Store struct {
+	redis *redis.Client
+	ttl   time.Duration
+}
+
+func NewStore(redisClient *redis.Client, ttl time.Duration) *Store {
+	return &Store{
+		redis: redisClient,
+		ttl:   ttl,
+	}
+}
+
+// Set сохраняет состояние устройства и продлевает TTL записи
+func (s *Store) Set(ctx context.Context, p *Presence) error {
+	p.UpdatedAt = time.Now()
+
+	data, err := json.Marshal(p)
+	if err != nil {
+		return err
+	}
+
+	return s.redis.Set(ctx, keyPrefix+p.DeviceID.String(), data, s.ttl).Err()
+}
+
+// Refresh продлевает TTL записи без изменения состояния
+func (s *Store) Refresh(ctx context.Context, deviceID uuid.UUID) error {
+	return s.redis.Expire(ctx, keyPrefix+deviceID.String(), s.ttl).Err()
+}
+
+// SetOffline удаляет запись: отсутствие записи означает offline
+func (s *Store) SetOffline(ctx context.Context, deviceID uuid.UUID) error {
+	return s.redis.Del(ctx, keyPrefix+deviceID.String()).Err()
+}
+
+// Get возвращает состояние устройства. Для неподключенного устройства возвращается offline.
+func (s *Store) Get(ctx context.Context, deviceID uuid.UUID) (*Presence, error) {
+	data, err := s.redis.Get(ctx, keyPrefix+deviceID.String()).Bytes()
+	if err == redis.Nil {
+		return offline(deviceID), nil
+	}
+
+	if err != nil {
+		return nil, err
+	}
+
+	p := &Presence{}
+	if err := json.Unmarshal(data, p); err != nil {
+		return nil, err
+	}
+
+	return p, nil
+}
+
+// GetMany возвращает состояния нескольких устройств одним запросом
+func (s *Store) GetMany(ctx context.Context, deviceIDs []uuid.UUID) (map[uuid.UUID]*Presence, error) {
+	result := make(map[uuid.UUID]*Presence, len(deviceIDs))
+	if len(deviceIDs) == 0 {
+		return result, nil
+	}
+
+	keys := make([]string, len(deviceIDs))
+	for i, id := range deviceIDs {
+		keys[i] = keyPrefix + id.String()
+	}
+
+	values, err := s.redis.MGet(ctx, keys...).Result()
+	if err != nil {
+		return nil, err
+	}
+
+	for i, id := range deviceIDs {
+		str, ok := values[i].(string)
+		if !ok {
+			result[id] = offline(id)
+			continue
+		}
+
+		p := &Presence{}
+		if err := json.Unmarshal([]byte(str), p); err != nil {
+			result[id] = offline(id)
+			continue
+		}
+
+		result[id] = p
+	}
+
+	return result, nil
+}
+
+func offline(deviceID uuid.UUID) *Presence {
+	return &Presence{
+		DeviceID: deviceID,
+		Status:   StatusOffline,
+	}
+}
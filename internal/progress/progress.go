@@ -0,0 +1,133 @@
+package progress
+
+import (
+	"context"
+	"encoding/json"
+	"time"
+
+	"github.com/backend-app/backend/internal/models"
+	"github.com/google/uuid"
+	"github.com/redis/go-redis/v9"
+)
+
+// channelPrefix - Redis канал обновлений одной передачи
+const channelPrefix = "transfer:progress:"
+
+// Update - состояние передачи после очередного обновления статуса или прогресса
+type Update struct {
+	TransferID       uuid.UUID             `json:"transfer_id"`
+	Status           models.TransferStatus `json:"status"`
+	Progress         int64                 `json:"progress"`
+	BytesTransferred int64                 `json:"bytes_transferred"`
+	TotalSize        int64                 `json:"total_size"`
+	Rate             float64               `json:"rate"`        // байт в секунду, 0 - неизвестно
+	ETASeconds       int64                 `json:"eta_seconds"` // 0 - неизвестно
+	FailureReason    string                `json:"failure_reason,omitempty"`
+	UpdatedAt        time.Time             `json:"updated_at"`
+}
+
+// NewUpdate строит обновление передачи. Скорость считается по приросту байтов
+// с предыдущего обновления (previousBytes в момент previousAt) и известна только
+// для передачи в процессе.
+func NewUpdate(transfer *models.Transfer, totalSize, previousBytes int64, previousAt time.Time) *Update {
+	update := &Update{
+		TransferID:       transfer.ID,
+		Status:           transfer.Status,
+		Progress:         transfer.Progress,
+		BytesTransferred: transfer.BytesTransferred,
+		TotalSize:        totalSize,
+		FailureReason:    transfer.FailureReason,
+		UpdatedAt:        transfer.UpdatedAt,
+	}
+
+	if transfer.Status != models.TransferStatusInProgress {
+		return update
+	}
+
+	elapsed := transfer.UpdatedAt.Sub(previousAt).Seconds()
+	delta := transfer.BytesTransferred - previousBytes
+	if elapsed <= 0 || delta <= 0 {
+		return update
+	}
+
+	update.Rate = float64(delta) / elapsed
+	if remaining := totalSize - transfer.BytesTransferred; remaining > 0 {
+		update.ETASeconds = int64(float64(remaining)/update.Rate + 0.5)
+	}
+
+	return update
+}
+
+// Broker рассылает обновления передач подписчикам через Redis pub/sub, поэтому
+// поток прогресса работает на любом экземпляре сервера
+type Broker struct {
+	redis *redis.Client
+}
+
+func NewBroker(redisClient *redis.Client) *Broker {
+	return &Broker{redis: redisClient}
+}
+
+// Publish отправляет обновление подписчикам передачи
+func (b *Broker) Publish(ctx context.Context, update *Update) error {
+	message, err := json.Marshal(update)
+	if err != nil {
+		return err
+	}
+
+	return b.redis.Publish(ctx, channelPrefix+update.TransferID.String(), message).Err()
+}
+
+// Subscription - подписка на обновления одной передачи
+type Subscription struct {
+	pubsub  *redis.PubSub
+	updates chan *Update
+	done    chan struct{}
+}
+
+// Subscribe подписывается на обновления передачи. Подписка подтверждена к моменту
+// возврата, поэтому снимок, прочитанный после Subscribe, не пропустит обновлений.
+func (b *Broker) Subscribe(ctx context.Context, transferID uuid.UUID) (*Subscription, error) {
+	pubsub := b.redis.Subscribe(ctx, channelPrefix+transferID.String())
+
+	if _, err := pubsub.Receive(ctx); err != nil {
+		pubsub.Close()
+		return nil, err
+	}
+
+	sub := &Subscription{
+		pubsub:  pubsub,
+		updates: make(chan *Update),
+		done:    make(chan struct{}),
+	}
+	go sub.run()
+
+	return sub, nil
+}
+
+func (s *Subscription) run() {
+	defer close(s.updates)
+
+	for msg := range s.pubsub.Channel() {
+		update := &Update{}
+		if err := json.Unmarshal([]byte(msg.Payload), update); err != nil {
+			continue
+		}
+
+		select {
+		case s.updates <- update:
+		case <-s.done:
+			return
+		}
+	}
+}
+
+// Updates возвращает канал обновлений. Канал закрывается после Close.
+func (s *Subscription) Updates() <-chan *Update {
+	return s.updates
+}
+
+func (s *Subscription) Close() error {
+	close(s.done)
+	return s.pubsub.Close()
+}
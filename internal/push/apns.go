@@ -0,0 +1,162 @@
+package push
+
+import (
+	"bytes"
+	"context"
+	"crypto/ecdsa"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"os"
+	"sync"
+	"time"
+
+	"github.com/backend-app/backend/pkg/config"
+	"github.com/golang-jwt/jwt/v5"
+)
+
+const (
+	apnsProductionHost = "https://api.push.apple.com"
+	apnsSandboxHost    = "https://api.sandbox.push.apple.com"
+
+	// Apple требует обновлять provider token не реже раза в час и не чаще раза в 20 минут
+	apnsTokenLifetime = 50 * time.Minute
+)
+
+// APNsNotifier отправляет уведомления через APNs HTTP/2 API с token-based
+// аутентификацией (JWT ES256, подписанный ключом .p8)
+type APNsNotifier struct {
+	client *http.Client
+	host   string
+	topic  string
+	keyID  string
+	teamID string
+	key    *ecdsa.PrivateKey
+
+	mu       sync.Mutex
+	token    string
+	issuedAt time.Time
+}
+
+func NewAPNsNotifier(cfg *config.PushConfig, client *http.Client) (*APNsNotifier, error) {
+	data, err := os.ReadFile(cfg.APNsKeyFile)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read APNs key: %w", err)
+	}
+
+	key, err := jwt.ParseECPrivateKeyFromPEM(data)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse APNs key: %w", err)
+	}
+
+	if cfg.APNsKeyID == "" || cfg.APNsTeamID == "" || cfg.APNsTopic == "" {
+		return nil, errors.New("APNS_KEY_ID, APNS_TEAM_ID and APNS_TOPIC are required")
+	}
+
+	host := apnsProductionHost
+	if cfg.APNsSandbox {
+		host = apnsSandboxHost
+	}
+
+	return &APNsNotifier{
+		client: client,
+		host:   host,
+		topic:  cfg.APNsTopic,
+		keyID:  cfg.APNsKeyID,
+		teamID: cfg.APNsTeamID,
+		key:    key,
+	}, nil
+}
+
+func (n *APNsNotifier) Send(ctx context.Context, token string, notification *Notification) error {
+	providerToken, err := n.getProviderToken()
+	if err != nil {
+		return err
+	}
+
+	// Без заголовка уведомление отправляется как фоновое (content-available)
+	aps := map[string]interface{}{"content-available": 1}
+	pushType, priority := "background", "5"
+	if notification.Title != "" {
+		aps = map[string]interface{}{
+			"alert": map[string]string{
+				"title": notification.Title,
+				"body":  notification.Body,
+			},
+			"sound": "default",
+		}
+		pushType, priority = "alert", "10"
+	}
+
+	payload := map[string]interface{}{
+		"aps":  aps,
+		"type": notification.Type,
+	}
+	for k, v := range notification.Data {
+		payload[k] = v
+	}
+
+	body, err := json.Marshal(payload)
+	if err != nil {
+		return err
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, n.host+"/3/device/"+token, bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Authorization", "bearer "+providerToken)
+	req.Header.Set("apns-topic", n.topic)
+	req.Header.Set("apns-push-type", pushType)
+	req.Header.Set("apns-priority", priority)
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := n.client.Do(req)
+	if err != nil {
+		return fmt.Errorf("failed to send APNs notification: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode == http.StatusOK {
+		return nil
+	}
+
+	var apnsErr struct {
+		Reason string `json:"reason"`
+	}
+	json.NewDecoder(resp.Body).Decode(&apnsErr)
+
+	if resp.StatusCode == http.StatusGone || apnsErr.Reason == "BadDeviceToken" || apnsErr.Reason == "Unregistered" {
+		return ErrInvalidToken
+	}
+
+	return fmt.Errorf("APNs returned status %d: %s", resp.StatusCode, apnsErr.Reason)
+}
+
+// getProviderToken возвращает закешированный provider token или подписывает новый
+func (n *APNsNotifier) getProviderToken() (string, error) {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+
+	if n.token != "" && time.Since(n.issuedAt) < apnsTokenLifetime {
+		return n.token, nil
+	}
+
+	now := time.Now()
+	token := jwt.NewWithClaims(jwt.SigningMethodES256, jwt.MapClaims{
+		"iss": n.teamID,
+		"iat": now.Unix(),
+	})
+	token.Header["kid"] = n.keyID
+
+	signed, err := token.SignedString(n.key)
+	if err != nil {
+		return "", fmt.Errorf("failed to sign APNs provider token: %w", err)
+	}
+
+	n.token = signed
+	n.issuedAt = now
+
+	return n.token, nil
+}
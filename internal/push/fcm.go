@@ -0,0 +1,183 @@
+package push
+
+import (
+	"bytes"
+	"context"
+	"crypto/rsa"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"os"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+)
+
+const fcmScope = "https://www.googleapis.com/auth/firebase.messaging"
+
+// fcmCredentials - поля JSON ключа сервисного аккаунта Firebase
+type fcmCredentials struct {
+	ProjectID   string `json:"project_id"`
+	ClientEmail string `json:"client_email"`
+	PrivateKey  string `json:"private_key"`
+	TokenURI    string `json:"token_uri"`
+}
+
+// FCMNotifier отправляет уведомления через FCM HTTP v1 API. Access token
+// сервисного аккаунта получается по OAuth2 JWT bearer и кешируется до истечения.
+type FCMNotifier struct {
+	client      *http.Client
+	projectID   string
+	clientEmail string
+	tokenURI    string
+	key         *rsa.PrivateKey
+
+	mu          sync.Mutex
+	accessToken string
+	expiresAt   time.Time
+}
+
+func NewFCMNotifier(credentialsFile string, client *http.Client) (*FCMNotifier, error) {
+	data, err := os.ReadFile(credentialsFile)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read FCM credentials: %w", err)
+	}
+
+	var creds fcmCredentials
+	if err := json.Unmarshal(data, &creds); err != nil {
+		return nil, fmt.Errorf("failed to parse FCM credentials: %w", err)
+	}
+
+	key, err := jwt.ParseRSAPrivateKeyFromPEM([]byte(creds.PrivateKey))
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse FCM private key: %w", err)
+	}
+
+	if creds.TokenURI == "" {
+		creds.TokenURI = "https://oauth2.googleapis.com/token"
+	}
+
+	return &FCMNotifier{
+		client:      client,
+		projectID:   creds.ProjectID,
+		clientEmail: creds.ClientEmail,
+		tokenURI:    creds.TokenURI,
+		key:         key,
+	}, nil
+}
+
+func (n *FCMNotifier) Send(ctx context.Context, token string, notification *Notification) error {
+	accessToken, err := n.getAccessToken(ctx)
+	if err != nil {
+		return err
+	}
+
+	// Тип уведомления передается в data, чтобы приложение могло обработать его в фоне
+	data := map[string]string{"type": notification.Type}
+	for k, v := range notification.Data {
+		data[k] = v
+	}
+
+	message := map[string]interface{}{
+		"token": token,
+		"data":  data,
+		"android": map[string]interface{}{
+			"priority": "high",
+		},
+	}
+	if notification.Title != "" {
+		message["notification"] = map[string]string{
+			"title": notification.Title,
+			"body":  notification.Body,
+		}
+	}
+
+	body, err := json.Marshal(map[string]interface{}{"message": message})
+	if err != nil {
+		return err
+	}
+
+	endpoint := fmt.Sprintf("https://fcm.googleapis.com/v1/projects/%s/messages:send", n.projectID)
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, endpoint, bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Authorization", "Bearer "+accessToken)
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := n.client.Do(req)
+	if err != nil {
+		return fmt.Errorf("failed to send FCM message: %w", err)
+	}
+	defer resp.Body.Close()
+
+	switch {
+	case resp.StatusCode == http.StatusOK:
+		return nil
+	case resp.StatusCode == http.StatusNotFound:
+		// UNREGISTERED: приложение удалено или токен обновлен
+		return ErrInvalidToken
+	default:
+		return fmt.Errorf("FCM returned status %d", resp.StatusCode)
+	}
+}
+
+// getAccessToken возвращает закешированный access token или получает новый
+func (n *FCMNotifier) getAccessToken(ctx context.Context) (string, error) {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+
+	if n.accessToken != "" && time.Now().Before(n.expiresAt) {
+		return n.accessToken, nil
+	}
+
+	now := time.Now()
+	assertion, err := jwt.NewWithClaims(jwt.SigningMethodRS256, jwt.MapClaims{
+		"iss":   n.clientEmail,
+		"scope": fcmScope,
+		"aud":   n.tokenURI,
+		"iat":   now.Unix(),
+		"exp":   now.Add(time.Hour).Unix(),
+	}).SignedString(n.key)
+	if err != nil {
+		return "", fmt.Errorf("failed to sign FCM assertion: %w", err)
+	}
+
+	form := url.Values{
+		"grant_type": {"urn:ietf:params:oauth:grant-type:jwt-bearer"},
+		"assertion":  {assertion},
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, n.tokenURI, strings.NewReader(form.Encode()))
+	if err != nil {
+		return "", err
+	}
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+
+	resp, err := n.client.Do(req)
+	if err != nil {
+		return "", fmt.Errorf("failed to get FCM access token: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return "", fmt.Errorf("FCM token endpoint returned status %d", resp.StatusCode)
+	}
+
+	var tokenResp struct {
+		AccessToken string `json:"access_token"`
+		ExpiresIn   int    `json:"expires_in"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&tokenResp); err != nil {
+		return "", fmt.Errorf("failed to decode FCM access token: %w", err)
+	}
+
+	n.accessToken = tokenResp.AccessToken
+	// Обновляем токен заранее, чтобы он не истек во время запроса
+	n.expiresAt = now.Add(time.Duration(tokenResp.ExpiresIn)*time.Second - time.Minute)
+
+	return n.accessToken, nil
+}
@@ -0,0 +1,56 @@
+package push
+
+import (
+	"context"
+	"sync"
+
+	"github.com/backend-app/backend/pkg/logger"
+	"github.com/rs/zerolog"
+)
+
+// SentNotification - уведомление, записанное LogNotifier
+type SentNotification struct {
+	Token        string
+	Notification *Notification
+}
+
+// LogNotifier только логирует уведомления и запоминает их.
+// Используется в разработке и тестах вместо реальных провайдеров.
+type LogNotifier struct {
+	log  zerolog.Logger
+	mu   sync.Mutex
+	sent []SentNotification
+}
+
+func NewLogNotifier() *LogNotifier {
+	return &LogNotifier{
+		log: logger.Get(),
+	}
+}
+
+func (n *LogNotifier) Send(ctx context.Context, token string, notification *Notification) error {
+	n.mu.Lock()
+	n.sent = append(n.sent, SentNotification{
+		Token:        token,
+		Notification: notification,
+	})
+	n.mu.Unlock()
+
+	n.log.Info().
+		Str("type", notification.Type).
+		Str("title", notification.Title).
+		Interface("data", notification.Data).
+		Msg("Push notification (not sent: provider is not configured)")
+
+	return nil
+}
+
+// Sent возвращает копию отправленных уведомлений
+func (n *LogNotifier) Sent() []SentNotification {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+
+	sent := make([]SentNotification, len(n.sent))
+	copy(sent, n.sent)
+	return sent
+}
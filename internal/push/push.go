@@ -0,0 +1,135 @@
+package push
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"time"
+
+	"github.com/backend-app/backend/internal/models"
+	"github.com/backend-app/backend/internal/repository"
+	"github.com/backend-app/backend/pkg/config"
+	"github.com/backend-app/backend/pkg/logger"
+	"github.com/rs/zerolog"
+)
+
+const (
+	TypeIncomingOffer           = "incoming-offer"
+	TypeIncomingTransfer        = "incoming-transfer"
+	TypeDeviceInactivityWarning = "device-inactivity-warning"
+)
+
+// ErrInvalidToken возвращается провайдером, если токен устройства больше не действителен
+var ErrInvalidToken = errors.New("push token is no longer valid")
+
+// Notification - уведомление, которое будит приложение на устройстве
+type Notification struct {
+	Type  string
+	Title string
+	Body  string
+	Data  map[string]string
+}
+
+// Notifier отправляет уведомление на токен конкретного провайдера
+type Notifier interface {
+	Send(ctx context.Context, token string, notification *Notification) error
+}
+
+// Service выбирает провайдера по устройству и отключает недействительные токены
+type Service struct {
+	notifiers  map[models.PushProvider]Notifier
+	deviceRepo *repository.DeviceRepo
+	timeout    time.Duration
+	log        zerolog.Logger
+}
+
+func NewService(notifiers map[models.PushProvider]Notifier, deviceRepo *repository.DeviceRepo, timeout time.Duration) *Service {
+	return &Service{
+		notifiers:  notifiers,
+		deviceRepo: deviceRepo,
+		timeout:    timeout,
+		log:        logger.Get(),
+	}
+}
+
+// New создает сервис с провайдерами из конфигурации. Провайдеры без настроек
+// заменяются LogNotifier, чтобы уведомления были видны в логах при разработке.
+func New(cfg *config.PushConfig, deviceRepo *repository.DeviceRepo) (*Service, error) {
+	client := &http.Client{Timeout: cfg.Timeout}
+	fallback := NewLogNotifier()
+
+	notifiers := map[models.PushProvider]Notifier{
+		models.PushProviderFCM:     fallback,
+		models.PushProviderAPNs:    fallback,
+		models.PushProviderWebPush: fallback,
+	}
+
+	if cfg.FCMCredentialsFile != "" {
+		fcm, err := NewFCMNotifier(cfg.FCMCredentialsFile, client)
+		if err != nil {
+			return nil, err
+		}
+		notifiers[models.PushProviderFCM] = fcm
+	}
+
+	if cfg.APNsKeyFile != "" {
+		apns, err := NewAPNsNotifier(cfg, client)
+		if err != nil {
+			return nil, err
+		}
+		notifiers[models.PushProviderAPNs] = apns
+	}
+
+	if cfg.WebPushVAPIDPrivateKey != "" {
+		webPush, err := NewWebPushNotifier(cfg.WebPushVAPIDPublicKey, cfg.WebPushVAPIDPrivateKey, cfg.WebPushSubject, newWebPushClient(cfg.Timeout))
+		if err != nil {
+			return nil, err
+		}
+		notifiers[models.PushProviderWebPush] = webPush
+	}
+
+	return NewService(notifiers, deviceRepo, cfg.Timeout), nil
+}
+
+// Notify отправляет уведомление на устройство. Устройства без токена пропускаются,
+// недействительный токен удаляется.
+func (s *Service) Notify(ctx context.Context, device *models.Device, notification *Notification) error {
+	if !device.HasPushToken() {
+		return nil
+	}
+
+	notifier, ok := s.notifiers[device.PushProvider]
+	if !ok {
+		return errors.New("unsupported push provider")
+	}
+
+	err := notifier.Send(ctx, device.PushToken, notification)
+	if errors.Is(err, ErrInvalidToken) {
+		if clearErr := s.deviceRepo.SetPushToken(device, "", ""); clearErr != nil {
+			s.log.Error().Err(clearErr).Str("device_id", device.ID.String()).Msg("Failed to clear invalid push token")
+		}
+	}
+
+	return err
+}
+
+// NotifyAsync отправляет уведомление в фоне, не задерживая вызывающий код
+func (s *Service) NotifyAsync(device *models.Device, notification *Notification) {
+	if !device.HasPushToken() {
+		return
+	}
+
+	go func() {
+		ctx, cancel := context.WithTimeout(context.Background(), s.timeout)
+		defer cancel()
+
+		if err := s.Notify(ctx, device, notification); err != nil {
+			s.log.Warn().
+				Err(err).
+				Str("device_id", device.ID.String()).
+				Str("provider", string(device.PushProvider)).
+				Str("type", notification.Type).
+				Msg("Failed to send push notification")
+		}
+	}()
+}
@@ -0,0 +1,206 @@
+package push
+
+import (
+	"bytes"
+	"context"
+	"crypto/ecdh"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math/big"
+	"net"
+	"net/http"
+	"net/netip"
+	"net/url"
+	"strings"
+	"syscall"
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+)
+
+// webPushTTL - сколько push-сервис хранит уведомление для неподключенного браузера
+const webPushTTL = 10 * time.Minute
+
+// webPushHosts - push-сервисы браузеров, на которые разрешено отправлять уведомления.
+// Endpoint подписки задает клиент, поэтому произвольный адрес превратил бы сервер в
+// прокси для запросов во внутреннюю сеть.
+var webPushHosts = []string{
+	"fcm.googleapis.com",                // Chrome, Edge на Android, Opera
+	"updates.push.services.mozilla.com", // Firefox
+	"web.push.apple.com",                // Safari
+	".notify.windows.com",               // Edge (wns2-*.notify.windows.com)
+}
+
+// webPushSubscription - PushSubscription браузера, сохраненная как push_token
+type webPushSubscription struct {
+	Endpoint string `json:"endpoint"`
+}
+
+// ValidateWebPushToken проверяет, что токен - подписка с HTTPS endpoint известного
+// push-сервиса браузера
+func ValidateWebPushToken(token string) error {
+	_, err := parseWebPushEndpoint(token)
+	return err
+}
+
+func parseWebPushEndpoint(token string) (*url.URL, error) {
+	var subscription webPushSubscription
+	if err := json.Unmarshal([]byte(token), &subscription); err != nil || subscription.Endpoint == "" {
+		return nil, errors.New("invalid web push subscription")
+	}
+
+	endpoint, err := url.Parse(subscription.Endpoint)
+	if err != nil || endpoint.Scheme != "https" || endpoint.User != nil {
+		return nil, errors.New("web push endpoint must be an https URL")
+	}
+	if port := endpoint.Port(); port != "" && port != "443" {
+		return nil, errors.New("web push endpoint must use the default https port")
+	}
+
+	host := strings.ToLower(endpoint.Hostname())
+	for _, allowed := range webPushHosts {
+		if host == allowed || (strings.HasPrefix(allowed, ".") && strings.HasSuffix(host, allowed)) {
+			return endpoint, nil
+		}
+	}
+
+	return nil, fmt.Errorf("web push endpoint host %q is not a known push service", host)
+}
+
+// newWebPushClient создает HTTP клиент, который соединяется только с публичными
+// адресами: имя push-сервиса может разрешиться во внутренний адрес, поэтому адрес
+// проверяется при каждом соединении. Редиректы не выполняются, прокси не используется.
+func newWebPushClient(timeout time.Duration) *http.Client {
+	dialer := &net.Dialer{
+		Timeout: 10 * time.Second,
+		Control: func(network, address string, _ syscall.RawConn) error {
+			host, _, err := net.SplitHostPort(address)
+			if err != nil {
+				return err
+			}
+
+			addr, err := netip.ParseAddr(host)
+			if err != nil || !isPublicAddr(addr) {
+				return fmt.Errorf("web push endpoint resolves to non-public address %s", host)
+			}
+			return nil
+		},
+	}
+
+	return &http.Client{
+		Timeout: timeout,
+		Transport: &http.Transport{
+			DialContext:         dialer.DialContext,
+			ForceAttemptHTTP2:   true,
+			TLSHandshakeTimeout: 10 * time.Second,
+			IdleConnTimeout:     90 * time.Second,
+		},
+		CheckRedirect: func(*http.Request, []*http.Request) error {
+			return http.ErrUseLastResponse
+		},
+	}
+}
+
+// cgnatPrefix - общее адресное пространство провайдеров (RFC 6598)
+var cgnatPrefix = netip.MustParsePrefix("100.64.0.0/10")
+
+func isPublicAddr(addr netip.Addr) bool {
+	addr = addr.Unmap()
+	return addr.IsGlobalUnicast() && !addr.IsPrivate() && !cgnatPrefix.Contains(addr)
+}
+
+// WebPushNotifier отправляет Web Push с VAPID аутентификацией (RFC 8292).
+// Уведомления отправляются без payload: service worker просыпается по событию
+// push и сам запрашивает входящие передачи через API, поэтому шифрование
+// содержимого (RFC 8291) не требуется.
+type WebPushNotifier struct {
+	client    *http.Client
+	key       *ecdsa.PrivateKey
+	publicKey string
+	subject   string
+}
+
+func NewWebPushNotifier(publicKey, privateKey, subject string, client *http.Client) (*WebPushNotifier, error) {
+	if subject == "" {
+		return nil, errors.New("WEBPUSH_SUBJECT is required")
+	}
+
+	raw, err := base64.RawURLEncoding.DecodeString(privateKey)
+	if err != nil {
+		return nil, fmt.Errorf("failed to decode VAPID private key: %w", err)
+	}
+
+	ecdhKey, err := ecdh.P256().NewPrivateKey(raw)
+	if err != nil {
+		return nil, fmt.Errorf("invalid VAPID private key: %w", err)
+	}
+
+	// Несжатая точка: 0x04 || X || Y
+	point := ecdhKey.PublicKey().Bytes()
+	derivedPublicKey := base64.RawURLEncoding.EncodeToString(point)
+	if publicKey != "" && publicKey != derivedPublicKey {
+		return nil, errors.New("VAPID public key does not match private key")
+	}
+
+	key := &ecdsa.PrivateKey{
+		PublicKey: ecdsa.PublicKey{
+			Curve: elliptic.P256(),
+			X:     new(big.Int).SetBytes(point[1:33]),
+			Y:     new(big.Int).SetBytes(point[33:65]),
+		},
+		D: new(big.Int).SetBytes(raw),
+	}
+
+	return &WebPushNotifier{
+		client:    client,
+		key:       key,
+		publicKey: derivedPublicKey,
+		subject:   subject,
+	}, nil
+}
+
+func (n *WebPushNotifier) Send(ctx context.Context, token string, notification *Notification) error {
+	// Токены, сохраненные до проверки endpoint, тоже не должны уходить по чужим адресам
+	endpoint, err := parseWebPushEndpoint(token)
+	if err != nil {
+		return ErrInvalidToken
+	}
+
+	vapidToken, err := jwt.NewWithClaims(jwt.SigningMethodES256, jwt.MapClaims{
+		"aud": endpoint.Scheme + "://" + endpoint.Host,
+		"exp": time.Now().Add(12 * time.Hour).Unix(),
+		"sub": n.subject,
+	}).SignedString(n.key)
+	if err != nil {
+		return fmt.Errorf("failed to sign VAPID token: %w", err)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, endpoint.String(), bytes.NewReader(nil))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Authorization", fmt.Sprintf("vapid t=%s, k=%s", vapidToken, n.publicKey))
+	req.Header.Set("TTL", fmt.Sprintf("%d", int(webPushTTL.Seconds())))
+	req.Header.Set("Urgency", "high")
+	req.Header.Set("Topic", notification.Type)
+
+	resp, err := n.client.Do(req)
+	if err != nil {
+		return fmt.Errorf("failed to send web push: %w", err)
+	}
+	defer resp.Body.Close()
+
+	switch {
+	case resp.StatusCode >= 200 && resp.StatusCode < 300:
+		return nil
+	case resp.StatusCode == http.StatusNotFound || resp.StatusCode == http.StatusGone:
+		// Подписка истекла или отозвана браузером
+		return ErrInvalidToken
+	default:
+		return fmt.Errorf("web push service returned status %d", resp.StatusCode)
+	}
+}
@@ -0,0 +1,60 @@
+package push
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+func TestValidateWebPushToken(t *testing.T) {
+	tests := []struct {
+		name     string
+		endpoint string
+		valid    bool
+	}{
+		{"chrome", "https://fcm.googleapis.com/fcm/send/abc", true},
+		{"firefox", "https://updates.push.services.mozilla.com/wpush/v2/abc", true},
+		{"safari", "https://web.push.apple.com/abc", true},
+		{"edge", "https://wns2-par02p.notify.windows.com/w/?token=abc", true},
+		{"plain http", "http://fcm.googleapis.com/fcm/send/abc", false},
+		{"unknown host", "https://example.com/push", false},
+		{"suffix lookalike", "https://evilnotify.windows.com/push", false},
+		{"loopback", "https://127.0.0.1/push", false},
+		{"metadata", "https://169.254.169.254/latest/meta-data", false},
+		{"custom port", "https://fcm.googleapis.com:8443/fcm/send/abc", false},
+		{"userinfo", "https://user@fcm.googleapis.com/fcm/send/abc", false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := ValidateWebPushToken(`{"endpoint":"` + tt.endpoint + `"}`)
+			if (err == nil) != tt.valid {
+				t.Errorf("ValidateWebPushToken(%q) error = %v, want valid %v", tt.endpoint, err, tt.valid)
+			}
+		})
+	}
+
+	if err := ValidateWebPushToken("not json"); err == nil {
+		t.Error("ValidateWebPushToken accepted a token that is not a subscription")
+	}
+}
+
+func TestWebPushClientRefusesPrivateAddresses(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		t.Error("request reached a loopback server")
+	}))
+	defer server.Close()
+
+	req, err := http.NewRequestWithContext(context.Background(), http.MethodPost, server.URL, nil)
+	if err != nil {
+		t.Fatalf("new request: %v", err)
+	}
+
+	resp, err := newWebPushClient(time.Second).Do(req)
+	if err == nil {
+		resp.Body.Close()
+		t.Fatal("web push client connected to a loopback address")
+	}
+}
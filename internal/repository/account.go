@@ -0,0 +1,340 @@
+package repository
+
+import (
+	"database/sql"
+	"errors"
+	"time"
+
+	"github.com/backend-app/backend/internal/models"
+	"github.com/google/uuid"
+)
+
+// ErrSoleOrganizationOwner возвращается, если удаляемый пользователь - единственный
+// владелец организации: сначала нужно передать владение
+var ErrSoleOrganizationOwner = errors.New("user is the sole owner of an organization")
+
+type AccountRepo struct {
+	db *sql.DB
+}
+
+func NewAccountRepo(db *sql.DB) *AccountRepo {
+	return &AccountRepo{db: db}
+}
+
+func (r *AccountRepo) CreateExport(export *models.DataExport) error {
+	query := `
+		INSERT INTO data_exports (id, user_id, status, storage_path, size, error, expires_at, created_at, updated_at)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9)
+	`
+
+	export.ID = uuid.New()
+	now := time.Now()
+	export.CreatedAt = now
+	export.UpdatedAt = now
+
+	_, err := r.db.Exec(query,
+		export.ID,
+		export.UserID,
+		export.Status,
+		export.StoragePath,
+		export.Size,
+		export.Error,
+		export.ExpiresAt,
+		export.CreatedAt,
+		export.UpdatedAt,
+	)
+
+	return err
+}
+
+func (r *AccountRepo) GetExportByID(id uuid.UUID) (*models.DataExport, error) {
+	query := `
+		SELECT id, user_id, status, storage_path, size, error, expires_at, created_at, updated_at
+		FROM data_exports
+		WHERE id = $1
+	`
+
+	export := &models.DataExport{}
+	var storagePath, exportError sql.NullString
+	var expiresAt sql.NullTime
+
+	err := r.db.QueryRow(query, id).Scan(
+		&export.ID,
+		&export.UserID,
+		&export.Status,
+		&storagePath,
+		&export.Size,
+		&exportError,
+		&expiresAt,
+		&export.CreatedAt,
+		&export.UpdatedAt,
+	)
+
+	if err == sql.ErrNoRows {
+		return nil, nil
+	}
+
+	if err != nil {
+		return nil, err
+	}
+
+	export.StoragePath = storagePath.String
+	export.Error = exportError.String
+	if expiresAt.Valid {
+		export.ExpiresAt = &expiresAt.Time
+	}
+
+	return export, nil
+}
+
+// ClaimPendingExport атомарно переводит самый старый pending экспорт в processing.
+// Возвращает nil, если ожидающих экспортов нет.
+func (r *AccountRepo) ClaimPendingExport() (*models.DataExport, error) {
+	query := `
+		UPDATE data_exports
+		SET status = $1, updated_at = $2
+		WHERE id = (
+			SELECT id FROM data_exports
+			WHERE status = $3
+			ORDER BY created_at ASC
+			LIMIT 1
+			FOR UPDATE SKIP LOCKED
+		)
+		RETURNING id, user_id, status, storage_path, size, error, expires_at, created_at, updated_at
+	`
+
+	export := &models.DataExport{}
+	var storagePath, exportError sql.NullString
+	var expiresAt sql.NullTime
+
+	err := r.db.QueryRow(query,
+		models.DataExportStatusProcessing,
+		time.Now(),
+		models.DataExportStatusPending,
+	).Scan(
+		&export.ID,
+		&export.UserID,
+		&export.Status,
+		&storagePath,
+		&export.Size,
+		&exportError,
+		&expiresAt,
+		&export.CreatedAt,
+		&export.UpdatedAt,
+	)
+
+	if err == sql.ErrNoRows {
+		return nil, nil
+	}
+
+	if err != nil {
+		return nil, err
+	}
+
+	export.StoragePath = storagePath.String
+	export.Error = exportError.String
+	if expiresAt.Valid {
+		export.ExpiresAt = &expiresAt.Time
+	}
+
+	return export, nil
+}
+
+func (r *AccountRepo) UpdateExport(export *models.DataExport) error {
+	query := `
+		UPDATE data_exports
+		SET status = $1, storage_path = $2, size = $3, error = $4, expires_at = $5, updated_at = $6
+		WHERE id = $7
+	`
+
+	now := time.Now()
+	export.UpdatedAt = now
+
+	res, err := r.db.Exec(query,
+		export.Status,
+		export.StoragePath,
+		export.Size,
+		export.Error,
+		export.ExpiresAt,
+		export.UpdatedAt,
+		export.ID,
+	)
+
+	if err != nil {
+		return err
+	}
+
+	affected, err := res.RowsAffected()
+	if err != nil {
+		return err
+	}
+
+	if affected == 0 {
+		return sql.ErrNoRows
+	}
+
+	return nil
+}
+
+func (r *AccountRepo) GetExpiredExports() ([]*models.DataExport, error) {
+	query := `
+		SELECT id, user_id, status, storage_path, size, error, expires_at, created_at, updated_at
+		FROM data_exports
+		WHERE expires_at IS NOT NULL AND expires_at < NOW()
+		ORDER BY expires_at ASC
+	`
+
+	var exports []*models.DataExport
+
+	rows, err := r.db.Query(query)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		export := &models.DataExport{}
+		var storagePath, exportError sql.NullString
+		var expiresAt sql.NullTime
+
+		err := rows.Scan(
+			&export.ID,
+			&export.UserID,
+			&export.Status,
+			&storagePath,
+			&export.Size,
+			&exportError,
+			&expiresAt,
+			&export.CreatedAt,
+			&export.UpdatedAt,
+		)
+		if err != nil {
+			return nil, err
+		}
+
+		export.StoragePath = storagePath.String
+		export.Error = exportError.String
+		if expiresAt.Valid {
+			export.ExpiresAt = &expiresAt.Time
+		}
+
+		exports = append(exports, export)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return exports, nil
+}
+
+func (r *AccountRepo) DeleteExport(id uuid.UUID) error {
+	query := `
+		DELETE FROM data_exports
+		WHERE id = $1
+	`
+
+	res, err := r.db.Exec(query, id)
+	if err != nil {
+		return err
+	}
+
+	affected, err := res.RowsAffected()
+	if err != nil {
+		return err
+	}
+
+	if affected == 0 {
+		return sql.ErrNoRows
+	}
+
+	return nil
+}
+
+// PurgeUser удаляет пользователя, если его удаление все еще запланировано не позже now
+// (устройства, личные файлы и передачи удаляются каскадно), и в той же транзакции
+// записывает запись аудита. Строка пользователя блокируется до конца транзакции, поэтому
+// purgeData удаляет данные вне БД, пока отмена удаления ждет. Если удаление отменено
+// или пользователь уже удален, purgeData не вызывается и возвращается sql.ErrNoRows.
+// Если пользователь - единственный владелец организации, возвращается
+// ErrSoleOrganizationOwner. Загруженные им файлы организаций переходят к другому
+// владельцу организации и не удаляются. Возвращает записанную запись аудита.
+func (r *AccountRepo) PurgeUser(userID uuid.UUID, now time.Time, purgeData func() (*models.AccountDeletionAudit, error)) (*models.AccountDeletionAudit, error) {
+	tx, err := r.db.Begin()
+	if err != nil {
+		return nil, err
+	}
+	defer tx.Rollback()
+
+	var id uuid.UUID
+	err = tx.QueryRow(`
+		SELECT id
+		FROM users
+		WHERE id = $1 AND deletion_scheduled_at IS NOT NULL AND deletion_scheduled_at <= $2
+		FOR UPDATE
+	`, userID, now).Scan(&id)
+	if err != nil {
+		return nil, err
+	}
+
+	var soleOwned bool
+	err = tx.QueryRow(`SELECT EXISTS (`+soleOwnedOrganizationsQuery+`)`, userID, models.OrganizationRoleOwner).Scan(&soleOwned)
+	if err != nil {
+		return nil, err
+	}
+	if soleOwned {
+		return nil, ErrSoleOrganizationOwner
+	}
+
+	// Файлы организаций принадлежат организации: каскад по files.user_id их бы удалил
+	_, err = tx.Exec(`
+		UPDATE files f
+		SET user_id = (
+			SELECT m.user_id
+			FROM organization_members m
+			WHERE m.organization_id = f.organization_id AND m.role = $2 AND m.user_id <> $1
+			ORDER BY m.created_at ASC
+			LIMIT 1
+		), updated_at = $3
+		WHERE f.user_id = $1 AND f.organization_id IS NOT NULL
+	`, userID, models.OrganizationRoleOwner, time.Now())
+	if err != nil {
+		return nil, err
+	}
+
+	audit, err := purgeData()
+	if err != nil {
+		return nil, err
+	}
+
+	if _, err := tx.Exec(`DELETE FROM users WHERE id = $1`, userID); err != nil {
+		return nil, err
+	}
+
+	audit.ID = uuid.New()
+	audit.PurgedAt = time.Now()
+
+	_, err = tx.Exec(`
+		INSERT INTO account_deletion_audit (id, user_id, email_hash, files_deleted, bytes_deleted, devices_deleted, transfers_deleted, requested_at, purged_at)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9)
+	`,
+		audit.ID,
+		audit.UserID,
+		audit.EmailHash,
+		audit.FilesDeleted,
+		audit.BytesDeleted,
+		audit.DevicesDeleted,
+		audit.TransfersDeleted,
+		audit.RequestedAt,
+		audit.PurgedAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return nil, err
+	}
+
+	return audit, nil
+}
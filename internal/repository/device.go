@@ -6,8 +6,11 @@ import (
 
 	"github.com/backend-app/backend/internal/models"
 	"github.com/google/uuid"
+	"github.com/lib/pq"
 )
 
+const deviceColumns = `id, user_id, organization_id, name, device_type, platform, os_version, app_version, transfer_protocols, max_file_size, approval_status, approved_by_device_id, push_provider, push_token, prune_exempt, inactivity_warned_at, deactivated_at, device_token_hash, previous_token_hash, previous_token_expires_at, last_seen_at, created_at, updated_at`
+
 type DeviceRepo struct {
 	db *sql.DB
 }
@@ -16,10 +19,116 @@ func NewDeviceRepo(db *sql.DB) *DeviceRepo {
 	return &DeviceRepo{db: db}
 }
 
+// rowScanner - общий интерфейс *sql.Row и *sql.Rows
+type rowScanner interface {
+	Scan(dest ...interface{}) error
+}
+
+func scanDevice(row rowScanner) (*models.Device, error) {
+	device := &models.Device{}
+	var organizationID uuid.NullUUID
+	var transferProtocols pq.StringArray
+	var maxFileSize sql.NullInt64
+	var approvedByDeviceID uuid.NullUUID
+	var inactivityWarnedAt sql.NullTime
+	var deactivatedAt sql.NullTime
+	var previousTokenHash sql.NullString
+	var previousTokenExpiresAt sql.NullTime
+
+	err := row.Scan(
+		&device.ID,
+		&device.UserID,
+		&organizationID,
+		&device.Name,
+		&device.DeviceType,
+		&device.Platform,
+		&device.OSVersion,
+		&device.AppVersion,
+		&transferProtocols,
+		&maxFileSize,
+		&device.ApprovalStatus,
+		&approvedByDeviceID,
+		&device.PushProvider,
+		&device.PushToken,
+		&device.PruneExempt,
+		&inactivityWarnedAt,
+		&deactivatedAt,
+		&device.DeviceTokenHash,
+		&previousTokenHash,
+		&previousTokenExpiresAt,
+		&device.LastSeenAt,
+		&device.CreatedAt,
+		&device.UpdatedAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	if organizationID.Valid {
+		device.OrganizationID = &organizationID.UUID
+	}
+
+	device.TransferProtocols = make([]models.TransferProtocol, len(transferProtocols))
+	for i, protocol := range transferProtocols {
+		device.TransferProtocols[i] = models.TransferProtocol(protocol)
+	}
+
+	if maxFileSize.Valid {
+		device.MaxFileSize = &maxFileSize.Int64
+	}
+
+	if approvedByDeviceID.Valid {
+		device.ApprovedByDeviceID = &approvedByDeviceID.UUID
+	}
+
+	if inactivityWarnedAt.Valid {
+		device.InactivityWarnedAt = &inactivityWarnedAt.Time
+	}
+
+	if deactivatedAt.Valid {
+		device.DeactivatedAt = &deactivatedAt.Time
+	}
+
+	if previousTokenHash.Valid {
+		device.PreviousTokenHash = &previousTokenHash.String
+	}
+
+	if previousTokenExpiresAt.Valid {
+		device.PreviousTokenExpiresAt = &previousTokenExpiresAt.Time
+	}
+
+	return device, nil
+}
+
+func (r *DeviceRepo) queryDevices(query string, args ...interface{}) ([]*models.Device, error) {
+	var devices []*models.Device
+
+	rows, err := r.db.Query(query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		device, err := scanDevice(rows)
+		if err != nil {
+			return nil, err
+		}
+
+		devices = append(devices, device)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return devices, nil
+}
+
 func (r *DeviceRepo) Create(device *models.Device) error {
 	query := `
-		INSERT INTO devices (id, user_id, name, device_type, device_token, last_seen_at, created_at, updated_at)
-		VALUES ($1, $2, $3, $4, $5, $6, $7, $8)
+		INSERT INTO devices (id, user_id, organization_id, name, device_type, platform, os_version, app_version, transfer_protocols, max_file_size, approval_status, approved_by_device_id, device_token_hash, last_seen_at, created_at, updated_at)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14, $15, $16)
 	`
 
 	device.ID = uuid.New()
@@ -31,9 +140,17 @@ func (r *DeviceRepo) Create(device *models.Device) error {
 	_, err := r.db.Exec(query,
 		device.ID,
 		device.UserID,
+		device.OrganizationID,
 		device.Name,
 		device.DeviceType,
-		device.DeviceToken,
+		device.Platform,
+		device.OSVersion,
+		device.AppVersion,
+		transferProtocolsArray(device.TransferProtocols),
+		device.MaxFileSize,
+		device.ApprovalStatus,
+		device.ApprovedByDeviceID,
+		device.DeviceTokenHash,
 		device.LastSeenAt,
 		device.CreatedAt,
 		device.UpdatedAt,
@@ -44,22 +161,12 @@ func (r *DeviceRepo) Create(device *models.Device) error {
 
 func (r *DeviceRepo) GetByID(id uuid.UUID) (*models.Device, error) {
 	query := `
-		SELECT id, user_id, name, device_type, device_token, last_seen_at, created_at, updated_at
+		SELECT ` + deviceColumns + `
 		FROM devices
 		WHERE id = $1
 	`
 
-	device := &models.Device{}
-	err := r.db.QueryRow(query, id).Scan(
-		&device.ID,
-		&device.UserID,
-		&device.Name,
-		&device.DeviceType,
-		&device.DeviceToken,
-		&device.LastSeenAt,
-		&device.CreatedAt,
-		&device.UpdatedAt,
-	)
+	device, err := scanDevice(r.db.QueryRow(query, id))
 
 	if err == sql.ErrNoRows {
 		return nil, nil
@@ -72,24 +179,19 @@ func (r *DeviceRepo) GetByID(id uuid.UUID) (*models.Device, error) {
 	return device, nil
 }
 
-func (r *DeviceRepo) GetByToken(token string) (*models.Device, error) {
+// GetByTokenHash ищет активное устройство по хешу текущего токена или предыдущего токена,
+// если его период действия после ротации еще не истек
+func (r *DeviceRepo) GetByTokenHash(tokenHash string) (*models.Device, error) {
 	query := `
-		SELECT id, user_id, name, device_type, device_token, last_seen_at, created_at, updated_at
+		SELECT ` + deviceColumns + `
 		FROM devices
-		WHERE device_token = $1
+		WHERE deactivated_at IS NULL
+		  AND (device_token_hash = $1
+		   OR (previous_token_hash = $1 AND previous_token_expires_at > NOW()))
+		LIMIT 1
 	`
 
-	device := &models.Device{}
-	err := r.db.QueryRow(query, token).Scan(
-		&device.ID,
-		&device.UserID,
-		&device.Name,
-		&device.DeviceType,
-		&device.DeviceToken,
-		&device.LastSeenAt,
-		&device.CreatedAt,
-		&device.UpdatedAt,
-	)
+	device, err := scanDevice(r.db.QueryRow(query, tokenHash))
 
 	if err == sql.ErrNoRows {
 		return nil, nil
@@ -104,61 +206,352 @@ func (r *DeviceRepo) GetByToken(token string) (*models.Device, error) {
 
 func (r *DeviceRepo) GetByUserID(userID uuid.UUID) ([]*models.Device, error) {
 	query := `
-		SELECT id, user_id, name, device_type, device_token, last_seen_at, created_at, updated_at
+		SELECT ` + deviceColumns + `
 		FROM devices
 		WHERE user_id = $1
 	`
 
-	var devices []*models.Device
+	return r.queryDevices(query, userID)
+}
+
+func (r *DeviceRepo) GetByOrganizationID(organizationID uuid.UUID) ([]*models.Device, error) {
+	query := `
+		SELECT ` + deviceColumns + `
+		FROM devices
+		WHERE organization_id = $1
+		ORDER BY created_at ASC
+	`
+
+	return r.queryDevices(query, organizationID)
+}
+
+func (r *DeviceRepo) Update(device *models.Device) error {
+	query := `
+		UPDATE devices
+		SET organization_id = $1, name = $2, device_type = $3, last_seen_at = $4, updated_at = $5
+		WHERE id = $6
+	`
+
+	now := time.Now()
+	device.UpdatedAt = now
+
+	res, err := r.db.Exec(query,
+		device.OrganizationID,
+		device.Name,
+		device.DeviceType,
+		device.LastSeenAt,
+		device.UpdatedAt,
+		device.ID,
+	)
 
-	rows, err := r.db.Query(query, userID)
 	if err != nil {
-		return nil, err
+		return err
 	}
-	defer rows.Close()
 
-	for rows.Next() {
-		device := &models.Device{}
-
-		err := rows.Scan(
-			&device.ID,
-			&device.UserID,
-			&device.Name,
-			&device.DeviceType,
-			&device.DeviceToken,
-			&device.LastSeenAt,
-			&device.CreatedAt,
-			&device.UpdatedAt,
+	affected, err := res.RowsAffected()
+	if err != nil {
+		return err
+	}
+
+	if affected == 0 {
+		return sql.ErrNoRows
+	}
+
+	return nil
+}
+
+// SetPushToken сохраняет токен push-уведомлений устройства. Пустые значения отключают уведомления.
+func (r *DeviceRepo) SetPushToken(device *models.Device, provider models.PushProvider, token string) error {
+	query := `
+		UPDATE devices
+		SET push_provider = $1, push_token = $2, updated_at = $3
+		WHERE id = $4
+	`
+
+	now := time.Now()
+
+	res, err := r.db.Exec(query,
+		provider,
+		token,
+		now,
+		device.ID,
+	)
+
+	if err != nil {
+		return err
+	}
+
+	affected, err := res.RowsAffected()
+	if err != nil {
+		return err
+	}
+
+	if affected == 0 {
+		return sql.ErrNoRows
+	}
+
+	device.PushProvider = provider
+	device.PushToken = token
+	device.UpdatedAt = now
+
+	return nil
+}
+
+// inactiveDevicesCondition отбирает активные устройства без исключения, у владельцев
+// которых включено отключение по неактивности. $1 - порог по умолчанию в днях,
+// $2 - текущее время, $3 - период предупреждения в секундах.
+const inactiveDevicesCondition = `
+	d.deactivated_at IS NULL
+	AND NOT d.prune_exempt
+	AND COALESCE(u.device_inactivity_days, $1) > 0
+`
+
+// GetDueForInactivityWarning возвращает устройства, которые будут отключены
+// через период предупреждения и еще не получали предупреждение
+func (r *DeviceRepo) GetDueForInactivityWarning(defaultDays int, now time.Time, warningPeriod time.Duration, limit int) ([]*models.Device, error) {
+	query := `
+		SELECT ` + deviceColumns + `
+		FROM devices
+		WHERE id IN (
+			SELECT d.id
+			FROM devices d
+			JOIN users u ON u.id = d.user_id
+			WHERE ` + inactiveDevicesCondition + `
+			  AND d.inactivity_warned_at IS NULL
+			  AND d.last_seen_at + make_interval(days => COALESCE(u.device_inactivity_days, $1)) - make_interval(secs => $3) <= $2
 		)
-		if err != nil {
-			return nil, err
-		}
+		ORDER BY last_seen_at ASC
+		LIMIT $4
+	`
 
-		devices = append(devices, device)
+	return r.queryDevices(query, defaultDays, now, warningPeriod.Seconds(), limit)
+}
+
+// GetDueForDeactivation возвращает предупрежденные устройства, у которых истекли
+// и порог неактивности, и период предупреждения
+func (r *DeviceRepo) GetDueForDeactivation(defaultDays int, now time.Time, warningPeriod time.Duration, limit int) ([]*models.Device, error) {
+	query := `
+		SELECT ` + deviceColumns + `
+		FROM devices
+		WHERE id IN (
+			SELECT d.id
+			FROM devices d
+			JOIN users u ON u.id = d.user_id
+			WHERE ` + inactiveDevicesCondition + `
+			  AND d.inactivity_warned_at IS NOT NULL
+			  AND d.inactivity_warned_at + make_interval(secs => $3) <= $2
+			  AND d.last_seen_at + make_interval(days => COALESCE(u.device_inactivity_days, $1)) <= $2
+		)
+		ORDER BY last_seen_at ASC
+		LIMIT $4
+	`
+
+	return r.queryDevices(query, defaultDays, now, warningPeriod.Seconds(), limit)
+}
+
+func (r *DeviceRepo) MarkInactivityWarned(device *models.Device, warnedAt time.Time) error {
+	query := `
+		UPDATE devices
+		SET inactivity_warned_at = $1
+		WHERE id = $2
+	`
+
+	res, err := r.db.Exec(query, warnedAt, device.ID)
+	if err != nil {
+		return err
 	}
 
-	if err := rows.Err(); err != nil {
-		return nil, err
+	affected, err := res.RowsAffected()
+	if err != nil {
+		return err
 	}
 
-	return devices, nil
+	if affected == 0 {
+		return sql.ErrNoRows
+	}
+
+	device.InactivityWarnedAt = &warnedAt
+
+	return nil
 }
 
-func (r *DeviceRepo) Update(device *models.Device) error {
+// Deactivate отключает устройство: заменяет хеш токена значением revokedTokenHash,
+// от которого ни у кого нет токена, удаляет push-токен и в той же транзакции
+// переводит незавершенные передачи устройства в failed. Возвращает число таких передач.
+func (r *DeviceRepo) Deactivate(device *models.Device, revokedTokenHash string) (int64, error) {
+	tx, err := r.db.Begin()
+	if err != nil {
+		return 0, err
+	}
+	defer tx.Rollback()
+
+	now := time.Now()
+
+	res, err := tx.Exec(`
+		UPDATE devices
+		SET deactivated_at = $1, device_token_hash = $2, previous_token_hash = NULL,
+		    previous_token_expires_at = NULL, push_provider = '', push_token = '', updated_at = $1
+		WHERE id = $3 AND deactivated_at IS NULL
+	`, now, revokedTokenHash, device.ID)
+	if err != nil {
+		return 0, err
+	}
+
+	affected, err := res.RowsAffected()
+	if err != nil {
+		return 0, err
+	}
+
+	if affected == 0 {
+		return 0, sql.ErrNoRows
+	}
+
+	// Переход в failed записывается в историю каждой передачи
+	res, err = tx.Exec(`
+		WITH target AS (
+			SELECT id, status
+			FROM transfers
+			WHERE (from_device_id = $3 OR to_device_id = $3) AND status IN ($4, $5, $6)
+			FOR UPDATE
+		), failed AS (
+			UPDATE transfers t
+			SET status = $1, failure_reason = $7, updated_at = $2
+			FROM target
+			WHERE t.id = target.id
+			RETURNING t.id, target.status AS from_status, t.delivery_path
+		)
+		INSERT INTO transfer_events (transfer_id, from_status, to_status, reason, delivery_path, created_at)
+		SELECT id, from_status, $1, $7, delivery_path, $2
+		FROM failed
+	`, models.TransferStatusFailed, now, device.ID,
+		models.TransferStatusPending, models.TransferStatusInProgress, models.TransferStatusPaused,
+		models.TransferReasonDeviceDeactivated)
+	if err != nil {
+		return 0, err
+	}
+
+	failedTransfers, err := res.RowsAffected()
+	if err != nil {
+		return 0, err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return 0, err
+	}
+
+	device.DeactivatedAt = &now
+	device.DeviceTokenHash = revokedTokenHash
+	device.PreviousTokenHash = nil
+	device.PreviousTokenExpiresAt = nil
+	device.PushProvider = ""
+	device.PushToken = ""
+	device.UpdatedAt = now
+
+	return failedTransfers, nil
+}
+
+// SetPruneExempt включает или снимает исключение устройства из отключения по неактивности
+func (r *DeviceRepo) SetPruneExempt(device *models.Device, exempt bool) error {
 	query := `
 		UPDATE devices
-		SET name = $1, device_type = $2, device_token = $3, last_seen_at = $4, updated_at = $5
-		WHERE id = $6
+		SET prune_exempt = $1, updated_at = $2
+		WHERE id = $3
 	`
 
 	now := time.Now()
+
+	res, err := r.db.Exec(query, exempt, now, device.ID)
+	if err != nil {
+		return err
+	}
+
+	affected, err := res.RowsAffected()
+	if err != nil {
+		return err
+	}
+
+	if affected == 0 {
+		return sql.ErrNoRows
+	}
+
+	device.PruneExempt = exempt
 	device.UpdatedAt = now
 
+	return nil
+}
+
+// HasApprovedDevices проверяет, есть ли у пользователя подтвержденные устройства
+func (r *DeviceRepo) HasApprovedDevices(userID uuid.UUID) (bool, error) {
+	query := `
+		SELECT EXISTS (
+			SELECT 1 FROM devices
+			WHERE user_id = $1 AND approval_status = $2 AND deactivated_at IS NULL
+		)
+	`
+
+	var exists bool
+	err := r.db.QueryRow(query, userID, models.DeviceApprovalApproved).Scan(&exists)
+
+	return exists, err
+}
+
+// DecideApproval переводит ожидающее устройство в approved или rejected.
+// Возвращает sql.ErrNoRows, если устройство уже не ожидает подтверждения.
+func (r *DeviceRepo) DecideApproval(device *models.Device, approvalStatus models.DeviceApprovalStatus, approverID uuid.UUID) error {
+	query := `
+		UPDATE devices
+		SET approval_status = $1, approved_by_device_id = $2, updated_at = $3
+		WHERE id = $4 AND approval_status = $5
+	`
+
+	now := time.Now()
+
 	res, err := r.db.Exec(query,
-		device.Name,
-		device.DeviceType,
-		device.DeviceToken,
-		device.LastSeenAt,
+		approvalStatus,
+		approverID,
+		now,
+		device.ID,
+		models.DeviceApprovalPending,
+	)
+
+	if err != nil {
+		return err
+	}
+
+	affected, err := res.RowsAffected()
+	if err != nil {
+		return err
+	}
+
+	if affected == 0 {
+		return sql.ErrNoRows
+	}
+
+	device.ApprovalStatus = approvalStatus
+	device.ApprovedByDeviceID = &approverID
+	device.UpdatedAt = now
+
+	return nil
+}
+
+// UpdateClientInfo сохраняет платформу, версии и возможности, которые клиент
+// сообщает при каждом подключении к signaling серверу
+func (r *DeviceRepo) UpdateClientInfo(device *models.Device) error {
+	query := `
+		UPDATE devices
+		SET platform = $1, os_version = $2, app_version = $3, transfer_protocols = $4, max_file_size = $5, updated_at = $6
+		WHERE id = $7
+	`
+
+	now := time.Now()
+	device.UpdatedAt = now
+
+	res, err := r.db.Exec(query,
+		device.Platform,
+		device.OSVersion,
+		device.AppVersion,
+		transferProtocolsArray(device.TransferProtocols),
+		device.MaxFileSize,
 		device.UpdatedAt,
 		device.ID,
 	)
@@ -179,10 +572,52 @@ func (r *DeviceRepo) Update(device *models.Device) error {
 	return nil
 }
 
+// RotateToken заменяет хеш токена устройства. Текущий токен остается действительным
+// до previousExpiresAt, более старый предыдущий токен перестает действовать сразу.
+// Для деактивированного устройства возвращает sql.ErrNoRows.
+func (r *DeviceRepo) RotateToken(device *models.Device, newTokenHash string, previousExpiresAt time.Time) error {
+	query := `
+		UPDATE devices
+		SET previous_token_hash = device_token_hash, previous_token_expires_at = $1, device_token_hash = $2, updated_at = $3
+		WHERE id = $4 AND deactivated_at IS NULL
+	`
+
+	now := time.Now()
+
+	res, err := r.db.Exec(query,
+		previousExpiresAt,
+		newTokenHash,
+		now,
+		device.ID,
+	)
+
+	if err != nil {
+		return err
+	}
+
+	affected, err := res.RowsAffected()
+	if err != nil {
+		return err
+	}
+
+	if affected == 0 {
+		return sql.ErrNoRows
+	}
+
+	previousTokenHash := device.DeviceTokenHash
+	device.PreviousTokenHash = &previousTokenHash
+	device.PreviousTokenExpiresAt = &previousExpiresAt
+	device.DeviceTokenHash = newTokenHash
+	device.UpdatedAt = now
+
+	return nil
+}
+
+// UpdateLastSeen обновляет время активности и сбрасывает предупреждение о неактивности
 func (r *DeviceRepo) UpdateLastSeen(id uuid.UUID) error {
 	query := `
 		UPDATE devices
-		SET last_seen_at = $1, updated_at = $2
+		SET last_seen_at = $1, inactivity_warned_at = NULL, updated_at = $2
 		WHERE id = $3
 	`
 
@@ -232,3 +667,11 @@ func (r *DeviceRepo) Delete(id uuid.UUID) error {
 
 	return nil
 }
+
+func transferProtocolsArray(protocols []models.TransferProtocol) pq.StringArray {
+	array := make(pq.StringArray, len(protocols))
+	for i, protocol := range protocols {
+		array[i] = string(protocol)
+	}
+	return array
+}
@@ -8,6 +8,8 @@ import (
 	"github.com/google/uuid"
 )
 
+const fileColumns = `id, user_id, organization_id, name, size, mime_type, storage_path, storage_type, expires_at, created_at, updated_at`
+
 type FileRepo struct {
 	db *sql.DB
 }
@@ -16,10 +18,68 @@ func NewFileRepo(db *sql.DB) *FileRepo {
 	return &FileRepo{db: db}
 }
 
+func scanFile(row rowScanner) (*models.File, error) {
+	file := &models.File{}
+	var organizationID uuid.NullUUID
+	var expiresAt sql.NullTime
+
+	err := row.Scan(
+		&file.ID,
+		&file.UserID,
+		&organizationID,
+		&file.Name,
+		&file.Size,
+		&file.MimeType,
+		&file.StoragePath,
+		&file.StorageType,
+		&expiresAt,
+		&file.CreatedAt,
+		&file.UpdatedAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	if organizationID.Valid {
+		file.OrganizationID = &organizationID.UUID
+	}
+
+	if expiresAt.Valid {
+		file.ExpiresAt = &expiresAt.Time
+	}
+
+	return file, nil
+}
+
+func (r *FileRepo) queryFiles(query string, args ...interface{}) ([]*models.File, error) {
+	var files []*models.File
+
+	rows, err := r.db.Query(query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		file, err := scanFile(rows)
+		if err != nil {
+			return nil, err
+		}
+
+		files = append(files, file)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return files, nil
+}
+
 func (r *FileRepo) Create(file *models.File) error {
 	query := `
-		INSERT INTO files (id, user_id, name, size, mime_type, storage_path, storage_type, expires_at, created_at, updated_at)
-		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10)
+		INSERT INTO files (id, user_id, organization_id, name, size, mime_type, storage_path, storage_type, expires_at, created_at, updated_at)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11)
 	`
 
 	file.ID = uuid.New()
@@ -30,6 +90,7 @@ func (r *FileRepo) Create(file *models.File) error {
 	_, err := r.db.Exec(query,
 		file.ID,
 		file.UserID,
+		file.OrganizationID,
 		file.Name,
 		file.Size,
 		file.MimeType,
@@ -45,26 +106,12 @@ func (r *FileRepo) Create(file *models.File) error {
 
 func (r *FileRepo) GetByID(id uuid.UUID) (*models.File, error) {
 	query := `
-		SELECT id, user_id, name, size, mime_type, storage_path, storage_type, expires_at, created_at, updated_at
+		SELECT ` + fileColumns + `
 		FROM files
 		WHERE id = $1
 	`
 
-	file := &models.File{}
-	var expiresAt sql.NullTime
-
-	err := r.db.QueryRow(query, id).Scan(
-		&file.ID,
-		&file.UserID,
-		&file.Name,
-		&file.Size,
-		&file.MimeType,
-		&file.StoragePath,
-		&file.StorageType,
-		&expiresAt,
-		&file.CreatedAt,
-		&file.UpdatedAt,
-	)
+	file, err := scanFile(r.db.QueryRow(query, id))
 
 	if err == sql.ErrNoRows {
 		return nil, nil
@@ -74,75 +121,70 @@ func (r *FileRepo) GetByID(id uuid.UUID) (*models.File, error) {
 		return nil, err
 	}
 
-	if expiresAt.Valid {
-		file.ExpiresAt = &expiresAt.Time
-	}
-
 	return file, nil
 }
 
+// GetByUserID возвращает личные файлы пользователя (без файлов организаций)
 func (r *FileRepo) GetByUserID(userID uuid.UUID, limit, offset int) ([]*models.File, error) {
 	query := `
-		SELECT id, user_id, name, size, mime_type, storage_path, storage_type, expires_at, created_at, updated_at
+		SELECT ` + fileColumns + `
 		FROM files
-		WHERE user_id = $1
+		WHERE user_id = $1 AND organization_id IS NULL
 		ORDER BY created_at DESC
 		LIMIT $2 OFFSET $3
 	`
 
-	var files []*models.File
+	return r.queryFiles(query, userID, limit, offset)
+}
 
-	rows, err := r.db.Query(query, userID, limit, offset)
-	if err != nil {
-		return nil, err
-	}
-	defer rows.Close()
+func (r *FileRepo) GetByOrganizationID(organizationID uuid.UUID, limit, offset int) ([]*models.File, error) {
+	query := `
+		SELECT ` + fileColumns + `
+		FROM files
+		WHERE organization_id = $1
+		ORDER BY created_at DESC
+		LIMIT $2 OFFSET $3
+	`
 
-	for rows.Next() {
-		file := &models.File{}
-		var expiresAt sql.NullTime
-
-		err := rows.Scan(
-			&file.ID,
-			&file.UserID,
-			&file.Name,
-			&file.Size,
-			&file.MimeType,
-			&file.StoragePath,
-			&file.StorageType,
-			&expiresAt,
-			&file.CreatedAt,
-			&file.UpdatedAt,
-		)
-		if err != nil {
-			return nil, err
-		}
+	return r.queryFiles(query, organizationID, limit, offset)
+}
 
-		if expiresAt.Valid {
-			file.ExpiresAt = &expiresAt.Time
-		}
+// GetAllByUserID возвращает все файлы пользователя без пагинации (экспорт и удаление аккаунта)
+func (r *FileRepo) GetAllByUserID(userID uuid.UUID) ([]*models.File, error) {
+	query := `
+		SELECT ` + fileColumns + `
+		FROM files
+		WHERE user_id = $1
+		ORDER BY created_at ASC
+	`
 
-		files = append(files, file)
-	}
+	return r.queryFiles(query, userID)
+}
 
-	if err := rows.Err(); err != nil {
-		return nil, err
-	}
+// GetAllByOrganizationID возвращает все файлы организации без пагинации (удаление организации)
+func (r *FileRepo) GetAllByOrganizationID(organizationID uuid.UUID) ([]*models.File, error) {
+	query := `
+		SELECT ` + fileColumns + `
+		FROM files
+		WHERE organization_id = $1
+		ORDER BY created_at ASC
+	`
 
-	return files, nil
+	return r.queryFiles(query, organizationID)
 }
 
 func (r *FileRepo) Update(file *models.File) error {
 	query := `
 		UPDATE files
-		SET name = $1, size = $2, mime_type = $3, storage_path = $4, storage_type = $5, expires_at = $6, updated_at = $7
-		WHERE id = $8
+		SET organization_id = $1, name = $2, size = $3, mime_type = $4, storage_path = $5, storage_type = $6, expires_at = $7, updated_at = $8
+		WHERE id = $9
 	`
 
 	now := time.Now()
 	file.UpdatedAt = now
 
 	res, err := r.db.Exec(query,
+		file.OrganizationID,
 		file.Name,
 		file.Size,
 		file.MimeType,
@@ -194,50 +236,11 @@ func (r *FileRepo) Delete(id uuid.UUID) error {
 
 func (r *FileRepo) GetExpiredFiles() ([]*models.File, error) {
 	query := `
-		SELECT id, user_id, name, size, mime_type, storage_path, storage_type, expires_at, created_at, updated_at
+		SELECT ` + fileColumns + `
 		FROM files
 		WHERE expires_at IS NOT NULL AND expires_at < NOW()
 		ORDER BY expires_at ASC
 	`
 
-	var files []*models.File
-
-	rows, err := r.db.Query(query)
-	if err != nil {
-		return nil, err
-	}
-	defer rows.Close()
-
-	for rows.Next() {
-		file := &models.File{}
-		var expiresAt sql.NullTime
-
-		err := rows.Scan(
-			&file.ID,
-			&file.UserID,
-			&file.Name,
-			&file.Size,
-			&file.MimeType,
-			&file.StoragePath,
-			&file.StorageType,
-			&expiresAt,
-			&file.CreatedAt,
-			&file.UpdatedAt,
-		)
-		if err != nil {
-			return nil, err
-		}
-
-		if expiresAt.Valid {
-			file.ExpiresAt = &expiresAt.Time
-		}
-
-		files = append(files, file)
-	}
-
-	if err := rows.Err(); err != nil {
-		return nil, err
-	}
-
-	return files, nil
+	return r.queryFiles(query)
 }
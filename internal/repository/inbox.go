@@ -0,0 +1,180 @@
+package repository
+
+import (
+	"database/sql"
+	"time"
+
+	"github.com/backend-app/backend/internal/models"
+	"github.com/google/uuid"
+)
+
+// inboxPendingCondition - элемент входящих еще ждет получателя: получение не
+// подтверждено, а передача не завершена (отменена, истекла и т.п.)
+const inboxPendingCondition = `i.acknowledged_at IS NULL AND t.status IN ('pending', 'in_progress', 'paused')`
+
+// InboxRepo - входящие устройств для облачных передач (store-and-forward)
+type InboxRepo struct {
+	db *sql.DB
+}
+
+func NewInboxRepo(db *sql.DB) *InboxRepo {
+	return &InboxRepo{db: db}
+}
+
+// insertInboxItem кладет файл передачи во входящие устройства-получателя
+func insertInboxItem(tx *sql.Tx, transfer *models.Transfer) error {
+	_, err := tx.Exec(`
+		INSERT INTO device_inbox (id, device_id, transfer_id, file_id, created_at)
+		VALUES ($1, $2, $3, $4, $5)
+	`, uuid.New(), transfer.ToDeviceID, transfer.ID, transfer.FileID, transfer.UpdatedAt)
+
+	return err
+}
+
+// GetPendingByDeviceID возвращает файлы, которые ждут устройство, от старых к новым
+func (r *InboxRepo) GetPendingByDeviceID(deviceID uuid.UUID) ([]*models.InboxItem, error) {
+	query := `
+		SELECT i.id, i.device_id, i.transfer_id, i.file_id, t.from_device_id, f.name, f.size, f.mime_type, i.acknowledged_at, i.created_at
+		FROM device_inbox i
+		JOIN transfers t ON t.id = i.transfer_id
+		JOIN files f ON f.id = i.file_id
+		WHERE i.device_id = $1 AND ` + inboxPendingCondition + `
+		ORDER BY i.created_at ASC
+	`
+
+	var items []*models.InboxItem
+
+	rows, err := r.db.Query(query, deviceID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		item := &models.InboxItem{}
+		var fromDeviceID uuid.NullUUID
+		var acknowledgedAt sql.NullTime
+
+		err := rows.Scan(
+			&item.ID,
+			&item.DeviceID,
+			&item.TransferID,
+			&item.FileID,
+			&fromDeviceID,
+			&item.FileName,
+			&item.FileSize,
+			&item.MimeType,
+			&acknowledgedAt,
+			&item.CreatedAt,
+		)
+		if err != nil {
+			return nil, err
+		}
+
+		if fromDeviceID.Valid {
+			item.FromDeviceID = &fromDeviceID.UUID
+		}
+
+		if acknowledgedAt.Valid {
+			item.AcknowledgedAt = &acknowledgedAt.Time
+		}
+
+		items = append(items, item)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return items, nil
+}
+
+// HasPendingForUser проверяет, что файл ждет одно из устройств пользователя.
+// Дает получателю доступ к чужому файлу до подтверждения получения.
+func (r *InboxRepo) HasPendingForUser(fileID, userID uuid.UUID) (bool, error) {
+	query := `
+		SELECT EXISTS (
+			SELECT 1
+			FROM device_inbox i
+			JOIN transfers t ON t.id = i.transfer_id
+			JOIN devices d ON d.id = i.device_id
+			WHERE i.file_id = $1 AND d.user_id = $2 AND d.deactivated_at IS NULL AND ` + inboxPendingCondition + `
+		)
+	`
+
+	var exists bool
+	err := r.db.QueryRow(query, fileID, userID).Scan(&exists)
+	return exists, err
+}
+
+// Acknowledge отмечает получение файла устройством и в той же транзакции проводит
+// передачу через статусы steps. Если элемент уже подтвержден или статус передачи
+// изменился параллельно, возвращается sql.ErrNoRows.
+func (r *InboxRepo) Acknowledge(deviceID uuid.UUID, transfer *models.Transfer, steps []models.TransferStatus) error {
+	tx, err := r.db.Begin()
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	res, err := tx.Exec(`
+		UPDATE device_inbox
+		SET acknowledged_at = $1
+		WHERE device_id = $2 AND transfer_id = $3 AND acknowledged_at IS NULL
+	`, time.Now(), deviceID, transfer.ID)
+	if err != nil {
+		return err
+	}
+
+	affected, err := res.RowsAffected()
+	if err != nil {
+		return err
+	}
+
+	if affected == 0 {
+		return sql.ErrNoRows
+	}
+
+	for _, next := range steps {
+		from := transfer.Status
+		if err := transfer.TransitionTo(next, ""); err != nil {
+			return err
+		}
+
+		if err := transitionTransfer(tx, transfer, from); err != nil {
+			return err
+		}
+	}
+
+	return tx.Commit()
+}
+
+// ExpireFileIfDelivered назначает файлу срок хранения expiresAt, если его больше не
+// ждет ни одно устройство. Более ранний срок файла сохраняется.
+// Возвращает true, если срок назначен.
+func (r *InboxRepo) ExpireFileIfDelivered(fileID uuid.UUID, expiresAt time.Time) (bool, error) {
+	query := `
+		UPDATE files
+		SET expires_at = $1, updated_at = NOW()
+		WHERE id = $2
+			AND (expires_at IS NULL OR expires_at > $1)
+			AND NOT EXISTS (
+				SELECT 1
+				FROM device_inbox i
+				JOIN transfers t ON t.id = i.transfer_id
+				WHERE i.file_id = $2 AND ` + inboxPendingCondition + `
+			)
+	`
+
+	res, err := r.db.Exec(query, expiresAt, fileID)
+	if err != nil {
+		return false, err
+	}
+
+	affected, err := res.RowsAffected()
+	if err != nil {
+		return false, err
+	}
+
+	return affected > 0, nil
+}
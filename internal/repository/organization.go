@@ -0,0 +1,441 @@
+package repository
+
+import (
+	"database/sql"
+	"time"
+
+	"github.com/backend-app/backend/internal/models"
+	"github.com/google/uuid"
+)
+
+type OrganizationRepo struct {
+	db *sql.DB
+}
+
+func NewOrganizationRepo(db *sql.DB) *OrganizationRepo {
+	return &OrganizationRepo{db: db}
+}
+
+func scanOrganization(row rowScanner) (*models.Organization, error) {
+	org := &models.Organization{}
+	var storageQuotaBytes sql.NullInt64
+
+	err := row.Scan(
+		&org.ID,
+		&org.Name,
+		&storageQuotaBytes,
+		&org.CreatedAt,
+		&org.UpdatedAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	if storageQuotaBytes.Valid {
+		org.StorageQuotaBytes = &storageQuotaBytes.Int64
+	}
+
+	return org, nil
+}
+
+// Create создает организацию и в той же транзакции добавляет ownerID владельцем
+func (r *OrganizationRepo) Create(org *models.Organization, ownerID uuid.UUID) error {
+	tx, err := r.db.Begin()
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	org.ID = uuid.New()
+	now := time.Now()
+	org.CreatedAt = now
+	org.UpdatedAt = now
+
+	_, err = tx.Exec(`
+		INSERT INTO organizations (id, name, storage_quota_bytes, created_at, updated_at)
+		VALUES ($1, $2, $3, $4, $5)
+	`,
+		org.ID,
+		org.Name,
+		org.StorageQuotaBytes,
+		org.CreatedAt,
+		org.UpdatedAt,
+	)
+	if err != nil {
+		return err
+	}
+
+	_, err = tx.Exec(`
+		INSERT INTO organization_members (organization_id, user_id, role, created_at, updated_at)
+		VALUES ($1, $2, $3, $4, $5)
+	`,
+		org.ID,
+		ownerID,
+		models.OrganizationRoleOwner,
+		now,
+		now,
+	)
+	if err != nil {
+		return err
+	}
+
+	return tx.Commit()
+}
+
+func (r *OrganizationRepo) GetByID(id uuid.UUID) (*models.Organization, error) {
+	query := `
+		SELECT id, name, storage_quota_bytes, created_at, updated_at
+		FROM organizations
+		WHERE id = $1
+	`
+
+	org, err := scanOrganization(r.db.QueryRow(query, id))
+
+	if err == sql.ErrNoRows {
+		return nil, nil
+	}
+
+	if err != nil {
+		return nil, err
+	}
+
+	return org, nil
+}
+
+// GetByUserID возвращает организации, в которых состоит пользователь
+func (r *OrganizationRepo) GetByUserID(userID uuid.UUID) ([]*models.Organization, error) {
+	query := `
+		SELECT o.id, o.name, o.storage_quota_bytes, o.created_at, o.updated_at
+		FROM organizations o
+		JOIN organization_members m ON m.organization_id = o.id
+		WHERE m.user_id = $1
+		ORDER BY o.created_at ASC
+	`
+
+	var orgs []*models.Organization
+
+	rows, err := r.db.Query(query, userID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		org, err := scanOrganization(rows)
+		if err != nil {
+			return nil, err
+		}
+
+		orgs = append(orgs, org)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return orgs, nil
+}
+
+// soleOwnedOrganizationsQuery выбирает организации, в которых пользователь $1 -
+// единственный участник с ролью $2
+const soleOwnedOrganizationsQuery = `
+	SELECT m.organization_id
+	FROM organization_members m
+	WHERE m.user_id = $1 AND m.role = $2 AND NOT EXISTS (
+		SELECT 1
+		FROM organization_members o
+		WHERE o.organization_id = m.organization_id AND o.role = $2 AND o.user_id <> $1
+	)
+`
+
+// GetSoleOwnedByUserID возвращает организации, в которых пользователь - единственный владелец
+func (r *OrganizationRepo) GetSoleOwnedByUserID(userID uuid.UUID) ([]*models.Organization, error) {
+	query := `
+		SELECT id, name, storage_quota_bytes, created_at, updated_at
+		FROM organizations
+		WHERE id IN (` + soleOwnedOrganizationsQuery + `)
+		ORDER BY created_at ASC
+	`
+
+	var orgs []*models.Organization
+
+	rows, err := r.db.Query(query, userID, models.OrganizationRoleOwner)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		org, err := scanOrganization(rows)
+		if err != nil {
+			return nil, err
+		}
+
+		orgs = append(orgs, org)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return orgs, nil
+}
+
+func (r *OrganizationRepo) Update(org *models.Organization) error {
+	query := `
+		UPDATE organizations
+		SET name = $1, storage_quota_bytes = $2, updated_at = $3
+		WHERE id = $4
+	`
+
+	now := time.Now()
+	org.UpdatedAt = now
+
+	res, err := r.db.Exec(query,
+		org.Name,
+		org.StorageQuotaBytes,
+		org.UpdatedAt,
+		org.ID,
+	)
+
+	if err != nil {
+		return err
+	}
+
+	affected, err := res.RowsAffected()
+	if err != nil {
+		return err
+	}
+
+	if affected == 0 {
+		return sql.ErrNoRows
+	}
+
+	return nil
+}
+
+func (r *OrganizationRepo) Delete(id uuid.UUID) error {
+	query := `
+		DELETE FROM organizations
+		WHERE id = $1
+	`
+
+	res, err := r.db.Exec(query, id)
+	if err != nil {
+		return err
+	}
+
+	affected, err := res.RowsAffected()
+	if err != nil {
+		return err
+	}
+
+	if affected == 0 {
+		return sql.ErrNoRows
+	}
+
+	return nil
+}
+
+func (r *OrganizationRepo) AddMember(member *models.OrganizationMember) error {
+	query := `
+		INSERT INTO organization_members (organization_id, user_id, role, created_at, updated_at)
+		VALUES ($1, $2, $3, $4, $5)
+	`
+
+	now := time.Now()
+	member.CreatedAt = now
+	member.UpdatedAt = now
+
+	_, err := r.db.Exec(query,
+		member.OrganizationID,
+		member.UserID,
+		member.Role,
+		member.CreatedAt,
+		member.UpdatedAt,
+	)
+
+	return err
+}
+
+// GetMember возвращает участника организации или nil, если пользователь в ней не состоит
+func (r *OrganizationRepo) GetMember(organizationID, userID uuid.UUID) (*models.OrganizationMember, error) {
+	query := `
+		SELECT m.organization_id, m.user_id, u.email, m.role, m.created_at, m.updated_at
+		FROM organization_members m
+		JOIN users u ON u.id = m.user_id
+		WHERE m.organization_id = $1 AND m.user_id = $2
+	`
+
+	member := &models.OrganizationMember{}
+
+	err := r.db.QueryRow(query, organizationID, userID).Scan(
+		&member.OrganizationID,
+		&member.UserID,
+		&member.Email,
+		&member.Role,
+		&member.CreatedAt,
+		&member.UpdatedAt,
+	)
+
+	if err == sql.ErrNoRows {
+		return nil, nil
+	}
+
+	if err != nil {
+		return nil, err
+	}
+
+	return member, nil
+}
+
+func (r *OrganizationRepo) ListMembers(organizationID uuid.UUID) ([]*models.OrganizationMember, error) {
+	query := `
+		SELECT m.organization_id, m.user_id, u.email, m.role, m.created_at, m.updated_at
+		FROM organization_members m
+		JOIN users u ON u.id = m.user_id
+		WHERE m.organization_id = $1
+		ORDER BY m.created_at ASC
+	`
+
+	var members []*models.OrganizationMember
+
+	rows, err := r.db.Query(query, organizationID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		member := &models.OrganizationMember{}
+
+		err := rows.Scan(
+			&member.OrganizationID,
+			&member.UserID,
+			&member.Email,
+			&member.Role,
+			&member.CreatedAt,
+			&member.UpdatedAt,
+		)
+		if err != nil {
+			return nil, err
+		}
+
+		members = append(members, member)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return members, nil
+}
+
+func (r *OrganizationRepo) UpdateMemberRole(organizationID, userID uuid.UUID, role models.OrganizationRole) error {
+	query := `
+		UPDATE organization_members
+		SET role = $1, updated_at = $2
+		WHERE organization_id = $3 AND user_id = $4
+	`
+
+	res, err := r.db.Exec(query,
+		role,
+		time.Now(),
+		organizationID,
+		userID,
+	)
+
+	if err != nil {
+		return err
+	}
+
+	affected, err := res.RowsAffected()
+	if err != nil {
+		return err
+	}
+
+	if affected == 0 {
+		return sql.ErrNoRows
+	}
+
+	return nil
+}
+
+// RemoveMember удаляет участника и отвязывает его устройства от организации
+func (r *OrganizationRepo) RemoveMember(organizationID, userID uuid.UUID) error {
+	tx, err := r.db.Begin()
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	res, err := tx.Exec(`
+		DELETE FROM organization_members
+		WHERE organization_id = $1 AND user_id = $2
+	`, organizationID, userID)
+	if err != nil {
+		return err
+	}
+
+	affected, err := res.RowsAffected()
+	if err != nil {
+		return err
+	}
+
+	if affected == 0 {
+		return sql.ErrNoRows
+	}
+
+	_, err = tx.Exec(`
+		UPDATE devices
+		SET organization_id = NULL, updated_at = $1
+		WHERE organization_id = $2 AND user_id = $3
+	`, time.Now(), organizationID, userID)
+	if err != nil {
+		return err
+	}
+
+	return tx.Commit()
+}
+
+func (r *OrganizationRepo) CountOwners(organizationID uuid.UUID) (int, error) {
+	query := `
+		SELECT COUNT(*)
+		FROM organization_members
+		WHERE organization_id = $1 AND role = $2
+	`
+
+	var count int
+	err := r.db.QueryRow(query, organizationID, models.OrganizationRoleOwner).Scan(&count)
+
+	return count, err
+}
+
+// GetStorageUsage возвращает суммарный размер файлов организации в байтах
+func (r *OrganizationRepo) GetStorageUsage(organizationID uuid.UUID) (int64, error) {
+	query := `
+		SELECT COALESCE(SUM(size), 0)
+		FROM files
+		WHERE organization_id = $1
+	`
+
+	var used int64
+	err := r.db.QueryRow(query, organizationID).Scan(&used)
+
+	return used, err
+}
+
+// IsMember проверяет, что пользователь состоит в организации
+func (r *OrganizationRepo) IsMember(organizationID, userID uuid.UUID) (bool, error) {
+	query := `
+		SELECT EXISTS (
+			SELECT 1 FROM organization_members
+			WHERE organization_id = $1 AND user_id = $2
+		)
+	`
+
+	var exists bool
+	err := r.db.QueryRow(query, organizationID, userID).Scan(&exists)
+
+	return exists, err
+}
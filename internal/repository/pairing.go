@@ -0,0 +1,96 @@
+package repository
+
+import (
+	"context"
+	"encoding/json"
+	"time"
+
+	"github.com/backend-app/backend/internal/models"
+	"github.com/redis/go-redis/v9"
+)
+
+const (
+	pairingCodeKeyPrefix     = "pairing:code:"
+	pairingAttemptsKeyPrefix = "pairing:attempts:"
+	pairingFailedAttemptsKey = "pairing:failed"
+)
+
+type PairingRepo struct {
+	redis *redis.Client
+}
+
+func NewPairingRepo(redisClient *redis.Client) *PairingRepo {
+	return &PairingRepo{redis: redisClient}
+}
+
+// Create сохраняет сопряжение с TTL. Возвращает false, если такой код уже занят.
+func (r *PairingRepo) Create(ctx context.Context, pairing *models.DevicePairing, ttl time.Duration) (bool, error) {
+	now := time.Now()
+	pairing.CreatedAt = now
+	pairing.ExpiresAt = now.Add(ttl)
+
+	data, err := json.Marshal(pairing)
+	if err != nil {
+		return false, err
+	}
+
+	return r.redis.SetNX(ctx, pairingCodeKeyPrefix+pairing.Code, data, ttl).Result()
+}
+
+// Redeem атомарно забирает и удаляет сопряжение, чтобы код нельзя было использовать дважды.
+// Возвращает nil, если код не найден или истек.
+func (r *PairingRepo) Redeem(ctx context.Context, code string) (*models.DevicePairing, error) {
+	data, err := r.redis.GetDel(ctx, pairingCodeKeyPrefix+code).Bytes()
+	if err == redis.Nil {
+		return nil, nil
+	}
+
+	if err != nil {
+		return nil, err
+	}
+
+	pairing := &models.DevicePairing{}
+	if err := json.Unmarshal(data, pairing); err != nil {
+		return nil, err
+	}
+
+	if pairing.IsExpired() {
+		return nil, nil
+	}
+
+	return pairing, nil
+}
+
+// RegisterAttempt увеличивает счетчик попыток ввода кода для ключа (например, IP адреса)
+// и возвращает количество попыток в текущем окне.
+func (r *PairingRepo) RegisterAttempt(ctx context.Context, key string, window time.Duration) (int64, error) {
+	return r.incrAttempts(ctx, pairingAttemptsKeyPrefix+key, window)
+}
+
+// FailedAttempts возвращает количество неверных кодов со всех адресов в текущем окне
+func (r *PairingRepo) FailedAttempts(ctx context.Context) (int64, error) {
+	count, err := r.redis.Get(ctx, pairingFailedAttemptsKey).Int64()
+	if err == redis.Nil {
+		return 0, nil
+	}
+
+	return count, err
+}
+
+// RegisterFailedAttempt учитывает неверный код независимо от адреса клиента
+func (r *PairingRepo) RegisterFailedAttempt(ctx context.Context, window time.Duration) error {
+	_, err := r.incrAttempts(ctx, pairingFailedAttemptsKey, window)
+	return err
+}
+
+func (r *PairingRepo) incrAttempts(ctx context.Context, key string, window time.Duration) (int64, error) {
+	pipe := r.redis.TxPipeline()
+	incr := pipe.Incr(ctx, key)
+	pipe.ExpireNX(ctx, key, window)
+
+	if _, err := pipe.Exec(ctx); err != nil {
+		return 0, err
+	}
+
+	return incr.Val(), nil
+}
@@ -2,12 +2,31 @@ package repository
 
 import (
 	"database/sql"
+	"fmt"
+	"strings"
 	"time"
 
 	"github.com/backend-app/backend/internal/models"
 	"github.com/google/uuid"
 )
 
+const transferColumns = `id, file_id, from_device_id, to_device_id, group_id, transfer_type, delivery_path, status, progress, bytes_transferred, failure_reason, created_at, updated_at`
+
+// TransferFilter - условия выборки передач. Пустые поля не ограничивают выборку.
+type TransferFilter struct {
+	// UserID ограничивает выборку передачами файлов или устройств пользователя
+	UserID   *uuid.UUID
+	FileID   *uuid.UUID
+	GroupID  *uuid.UUID
+	DeviceID *uuid.UUID
+	// Direction относительно DeviceID: incoming - передачи на устройство,
+	// outgoing - с устройства, пусто - в обе стороны
+	Direction models.TransferDirection
+	Status    models.TransferStatus
+	Limit     int
+	Offset    int
+}
+
 type TransferRepo struct {
 	db *sql.DB
 }
@@ -16,54 +35,175 @@ func NewTransferRepo(db *sql.DB) *TransferRepo {
 	return &TransferRepo{db: db}
 }
 
+func scanTransfer(row rowScanner) (*models.Transfer, error) {
+	transfer := &models.Transfer{}
+	var fromDeviceID, toDeviceID, groupID uuid.NullUUID
+
+	err := row.Scan(
+		&transfer.ID,
+		&transfer.FileID,
+		&fromDeviceID,
+		&toDeviceID,
+		&groupID,
+		&transfer.TransferType,
+		&transfer.DeliveryPath,
+		&transfer.Status,
+		&transfer.Progress,
+		&transfer.BytesTransferred,
+		&transfer.FailureReason,
+		&transfer.CreatedAt,
+		&transfer.UpdatedAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	if fromDeviceID.Valid {
+		transfer.FromDeviceID = &fromDeviceID.UUID
+	}
+
+	if toDeviceID.Valid {
+		transfer.ToDeviceID = &toDeviceID.UUID
+	}
+
+	if groupID.Valid {
+		transfer.GroupID = &groupID.UUID
+	}
+
+	return transfer, nil
+}
+
+func (r *TransferRepo) queryTransfers(query string, args ...interface{}) ([]*models.Transfer, error) {
+	var transfers []*models.Transfer
+
+	rows, err := r.db.Query(query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		transfer, err := scanTransfer(rows)
+		if err != nil {
+			return nil, err
+		}
+
+		transfers = append(transfers, transfer)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return transfers, nil
+}
+
+// insertTransferEvent записывает переход в историю передачи вместе с текущим
+// способом доставки. from == nil - создание передачи.
+func insertTransferEvent(tx *sql.Tx, transfer *models.Transfer, from *models.TransferStatus, reason string) error {
+	_, err := tx.Exec(`
+		INSERT INTO transfer_events (id, transfer_id, from_status, to_status, reason, delivery_path, created_at)
+		VALUES ($1, $2, $3, $4, $5, $6, $7)
+	`, uuid.New(), transfer.ID, from, transfer.Status, reason, transfer.DeliveryPath, transfer.UpdatedAt)
+
+	return err
+}
+
+// Create сохраняет передачу и первую запись ее истории. Облачная передача на
+// устройство сразу попадает во входящие получателя.
 func (r *TransferRepo) Create(transfer *models.Transfer) error {
+	tx, err := r.db.Begin()
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	if err := insertTransfer(tx, transfer, time.Now()); err != nil {
+		return err
+	}
+
+	return tx.Commit()
+}
+
+// CreateGroup сохраняет группу и передачи всех получателей в одной транзакции
+func (r *TransferRepo) CreateGroup(group *models.TransferGroup) error {
+	group.ID = uuid.New()
+	group.CreatedAt = time.Now()
+
+	tx, err := r.db.Begin()
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	_, err = tx.Exec(`
+		INSERT INTO transfer_groups (id, user_id, file_id, from_device_id, transfer_type, created_at)
+		VALUES ($1, $2, $3, $4, $5, $6)
+	`, group.ID, group.UserID, group.FileID, group.FromDeviceID, group.TransferType, group.CreatedAt)
+	if err != nil {
+		return err
+	}
+
+	for _, transfer := range group.Transfers {
+		transfer.GroupID = &group.ID
+		if err := insertTransfer(tx, transfer, group.CreatedAt); err != nil {
+			return err
+		}
+	}
+
+	return tx.Commit()
+}
+
+// insertTransfer сохраняет передачу, запись о ее создании и, для облачной передачи
+// на устройство, элемент входящих получателя
+func insertTransfer(tx *sql.Tx, transfer *models.Transfer, now time.Time) error {
 	query := `
-		INSERT INTO transfers (id, file_id, from_device_id, to_device_id, transfer_type, status, progress, created_at, updated_at)
-		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9)
+		INSERT INTO transfers (id, file_id, from_device_id, to_device_id, group_id, transfer_type, delivery_path, status, progress, bytes_transferred, failure_reason, created_at, updated_at)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13)
 	`
 
 	transfer.ID = uuid.New()
-	now := time.Now()
 	transfer.CreatedAt = now
 	transfer.UpdatedAt = now
 
-	_, err := r.db.Exec(query,
+	_, err := tx.Exec(query,
 		transfer.ID,
 		transfer.FileID,
 		transfer.FromDeviceID,
 		transfer.ToDeviceID,
+		transfer.GroupID,
 		transfer.TransferType,
+		transfer.DeliveryPath,
 		transfer.Status,
 		transfer.Progress,
+		transfer.BytesTransferred,
+		transfer.FailureReason,
 		transfer.CreatedAt,
 		transfer.UpdatedAt,
 	)
+	if err != nil {
+		return err
+	}
 
-	return err
+	if err := insertTransferEvent(tx, transfer, nil, ""); err != nil {
+		return err
+	}
+
+	if transfer.IsStoreAndForward() {
+		return insertInboxItem(tx, transfer)
+	}
+
+	return nil
 }
 
 func (r *TransferRepo) GetByID(id uuid.UUID) (*models.Transfer, error) {
 	query := `
-		SELECT id, file_id, from_device_id, to_device_id, transfer_type, status, progress, created_at, updated_at
+		SELECT ` + transferColumns + `
 		FROM transfers
 		WHERE id = $1
 	`
 
-	transfer := &models.Transfer{}
-	var fromDeviceID, toDeviceID sql.NullString
-
-	err := r.db.QueryRow(query, id).Scan(
-		&transfer.ID,
-		&transfer.FileID,
-		&fromDeviceID,
-		&toDeviceID,
-		&transfer.TransferType,
-		&transfer.Status,
-		&transfer.Progress,
-		&transfer.CreatedAt,
-		&transfer.UpdatedAt,
-	)
-
+	transfer, err := scanTransfer(r.db.QueryRow(query, id))
 	if err == sql.ErrNoRows {
 		return nil, nil
 	}
@@ -72,98 +212,166 @@ func (r *TransferRepo) GetByID(id uuid.UUID) (*models.Transfer, error) {
 		return nil, err
 	}
 
-	if fromDeviceID.Valid {
-		parsedUUID, err := uuid.Parse(fromDeviceID.String)
-		if err == nil {
-			transfer.FromDeviceID = &parsedUUID
-		}
+	return transfer, nil
+}
+
+// List возвращает передачи, подходящие под фильтр, от новых к старым
+func (r *TransferRepo) List(filter TransferFilter) ([]*models.Transfer, error) {
+	var conditions []string
+	var args []interface{}
+
+	addCondition := func(condition string, arg interface{}) {
+		args = append(args, arg)
+		conditions = append(conditions, strings.ReplaceAll(condition, "?", fmt.Sprintf("$%d", len(args))))
 	}
 
-	if toDeviceID.Valid {
-		parsedUUID, err := uuid.Parse(toDeviceID.String)
-		if err == nil {
-			transfer.ToDeviceID = &parsedUUID
+	if filter.UserID != nil {
+		addCondition(`id IN (
+			SELECT t.id
+			FROM transfers t
+			LEFT JOIN files f ON f.id = t.file_id
+			LEFT JOIN devices fd ON fd.id = t.from_device_id
+			LEFT JOIN devices td ON td.id = t.to_device_id
+			WHERE f.user_id = ? OR fd.user_id = ? OR td.user_id = ?
+		)`, *filter.UserID)
+	}
+
+	if filter.FileID != nil {
+		addCondition("file_id = ?", *filter.FileID)
+	}
+
+	if filter.GroupID != nil {
+		addCondition("group_id = ?", *filter.GroupID)
+	}
+
+	if filter.DeviceID != nil {
+		switch filter.Direction {
+		case models.TransferDirectionIncoming:
+			addCondition("to_device_id = ?", *filter.DeviceID)
+		case models.TransferDirectionOutgoing:
+			addCondition("from_device_id = ?", *filter.DeviceID)
+		default:
+			addCondition("(from_device_id = ? OR to_device_id = ?)", *filter.DeviceID)
 		}
 	}
 
-	return transfer, nil
-}
+	if filter.Status != "" {
+		addCondition("status = ?", filter.Status)
+	}
 
-func (r *TransferRepo) GetByFileID(fileID uuid.UUID) ([]*models.Transfer, error) {
 	query := `
-		SELECT id, file_id, from_device_id, to_device_id, transfer_type, status, progress, created_at, updated_at
+		SELECT ` + transferColumns + `
 		FROM transfers
-		WHERE file_id = $1
-		ORDER BY created_at DESC
 	`
+	if len(conditions) > 0 {
+		query += "WHERE " + strings.Join(conditions, " AND ") + "\n"
+	}
+	query += "ORDER BY created_at DESC"
 
-	var transfers []*models.Transfer
+	if filter.Limit > 0 {
+		args = append(args, filter.Limit, filter.Offset)
+		query += fmt.Sprintf(" LIMIT $%d OFFSET $%d", len(args)-1, len(args))
+	}
+
+	return r.queryTransfers(query, args...)
+}
+
+// GetGroup возвращает группу с передачами получателей
+func (r *TransferRepo) GetGroup(id uuid.UUID) (*models.TransferGroup, error) {
+	query := `
+		SELECT id, user_id, file_id, from_device_id, transfer_type, created_at
+		FROM transfer_groups
+		WHERE id = $1
+	`
+
+	group := &models.TransferGroup{}
+	var fromDeviceID uuid.NullUUID
+
+	err := r.db.QueryRow(query, id).Scan(
+		&group.ID,
+		&group.UserID,
+		&group.FileID,
+		&fromDeviceID,
+		&group.TransferType,
+		&group.CreatedAt,
+	)
+	if err == sql.ErrNoRows {
+		return nil, nil
+	}
 
-	rows, err := r.db.Query(query, fileID)
 	if err != nil {
 		return nil, err
 	}
-	defer rows.Close()
 
-	for rows.Next() {
-		transfer := &models.Transfer{}
-		var fromDeviceID, toDeviceID sql.NullString
+	if fromDeviceID.Valid {
+		group.FromDeviceID = &fromDeviceID.UUID
+	}
 
-		err := rows.Scan(
-			&transfer.ID,
-			&transfer.FileID,
-			&fromDeviceID,
-			&toDeviceID,
-			&transfer.TransferType,
-			&transfer.Status,
-			&transfer.Progress,
-			&transfer.CreatedAt,
-			&transfer.UpdatedAt,
-		)
-		if err != nil {
-			return nil, err
-		}
+	group.Transfers, err = r.queryTransfers(`
+		SELECT `+transferColumns+`
+		FROM transfers
+		WHERE group_id = $1
+		ORDER BY created_at ASC, id
+	`, id)
+	if err != nil {
+		return nil, err
+	}
 
-		if fromDeviceID.Valid {
-			parsedUUID, err := uuid.Parse(fromDeviceID.String)
-			if err == nil {
-				transfer.FromDeviceID = &parsedUUID
-			}
-		}
+	return group, nil
+}
 
-		if toDeviceID.Valid {
-			parsedUUID, err := uuid.Parse(toDeviceID.String)
-			if err == nil {
-				transfer.ToDeviceID = &parsedUUID
-			}
-		}
+// GetByUserID возвращает передачи, в которых участвуют файлы или устройства пользователя
+func (r *TransferRepo) GetByUserID(userID uuid.UUID) ([]*models.Transfer, error) {
+	query := `
+		SELECT ` + transferColumns + `
+		FROM transfers
+		WHERE id IN (
+			SELECT t.id
+			FROM transfers t
+			LEFT JOIN files f ON f.id = t.file_id
+			LEFT JOIN devices fd ON fd.id = t.from_device_id
+			LEFT JOIN devices td ON td.id = t.to_device_id
+			WHERE f.user_id = $1 OR fd.user_id = $1 OR td.user_id = $1
+		)
+		ORDER BY created_at DESC
+	`
 
-		transfers = append(transfers, transfer)
+	return r.queryTransfers(query, userID)
+}
+
+// Transition сохраняет новый статус передачи и запись истории. Обновление выполняется,
+// только если передача все еще в статусе from: параллельная смена статуса дает sql.ErrNoRows.
+func (r *TransferRepo) Transition(transfer *models.Transfer, from models.TransferStatus) error {
+	tx, err := r.db.Begin()
+	if err != nil {
+		return err
 	}
+	defer tx.Rollback()
 
-	if err := rows.Err(); err != nil {
-		return nil, err
+	if err := transitionTransfer(tx, transfer, from); err != nil {
+		return err
 	}
 
-	return transfers, nil
+	return tx.Commit()
 }
 
-func (r *TransferRepo) UpdateStatus(id uuid.UUID, status models.TransferStatus, progress int64) error {
+// transitionTransfer выполняет Transition в транзакции tx
+func transitionTransfer(tx *sql.Tx, transfer *models.Transfer, from models.TransferStatus) error {
 	query := `
 		UPDATE transfers
-		SET status = $1, progress = $2, updated_at = $3
-		WHERE id = $4
+		SET status = $1, progress = $2, bytes_transferred = $3, failure_reason = $4, updated_at = $5
+		WHERE id = $6 AND status = $7
 	`
 
-	now := time.Now()
-
-	res, err := r.db.Exec(query,
-		status,
-		progress,
-		now,
-		id,
+	res, err := tx.Exec(query,
+		transfer.Status,
+		transfer.Progress,
+		transfer.BytesTransferred,
+		transfer.FailureReason,
+		transfer.UpdatedAt,
+		transfer.ID,
+		from,
 	)
-
 	if err != nil {
 		return err
 	}
@@ -177,89 +385,100 @@ func (r *TransferRepo) UpdateStatus(id uuid.UUID, status models.TransferStatus,
 		return sql.ErrNoRows
 	}
 
-	return nil
+	return insertTransferEvent(tx, transfer, &from, transfer.FailureReason)
 }
 
-func (r *TransferRepo) GetByStatus(status models.TransferStatus) ([]*models.Transfer, error) {
+// ChangeDeliveryPath переводит незавершенную передачу со способа доставки from на
+// transfer.DeliveryPath и записывает смену в историю с причиной reason. При переходе
+// на cloud файл попадает во входящие получателя. Если передача уже завершена или
+// способ доставки сменился параллельно, возвращается sql.ErrNoRows.
+func (r *TransferRepo) ChangeDeliveryPath(transfer *models.Transfer, from models.TransferProtocol, reason string) error {
 	query := `
-		SELECT id, file_id, from_device_id, to_device_id, transfer_type, status, progress, created_at, updated_at
-		FROM transfers
-		WHERE status = $1
-		ORDER BY created_at DESC
+		UPDATE transfers
+		SET delivery_path = $1, updated_at = $2
+		WHERE id = $3 AND delivery_path = $4 AND status = $5
 	`
 
-	var transfers []*models.Transfer
+	tx, err := r.db.Begin()
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
 
-	rows, err := r.db.Query(query, status)
+	res, err := tx.Exec(query, transfer.DeliveryPath, transfer.UpdatedAt, transfer.ID, from, transfer.Status)
 	if err != nil {
-		return nil, err
+		return err
 	}
-	defer rows.Close()
 
-	for rows.Next() {
-		transfer := &models.Transfer{}
-		var fromDeviceID, toDeviceID sql.NullString
+	affected, err := res.RowsAffected()
+	if err != nil {
+		return err
+	}
 
-		err := rows.Scan(
-			&transfer.ID,
-			&transfer.FileID,
-			&fromDeviceID,
-			&toDeviceID,
-			&transfer.TransferType,
-			&transfer.Status,
-			&transfer.Progress,
-			&transfer.CreatedAt,
-			&transfer.UpdatedAt,
-		)
-		if err != nil {
-			return nil, err
-		}
+	if affected == 0 {
+		return sql.ErrNoRows
+	}
 
-		if fromDeviceID.Valid {
-			parsedUUID, err := uuid.Parse(fromDeviceID.String)
-			if err == nil {
-				transfer.FromDeviceID = &parsedUUID
-			}
-		}
+	if err := insertTransferEvent(tx, transfer, &transfer.Status, reason); err != nil {
+		return err
+	}
 
-		if toDeviceID.Valid {
-			parsedUUID, err := uuid.Parse(toDeviceID.String)
-			if err == nil {
-				transfer.ToDeviceID = &parsedUUID
-			}
+	if transfer.IsStoreAndForward() {
+		if err := insertInboxItem(tx, transfer); err != nil {
+			return err
 		}
-
-		transfers = append(transfers, transfer)
 	}
 
-	if err := rows.Err(); err != nil {
-		return nil, err
-	}
+	return tx.Commit()
+}
 
-	return transfers, nil
+// TimeOutStale переводит до limit передач в статусе from, не обновлявшихся с before,
+// в статус to с причиной reason и записывает переходы в историю. Облачные передачи
+// во входящих устройств не затрагиваются: они ждут получателя до подтверждения.
+// Возвращает переведенные передачи.
+func (r *TransferRepo) TimeOutStale(from, to models.TransferStatus, reason string, before time.Time, limit int) ([]*models.Transfer, error) {
+	query := `
+		WITH target AS (
+			SELECT id AS target_id
+			FROM transfers
+			WHERE status = $1 AND updated_at < $2
+				AND NOT (delivery_path = $3 AND to_device_id IS NOT NULL)
+			ORDER BY updated_at ASC
+			LIMIT $4
+			FOR UPDATE SKIP LOCKED
+		), updated AS (
+			UPDATE transfers
+			SET status = $5, failure_reason = $6, updated_at = $7
+			FROM target
+			WHERE id = target.target_id
+			RETURNING ` + transferColumns + `
+		), history AS (
+			INSERT INTO transfer_events (transfer_id, from_status, to_status, reason, delivery_path, created_at)
+			SELECT id, $1, status, failure_reason, delivery_path, updated_at
+			FROM updated
+		)
+		SELECT ` + transferColumns + `
+		FROM updated
+	`
+
+	return r.queryTransfers(query, from, before, models.TransferProtocolCloud, limit, to, reason, time.Now())
 }
 
-func (r *TransferRepo) Update(transfer *models.Transfer) error {
+// UpdateProgress обновляет прогресс без смены статуса. Завершенные передачи не меняются.
+func (r *TransferRepo) UpdateProgress(transfer *models.Transfer) error {
 	query := `
 		UPDATE transfers
-		SET file_id = $1, from_device_id = $2, to_device_id = $3, transfer_type = $4, status = $5, progress = $6, updated_at = $7
-		WHERE id = $8
+		SET progress = $1, bytes_transferred = $2, updated_at = $3
+		WHERE id = $4 AND status = $5
 	`
 
-	now := time.Now()
-	transfer.UpdatedAt = now
-
 	res, err := r.db.Exec(query,
-		transfer.FileID,
-		transfer.FromDeviceID,
-		transfer.ToDeviceID,
-		transfer.TransferType,
-		transfer.Status,
 		transfer.Progress,
+		transfer.BytesTransferred,
 		transfer.UpdatedAt,
 		transfer.ID,
+		transfer.Status,
 	)
-
 	if err != nil {
 		return err
 	}
@@ -276,6 +495,55 @@ func (r *TransferRepo) Update(transfer *models.Transfer) error {
 	return nil
 }
 
+// GetEvents возвращает историю статусов передачи в хронологическом порядке
+func (r *TransferRepo) GetEvents(transferID uuid.UUID) ([]*models.TransferEvent, error) {
+	query := `
+		SELECT id, transfer_id, from_status, to_status, reason, delivery_path, created_at
+		FROM transfer_events
+		WHERE transfer_id = $1
+		ORDER BY created_at ASC, id
+	`
+
+	var events []*models.TransferEvent
+
+	rows, err := r.db.Query(query, transferID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		event := &models.TransferEvent{}
+		var fromStatus sql.NullString
+
+		err := rows.Scan(
+			&event.ID,
+			&event.TransferID,
+			&fromStatus,
+			&event.ToStatus,
+			&event.Reason,
+			&event.DeliveryPath,
+			&event.CreatedAt,
+		)
+		if err != nil {
+			return nil, err
+		}
+
+		if fromStatus.Valid {
+			status := models.TransferStatus(fromStatus.String)
+			event.FromStatus = &status
+		}
+
+		events = append(events, event)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return events, nil
+}
+
 func (r *TransferRepo) Delete(id uuid.UUID) error {
 	query := `
 		DELETE FROM transfers
@@ -40,16 +40,20 @@ func (r *UserRepo) Create(user *models.User) error {
 
 func (r *UserRepo) GetByEmail(email string) (*models.User, error) {
 	query := `
-		SELECT id, email, password_hash, created_at, updated_at
+		SELECT id, email, password_hash, deletion_requested_at, deletion_scheduled_at, created_at, updated_at
 		FROM users
 		WHERE email = $1
 	`
 
 	user := &models.User{}
+	var deletionRequestedAt, deletionScheduledAt sql.NullTime
+
 	err := r.db.QueryRow(query, email).Scan(
 		&user.ID,
 		&user.Email,
 		&user.PasswordHash,
+		&deletionRequestedAt,
+		&deletionScheduledAt,
 		&user.CreatedAt,
 		&user.UpdatedAt,
 	)
@@ -62,20 +66,32 @@ func (r *UserRepo) GetByEmail(email string) (*models.User, error) {
 		return nil, err
 	}
 
+	if deletionRequestedAt.Valid {
+		user.DeletionRequestedAt = &deletionRequestedAt.Time
+	}
+
+	if deletionScheduledAt.Valid {
+		user.DeletionScheduledAt = &deletionScheduledAt.Time
+	}
+
 	return user, nil
 }
 
 func (r *UserRepo) GetByID(id uuid.UUID) (*models.User, error) {
 	query := `
-		SELECT id, email, password_hash, created_at, updated_at
+		SELECT id, email, password_hash, deletion_requested_at, deletion_scheduled_at, created_at, updated_at
 		FROM users
 		WHERE id = $1
 	`
 	user := &models.User{}
+	var deletionRequestedAt, deletionScheduledAt sql.NullTime
+
 	err := r.db.QueryRow(query, id).Scan(
 		&user.ID,
 		&user.Email,
 		&user.PasswordHash,
+		&deletionRequestedAt,
+		&deletionScheduledAt,
 		&user.CreatedAt,
 		&user.UpdatedAt,
 	)
@@ -88,5 +104,171 @@ func (r *UserRepo) GetByID(id uuid.UUID) (*models.User, error) {
 		return nil, err
 	}
 
+	if deletionRequestedAt.Valid {
+		user.DeletionRequestedAt = &deletionRequestedAt.Time
+	}
+
+	if deletionScheduledAt.Valid {
+		user.DeletionScheduledAt = &deletionScheduledAt.Time
+	}
+
 	return user, nil
 }
+
+func (r *UserRepo) ScheduleDeletion(id uuid.UUID, scheduledAt time.Time) error {
+	query := `
+		UPDATE users
+		SET deletion_requested_at = $1, deletion_scheduled_at = $2, updated_at = $3
+		WHERE id = $4
+	`
+
+	now := time.Now()
+
+	res, err := r.db.Exec(query,
+		now,
+		scheduledAt,
+		now,
+		id,
+	)
+
+	if err != nil {
+		return err
+	}
+
+	affected, err := res.RowsAffected()
+	if err != nil {
+		return err
+	}
+
+	if affected == 0 {
+		return sql.ErrNoRows
+	}
+
+	return nil
+}
+
+func (r *UserRepo) CancelDeletion(id uuid.UUID) error {
+	query := `
+		UPDATE users
+		SET deletion_requested_at = NULL, deletion_scheduled_at = NULL, updated_at = $1
+		WHERE id = $2
+	`
+
+	res, err := r.db.Exec(query, time.Now(), id)
+	if err != nil {
+		return err
+	}
+
+	affected, err := res.RowsAffected()
+	if err != nil {
+		return err
+	}
+
+	if affected == 0 {
+		return sql.ErrNoRows
+	}
+
+	return nil
+}
+
+// GetDueForDeletion возвращает пользователей, у которых истек период ожидания удаления
+func (r *UserRepo) GetDueForDeletion(before time.Time, limit int) ([]*models.User, error) {
+	query := `
+		SELECT id, email, password_hash, deletion_requested_at, deletion_scheduled_at, created_at, updated_at
+		FROM users
+		WHERE deletion_scheduled_at IS NOT NULL AND deletion_scheduled_at <= $1
+		ORDER BY deletion_scheduled_at ASC
+		LIMIT $2
+	`
+
+	var users []*models.User
+
+	rows, err := r.db.Query(query, before, limit)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		user := &models.User{}
+		var deletionRequestedAt, deletionScheduledAt sql.NullTime
+
+		err := rows.Scan(
+			&user.ID,
+			&user.Email,
+			&user.PasswordHash,
+			&deletionRequestedAt,
+			&deletionScheduledAt,
+			&user.CreatedAt,
+			&user.UpdatedAt,
+		)
+		if err != nil {
+			return nil, err
+		}
+
+		if deletionRequestedAt.Valid {
+			user.DeletionRequestedAt = &deletionRequestedAt.Time
+		}
+
+		if deletionScheduledAt.Valid {
+			user.DeletionScheduledAt = &deletionScheduledAt.Time
+		}
+
+		users = append(users, user)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return users, nil
+}
+
+// GetDeviceInactivityDays возвращает порог неактивности устройств пользователя.
+// nil означает порог по умолчанию.
+func (r *UserRepo) GetDeviceInactivityDays(id uuid.UUID) (*int, error) {
+	query := `
+		SELECT device_inactivity_days
+		FROM users
+		WHERE id = $1
+	`
+
+	var days sql.NullInt32
+	err := r.db.QueryRow(query, id).Scan(&days)
+	if err != nil {
+		return nil, err
+	}
+
+	if !days.Valid {
+		return nil, nil
+	}
+
+	value := int(days.Int32)
+	return &value, nil
+}
+
+// SetDeviceInactivityDays задает порог неактивности устройств пользователя.
+// nil возвращает порог по умолчанию, 0 отключает автоматическое отключение устройств.
+func (r *UserRepo) SetDeviceInactivityDays(id uuid.UUID, days *int) error {
+	query := `
+		UPDATE users
+		SET device_inactivity_days = $1, updated_at = $2
+		WHERE id = $3
+	`
+
+	res, err := r.db.Exec(query, days, time.Now(), id)
+	if err != nil {
+		return err
+	}
+
+	affected, err := res.RowsAffected()
+	if err != nil {
+		return err
+	}
+
+	if affected == 0 {
+		return sql.ErrNoRows
+	}
+
+	return nil
+}
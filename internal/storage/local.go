@@ -31,7 +31,22 @@ func (s *LocalStorage) SaveFile(userID uuid.UUID, fileID uuid.UUID, filename str
 		return "", fmt.Errorf("failed to create user directory: %w", err)
 	}
 
-	filePath := filepath.Join(userDir, fmt.Sprintf("%s_%s", fileID.String(), filename))
+	return s.writeChunks(userDir, fileID, filename, chunks)
+}
+
+// SaveOrganizationFile сохраняет файл в общее хранилище организации
+// Возвращает относительный путь к файлу (относительно basePath)
+func (s *LocalStorage) SaveOrganizationFile(organizationID uuid.UUID, fileID uuid.UUID, filename string, chunks [][]byte) (string, error) {
+	orgDir := filepath.Join(s.basePath, "organizations", organizationID.String(), "files")
+	if err := os.MkdirAll(orgDir, 0755); err != nil {
+		return "", fmt.Errorf("failed to create organization directory: %w", err)
+	}
+
+	return s.writeChunks(orgDir, fileID, filename, chunks)
+}
+
+func (s *LocalStorage) writeChunks(dir string, fileID uuid.UUID, filename string, chunks [][]byte) (string, error) {
+	filePath := filepath.Join(dir, fmt.Sprintf("%s_%s", fileID.String(), filename))
 
 	file, err := os.Create(filePath)
 	if err != nil {
@@ -106,6 +121,52 @@ func (s *LocalStorage) DeleteFile(storagePath string) error {
 	return nil
 }
 
+// CreateExportFile создает файл архива экспорта данных пользователя
+// Возвращает writer и относительный путь к файлу (относительно basePath)
+func (s *LocalStorage) CreateExportFile(userID uuid.UUID, exportID uuid.UUID) (io.WriteCloser, string, error) {
+	exportDir := filepath.Join(s.basePath, "users", userID.String(), "exports")
+	if err := os.MkdirAll(exportDir, 0755); err != nil {
+		return nil, "", fmt.Errorf("failed to create export directory: %w", err)
+	}
+
+	filePath := filepath.Join(exportDir, fmt.Sprintf("%s.zip", exportID.String()))
+
+	file, err := os.Create(filePath)
+	if err != nil {
+		return nil, "", fmt.Errorf("failed to create export file: %w", err)
+	}
+
+	relPath, err := filepath.Rel(s.basePath, filePath)
+	if err != nil {
+		file.Close()
+		return nil, "", fmt.Errorf("failed to get relative path: %w", err)
+	}
+
+	return file, relPath, nil
+}
+
+// DeleteUserData удаляет все данные пользователя из хранилища (файлы, экспорты)
+func (s *LocalStorage) DeleteUserData(userID uuid.UUID) error {
+	userDir := filepath.Join(s.basePath, "users", userID.String())
+
+	if err := os.RemoveAll(userDir); err != nil {
+		return fmt.Errorf("failed to delete user data: %w", err)
+	}
+
+	return nil
+}
+
+// DeleteOrganizationData удаляет общее хранилище организации
+func (s *LocalStorage) DeleteOrganizationData(organizationID uuid.UUID) error {
+	orgDir := filepath.Join(s.basePath, "organizations", organizationID.String())
+
+	if err := os.RemoveAll(orgDir); err != nil {
+		return fmt.Errorf("failed to delete organization data: %w", err)
+	}
+
+	return nil
+}
+
 // FileExists проверяет существование файла
 func (s *LocalStorage) FileExists(storagePath string) bool {
 	fullPath := filepath.Join(s.basePath, storagePath)
@@ -0,0 +1,295 @@
+package websocket
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/backend-app/backend/internal/models"
+	"github.com/backend-app/backend/pkg/devicetoken"
+	"github.com/backend-app/backend/pkg/jwt"
+	"github.com/google/uuid"
+	"github.com/gorilla/websocket"
+)
+
+const (
+	signalingProtocol    = "signaling" // подпротокол v1, который сервер подтверждает клиенту
+	ticketProtocolPrefix = "ticket."   // билет в Sec-WebSocket-Protocol: "ticket.<билет>"
+)
+
+// AuthMessage - данные сообщения "auth", первого сообщения соединения v1, и учетные
+// данные в hello v2. Клиент передает билет из POST /api/v1/signaling/ticket или access
+// токен пользователя вместе с устройством и его токеном.
+type AuthMessage struct {
+	Ticket      string `json:"ticket,omitempty"`
+	Token       string `json:"token,omitempty"` // access токен (JWT)
+	DeviceID    string `json:"device_id,omitempty"`
+	DeviceToken string `json:"device_token,omitempty"`
+}
+
+// HelloMessage - данные сообщения "hello", первого сообщения соединения v2
+type HelloMessage struct {
+	AuthMessage
+	Capabilities []string `json:"capabilities,omitempty"`
+}
+
+// AuthenticatedMessage - данные сообщения "authenticated" (v1): соединение аутентифицировано
+type AuthenticatedMessage struct {
+	DeviceID string `json:"device_id"`
+	UserID   string `json:"user_id"`
+}
+
+// WelcomeMessage - данные сообщения "welcome" (v2): ответ на hello
+type WelcomeMessage struct {
+	ProtocolVersion int      `json:"protocol_version"`
+	Capabilities    []string `json:"capabilities"`
+	DeviceID        string   `json:"device_id"`
+	UserID          string   `json:"user_id"`
+	SessionID       string   `json:"session_id"`
+}
+
+// handshake - итог подключения: устройство, версия протокола и кодек сессии
+type handshake struct {
+	device       *models.Device
+	version      int
+	codec        codec
+	capabilities map[string]bool // nil - клиент v1, ему отправляются все сообщения
+}
+
+// newUpgrader создает upgrader, который принимает браузерные соединения только с
+// разрешенных Origin. Без списка разрешен только тот же хост; клиенты без Origin
+// (не браузеры) принимаются всегда.
+func newUpgrader(allowedOrigins []string) websocket.Upgrader {
+	upgrader := websocket.Upgrader{
+		ReadBufferSize:  1024,
+		WriteBufferSize: 1024,
+		Subprotocols:    []string{protocolV2Name, signalingProtocol},
+	}
+
+	if len(allowedOrigins) > 0 {
+		upgrader.CheckOrigin = func(r *http.Request) bool {
+			origin := r.Header.Get("Origin")
+			if origin == "" {
+				return true
+			}
+
+			for _, allowed := range allowedOrigins {
+				if allowed == "*" || strings.EqualFold(allowed, origin) {
+					return true
+				}
+			}
+
+			return false
+		}
+	}
+
+	return upgrader
+}
+
+// protocolTicket возвращает билет, переданный в Sec-WebSocket-Protocol
+func protocolTicket(r *http.Request) string {
+	for _, protocol := range websocket.Subprotocols(r) {
+		if strings.HasPrefix(protocol, ticketProtocolPrefix) {
+			return strings.TrimPrefix(protocol, ticketProtocolPrefix)
+		}
+	}
+
+	return ""
+}
+
+// requestsUnknownVersion проверяет, что клиент запросил только версии протокола, которых
+// сервер не знает (например, "signaling.v3")
+func requestsUnknownVersion(r *http.Request) bool {
+	for _, protocol := range websocket.Subprotocols(r) {
+		if strings.HasPrefix(protocol, signalingProtocol+".v") {
+			return true
+		}
+	}
+
+	return false
+}
+
+// handshake аутентифицирует соединение в версии протокола, выбранной подпротоколом.
+// Кодек в результате задан и при ошибке, чтобы ответить клиенту в его формате.
+func (s *Server) handshake(conn *websocket.Conn, r *http.Request) (*handshake, error) {
+	ticket := protocolTicket(r)
+
+	if conn.Subprotocol() == "" && requestsUnknownVersion(r) {
+		return &handshake{version: ProtocolV1, codec: jsonCodec{}}, newProtocolError(ErrorUnsupportedVersion, "unsupported protocol version")
+	}
+
+	if conn.Subprotocol() != protocolV2Name {
+		result := &handshake{version: ProtocolV1, codec: jsonCodec{}}
+
+		var err error
+		result.device, err = s.authenticateV1(conn, ticket)
+		return result, err
+	}
+
+	result := &handshake{version: ProtocolV2, codec: envelopeCodec{}}
+
+	// Формат сессии v2 задает кадр hello: бинарный - protobuf, текстовый - protojson
+	frameType, msg, err := s.readFirstMessage(conn, result.codec)
+	if frameType == websocket.BinaryMessage {
+		result.codec = envelopeCodec{binary: true}
+	}
+	if err != nil {
+		return result, err
+	}
+
+	return result, s.hub.acceptHello(result, msg, ticket)
+}
+
+// acceptHello аутентифицирует сессию v2 по первому сообщению hello и запоминает
+// возможности клиента. Билет, переданный вне hello (ticket), заменяет билет в hello.
+func (h *Hub) acceptHello(result *handshake, msg SignalingMessage, ticket string) error {
+	if msg.Type != "hello" {
+		return newProtocolError(ErrorUnauthenticated, "first message must be hello")
+	}
+
+	var hello HelloMessage
+	if err := json.Unmarshal(msg.Data, &hello); err != nil {
+		return newProtocolError(ErrorInvalidMessage, "invalid hello data")
+	}
+
+	result.capabilities = make(map[string]bool, len(hello.Capabilities))
+	for _, capability := range hello.Capabilities {
+		result.capabilities[capability] = true
+	}
+
+	if ticket != "" {
+		hello.Ticket = ticket
+	}
+
+	var err error
+	result.device, err = h.authenticate(&hello.AuthMessage)
+	return err
+}
+
+// authenticateV1 определяет устройство соединения v1: по билету из Sec-WebSocket-Protocol,
+// а без него - по сообщению "auth", которое клиент должен прислать первым
+func (s *Server) authenticateV1(conn *websocket.Conn, ticket string) (*models.Device, error) {
+	if ticket != "" {
+		return s.hub.authenticateTicket(ticket)
+	}
+
+	_, msg, err := s.readFirstMessage(conn, jsonCodec{})
+	if err != nil {
+		return nil, err
+	}
+
+	if msg.Type != "auth" {
+		return nil, newProtocolError(ErrorUnauthenticated, "first message must be auth")
+	}
+
+	var data AuthMessage
+	if err := json.Unmarshal(msg.Data, &data); err != nil {
+		return nil, newProtocolError(ErrorInvalidMessage, "invalid auth data")
+	}
+
+	return s.hub.authenticate(&data)
+}
+
+// readFirstMessage читает первое сообщение соединения, которое клиент должен прислать за AuthTimeout
+func (s *Server) readFirstMessage(conn *websocket.Conn, c codec) (int, SignalingMessage, error) {
+	conn.SetReadDeadline(time.Now().Add(s.config.Signaling.AuthTimeout))
+
+	frameType, payload, err := conn.ReadMessage()
+	if err != nil {
+		var netErr net.Error
+		if errors.As(err, &netErr) && netErr.Timeout() {
+			return 0, SignalingMessage{}, newProtocolError(ErrorUnauthenticated, "authentication timeout")
+		}
+		return 0, SignalingMessage{}, newProtocolError(ErrorInvalidMessage, "invalid first message")
+	}
+
+	conn.SetReadDeadline(time.Time{})
+
+	msg, err := c.Decode(frameType, payload)
+	return frameType, msg, err
+}
+
+// authenticate проверяет учетные данные: билет или access токен с токеном устройства
+func (h *Hub) authenticate(data *AuthMessage) (*models.Device, error) {
+	switch {
+	case data.Ticket != "":
+		return h.authenticateTicket(data.Ticket)
+	case data.Token != "":
+		return h.authenticateToken(data)
+	default:
+		return nil, newProtocolError(ErrorUnauthenticated, "ticket or token is required")
+	}
+}
+
+// authenticateTicket проверяет билет signaling сервера
+func (h *Hub) authenticateTicket(ticket string) (*models.Device, error) {
+	claims, err := jwt.ValidateToken(ticket, h.jwtSecret)
+	if err != nil || claims.Type != "signaling" || claims.DeviceID == nil {
+		return nil, newProtocolError(ErrorUnauthenticated, "invalid or expired ticket")
+	}
+
+	device, err := h.deviceRepo.GetByID(*claims.DeviceID)
+	if err != nil || device == nil || !device.IsActive() || device.UserID != claims.UserID {
+		return nil, newProtocolError(ErrorUnauthenticated, "invalid or expired ticket")
+	}
+
+	return device, checkApproved(device)
+}
+
+// authenticateToken проверяет access токен пользователя и токен его устройства
+func (h *Hub) authenticateToken(data *AuthMessage) (*models.Device, error) {
+	claims, err := jwt.ValidateToken(data.Token, h.jwtSecret)
+	if err != nil || claims.Type != "access" {
+		return nil, newProtocolError(ErrorUnauthenticated, "invalid or expired token")
+	}
+
+	deviceID, err := uuid.Parse(data.DeviceID)
+	if err != nil {
+		return nil, newProtocolError(ErrorInvalidMessage, "invalid device_id")
+	}
+
+	if data.DeviceToken == "" {
+		return nil, newProtocolError(ErrorUnauthenticated, "device_token is required")
+	}
+
+	device, err := h.deviceRepo.GetByTokenHash(devicetoken.Hash(data.DeviceToken))
+	if err != nil || device == nil {
+		return nil, newProtocolError(ErrorUnauthenticated, "invalid device_token")
+	}
+
+	if device.ID != deviceID {
+		return nil, newProtocolError(ErrorUnauthenticated, "device_id does not match device_token")
+	}
+
+	if device.UserID != claims.UserID {
+		return nil, newProtocolError(ErrorPermissionDenied, "device belongs to another user")
+	}
+
+	return device, checkApproved(device)
+}
+
+func checkApproved(device *models.Device) error {
+	if !device.IsApproved() {
+		return newProtocolError(ErrorPermissionDenied, fmt.Sprintf("device is not approved (status: %s)", device.ApprovalStatus))
+	}
+
+	return nil
+}
+
+// rejectConnection сообщает клиенту ошибку в формате его протокола и закрывает еще не
+// зарегистрированное соединение с кодом 1008
+func rejectConnection(conn *websocket.Conn, c codec, err error) {
+	deadline := time.Now().Add(writeWait)
+	conn.SetWriteDeadline(deadline)
+
+	if frameType, payload, encodeErr := c.Encode(errorMessage("", err)); encodeErr == nil {
+		conn.WriteMessage(frameType, payload)
+	}
+
+	conn.WriteControl(websocket.CloseMessage, websocket.FormatCloseMessage(websocket.ClosePolicyViolation, err.Error()), deadline)
+	conn.Close()
+}
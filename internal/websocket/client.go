@@ -0,0 +1,257 @@
+package websocket
+
+import (
+	"encoding/json"
+	"errors"
+	"sync"
+	"time"
+
+	"github.com/backend-app/backend/internal/presence"
+	"github.com/google/uuid"
+	"github.com/gorilla/websocket"
+)
+
+const (
+	sendBufferSize = 256              // сообщений в очереди клиента до отключения как медленного
+	writeWait      = 10 * time.Second // время на запись одного сообщения
+	pongWait       = 60 * time.Second // время ожидания pong (или любого сообщения) от клиента
+	pingPeriod     = 54 * time.Second // интервал ping, меньше pongWait
+	peerCacheTTL   = time.Minute      // сколько сессия помнит, что ей разрешено отправлять сигналы устройству
+)
+
+// Client - одна сессия (соединение) устройства. У устройства может быть несколько
+// сессий, например вкладки браузера или WebSocket и поток gRPC. Сессией владеет хаб:
+// он регистрирует ее, удаляет и закрывает; readPump и writePump завершаются после закрытия.
+type Client struct {
+	ID          uuid.UUID
+	UserID      uuid.UUID
+	DeviceID    uuid.UUID
+	Hub         *Hub
+	LastSeen    time.Time
+	ConnectedAt time.Time
+	mu          sync.Mutex
+
+	presence     presence.Status
+	awayReported bool // away выставлен самим клиентом, а не по неактивности
+
+	conn         transport
+	limiter      *rateLimiter
+	version      int             // версия протокола сессии
+	capabilities map[string]bool // возможности клиента v2; nil - клиенту v1 отправляются все сообщения
+
+	peers map[uuid.UUID]time.Time // проверенные адресаты сигналов; только в readPump
+
+	send      chan SignalingMessage
+	done      chan struct{} // закрывается при закрытии сессии
+	closeOnce sync.Once
+	closeCode int
+	closeText string
+}
+
+func newClient(hub *Hub, conn transport, h *handshake) *Client {
+	now := time.Now()
+
+	return &Client{
+		ID:           uuid.New(),
+		UserID:       h.device.UserID,
+		DeviceID:     h.device.ID,
+		Hub:          hub,
+		LastSeen:     now,
+		ConnectedAt:  now,
+		conn:         conn,
+		limiter:      newRateLimiter(hub.signalingCfg.RateLimits),
+		version:      h.version,
+		capabilities: h.capabilities,
+		peers:        make(map[uuid.UUID]time.Time),
+		send:         make(chan SignalingMessage, sendBufferSize),
+		done:         make(chan struct{}),
+	}
+}
+
+// supports проверяет, что клиент заявил возможность
+func (c *Client) supports(capability string) bool {
+	return c.capabilities == nil || c.capabilities[capability]
+}
+
+// sendGreeting подтверждает аутентификацию: клиент v1 получает authenticated, клиент
+// v2 - welcome с версией протокола и возможностями сервера
+func (c *Client) sendGreeting() {
+	var message SignalingMessage
+	var data interface{}
+
+	if c.version == ProtocolV1 {
+		message.Type = "authenticated"
+		data = &AuthenticatedMessage{
+			DeviceID: c.DeviceID.String(),
+			UserID:   c.UserID.String(),
+		}
+	} else {
+		message.Type = "welcome"
+		data = &WelcomeMessage{
+			ProtocolVersion: c.version,
+			Capabilities:    serverCapabilities,
+			DeviceID:        c.DeviceID.String(),
+			UserID:          c.UserID.String(),
+			SessionID:       c.ID.String(),
+		}
+	}
+
+	payload, err := json.Marshal(data)
+	if err != nil {
+		return
+	}
+
+	message.Data = payload
+	c.Send(message)
+}
+
+// Send ставит сообщение в очередь на отправку. Если очередь заполнена, клиент не
+// успевает читать: сессия закрывается с кодом 1013, сообщение не отправляется.
+// Возвращает false, если сообщение не поставлено в очередь. Сообщения, для которых у
+// клиента нет нужной возможности, пропускаются.
+func (c *Client) Send(message SignalingMessage) bool {
+	if capability, ok := messageCapabilities[message.Type]; ok && !c.supports(capability) {
+		return true
+	}
+
+	select {
+	case <-c.done:
+		return false
+	default:
+	}
+
+	select {
+	case c.send <- message:
+		return true
+	default:
+		c.Hub.log.Warn().
+			Str("device_id", c.DeviceID.String()).
+			Str("client_id", c.ID.String()).
+			Str("type", message.Type).
+			Msg("Client send buffer is full, disconnecting slow client")
+
+		c.close(websocket.CloseTryAgainLater, "send buffer full")
+		return false
+	}
+}
+
+// close закрывает сессию: writePump отправляет клиенту close frame с кодом code и
+// закрывает соединение. Повторные вызовы ничего не делают.
+func (c *Client) close(code int, text string) {
+	c.closeOnce.Do(func() {
+		c.closeCode = code
+		c.closeText = text
+		close(c.done)
+	})
+}
+
+// readPump читает сообщения из соединения, пока оно не закроется, и снимает сессию
+// с регистрации. Сессия, нарушившая лимиты размера или частоты сообщений, закрывается.
+func (c *Client) readPump() {
+	defer func() {
+		// Соединение закрытой сессии закрывает writePump, когда отправит close frame
+		closed := false
+		select {
+		case <-c.done:
+			closed = true
+		default:
+		}
+
+		c.Hub.unregisterClient(c)
+		if !closed {
+			c.conn.Close()
+		}
+	}()
+
+	for {
+		msg, err := c.conn.Receive()
+
+		var protoErr *protocolError
+		if err != nil && !errors.As(err, &protoErr) {
+			if errors.Is(err, errMessageTooBig) {
+				c.violate(violationMessageTooLarge, "", websocket.CloseMessageTooBig, "message too big")
+			} else if websocket.IsUnexpectedCloseError(err, websocket.CloseGoingAway, websocket.CloseAbnormalClosure) {
+				c.Hub.log.Error().Err(err).Msg("WebSocket error")
+			}
+			break
+		}
+
+		// Сообщения, пришедшие после закрытия сессии, до закрытия соединения не обрабатываются
+		select {
+		case <-c.done:
+			continue
+		default:
+		}
+
+		c.mu.Lock()
+		c.LastSeen = time.Now()
+		c.mu.Unlock()
+
+		// Неразобранное сообщение расходует общий лимит "*"
+		msgType := msg.Type
+		if err != nil {
+			msgType = "*"
+		}
+
+		if !c.limiter.allow(msgType) {
+			c.violate(violationRateLimited, msgType, closeRateLimited, "rate limit exceeded")
+			continue
+		}
+
+		if err != nil {
+			c.Send(errorMessage("", err))
+			continue
+		}
+
+		c.handleMessage(msg)
+	}
+}
+
+// writePump - единственный писатель в соединение: отправляет сообщения из очереди и
+// ping, а после закрытия сессии - close frame
+func (c *Client) writePump() {
+	ticker := time.NewTicker(pingPeriod)
+	defer func() {
+		ticker.Stop()
+		c.conn.Close()
+	}()
+
+	for {
+		select {
+		case <-c.done:
+			c.conn.WriteClose(c.closeCode, c.closeText)
+			return
+
+		case message := <-c.send:
+			// Закрытие важнее сообщений, оставшихся в очереди
+			select {
+			case <-c.done:
+				c.conn.WriteClose(c.closeCode, c.closeText)
+				return
+			default:
+			}
+
+			if err := c.conn.Write(message); err != nil {
+				if errors.Is(err, errEncode) {
+					c.Hub.log.Error().Err(err).Str("type", message.Type).Msg("Failed to encode message")
+					continue
+				}
+
+				c.Hub.log.Error().Err(err).Msg("Failed to write message")
+				c.close(websocket.CloseAbnormalClosure, "")
+				return
+			}
+
+		case <-ticker.C:
+			if err := c.conn.Ping(); err != nil {
+				c.close(websocket.CloseAbnormalClosure, "")
+				return
+			}
+		}
+	}
+}
+
+// sendError отправляет клиенту ошибку с кодом в ответ на его сообщение с id
+func (c *Client) sendError(id string, code ErrorCode, errorMsg string) {
+	c.Send(errorMessage(id, newProtocolError(code, errorMsg)))
+}
@@ -0,0 +1,174 @@
+package websocket
+
+import (
+	"context"
+	"errors"
+	"expvar"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/backend-app/backend/internal/models"
+	"github.com/backend-app/backend/pkg/config"
+	"github.com/gorilla/websocket"
+)
+
+// serveTestSessions запускает WebSocket сервер, который регистрирует в хабе сессию
+// устройства протокола v1 без аутентификации, и возвращает его адрес
+func serveTestSessions(t *testing.T, h *testHub, device *models.Device) string {
+	t.Helper()
+
+	upgrader := websocket.Upgrader{}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		conn, err := upgrader.Upgrade(w, r, nil)
+		if err != nil {
+			return
+		}
+
+		conn.SetReadLimit(h.signalingCfg.MaxMessageSize)
+
+		client := newClient(h.Hub, newWSTransport(conn, jsonCodec{}), &handshake{
+			device:  device,
+			version: ProtocolV1,
+			codec:   jsonCodec{},
+		})
+		if !h.registerClient(client) {
+			conn.Close()
+			return
+		}
+
+		go client.readPump()
+		go client.writePump()
+	}))
+	t.Cleanup(server.Close)
+
+	return "ws" + strings.TrimPrefix(server.URL, "http")
+}
+
+func dialTestSession(t *testing.T, url string) *websocket.Conn {
+	t.Helper()
+
+	conn, _, err := websocket.DefaultDialer.Dial(url, nil)
+	if err != nil {
+		t.Fatalf("dial: %v", err)
+	}
+	t.Cleanup(func() { conn.Close() })
+
+	return conn
+}
+
+// readCloseCode читает сообщения сервера до close frame и возвращает его код
+func readCloseCode(t *testing.T, conn *websocket.Conn) int {
+	t.Helper()
+
+	conn.SetReadDeadline(time.Now().Add(2 * time.Second))
+	for {
+		_, _, err := conn.ReadMessage()
+		if err == nil {
+			continue
+		}
+
+		var closeErr *websocket.CloseError
+		if !errors.As(err, &closeErr) {
+			t.Fatalf("connection closed without close frame: %v", err)
+		}
+		return closeErr.Code
+	}
+}
+
+// expvarInt возвращает значение счетчика key в метрике m
+func expvarInt(m *expvar.Map, key string) int64 {
+	if v, ok := m.Get(key).(*expvar.Int); ok {
+		return v.Value()
+	}
+	return 0
+}
+
+func withLimits(maxSize int64, limits map[string]config.RateLimit) func(cfg *config.Config) {
+	return func(cfg *config.Config) {
+		cfg.Signaling.MaxMessageSize = maxSize
+		cfg.Signaling.RateLimits = limits
+	}
+}
+
+func TestClientMessageTooBig(t *testing.T) {
+	h := newTestHub(t, withLimits(256, nil))
+	conn := dialTestSession(t, serveTestSessions(t, h, newTestDevice()))
+
+	before := expvarInt(violationsMetric, violationMessageTooLarge)
+
+	payload := `{"type":"hello","data":"` + strings.Repeat("x", 512) + `"}`
+	if err := conn.WriteMessage(websocket.TextMessage, []byte(payload)); err != nil {
+		t.Fatalf("write: %v", err)
+	}
+
+	if code := readCloseCode(t, conn); code != websocket.CloseMessageTooBig {
+		t.Errorf("close code = %d, want %d", code, websocket.CloseMessageTooBig)
+	}
+
+	if got := expvarInt(violationsMetric, violationMessageTooLarge) - before; got != 1 {
+		t.Errorf("message_too_large violations = %d, want 1", got)
+	}
+}
+
+func TestClientRateLimited(t *testing.T) {
+	h := newTestHub(t, withLimits(0, map[string]config.RateLimit{
+		"*": {Rate: 0.001, Burst: 3},
+	}))
+	conn := dialTestSession(t, serveTestSessions(t, h, newTestDevice()))
+
+	before := expvarInt(rateLimitedMetric, "*")
+
+	for i := 0; i < 4; i++ {
+		if err := conn.WriteMessage(websocket.TextMessage, []byte(`{"type":"hello"}`)); err != nil {
+			t.Fatalf("write: %v", err)
+		}
+	}
+
+	if code := readCloseCode(t, conn); code != closeRateLimited {
+		t.Errorf("close code = %d, want %d", code, closeRateLimited)
+	}
+
+	if got := expvarInt(rateLimitedMetric, "*") - before; got != 1 {
+		t.Errorf("rate limited sessions = %d, want 1", got)
+	}
+}
+
+func TestClientUndecodableFramesRateLimited(t *testing.T) {
+	h := newTestHub(t, withLimits(0, map[string]config.RateLimit{
+		"*":     {Rate: 0.001, Burst: 2},
+		"hello": {Rate: 1000, Burst: 1000},
+	}))
+	conn := dialTestSession(t, serveTestSessions(t, h, newTestDevice()))
+
+	for i := 0; i < 3; i++ {
+		if err := conn.WriteMessage(websocket.TextMessage, []byte("not json")); err != nil {
+			t.Fatalf("write: %v", err)
+		}
+	}
+
+	if code := readCloseCode(t, conn); code != closeRateLimited {
+		t.Errorf("close code = %d, want %d", code, closeRateLimited)
+	}
+}
+
+func TestClientShutdownCloseCode(t *testing.T) {
+	h := newTestHub(t)
+	device := newTestDevice()
+	conn := dialTestSession(t, serveTestSessions(t, h, device))
+
+	eventually(t, "session registered", func() bool { return h.sessionCount(device.ID) == 1 })
+
+	ctx, cancel := context.WithTimeout(context.Background(), 2*time.Second)
+	defer cancel()
+
+	if err := h.Shutdown(ctx); err != nil {
+		t.Fatalf("shutdown: %v", err)
+	}
+
+	if code := readCloseCode(t, conn); code != websocket.CloseGoingAway {
+		t.Errorf("close code = %d, want %d", code, websocket.CloseGoingAway)
+	}
+}
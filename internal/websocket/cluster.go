@@ -0,0 +1,291 @@
+package websocket
+
+import (
+	"context"
+	"encoding/json"
+	"time"
+
+	"github.com/backend-app/backend/internal/presence"
+	"github.com/google/uuid"
+)
+
+// claimDevice записывает в кластере, что у устройства есть сессии на этом узле
+func (h *Hub) claimDevice(deviceID, userID uuid.UUID) {
+	if h.cluster == nil {
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 2*time.Second)
+	defer cancel()
+
+	if err := h.cluster.Register(ctx, deviceID, userID); err != nil {
+		h.log.Error().Err(err).Str("device_id", deviceID.String()).Msg("Failed to register device in cluster")
+	}
+}
+
+// restoreDevices заново регистрирует устройства узла, записи которых могли пропасть,
+// пока пульс не продлевался: другой узел мог счесть этот упавшим и перевести его
+// устройства в offline. Присутствие сохраняется и рассылается заново.
+func (h *Hub) restoreDevices(deviceIDs []uuid.UUID) {
+	for _, deviceID := range deviceIDs {
+		deviceID := deviceID
+		h.schedule(deviceID, func() {
+			h.mu.Lock()
+			sessions, ok := h.devices[deviceID]
+			if ok {
+				// Узел, очистивший этот, уже разослал offline
+				sessions.presence = presence.StatusOffline
+			}
+			h.mu.Unlock()
+
+			// Сессии закрыты: устройство уже освобождено по очереди устройства
+			if !ok {
+				return
+			}
+
+			h.claimDevice(deviceID, sessions.userID)
+			h.updatePresence(deviceID)
+		})
+	}
+}
+
+// releaseDevice освобождает в кластере устройство, у которого не осталось сессий на
+// этом узле. Возвращает false, если у устройства есть сессии на других узлах и его
+// нельзя переводить в offline.
+func (h *Hub) releaseDevice(deviceID uuid.UUID) bool {
+	if h.cluster == nil {
+		return true
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 2*time.Second)
+	defer cancel()
+
+	offline, err := h.cluster.Unregister(ctx, deviceID)
+	if err != nil {
+		h.log.Error().Err(err).Str("device_id", deviceID.String()).Msg("Failed to unregister device from cluster")
+		return true
+	}
+
+	return offline
+}
+
+// remoteNodes возвращает другие узлы кластера, к которым подключены сессии устройства
+func (h *Hub) remoteNodes(deviceID uuid.UUID) ([]string, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), 2*time.Second)
+	defer cancel()
+
+	nodes, err := h.cluster.Nodes(ctx, deviceID)
+	if err != nil {
+		return nil, err
+	}
+
+	remote := nodes[:0]
+	for _, node := range nodes {
+		if node != h.cluster.NodeID() {
+			remote = append(remote, node)
+		}
+	}
+
+	return remote, nil
+}
+
+// connectedElsewhere проверяет, что у устройства есть сессии на других узлах кластера
+func (h *Hub) connectedElsewhere(deviceID uuid.UUID) bool {
+	if h.cluster == nil {
+		return false
+	}
+
+	nodes, err := h.remoteNodes(deviceID)
+	if err != nil {
+		h.log.Error().Err(err).Str("device_id", deviceID.String()).Msg("Failed to get device nodes")
+		return false
+	}
+
+	return len(nodes) > 0
+}
+
+// forwardMessages пересылает сообщения сессиям устройств на других узлах.
+// Одна горутина сохраняет порядок сообщений (offer, затем ICE candidates).
+func (h *Hub) forwardMessages() {
+	for {
+		var request forwardRequest
+		select {
+		case request = <-h.forward:
+		case <-h.done:
+			return
+		}
+
+		message := request.message
+
+		toDeviceID, err := uuid.Parse(message.ToDeviceID)
+		if err != nil {
+			continue
+		}
+
+		nodes, err := h.remoteNodes(toDeviceID)
+		if err != nil {
+			// Без списка узлов сообщение ждет получателя в очереди
+			h.log.Error().Err(err).Str("device_id", message.ToDeviceID).Msg("Failed to get device nodes")
+		}
+
+		// Сообщение, которое не получил ни один узел, ждет получателя в очереди
+		if !h.sendToNodes(nodes, message) && !request.delivered {
+			h.deviceNotConnected(toDeviceID, request.envelope)
+			continue
+		}
+
+		h.ack(request.sender, message, ackDelivered)
+	}
+}
+
+// sendToNodes отправляет сообщение устройству message.ToDeviceID на узлы nodes.
+// Возвращает false, если сообщение не удалось отправить ни на один узел.
+func (h *Hub) sendToNodes(nodes []string, message SignalingMessage) bool {
+	if len(nodes) == 0 {
+		return false
+	}
+
+	payload, err := json.Marshal(&clusterMessage{
+		Node:    h.cluster.NodeID(),
+		Message: message,
+	})
+	if err != nil {
+		return false
+	}
+
+	sent := false
+	for _, node := range nodes {
+		ctx, cancel := context.WithTimeout(context.Background(), 2*time.Second)
+		err = h.cluster.Send(ctx, node, payload)
+		cancel()
+		if err != nil {
+			h.log.Error().Err(err).
+				Str("device_id", message.ToDeviceID).
+				Str("node_id", node).
+				Msg("Failed to forward message to node")
+			continue
+		}
+		sent = true
+	}
+
+	return sent
+}
+
+// broadcastToCluster отправляет сообщение устройствам пользователя на всех узлах
+func (h *Hub) broadcastToCluster(message *clusterMessage) {
+	message.Node = h.cluster.NodeID()
+
+	payload, err := json.Marshal(message)
+	if err != nil {
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 2*time.Second)
+	defer cancel()
+
+	if err := h.cluster.Broadcast(ctx, payload); err != nil {
+		h.log.Error().Err(err).Msg("Failed to broadcast message to cluster")
+	}
+}
+
+// subscribeCluster доставляет подключенным клиентам сообщения других узлов.
+// При обрыве соединения с Redis подписка восстанавливается.
+func (h *Hub) subscribeCluster() {
+	for {
+		err := h.cluster.Subscribe(context.Background(), h.handleClusterMessage)
+		h.log.Error().Err(err).Msg("Cluster subscription interrupted, resubscribing")
+		time.Sleep(time.Second)
+	}
+}
+
+func (h *Hub) handleClusterMessage(payload []byte) {
+	message := &clusterMessage{}
+	if err := json.Unmarshal(payload, message); err != nil {
+		return
+	}
+
+	if message.UserID != nil {
+		// Своим сессиям рассылка уже доставлена
+		if message.Node == h.cluster.NodeID() {
+			return
+		}
+
+		var exceptDeviceID uuid.UUID
+		if message.ExceptDeviceID != nil {
+			exceptDeviceID = *message.ExceptDeviceID
+		}
+
+		h.sendToUser(*message.UserID, exceptDeviceID, message.Message)
+		return
+	}
+
+	toDeviceID, err := uuid.Parse(message.Message.ToDeviceID)
+	if err != nil {
+		return
+	}
+
+	if h.sendToDevice(toDeviceID, message.Message) {
+		return
+	}
+
+	// Устройство успело отключиться от этого узла. Сообщение не пересылается дальше:
+	// если других сессий нет, оно ждет устройство в очереди.
+	h.log.Warn().
+		Str("device_id", message.Message.ToDeviceID).
+		Str("node_id", message.Node).
+		Msg("Forwarded message target not connected")
+
+	if !h.connectedElsewhere(toDeviceID) {
+		h.deviceNotConnected(toDeviceID, envelope{message: message.Message})
+	}
+}
+
+// checkCluster продлевает пульс узла и владение его устройствами и переводит в
+// offline устройства упавших узлов
+func (h *Hub) checkCluster() {
+	if h.cluster == nil {
+		return
+	}
+
+	h.mu.RLock()
+	deviceIDs := make([]uuid.UUID, 0, len(h.devices))
+	for deviceID := range h.devices {
+		deviceIDs = append(deviceIDs, deviceID)
+	}
+	h.mu.RUnlock()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	lapsed, err := h.cluster.Heartbeat(ctx, deviceIDs)
+	if err != nil {
+		h.log.Error().Err(err).Msg("Failed to send cluster heartbeat")
+	}
+	if lapsed && len(deviceIDs) > 0 {
+		h.log.Warn().Int("devices", len(deviceIDs)).Msg("Cluster heartbeat lapsed, registering devices again")
+		h.restoreDevices(deviceIDs)
+	}
+
+	reaped, err := h.cluster.ReapDeadNodes(ctx)
+	if err != nil {
+		h.log.Error().Err(err).Msg("Failed to reap dead signaling nodes")
+	}
+
+	for deviceID, userID := range reaped {
+		if err := h.presence.SetOffline(ctx, deviceID); err != nil {
+			h.log.Error().Err(err).Str("device_id", deviceID.String()).Msg("Failed to clear presence")
+		}
+
+		h.deviceRepo.UpdateLastSeen(deviceID)
+
+		h.notifyPresence(userID, &presence.Presence{
+			DeviceID:  deviceID,
+			Status:    presence.StatusOffline,
+			UpdatedAt: time.Now(),
+		})
+	}
+
+	if len(reaped) > 0 {
+		h.log.Info().Int("devices", len(reaped)).Msg("Devices of dead signaling nodes set offline")
+	}
+}
@@ -0,0 +1,202 @@
+package websocket
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/alicebob/miniredis/v2"
+	"github.com/backend-app/backend/internal/models"
+	"github.com/backend-app/backend/internal/presence"
+	"github.com/backend-app/backend/pkg/config"
+	"github.com/google/uuid"
+)
+
+const testNodeTTL = 10 * time.Second
+
+// newTestCluster создает два узла кластера с общим Redis
+func newTestCluster(t *testing.T) (*miniredis.Miniredis, *testHub, *testHub) {
+	t.Helper()
+
+	redisServer := miniredis.RunT(t)
+
+	var hubs [2]*testHub
+	for i := range hubs {
+		hubs[i] = newTestHubOn(t, redisServer, func(cfg *config.Config) {
+			cfg.Presence.TTL = time.Hour
+			cfg.Signaling.ClusterEnabled = true
+			cfg.Signaling.NodeTTL = testNodeTTL
+		})
+
+		// Узел получает пересланные сообщения только после подписки на свой канал
+		channel := "signaling:node:" + hubs[i].cluster.NodeID() + ":messages"
+		eventually(t, "node subscribed", func() bool {
+			return redisServer.PubSubNumSub(channel)[channel] == 1
+		})
+	}
+
+	return redisServer, hubs[0], hubs[1]
+}
+
+// nodes возвращает узлы, на которых зарегистрировано устройство
+func (h *testHub) nodes(t *testing.T, deviceID uuid.UUID) []string {
+	t.Helper()
+
+	nodes, err := h.cluster.Nodes(context.Background(), deviceID)
+	if err != nil {
+		t.Fatalf("get device nodes: %v", err)
+	}
+	return nodes
+}
+
+// waitPresence ждет событие presence устройства deviceID со статусом status
+func waitPresence(t *testing.T, conn *fakeTransport, deviceID uuid.UUID, status presence.Status) {
+	t.Helper()
+
+	deadline := time.Now().Add(2 * time.Second)
+	for time.Now().Before(deadline) {
+		message := conn.next(t, "presence")
+
+		var p presence.Presence
+		if err := json.Unmarshal(message.Data, &p); err != nil {
+			t.Fatalf("decode presence: %v", err)
+		}
+		if p.DeviceID == deviceID && p.Status == status {
+			return
+		}
+	}
+
+	t.Fatalf("no %s presence for device %s", status, deviceID)
+}
+
+func sameUserDevices() (*models.Device, *models.Device) {
+	first, second := newTestDevice(), newTestDevice()
+	second.UserID = first.UserID
+	return first, second
+}
+
+func TestClusterCrossNodeDelivery(t *testing.T) {
+	_, node1, node2 := newTestCluster(t)
+	device := newTestDevice()
+
+	_, conn := node2.connect(t, device)
+	eventually(t, "device claimed by node 2", func() bool {
+		nodes := node1.nodes(t, device.ID)
+		return len(nodes) == 1 && nodes[0] == node2.cluster.NodeID()
+	})
+
+	node1.route(offer(device, "remote"), nil)
+
+	if got := conn.next(t, "offer"); got.ID != "remote" {
+		t.Errorf("offer id = %q, want remote", got.ID)
+	}
+}
+
+func TestClusterDeadNodeReaped(t *testing.T) {
+	redisServer, node1, node2 := newTestCluster(t)
+	watcher, device := sameUserDevices()
+
+	_, watcherConn := node1.connect(t, watcher)
+	node2.connect(t, device)
+
+	eventually(t, "device online on node 2", func() bool {
+		return len(node1.nodes(t, device.ID)) == 1 &&
+			node1.presenceStatus(t, device.ID) == presence.StatusOnline
+	})
+
+	// Узел 2 перестает продлевать пульс, как упавший процесс
+	redisServer.FastForward(testNodeTTL + time.Second)
+	node1.checkCluster()
+
+	if status := node1.presenceStatus(t, device.ID); status != presence.StatusOffline {
+		t.Errorf("presence = %q, want offline", status)
+	}
+
+	waitPresence(t, watcherConn, device.ID, presence.StatusOffline)
+
+	if nodes := node1.nodes(t, device.ID); len(nodes) != 0 {
+		t.Errorf("device nodes = %v, want none", nodes)
+	}
+}
+
+func TestClusterLiveNodeRecoversAfterReap(t *testing.T) {
+	redisServer, node1, node2 := newTestCluster(t)
+	watcher, device := sameUserDevices()
+
+	_, watcherConn := node1.connect(t, watcher)
+	_, conn := node2.connect(t, device)
+
+	eventually(t, "device online on node 2", func() bool {
+		return len(node1.nodes(t, device.ID)) == 1 &&
+			node1.presenceStatus(t, device.ID) == presence.StatusOnline
+	})
+
+	// Пульс узла 2 пропал (например, пауза процесса), и узел 1 счел его упавшим
+	redisServer.Del("signaling:node:" + node2.cluster.NodeID())
+	node1.checkCluster()
+	waitPresence(t, watcherConn, device.ID, presence.StatusOffline)
+
+	if nodes := node1.nodes(t, device.ID); len(nodes) != 0 {
+		t.Fatalf("device nodes after reap = %v, want none", nodes)
+	}
+
+	// Следующий пульс узла 2 возвращает его устройства в кластер
+	node2.checkCluster()
+
+	eventually(t, "device registered again", func() bool {
+		nodes := node1.nodes(t, device.ID)
+		return len(nodes) == 1 && nodes[0] == node2.cluster.NodeID()
+	})
+	waitPresence(t, watcherConn, device.ID, presence.StatusOnline)
+
+	if status := node1.presenceStatus(t, device.ID); status != presence.StatusOnline {
+		t.Errorf("presence = %q, want online", status)
+	}
+
+	node1.route(offer(device, "recovered"), nil)
+	if got := conn.next(t, "offer"); got.ID != "recovered" {
+		t.Errorf("offer id = %q, want recovered", got.ID)
+	}
+
+	// Узел 2 снова в кластере: его пульс продлевается, устройство не очищается
+	node1.checkCluster()
+	if nodes := node1.nodes(t, device.ID); len(nodes) != 1 {
+		t.Errorf("device nodes = %v, want node 2", nodes)
+	}
+}
+
+func TestClusterDeviceClaimHandoff(t *testing.T) {
+	_, node1, node2 := newTestCluster(t)
+	device := newTestDevice()
+
+	_, conn1 := node1.connect(t, device)
+	_, conn2 := node2.connect(t, device)
+
+	eventually(t, "device claimed by both nodes", func() bool {
+		return len(node1.nodes(t, device.ID)) == 2
+	})
+
+	// Сессия на узле 2 закрыта: устройство остается online на узле 1
+	conn2.Close()
+	eventually(t, "node 2 released the device", func() bool {
+		nodes := node1.nodes(t, device.ID)
+		return len(nodes) == 1 && nodes[0] == node1.cluster.NodeID()
+	})
+
+	if status := node1.presenceStatus(t, device.ID); status != presence.StatusOnline {
+		t.Errorf("presence = %q, want online", status)
+	}
+
+	node2.route(offer(device, "handoff"), nil)
+	if got := conn1.next(t, "offer"); got.ID != "handoff" {
+		t.Errorf("offer id = %q, want handoff", got.ID)
+	}
+
+	// Последняя сессия закрыта: устройство offline во всем кластере
+	conn1.Close()
+	eventually(t, "device offline", func() bool {
+		return len(node1.nodes(t, device.ID)) == 0 &&
+			node1.presenceStatus(t, device.ID) == presence.StatusOffline
+	})
+}
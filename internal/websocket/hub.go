@@ -0,0 +1,762 @@
+package websocket
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"sync"
+	"time"
+
+	"github.com/backend-app/backend/internal/cluster"
+	"github.com/backend-app/backend/internal/events"
+	"github.com/backend-app/backend/internal/fallback"
+	"github.com/backend-app/backend/internal/mailbox"
+	"github.com/backend-app/backend/internal/models"
+	"github.com/backend-app/backend/internal/presence"
+	"github.com/backend-app/backend/internal/progress"
+	"github.com/backend-app/backend/internal/push"
+	"github.com/backend-app/backend/internal/repository"
+	"github.com/backend-app/backend/pkg/config"
+	"github.com/backend-app/backend/pkg/logger"
+	"github.com/google/uuid"
+	"github.com/gorilla/websocket"
+	"github.com/redis/go-redis/v9"
+	"github.com/rs/zerolog"
+)
+
+// deviceSessions - сессии одного устройства, подключенные к узлу
+type deviceSessions struct {
+	userID      uuid.UUID
+	clients     map[*Client]struct{}
+	connectedAt time.Time       // подключение первой сессии
+	presence    presence.Status // последнее сохраненное состояние устройства
+	flushing    int             // сессии, которые еще получают сообщения из очереди
+}
+
+// status - состояние устройства: online, если online хотя бы одна сессия
+func (d *deviceSessions) status() presence.Status {
+	for client := range d.clients {
+		client.mu.Lock()
+		status := client.presence
+		client.mu.Unlock()
+
+		if status == presence.StatusOnline {
+			return presence.StatusOnline
+		}
+	}
+
+	return presence.StatusAway
+}
+
+// envelope - сообщение устройству и сессия, от которой оно пришло. Отправитель
+// получает ack или delivery-failed; у сообщений сервера его нет.
+type envelope struct {
+	message SignalingMessage
+	sender  *Client
+}
+
+// forwardRequest - сообщение устройству для пересылки на другие узлы кластера
+type forwardRequest struct {
+	envelope
+	delivered bool // доставлено сессиям на этом узле
+}
+
+// Hub управляет сессиями подключенных устройств. Набор сессий меняет только горутина
+// run (регистрация, отключение, остановка); остальные горутины читают его под RLock и
+// отправляют сообщения через Client.Send, который не блокируется. Запросы к Redis и
+// базе run передает очередям устройств (schedule).
+type Hub struct {
+	devices        map[uuid.UUID]*deviceSessions // device_id -> сессии
+	mu             sync.RWMutex                  // защищает devices
+	workers        map[uuid.UUID]*deviceWorker   // очереди операций устройств
+	workersMu      sync.Mutex                    // защищает workers и workersStopped
+	workersWG      sync.WaitGroup                // работающие очереди
+	workersStopped bool                          // хаб остановлен, операции выполняются без очереди
+	broadcast      chan envelope
+	register       chan *Client
+	unregister     chan *Client
+	stop           chan chan struct{}
+	done           chan struct{} // закрывается, когда хаб остановлен
+	deviceRepo     *repository.DeviceRepo
+	orgRepo        *repository.OrganizationRepo
+	inboxRepo      *repository.InboxRepo
+	transferRepo   *repository.TransferRepo
+	fileRepo       *repository.FileRepo
+	progress       *progress.Broker
+	fallback       *fallback.Orchestrator
+	cluster        *cluster.Registry   // nil - узел работает без кластера
+	forward        chan forwardRequest // сообщения устройствам на других узлах
+	mailbox        *mailbox.Mailbox    // сообщения устройствам без сессий
+	presence       *presence.Store
+	presenceCfg    config.PresenceConfig
+	signalingCfg   config.SignalingConfig
+	jwtSecret      string // проверка билетов и access токенов при подключении
+	events         *events.Bus
+	push           *push.Service
+	log            zerolog.Logger
+}
+
+// NewHub создает хаб signaling сессий. Один хаб обслуживает WebSocket сервер и
+// gRPC SignalingService, поэтому их клиенты обмениваются сообщениями.
+func NewHub(cfg *config.Config, db *sql.DB, deviceRepo *repository.DeviceRepo, redisClient *redis.Client, pushService *push.Service) *Hub {
+	hub := &Hub{
+		devices:      make(map[uuid.UUID]*deviceSessions),
+		workers:      make(map[uuid.UUID]*deviceWorker),
+		broadcast:    make(chan envelope, 256),
+		register:     make(chan *Client),
+		unregister:   make(chan *Client),
+		stop:         make(chan chan struct{}),
+		done:         make(chan struct{}),
+		deviceRepo:   deviceRepo,
+		orgRepo:      repository.NewOrganizationRepo(db),
+		inboxRepo:    repository.NewInboxRepo(db),
+		transferRepo: repository.NewTransferRepo(db),
+		fileRepo:     repository.NewFileRepo(db),
+		progress:     progress.NewBroker(redisClient),
+		mailbox:      mailbox.New(redisClient, cfg.Signaling.QueueTTL, cfg.Signaling.QueueSize),
+		presence:     presence.NewStore(redisClient, cfg.Presence.TTL),
+		presenceCfg:  cfg.Presence,
+		signalingCfg: cfg.Signaling,
+		jwtSecret:    cfg.Server.JWTSecret,
+		events:       events.NewBus(redisClient),
+		push:         pushService,
+		log:          logger.Get(),
+	}
+
+	hub.fallback = fallback.NewOrchestrator(
+		&cfg.Transfer,
+		hub.transferRepo,
+		deviceRepo,
+		hub.fileRepo,
+		hub.progress,
+		hub.notifyFallback,
+	)
+
+	if cfg.Signaling.ClusterEnabled {
+		hub.cluster = cluster.NewRegistry(redisClient, cfg.Signaling.NodeID, cfg.Signaling.NodeTTL)
+		hub.forward = make(chan forwardRequest, 256)
+
+		go hub.forwardMessages()
+		go hub.subscribeCluster()
+		hub.log.Info().Str("node_id", cfg.Signaling.NodeID).Msg("Signaling cluster mode enabled")
+	}
+
+	go hub.run()
+	go hub.maintain()
+	go hub.subscribeEvents()
+	go hub.expireMessages()
+
+	return hub
+}
+
+// run - единственная горутина, которая меняет набор сессий: регистрирует и удаляет
+// их, доставляет сообщения устройствам и останавливает хаб
+func (h *Hub) run() {
+	for {
+		select {
+		case client := <-h.register:
+			h.addClient(client)
+
+		case client := <-h.unregister:
+			h.removeClient(client)
+
+		case e := <-h.broadcast:
+			h.deliver(e)
+
+		case stopped := <-h.stop:
+			h.closeAll()
+			close(h.done)
+			close(stopped)
+			return
+		}
+	}
+}
+
+// maintain периодически продлевает присутствие и пульс узла в кластере, пока хаб не остановлен
+func (h *Hub) maintain() {
+	ticker := time.NewTicker(h.presenceCfg.CheckInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-h.done:
+			return
+		case <-ticker.C:
+			h.checkPresence()
+			h.checkCluster()
+		}
+	}
+}
+
+// registerClient передает новую сессию хабу. Возвращает false, если хаб остановлен.
+func (h *Hub) registerClient(client *Client) bool {
+	select {
+	case h.register <- client:
+		return true
+	case <-h.done:
+		return false
+	}
+}
+
+// unregisterClient снимает сессию с регистрации; после остановки хаба ничего не делает
+func (h *Hub) unregisterClient(client *Client) {
+	select {
+	case h.unregister <- client:
+	case <-h.done:
+	}
+}
+
+// route передает сообщение устройству message.ToDeviceID через хаб. Сессия sender
+// получает ack, если сообщение доставлено или поставлено в очередь; nil - сообщение сервера.
+func (h *Hub) route(message SignalingMessage, sender *Client) {
+	select {
+	case h.broadcast <- envelope{message: message, sender: sender}:
+	case <-h.done:
+	}
+}
+
+// Shutdown останавливает хаб: закрывает все сессии с кодом 1001 (поток gRPC
+// завершается с Unavailable) и переводит их устройства в offline
+func (h *Hub) Shutdown(ctx context.Context) error {
+	h.fallback.Stop()
+
+	stopped := make(chan struct{})
+
+	select {
+	case h.stop <- stopped:
+	case <-h.done:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+
+	select {
+	case <-stopped:
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+
+	if err := h.waitWorkers(ctx); err != nil {
+		return err
+	}
+
+	if h.cluster != nil {
+		leaveCtx, cancel := context.WithTimeout(ctx, 2*time.Second)
+		defer cancel()
+
+		if err := h.cluster.Leave(leaveCtx); err != nil {
+			h.log.Error().Err(err).Msg("Failed to leave signaling cluster")
+		}
+	}
+
+	return nil
+}
+
+func (h *Hub) addClient(client *Client) {
+	h.mu.Lock()
+	sessions, ok := h.devices[client.DeviceID]
+	if !ok {
+		sessions = &deviceSessions{
+			userID:      client.UserID,
+			clients:     make(map[*Client]struct{}),
+			connectedAt: client.ConnectedAt,
+		}
+		h.devices[client.DeviceID] = sessions
+	}
+	sessions.clients[client] = struct{}{}
+	sessions.flushing++
+	count := len(sessions.clients)
+	h.mu.Unlock()
+
+	h.log.Info().
+		Str("device_id", client.DeviceID.String()).
+		Str("client_id", client.ID.String()).
+		Int("sessions", count).
+		Msg("Client registered")
+
+	client.mu.Lock()
+	client.presence = presence.StatusOnline
+	client.mu.Unlock()
+
+	h.schedule(client.DeviceID, func() {
+		if !ok {
+			h.claimDevice(client.DeviceID, client.UserID)
+		}
+
+		h.updatePresence(client.DeviceID)
+		h.flushMailbox(client)
+
+		h.mu.Lock()
+		sessions.flushing--
+		h.mu.Unlock()
+	})
+
+	go h.sendInbox(client)
+}
+
+func (h *Hub) removeClient(client *Client) {
+	h.mu.Lock()
+	sessions, ok := h.devices[client.DeviceID]
+	if ok {
+		_, ok = sessions.clients[client]
+	}
+	if !ok {
+		h.mu.Unlock()
+		return
+	}
+
+	delete(sessions.clients, client)
+	last := len(sessions.clients) == 0
+	if last {
+		delete(h.devices, client.DeviceID)
+	}
+	h.mu.Unlock()
+
+	client.close(websocket.CloseNormalClosure, "")
+
+	h.log.Info().
+		Str("device_id", client.DeviceID.String()).
+		Str("client_id", client.ID.String()).
+		Msg("Client unregistered")
+
+	if !last {
+		// Закрытая сессия могла быть единственной online
+		h.schedule(client.DeviceID, func() {
+			h.updatePresence(client.DeviceID)
+		})
+		return
+	}
+
+	h.schedule(client.DeviceID, func() {
+		if h.releaseDevice(client.DeviceID) {
+			h.deviceRepo.UpdateLastSeen(client.DeviceID)
+			h.setOffline(client.DeviceID, client.UserID)
+		}
+	})
+}
+
+// closeAll закрывает все сессии при остановке хаба
+func (h *Hub) closeAll() {
+	h.mu.Lock()
+	devices := h.devices
+	h.devices = make(map[uuid.UUID]*deviceSessions)
+	h.mu.Unlock()
+
+	for deviceID, sessions := range devices {
+		for client := range sessions.clients {
+			client.close(websocket.CloseGoingAway, "server shutting down")
+		}
+
+		deviceID, userID := deviceID, sessions.userID
+		h.schedule(deviceID, func() {
+			if h.releaseDevice(deviceID) {
+				h.setOffline(deviceID, userID)
+			}
+		})
+	}
+}
+
+// deliver отправляет сообщение сессиям устройства на этом узле, а в кластере -
+// и на других узлах
+func (h *Hub) deliver(e envelope) {
+	toDeviceID, err := uuid.Parse(e.message.ToDeviceID)
+	if err != nil {
+		return
+	}
+
+	// Пока новая сессия получает сообщения из очереди, остальные сообщения идут после них
+	h.mu.RLock()
+	sessions, ok := h.devices[toDeviceID]
+	flushing := ok && sessions.flushing > 0
+	h.mu.RUnlock()
+
+	if flushing {
+		h.schedule(toDeviceID, func() {
+			h.deliverTo(toDeviceID, e)
+		})
+		return
+	}
+
+	h.deliverTo(toDeviceID, e)
+}
+
+func (h *Hub) deliverTo(toDeviceID uuid.UUID, e envelope) {
+	message := e.message
+
+	delivered := h.sendToDevice(toDeviceID, message)
+
+	if h.cluster == nil {
+		if delivered {
+			h.ack(e.sender, message, ackDelivered)
+		} else {
+			h.deviceNotConnected(toDeviceID, e)
+		}
+		return
+	}
+
+	select {
+	case h.forward <- forwardRequest{envelope: e, delivered: delivered}:
+	default:
+		h.log.Warn().
+			Str("device_id", message.ToDeviceID).
+			Str("type", message.Type).
+			Msg("Cluster forward queue is full, message dropped")
+	}
+}
+
+// sendToDevice отправляет сообщение всем сессиям устройства на этом узле.
+// Возвращает false, если у устройства нет сессий на узле.
+func (h *Hub) sendToDevice(deviceID uuid.UUID, message SignalingMessage) bool {
+	h.mu.RLock()
+	defer h.mu.RUnlock()
+
+	sessions, ok := h.devices[deviceID]
+	if !ok {
+		return false
+	}
+
+	for client := range sessions.clients {
+		client.Send(message)
+	}
+
+	return true
+}
+
+// sendToUser отправляет сообщение сессиям устройств пользователя на этом узле,
+// кроме устройства exceptDeviceID
+func (h *Hub) sendToUser(userID, exceptDeviceID uuid.UUID, message SignalingMessage) {
+	h.mu.RLock()
+	defer h.mu.RUnlock()
+
+	for deviceID, sessions := range h.devices {
+		if sessions.userID != userID || deviceID == exceptDeviceID {
+			continue
+		}
+
+		for client := range sessions.clients {
+			client.Send(message)
+		}
+	}
+}
+
+// isConnected проверяет, что у устройства есть сессии на этом или другом узле
+func (h *Hub) isConnected(deviceID uuid.UUID) bool {
+	h.mu.RLock()
+	_, ok := h.devices[deviceID]
+	h.mu.RUnlock()
+
+	return ok || h.connectedElsewhere(deviceID)
+}
+
+// deviceNotConnected обрабатывает сообщение устройству, у которого нет ни одной
+// сессии: сообщение между устройствами ждет получателя в очереди, остальные теряются
+func (h *Hub) deviceNotConnected(toDeviceID uuid.UUID, e envelope) {
+	message := e.message
+
+	if message.Type == "offer" {
+		go h.pushOffer(toDeviceID, message.FromDeviceID)
+	}
+
+	if !queuedTypes[message.Type] {
+		h.log.Warn().
+			Str("device_id", message.ToDeviceID).
+			Str("type", message.Type).
+			Msg("Target device not connected")
+		return
+	}
+
+	h.schedule(toDeviceID, func() {
+		h.enqueue(toDeviceID, message, e.sender)
+	})
+}
+
+// subscribeEvents доставляет события сервисов подключенным устройствам пользователя.
+// При обрыве соединения с Redis подписка восстанавливается.
+func (h *Hub) subscribeEvents() {
+	for {
+		err := h.events.Subscribe(context.Background(), h.handleEvent)
+		h.log.Error().Err(err).Msg("Event subscription interrupted, resubscribing")
+		time.Sleep(time.Second)
+	}
+}
+
+func (h *Hub) handleEvent(event *events.Event) {
+	message := SignalingMessage{
+		Type: event.Type,
+		Data: event.Data,
+	}
+
+	if event.DeviceID != nil {
+		h.mu.RLock()
+		sessions, ok := h.devices[*event.DeviceID]
+		h.mu.RUnlock()
+
+		if ok && sessions.userID == event.UserID {
+			h.sendToDevice(*event.DeviceID, message)
+		}
+		return
+	}
+
+	h.sendToUser(event.UserID, uuid.Nil, message)
+}
+
+// inboxMessage строит сообщение "inbox" с файлами, которые ждут устройство во
+// входящих. Для пустых входящих возвращается false.
+func (h *Hub) inboxMessage(deviceID uuid.UUID) (SignalingMessage, bool) {
+	items, err := h.inboxRepo.GetPendingByDeviceID(deviceID)
+	if err != nil {
+		h.log.Error().Err(err).Str("device_id", deviceID.String()).Msg("Failed to get inbox")
+		return SignalingMessage{}, false
+	}
+
+	if len(items) == 0 {
+		return SignalingMessage{}, false
+	}
+
+	data, err := json.Marshal(&events.Inbox{Items: items})
+	if err != nil {
+		return SignalingMessage{}, false
+	}
+
+	return SignalingMessage{
+		Type: events.TypeInbox,
+		Data: data,
+	}, true
+}
+
+// sendInbox сообщает подключившейся сессии о файлах, которые ждут устройство во
+// входящих. Пустые входящие не отправляются.
+func (h *Hub) sendInbox(client *Client) {
+	if message, ok := h.inboxMessage(client.DeviceID); ok {
+		client.Send(message)
+	}
+}
+
+// notifyFallback сообщает обоим участникам передачи о смене способа доставки.
+// При переходе на cloud получатель сразу получает входящие, а если не подключен -
+// push-уведомление о передаче.
+func (h *Hub) notifyFallback(transfer *models.Transfer, reason string) {
+	data, err := json.Marshal(&TransferFallbackMessage{
+		TransferID:   transfer.ID.String(),
+		DeliveryPath: string(transfer.DeliveryPath),
+		Status:       string(transfer.Status),
+		Reason:       reason,
+	})
+	if err != nil {
+		return
+	}
+
+	for _, deviceID := range []*uuid.UUID{transfer.FromDeviceID, transfer.ToDeviceID} {
+		if deviceID == nil {
+			continue
+		}
+
+		h.route(SignalingMessage{
+			Type:       "transfer-fallback",
+			ToDeviceID: deviceID.String(),
+			Data:       data,
+		}, nil)
+	}
+
+	if !transfer.IsStoreAndForward() || transfer.Status.IsTerminal() {
+		return
+	}
+
+	device, err := h.deviceRepo.GetByID(*transfer.ToDeviceID)
+	if err != nil || device == nil {
+		return
+	}
+
+	// Входящие доставляются через шину событий всем сессиям получателя на всех узлах
+	if h.isConnected(device.ID) {
+		items, err := h.inboxRepo.GetPendingByDeviceID(device.ID)
+		if err == nil && len(items) > 0 {
+			h.events.PublishToDevice(context.Background(), events.TypeInbox, device.UserID, device.ID, &events.Inbox{Items: items})
+		}
+		return
+	}
+
+	h.push.NotifyAsync(device, &push.Notification{
+		Type:  push.TypeIncomingTransfer,
+		Title: "Incoming file",
+		Body:  "A file is waiting to be received",
+		Data: map[string]string{
+			"transfer_id": transfer.ID.String(),
+			"file_id":     transfer.FileID.String(),
+		},
+	})
+}
+
+// sendTransferMessage пересылает сообщение msg о передаче от сессии sender второму участнику
+func (h *Hub) sendTransferMessage(sender *Client, msg SignalingMessage, toDeviceID uuid.UUID, data *TransferRequestMessage) {
+	payload, err := json.Marshal(data)
+	if err != nil {
+		return
+	}
+
+	h.route(SignalingMessage{
+		Type:         msg.Type,
+		ID:           msg.ID,
+		FromDeviceID: sender.DeviceID.String(),
+		ToDeviceID:   toDeviceID.String(),
+		Data:         payload,
+	}, sender)
+}
+
+// pushOffer будит приложение неподключенного устройства, которому пришел offer,
+// чтобы оно подключилось и приняло соединение
+func (h *Hub) pushOffer(toDeviceID uuid.UUID, fromDeviceID string) {
+	device, err := h.deviceRepo.GetByID(toDeviceID)
+	if err != nil || device == nil || !device.IsApproved() {
+		return
+	}
+
+	h.push.NotifyAsync(device, &push.Notification{
+		Type:  push.TypeIncomingOffer,
+		Title: "Incoming connection",
+		Body:  "Another device is trying to connect",
+		Data: map[string]string{
+			"from_device_id": fromDeviceID,
+		},
+	})
+}
+
+// pushTransferRequest будит приложение неподключенного получателя передачи, чтобы
+// оно подключилось и ответило на запрос
+func (h *Hub) pushTransferRequest(transfer *models.Transfer) {
+	device, err := h.deviceRepo.GetByID(*transfer.ToDeviceID)
+	if err != nil || device == nil || !device.IsApproved() {
+		return
+	}
+
+	h.push.NotifyAsync(device, &push.Notification{
+		Type:  push.TypeIncomingTransfer,
+		Title: "Incoming file",
+		Body:  "Another device wants to send you a file",
+		Data: map[string]string{
+			"transfer_id": transfer.ID.String(),
+			"file_id":     transfer.FileID.String(),
+		},
+	})
+}
+
+// setPresence запоминает состояние сессии и обновляет состояние ее устройства
+func (h *Hub) setPresence(client *Client, status presence.Status) {
+	client.mu.Lock()
+	client.presence = status
+	client.mu.Unlock()
+
+	h.schedule(client.DeviceID, func() {
+		h.updatePresence(client.DeviceID)
+	})
+}
+
+// updatePresence сохраняет состояние устройства по его сессиям в Redis и уведомляет
+// другие устройства пользователя, если оно изменилось
+func (h *Hub) updatePresence(deviceID uuid.UUID) {
+	h.mu.Lock()
+	sessions, ok := h.devices[deviceID]
+	if !ok {
+		h.mu.Unlock()
+		return
+	}
+
+	status := sessions.status()
+	changed := sessions.presence != status
+	sessions.presence = status
+	userID := sessions.userID
+	connectedAt := sessions.connectedAt
+	h.mu.Unlock()
+
+	p := &presence.Presence{
+		DeviceID:       deviceID,
+		Status:         status,
+		ConnectedSince: &connectedAt,
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 2*time.Second)
+	defer cancel()
+
+	if err := h.presence.Set(ctx, p); err != nil {
+		h.log.Error().Err(err).Str("device_id", deviceID.String()).Msg("Failed to store presence")
+	}
+
+	if changed {
+		h.notifyPresence(userID, p)
+	}
+}
+
+// setOffline удаляет присутствие устройства без сессий и уведомляет другие устройства пользователя
+func (h *Hub) setOffline(deviceID, userID uuid.UUID) {
+	ctx, cancel := context.WithTimeout(context.Background(), 2*time.Second)
+	defer cancel()
+
+	if err := h.presence.SetOffline(ctx, deviceID); err != nil {
+		h.log.Error().Err(err).Str("device_id", deviceID.String()).Msg("Failed to clear presence")
+	}
+
+	h.notifyPresence(userID, &presence.Presence{
+		DeviceID:  deviceID,
+		Status:    presence.StatusOffline,
+		UpdatedAt: time.Now(),
+	})
+}
+
+// notifyPresence отправляет событие "presence" остальным подключенным устройствам пользователя
+func (h *Hub) notifyPresence(userID uuid.UUID, p *presence.Presence) {
+	data, err := json.Marshal(p)
+	if err != nil {
+		h.log.Error().Err(err).Msg("Failed to marshal presence")
+		return
+	}
+
+	message := SignalingMessage{
+		Type:         "presence",
+		FromDeviceID: p.DeviceID.String(),
+		Data:         data,
+	}
+
+	h.sendToUser(userID, p.DeviceID, message)
+
+	// Устройства пользователя на других узлах
+	if h.cluster != nil {
+		h.broadcastToCluster(&clusterMessage{
+			UserID:         &userID,
+			ExceptDeviceID: &p.DeviceID,
+			Message:        message,
+		})
+	}
+}
+
+// checkPresence продлевает записи подключенных устройств и переводит неактивные сессии в away
+func (h *Hub) checkPresence() {
+	h.mu.RLock()
+	deviceIDs := make([]uuid.UUID, 0, len(h.devices))
+	var clients []*Client
+	for deviceID, sessions := range h.devices {
+		deviceIDs = append(deviceIDs, deviceID)
+		for client := range sessions.clients {
+			clients = append(clients, client)
+		}
+	}
+	h.mu.RUnlock()
+
+	for _, client := range clients {
+		client.mu.Lock()
+		idle := client.presence == presence.StatusOnline && time.Since(client.LastSeen) > h.presenceCfg.AwayAfter
+		client.mu.Unlock()
+
+		if idle {
+			h.setPresence(client, presence.StatusAway)
+		}
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	for _, deviceID := range deviceIDs {
+		if err := h.presence.Refresh(ctx, deviceID); err != nil {
+			h.log.Error().Err(err).Str("device_id", deviceID.String()).Msg("Failed to refresh presence")
+		}
+	}
+}
@@ -0,0 +1,406 @@
+package websocket
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/alicebob/miniredis/v2"
+	"github.com/backend-app/backend/internal/models"
+	"github.com/backend-app/backend/internal/presence"
+	"github.com/backend-app/backend/internal/push"
+	"github.com/backend-app/backend/internal/repository"
+	"github.com/backend-app/backend/pkg/config"
+	"github.com/google/uuid"
+	"github.com/gorilla/websocket"
+	"github.com/redis/go-redis/v9"
+)
+
+// fakeTransport - соединение сессии в памяти. Сообщения клиента передаются в in,
+// сообщения сервера появляются в out; пока открыт hold, Write ждет.
+type fakeTransport struct {
+	in     chan SignalingMessage
+	out    chan SignalingMessage
+	hold   chan struct{}
+	closed chan struct{} // закрывается, когда сервер отправил close frame
+
+	mu        sync.Mutex
+	closeCode int
+	closeText string
+	closeOnce sync.Once
+	gone      chan struct{} // закрывается в Close
+	goneOnce  sync.Once
+}
+
+func newFakeTransport() *fakeTransport {
+	return &fakeTransport{
+		in:     make(chan SignalingMessage, 16),
+		out:    make(chan SignalingMessage, 1024),
+		closed: make(chan struct{}),
+		gone:   make(chan struct{}),
+	}
+}
+
+func (t *fakeTransport) Receive() (SignalingMessage, error) {
+	select {
+	case message := <-t.in:
+		return message, nil
+	case <-t.gone:
+		return SignalingMessage{}, io.EOF
+	}
+}
+
+func (t *fakeTransport) Write(message SignalingMessage) error {
+	if t.hold != nil {
+		<-t.hold
+	}
+
+	t.out <- message
+	return nil
+}
+
+func (t *fakeTransport) Ping() error {
+	return nil
+}
+
+func (t *fakeTransport) WriteClose(code int, text string) {
+	t.closeOnce.Do(func() {
+		t.mu.Lock()
+		t.closeCode = code
+		t.closeText = text
+		t.mu.Unlock()
+		close(t.closed)
+	})
+}
+
+func (t *fakeTransport) Close() error {
+	t.goneOnce.Do(func() { close(t.gone) })
+	return nil
+}
+
+// waitClose ждет close frame и возвращает его код
+func (t *fakeTransport) waitClose(tb testing.TB) int {
+	tb.Helper()
+
+	select {
+	case <-t.closed:
+	case <-time.After(2 * time.Second):
+		tb.Fatal("session was not closed")
+	}
+
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	return t.closeCode
+}
+
+// next ждет следующее сообщение сервера типа msgType, пропуская остальные
+func (t *fakeTransport) next(tb testing.TB, msgType string) SignalingMessage {
+	tb.Helper()
+
+	timeout := time.After(2 * time.Second)
+	for {
+		select {
+		case message := <-t.out:
+			if message.Type == msgType {
+				return message
+			}
+		case <-timeout:
+			tb.Fatalf("no %q message", msgType)
+		}
+	}
+}
+
+type testHub struct {
+	*Hub
+	redis *miniredis.Miniredis
+}
+
+func newTestHub(tb testing.TB, configure ...func(cfg *config.Config)) *testHub {
+	tb.Helper()
+
+	return newTestHubOn(tb, miniredis.RunT(tb), configure...)
+}
+
+// newTestHubOn создает хаб, который хранит состояние в redisServer. База не отвечает
+// на запросы: хаб только логирует ее ошибки.
+func newTestHubOn(tb testing.TB, redisServer *miniredis.Miniredis, configure ...func(cfg *config.Config)) *testHub {
+	tb.Helper()
+
+	db, _, err := sqlmock.New()
+	if err != nil {
+		tb.Fatalf("sqlmock: %v", err)
+	}
+	tb.Cleanup(func() { db.Close() })
+
+	redisClient := redis.NewClient(&redis.Options{Addr: redisServer.Addr()})
+	tb.Cleanup(func() { redisClient.Close() })
+
+	cfg := &config.Config{
+		Presence: config.PresenceConfig{
+			TTL:           time.Minute,
+			AwayAfter:     time.Minute,
+			CheckInterval: time.Hour,
+		},
+		Signaling: config.SignalingConfig{
+			NodeID:    uuid.NewString(),
+			NodeTTL:   time.Minute,
+			QueueTTL:  time.Minute,
+			QueueSize: 100,
+		},
+	}
+	for _, fn := range configure {
+		fn(cfg)
+	}
+
+	deviceRepo := repository.NewDeviceRepo(db)
+	pushService := push.NewService(map[models.PushProvider]push.Notifier{}, deviceRepo, time.Second)
+
+	hub := NewHub(cfg, db, deviceRepo, redisClient, pushService)
+	tb.Cleanup(func() {
+		ctx, cancel := context.WithTimeout(context.Background(), 2*time.Second)
+		defer cancel()
+		hub.Shutdown(ctx)
+	})
+
+	return &testHub{Hub: hub, redis: redisServer}
+}
+
+// connect регистрирует в хабе сессию устройства и запускает ее readPump и writePump
+func (h *testHub) connect(tb testing.TB, device *models.Device) (*Client, *fakeTransport) {
+	tb.Helper()
+
+	conn := newFakeTransport()
+	client := newClient(h.Hub, conn, &handshake{device: device, version: ProtocolV2})
+
+	if !h.registerClient(client) {
+		tb.Fatal("hub is stopped")
+	}
+
+	go client.readPump()
+	go client.writePump()
+
+	return client, conn
+}
+
+func newTestDevice() *models.Device {
+	return &models.Device{
+		ID:             uuid.New(),
+		UserID:         uuid.New(),
+		ApprovalStatus: models.DeviceApprovalApproved,
+	}
+}
+
+// sessionCount возвращает число сессий устройства на узле
+func (h *testHub) sessionCount(deviceID uuid.UUID) int {
+	h.mu.RLock()
+	defer h.mu.RUnlock()
+
+	sessions, ok := h.devices[deviceID]
+	if !ok {
+		return 0
+	}
+	return len(sessions.clients)
+}
+
+func (h *testHub) presenceStatus(tb testing.TB, deviceID uuid.UUID) presence.Status {
+	tb.Helper()
+
+	state, err := h.presence.Get(context.Background(), deviceID)
+	if err != nil {
+		tb.Fatalf("get presence: %v", err)
+	}
+	return state.Status
+}
+
+// eventually проверяет условие, пока оно не выполнится или не пройдет 2 секунды
+func eventually(tb testing.TB, what string, cond func() bool) {
+	tb.Helper()
+
+	deadline := time.Now().Add(2 * time.Second)
+	for !cond() {
+		if time.Now().After(deadline) {
+			tb.Fatalf("timed out waiting for %s", what)
+		}
+		time.Sleep(5 * time.Millisecond)
+	}
+}
+
+func offer(to *models.Device, id string) SignalingMessage {
+	return SignalingMessage{
+		Type:         "offer",
+		ID:           id,
+		FromDeviceID: uuid.NewString(),
+		ToDeviceID:   to.ID.String(),
+	}
+}
+
+func TestHubMultipleSessionsPerDevice(t *testing.T) {
+	h := newTestHub(t)
+	device := newTestDevice()
+
+	_, first := h.connect(t, device)
+	_, second := h.connect(t, device)
+
+	eventually(t, "both sessions registered", func() bool { return h.sessionCount(device.ID) == 2 })
+	eventually(t, "device online", func() bool {
+		return h.presenceStatus(t, device.ID) == presence.StatusOnline
+	})
+
+	h.route(offer(device, "1"), nil)
+
+	for _, conn := range []*fakeTransport{first, second} {
+		if got := conn.next(t, "offer"); got.ID != "1" {
+			t.Errorf("offer id = %q, want 1", got.ID)
+		}
+	}
+
+	// Отключение одной сессии не переводит устройство в offline
+	first.Close()
+	eventually(t, "first session unregistered", func() bool { return h.sessionCount(device.ID) == 1 })
+
+	if code := first.waitClose(t); code != websocket.CloseNormalClosure {
+		t.Errorf("close code = %d, want %d", code, websocket.CloseNormalClosure)
+	}
+
+	h.route(offer(device, "2"), nil)
+	if got := second.next(t, "offer"); got.ID != "2" {
+		t.Errorf("offer id = %q, want 2", got.ID)
+	}
+
+	if status := h.presenceStatus(t, device.ID); status != presence.StatusOnline {
+		t.Errorf("presence = %q, want online", status)
+	}
+
+	second.Close()
+	eventually(t, "device offline", func() bool {
+		return h.sessionCount(device.ID) == 0 && h.presenceStatus(t, device.ID) == presence.StatusOffline
+	})
+}
+
+func TestHubSlowClientClosedWhenBufferFull(t *testing.T) {
+	h := newTestHub(t)
+	device := newTestDevice()
+
+	client, conn := h.connect(t, device)
+	eventually(t, "session registered", func() bool { return h.sessionCount(device.ID) == 1 })
+
+	// writePump застревает на первом сообщении, остальные копятся в буфере
+	hold := make(chan struct{})
+	conn.hold = hold
+	client.Send(SignalingMessage{Type: "ping"})
+
+	sent := 0
+	for client.Send(SignalingMessage{Type: "pong"}) {
+		sent++
+		if sent > sendBufferSize+1 {
+			t.Fatal("send buffer never filled")
+		}
+	}
+
+	close(hold)
+
+	if code := conn.waitClose(t); code != websocket.CloseTryAgainLater {
+		t.Errorf("close code = %d, want %d", code, websocket.CloseTryAgainLater)
+	}
+
+	eventually(t, "slow session unregistered", func() bool { return h.sessionCount(device.ID) == 0 })
+}
+
+func TestHubConcurrentRegisterUnregister(t *testing.T) {
+	h := newTestHub(t)
+	devices := []*models.Device{newTestDevice(), newTestDevice(), newTestDevice()}
+
+	var wg sync.WaitGroup
+	for i := 0; i < 30; i++ {
+		wg.Add(1)
+		go func(device *models.Device) {
+			defer wg.Done()
+
+			conn := newFakeTransport()
+			client := newClient(h.Hub, conn, &handshake{device: device, version: ProtocolV2})
+			if !h.registerClient(client) {
+				t.Error("hub is stopped")
+				return
+			}
+
+			go client.readPump()
+			go client.writePump()
+
+			h.route(offer(device, client.ID.String()), nil)
+			conn.Close()
+
+			select {
+			case <-conn.closed:
+			case <-time.After(2 * time.Second):
+				t.Error("session was not closed")
+			}
+		}(devices[i%len(devices)])
+	}
+	wg.Wait()
+
+	for _, device := range devices {
+		device := device
+		eventually(t, fmt.Sprintf("device %s offline", device.ID), func() bool {
+			return h.sessionCount(device.ID) == 0 && h.presenceStatus(t, device.ID) == presence.StatusOffline
+		})
+	}
+}
+
+func TestHubQueuedMessagesBeforeNewOnes(t *testing.T) {
+	h := newTestHub(t)
+	device := newTestDevice()
+
+	h.route(offer(device, "queued"), nil)
+	eventually(t, "message queued", func() bool {
+		keys := h.redis.Keys()
+		return len(keys) > 0
+	})
+
+	_, conn := h.connect(t, device)
+	h.route(offer(device, "new"), nil)
+
+	if got := conn.next(t, "offer"); got.ID != "queued" {
+		t.Fatalf("first offer = %q, want queued", got.ID)
+	}
+	if got := conn.next(t, "offer"); got.ID != "new" {
+		t.Fatalf("second offer = %q, want new", got.ID)
+	}
+}
+
+func TestHubShutdownClosesSessions(t *testing.T) {
+	h := newTestHub(t)
+	device := newTestDevice()
+
+	_, first := h.connect(t, device)
+	_, second := h.connect(t, newTestDevice())
+	eventually(t, "device online", func() bool {
+		return h.presenceStatus(t, device.ID) == presence.StatusOnline
+	})
+
+	ctx, cancel := context.WithTimeout(context.Background(), 2*time.Second)
+	defer cancel()
+
+	if err := h.Shutdown(ctx); err != nil {
+		t.Fatalf("shutdown: %v", err)
+	}
+
+	for _, conn := range []*fakeTransport{first, second} {
+		if code := conn.waitClose(t); code != websocket.CloseGoingAway {
+			t.Errorf("close code = %d, want %d", code, websocket.CloseGoingAway)
+		}
+	}
+
+	// Shutdown дожидается очередей устройств, поэтому присутствие уже удалено
+	if status := h.presenceStatus(t, device.ID); status != presence.StatusOffline {
+		t.Errorf("presence = %q, want offline", status)
+	}
+
+	client := newClient(h.Hub, newFakeTransport(), &handshake{device: device, version: ProtocolV2})
+	if h.registerClient(client) {
+		t.Error("stopped hub registered a session")
+	}
+}
@@ -0,0 +1,115 @@
+package websocket
+
+import (
+	"errors"
+	"expvar"
+	"math"
+	"time"
+
+	"github.com/backend-app/backend/pkg/config"
+)
+
+// closeRateLimited - код закрытия сессии, превысившей лимит частоты сообщений (как HTTP 429)
+const closeRateLimited = 4429
+
+// Виды нарушений лимитов в метриках
+const (
+	violationMessageTooLarge = "message_too_large"
+	violationRateLimited     = "rate_limited"
+)
+
+// errMessageTooBig - сообщение клиента больше SIGNALING_MAX_MESSAGE_SIZE
+var errMessageTooBig = errors.New("message too big")
+
+// Метрики нарушений в expvar (/debug/vars на WS_PORT)
+var (
+	violationsMetric  = expvar.NewMap("signaling_violations")   // отключения по видам нарушений
+	rateLimitedMetric = expvar.NewMap("signaling_rate_limited") // отключения за частоту по типам сообщений
+)
+
+// tokenBucket - лимит частоты: rate токенов в секунду, не больше burst в запасе
+type tokenBucket struct {
+	rate   float64
+	burst  float64
+	tokens float64
+	last   time.Time
+}
+
+// take расходует токен, если он есть
+func (b *tokenBucket) take(now time.Time) bool {
+	b.tokens = math.Min(b.burst, b.tokens+now.Sub(b.last).Seconds()*b.rate)
+	b.last = now
+
+	if b.tokens < 1 {
+		return false
+	}
+
+	b.tokens--
+	return true
+}
+
+// rateLimiter - лимиты частоты сообщений одной сессии, отдельные для каждого типа.
+// Типы без своего лимита делят лимит "*"; без него не ограничиваются. Используется
+// только в readPump, поэтому без блокировок.
+type rateLimiter struct {
+	limits  map[string]config.RateLimit
+	buckets map[string]*tokenBucket
+}
+
+func newRateLimiter(limits map[string]config.RateLimit) *rateLimiter {
+	return &rateLimiter{
+		limits:  limits,
+		buckets: make(map[string]*tokenBucket),
+	}
+}
+
+// key - лимит, которому подчиняется тип сообщения
+func (l *rateLimiter) key(msgType string) string {
+	if _, ok := l.limits[msgType]; ok {
+		return msgType
+	}
+
+	return "*"
+}
+
+// allow проверяет, что сообщение типа msgType укладывается в лимит
+func (l *rateLimiter) allow(msgType string) bool {
+	key := l.key(msgType)
+
+	limit, ok := l.limits[key]
+	if !ok {
+		return true
+	}
+
+	now := time.Now()
+
+	bucket, ok := l.buckets[key]
+	if !ok {
+		bucket = &tokenBucket{
+			rate:   limit.Rate,
+			burst:  float64(limit.Burst),
+			tokens: float64(limit.Burst),
+			last:   now,
+		}
+		l.buckets[key] = bucket
+	}
+
+	return bucket.take(now)
+}
+
+// violate закрывает сессию, нарушившую лимит, и учитывает нарушение в метриках
+func (c *Client) violate(violation string, msgType string, code int, text string) {
+	violationsMetric.Add(violation, 1)
+	if violation == violationRateLimited {
+		rateLimitedMetric.Add(c.limiter.key(msgType), 1)
+	}
+
+	c.Hub.log.Warn().
+		Str("device_id", c.DeviceID.String()).
+		Str("client_id", c.ID.String()).
+		Str("type", msgType).
+		Str("violation", violation).
+		Msg("Signaling limit exceeded, disconnecting client")
+
+	c.close(code, text)
+}
@@ -0,0 +1,210 @@
+package websocket
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"time"
+
+	"github.com/backend-app/backend/internal/mailbox"
+	"github.com/google/uuid"
+)
+
+// mailboxSweepInterval - как часто хаб ищет сообщения, не дождавшиеся получателя
+const mailboxSweepInterval = time.Second
+
+const (
+	ackDelivered = "delivered" // сообщение передано сессиям получателя
+	ackQueued    = "queued"    // получатель не подключен, сообщение ждет его в очереди
+
+	deliveryFailedExpired     = "expired"           // получатель не подключился за время жизни очереди
+	deliveryFailedQueueFull   = "queue_full"        // очередь получателя заполнена
+	deliveryFailedUnavailable = "queue_unavailable" // очередь недоступна
+)
+
+// queuedTypes - сообщения между устройствами, которые ждут неподключенного получателя в очереди
+var queuedTypes = map[string]bool{
+	"offer":            true,
+	"answer":           true,
+	"ice-candidate":    true,
+	"ice-state":        true,
+	"transfer-request": true,
+	"transfer-accept":  true,
+	"transfer-reject":  true,
+}
+
+// AckMessage - данные сообщения "ack": сервер принял сообщение клиента с тем же id
+type AckMessage struct {
+	Status string `json:"status"` // "delivered" или "queued"
+}
+
+// DeliveryFailedMessage - данные сообщения "delivery-failed": сообщение с тем же id
+// не доставлено получателю
+type DeliveryFailedMessage struct {
+	MessageType string `json:"message_type"`
+	ToDeviceID  string `json:"to_device_id"`
+	Reason      string `json:"reason"` // "expired", "queue_full" или "queue_unavailable"
+}
+
+// ack подтверждает отправителю, что сообщение доставлено или поставлено в очередь
+func (h *Hub) ack(sender *Client, message SignalingMessage, status string) {
+	if sender == nil || message.ID == "" {
+		return
+	}
+
+	data, err := json.Marshal(&AckMessage{Status: status})
+	if err != nil {
+		return
+	}
+
+	sender.Send(SignalingMessage{
+		Type: "ack",
+		ID:   message.ID,
+		Data: data,
+	})
+}
+
+// enqueue ставит сообщение неподключенному устройству в очередь. Если это не удалось,
+// отправитель сразу получает delivery-failed.
+func (h *Hub) enqueue(toDeviceID uuid.UUID, message SignalingMessage, sender *Client) {
+	payload, err := json.Marshal(message)
+	if err != nil {
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 2*time.Second)
+	defer cancel()
+
+	err = h.mailbox.Push(ctx, toDeviceID, payload)
+	switch {
+	case err == nil:
+		h.ack(sender, message, ackQueued)
+	case errors.Is(err, mailbox.ErrFull):
+		h.log.Warn().Str("device_id", toDeviceID.String()).Msg("Signaling queue is full, message dropped")
+		h.failDelivery(message, deliveryFailedQueueFull)
+	default:
+		h.log.Error().Err(err).Str("device_id", toDeviceID.String()).Msg("Failed to queue signaling message")
+		h.failDelivery(message, deliveryFailedUnavailable)
+	}
+}
+
+// flushMailbox отдает подключившейся сессии сообщения, которые ждали устройство в
+// очереди, и сообщает их отправителям о доставке
+func (h *Hub) flushMailbox(client *Client) {
+	ctx, cancel := context.WithTimeout(context.Background(), 2*time.Second)
+	defer cancel()
+
+	pending, expired, err := h.mailbox.Drain(ctx, client.DeviceID)
+	if err != nil {
+		h.log.Error().Err(err).Str("device_id", client.DeviceID.String()).Msg("Failed to drain signaling queue")
+		return
+	}
+
+	for _, payload := range pending {
+		var message SignalingMessage
+		if err := json.Unmarshal(payload, &message); err != nil {
+			continue
+		}
+
+		if !client.Send(message) {
+			h.failDelivery(message, deliveryFailedUnavailable)
+			continue
+		}
+
+		h.notifySender(message, "ack", &AckMessage{Status: ackDelivered})
+	}
+
+	h.failExpired(expired)
+
+	if len(pending) > 0 {
+		h.log.Info().
+			Str("device_id", client.DeviceID.String()).
+			Int("messages", len(pending)).
+			Msg("Queued signaling messages delivered")
+	}
+}
+
+// expireMessages возвращает отправителям сообщения, срок ожидания которых истек,
+// пока хаб не остановлен
+func (h *Hub) expireMessages() {
+	ticker := time.NewTicker(mailboxSweepInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-h.done:
+			return
+		case <-ticker.C:
+			ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+			expired, err := h.mailbox.Expired(ctx)
+			cancel()
+			if err != nil {
+				h.log.Error().Err(err).Msg("Failed to collect expired signaling messages")
+			}
+
+			h.failExpired(expired)
+		}
+	}
+}
+
+func (h *Hub) failExpired(payloads [][]byte) {
+	for _, payload := range payloads {
+		var message SignalingMessage
+		if err := json.Unmarshal(payload, &message); err != nil {
+			continue
+		}
+
+		h.failDelivery(message, deliveryFailedExpired)
+	}
+}
+
+// failDelivery сообщает отправителю, что сообщение не доставлено
+func (h *Hub) failDelivery(message SignalingMessage, reason string) {
+	h.notifySender(message, "delivery-failed", &DeliveryFailedMessage{
+		MessageType: message.Type,
+		ToDeviceID:  message.ToDeviceID,
+		Reason:      reason,
+	})
+}
+
+// notifySender отправляет устройству-отправителю сообщения служебное сообщение с его id
+func (h *Hub) notifySender(message SignalingMessage, msgType string, data interface{}) {
+	if message.ID == "" {
+		return
+	}
+
+	fromDeviceID, err := uuid.Parse(message.FromDeviceID)
+	if err != nil {
+		return
+	}
+
+	payload, err := json.Marshal(data)
+	if err != nil {
+		return
+	}
+
+	h.notifyDevice(fromDeviceID, SignalingMessage{
+		Type:       msgType,
+		ID:         message.ID,
+		ToDeviceID: message.FromDeviceID,
+		Data:       payload,
+	})
+}
+
+// notifyDevice отправляет служебное сообщение сессиям устройства на этом и других
+// узлах. Такие сообщения не ставятся в очередь: без сессий сообщение теряется.
+func (h *Hub) notifyDevice(deviceID uuid.UUID, message SignalingMessage) {
+	h.sendToDevice(deviceID, message)
+
+	if h.cluster == nil {
+		return
+	}
+
+	nodes, err := h.remoteNodes(deviceID)
+	if err != nil {
+		h.log.Error().Err(err).Str("device_id", deviceID.String()).Msg("Failed to get device nodes")
+		return
+	}
+
+	h.sendToNodes(nodes, message)
+}
@@ -0,0 +1,282 @@
+package websocket
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+
+	signalingpb "github.com/backend-app/backend/pkg/proto/signaling"
+	"github.com/gorilla/websocket"
+	"google.golang.org/protobuf/encoding/protojson"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protoreflect"
+)
+
+// Версии протокола. Версия выбирается подпротоколом WebSocket: "signaling.v2" - v2,
+// "signaling" или без подпротокола - v1.
+const (
+	ProtocolV1 = 1 // JSON сообщения SignalingMessage
+	ProtocolV2 = 2 // Envelope из pkg/proto/signaling: protojson или protobuf
+
+	protocolV2Name = "signaling.v2"
+)
+
+// Возможности сервера, о которых он сообщает в welcome. Клиент v2 перечисляет в
+// hello свои: сообщения, требующие возможности, которой у клиента нет, ему не отправляются.
+const (
+	CapabilityAck             = "ack"              // ack и delivery-failed
+	CapabilityOfflineQueue    = "offline-queue"    // очередь сообщений неподключенным устройствам
+	CapabilityPresence        = "presence"         // события presence
+	CapabilityDeviceApproval  = "device-approval"  // device-approve и device-reject
+	CapabilityTransferRequest = "transfer-request" // transfer-request, transfer-accept и transfer-reject
+	CapabilityICEFallback     = "ice-fallback"     // ice-state и transfer-fallback
+)
+
+var serverCapabilities = []string{
+	CapabilityAck,
+	CapabilityOfflineQueue,
+	CapabilityPresence,
+	CapabilityDeviceApproval,
+	CapabilityTransferRequest,
+	CapabilityICEFallback,
+}
+
+// messageCapabilities - возможность клиента, без которой сообщение типа ему не отправляется
+var messageCapabilities = map[string]string{
+	"ack":             CapabilityAck,
+	"delivery-failed": CapabilityAck,
+	"presence":        CapabilityPresence,
+}
+
+// ErrorCode - код ошибки в сообщении error: в v1 строка в поле code, в v2 - ErrorCode схемы
+type ErrorCode string
+
+const (
+	ErrorInvalidMessage     ErrorCode = "invalid_message"
+	ErrorUnknownType        ErrorCode = "unknown_type"
+	ErrorUnsupportedVersion ErrorCode = "unsupported_version"
+	ErrorUnauthenticated    ErrorCode = "unauthenticated"
+	ErrorPermissionDenied   ErrorCode = "permission_denied"
+	ErrorNotFound           ErrorCode = "not_found"
+	ErrorFailedPrecondition ErrorCode = "failed_precondition"
+	ErrorInternal           ErrorCode = "internal"
+)
+
+var errorCodes = map[ErrorCode]signalingpb.ErrorCode{
+	ErrorInvalidMessage:     signalingpb.ErrorCode_ERROR_CODE_INVALID_MESSAGE,
+	ErrorUnknownType:        signalingpb.ErrorCode_ERROR_CODE_UNKNOWN_TYPE,
+	ErrorUnsupportedVersion: signalingpb.ErrorCode_ERROR_CODE_UNSUPPORTED_VERSION,
+	ErrorUnauthenticated:    signalingpb.ErrorCode_ERROR_CODE_UNAUTHENTICATED,
+	ErrorPermissionDenied:   signalingpb.ErrorCode_ERROR_CODE_PERMISSION_DENIED,
+	ErrorNotFound:           signalingpb.ErrorCode_ERROR_CODE_NOT_FOUND,
+	ErrorFailedPrecondition: signalingpb.ErrorCode_ERROR_CODE_FAILED_PRECONDITION,
+	ErrorInternal:           signalingpb.ErrorCode_ERROR_CODE_INTERNAL,
+}
+
+// protocolError - ошибка, которую клиент получает в сообщении error с кодом
+type protocolError struct {
+	code    ErrorCode
+	message string
+}
+
+func newProtocolError(code ErrorCode, message string) error {
+	return &protocolError{code: code, message: message}
+}
+
+func (e *protocolError) Error() string {
+	return e.message
+}
+
+// errorMessage строит сообщение error для ошибки err в ответ на сообщение с id
+func errorMessage(id string, err error) SignalingMessage {
+	code := ErrorInternal
+	var protoErr *protocolError
+	if errors.As(err, &protoErr) {
+		code = protoErr.code
+	}
+
+	return SignalingMessage{
+		Type:  "error",
+		ID:    id,
+		Code:  code,
+		Error: err.Error(),
+	}
+}
+
+// payloadFields - поле payload в Envelope для данных сообщения типа. Данные остальных
+// типов передаются в event.
+var payloadFields = map[string]protoreflect.Name{
+	"hello":             "hello",
+	"welcome":           "welcome",
+	"presence":          "presence",
+	"device-approve":    "approval",
+	"device-reject":     "approval",
+	"ice-state":         "ice_state",
+	"transfer-fallback": "transfer_fallback",
+	"transfer-request":  "transfer",
+	"transfer-accept":   "transfer",
+	"transfer-reject":   "transfer",
+	"ack":               "ack",
+	"delivery-failed":   "delivery_failed",
+}
+
+var (
+	envelopeFields  = (&signalingpb.Envelope{}).ProtoReflect().Descriptor().Fields()
+	payloadOneof    = (&signalingpb.Envelope{}).ProtoReflect().Descriptor().Oneofs().ByName("payload")
+	protojsonWriter = protojson.MarshalOptions{UseProtoNames: true}
+	protojsonReader = protojson.UnmarshalOptions{DiscardUnknown: true}
+)
+
+// codec кодирует сообщения сессии в кадры WebSocket версии протокола сессии
+type codec interface {
+	Encode(message SignalingMessage) (frameType int, payload []byte, err error)
+	Decode(frameType int, payload []byte) (SignalingMessage, error)
+}
+
+// jsonCodec - протокол v1: SignalingMessage в текстовых кадрах
+type jsonCodec struct{}
+
+func (jsonCodec) Encode(message SignalingMessage) (int, []byte, error) {
+	payload, err := json.Marshal(&message)
+	return websocket.TextMessage, payload, err
+}
+
+func (jsonCodec) Decode(_ int, payload []byte) (SignalingMessage, error) {
+	var message SignalingMessage
+	if err := json.Unmarshal(payload, &message); err != nil {
+		return SignalingMessage{}, newProtocolError(ErrorInvalidMessage, "invalid message")
+	}
+
+	return message, nil
+}
+
+// envelopeCodec - протокол v2: Envelope в бинарных кадрах (binary) или protojson в
+// текстовых. Входящие кадры разбираются по их типу.
+type envelopeCodec struct {
+	binary bool
+}
+
+func (c envelopeCodec) Encode(message SignalingMessage) (int, []byte, error) {
+	envelope, err := toEnvelope(message)
+	if err != nil {
+		return 0, nil, err
+	}
+
+	if c.binary {
+		payload, err := proto.Marshal(envelope)
+		return websocket.BinaryMessage, payload, err
+	}
+
+	payload, err := protojsonWriter.Marshal(envelope)
+	return websocket.TextMessage, payload, err
+}
+
+func (envelopeCodec) Decode(frameType int, payload []byte) (SignalingMessage, error) {
+	envelope := &signalingpb.Envelope{}
+
+	var err error
+	if frameType == websocket.BinaryMessage {
+		err = proto.Unmarshal(payload, envelope)
+	} else {
+		err = protojsonReader.Unmarshal(payload, envelope)
+	}
+	if err != nil {
+		return SignalingMessage{}, newProtocolError(ErrorInvalidMessage, "invalid message")
+	}
+
+	return fromEnvelope(envelope)
+}
+
+// toEnvelope переводит сообщение в Envelope протокола v2
+func toEnvelope(message SignalingMessage) (*signalingpb.Envelope, error) {
+	envelope := &signalingpb.Envelope{
+		Type:         message.Type,
+		Id:           message.ID,
+		FromDeviceId: message.FromDeviceID,
+		ToDeviceId:   message.ToDeviceID,
+	}
+
+	if message.SDP != nil {
+		envelope.Sdp = &signalingpb.SessionDescription{
+			Type: message.SDP.Type,
+			Sdp:  message.SDP.SDP,
+		}
+	}
+
+	if message.Candidate != nil {
+		envelope.Candidate = &signalingpb.IceCandidate{
+			Candidate:     message.Candidate.Candidate,
+			SdpMlineIndex: int32(message.Candidate.SDPMLineIndex),
+			SdpMid:        message.Candidate.SDPMid,
+		}
+	}
+
+	if message.Type == "error" {
+		envelope.Error = &signalingpb.Error{
+			Code:    errorCodes[message.Code],
+			Message: message.Error,
+		}
+	}
+
+	if len(message.Data) == 0 {
+		return envelope, nil
+	}
+
+	name, ok := payloadFields[message.Type]
+	if !ok {
+		name = "event"
+	}
+
+	field := envelopeFields.ByName(name)
+	payload := envelope.ProtoReflect().NewField(field).Message()
+	if err := protojsonReader.Unmarshal(message.Data, payload.Interface()); err != nil {
+		return nil, fmt.Errorf("failed to convert %s data: %w", message.Type, err)
+	}
+	envelope.ProtoReflect().Set(field, protoreflect.ValueOfMessage(payload))
+
+	return envelope, nil
+}
+
+// fromEnvelope переводит Envelope протокола v2 в сообщение. Данные должны быть в
+// поле payload, соответствующем типу сообщения.
+func fromEnvelope(envelope *signalingpb.Envelope) (SignalingMessage, error) {
+	message := SignalingMessage{
+		Type:         envelope.Type,
+		ID:           envelope.Id,
+		FromDeviceID: envelope.FromDeviceId,
+		ToDeviceID:   envelope.ToDeviceId,
+	}
+
+	if envelope.Sdp != nil {
+		message.SDP = &SDPMessage{
+			Type: envelope.Sdp.Type,
+			SDP:  envelope.Sdp.Sdp,
+		}
+	}
+
+	if envelope.Candidate != nil {
+		message.Candidate = &ICECandidate{
+			Candidate:     envelope.Candidate.Candidate,
+			SDPMLineIndex: int(envelope.Candidate.SdpMlineIndex),
+			SDPMid:        envelope.Candidate.SdpMid,
+		}
+	}
+
+	field := envelope.ProtoReflect().WhichOneof(payloadOneof)
+	if field == nil {
+		return message, nil
+	}
+
+	name, ok := payloadFields[message.Type]
+	if !ok || field.Name() != name {
+		return SignalingMessage{}, newProtocolError(ErrorInvalidMessage, fmt.Sprintf("unexpected payload %s for %s", field.Name(), message.Type))
+	}
+
+	data, err := protojsonWriter.Marshal(envelope.ProtoReflect().Get(field).Message().Interface())
+	if err != nil {
+		return SignalingMessage{}, newProtocolError(ErrorInvalidMessage, "invalid message")
+	}
+	message.Data = data
+
+	return message, nil
+}
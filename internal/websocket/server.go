@@ -4,34 +4,33 @@ import (
 	"context"
 	"database/sql"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"net/http"
-	"sync"
+	"net/url"
+	"strconv"
+	"strings"
 	"time"
 
-	"github.com/backend-app/backend/internal/repository"
+	"github.com/backend-app/backend/internal/events"
+	"github.com/backend-app/backend/internal/fallback"
+	"github.com/backend-app/backend/internal/models"
+	"github.com/backend-app/backend/internal/presence"
+	"github.com/backend-app/backend/internal/progress"
 	"github.com/backend-app/backend/pkg/config"
-	"github.com/backend-app/backend/pkg/logger"
 	"github.com/google/uuid"
 	"github.com/gorilla/websocket"
-	"github.com/rs/zerolog"
 )
 
-var upgrader = websocket.Upgrader{
-	CheckOrigin: func(r *http.Request) bool {
-		return true
-	},
-	ReadBufferSize:  1024,
-	WriteBufferSize: 1024,
-}
-
 // SignalingMessage представляет сообщение для WebRTC signaling
 type SignalingMessage struct {
-	Type         string          `json:"type"` // "offer", "answer", "ice-candidate", "error"
+	Type         string          `json:"type"`         // "offer", "answer", "ice-candidate", "presence", "device-approve", "device-reject", "ice-state", "transfer-fallback", "transfer-request", "transfer-accept", "transfer-reject", "inbox", "auth", "authenticated", "ack", "delivery-failed", "error"
+	ID           string          `json:"id,omitempty"` // идентификатор сообщения клиента; если не передан, его назначает сервер
 	FromDeviceID string          `json:"from_device_id,omitempty"`
 	ToDeviceID   string          `json:"to_device_id,omitempty"`
 	SDP          *SDPMessage     `json:"sdp,omitempty"`
 	Candidate    *ICECandidate   `json:"candidate,omitempty"`
+	Code         ErrorCode       `json:"code,omitempty"` // код ошибки для "error"
 	Error        string          `json:"error,omitempty"`
 	Data         json.RawMessage `json:"data,omitempty"`
 }
@@ -49,53 +48,70 @@ type ICECandidate struct {
 	SDPMid        string `json:"sdpMid,omitempty"`
 }
 
-// Client представляет подключенное устройство
-type Client struct {
-	ID       uuid.UUID
-	UserID   uuid.UUID
-	DeviceID uuid.UUID
-	Conn     *websocket.Conn
-	Send     chan SignalingMessage
-	Hub      *Hub
-	LastSeen time.Time
-	mu       sync.Mutex
+// PresenceMessage - данные сообщения "presence" от клиента
+type PresenceMessage struct {
+	Status presence.Status `json:"status"` // "online" или "away"
 }
 
-// Hub управляет всеми подключенными клиентами
-type Hub struct {
-	clients    map[uuid.UUID]*Client // device_id -> client
-	broadcast  chan SignalingMessage
-	register   chan *Client
-	unregister chan *Client
-	mu         sync.RWMutex
-	deviceRepo *repository.DeviceRepo
-	log        zerolog.Logger
+// ApprovalMessage - данные сообщений "device-approve" и "device-reject"
+type ApprovalMessage struct {
+	DeviceID string `json:"device_id"`
 }
 
-// Server представляет WebSocket сервер для signaling
-type Server struct {
-	hub    *Hub
-	config *config.Config
-	port   string
+// ICEStateMessage - данные сообщения "ice-state": клиент сообщает результат ICE
+// согласования для передачи. Сообщение пересылается второму участнику передачи.
+type ICEStateMessage struct {
+	TransferID string `json:"transfer_id"`
+	State      string `json:"state"`          // "checking", "connected" или "failed"
+	Path       string `json:"path,omitempty"` // "webrtc" или "relay"; пусто - текущий способ передачи
 }
 
-// NewServer создает новый WebSocket signaling сервер
-func NewServer(cfg *config.Config, db *sql.DB, deviceRepo *repository.DeviceRepo) *Server {
-	hub := &Hub{
-		clients:    make(map[uuid.UUID]*Client),
-		broadcast:  make(chan SignalingMessage, 256),
-		register:   make(chan *Client),
-		unregister: make(chan *Client),
-		deviceRepo: deviceRepo,
-		log:        logger.Get(),
-	}
+// TransferFallbackMessage - данные сообщения "transfer-fallback": передача переведена
+// на запасной способ доставки или, если их не осталось, завершилась с ошибкой
+type TransferFallbackMessage struct {
+	TransferID   string `json:"transfer_id"`
+	DeliveryPath string `json:"delivery_path"` // "relay" - повторить ICE только через TURN, "cloud" - файл во входящих получателя
+	Status       string `json:"status"`
+	Reason       string `json:"reason"`
+}
 
-	go hub.run()
+// TransferRequestMessage - данные сообщений "transfer-request", "transfer-accept" и
+// "transfer-reject". Клиент передает только transfer_id (и reason при отказе),
+// сведения о файле хаб берет из БД.
+type TransferRequestMessage struct {
+	TransferID string `json:"transfer_id"`
+	FileID     string `json:"file_id,omitempty"`
+	FileName   string `json:"file_name,omitempty"`
+	FileSize   int64  `json:"file_size,omitempty"`
+	MimeType   string `json:"mime_type,omitempty"`
+	Status     string `json:"status,omitempty"` // статус передачи после ответа получателя
+	Reason     string `json:"reason,omitempty"` // причина отказа
+}
+
+// clusterMessage - сообщение между узлами кластера. Без UserID адресат - устройство
+// Message.ToDeviceID; с UserID - все устройства пользователя, кроме ExceptDeviceID.
+type clusterMessage struct {
+	Node           string           `json:"node"`
+	UserID         *uuid.UUID       `json:"user_id,omitempty"`
+	ExceptDeviceID *uuid.UUID       `json:"except_device_id,omitempty"`
+	Message        SignalingMessage `json:"message"`
+}
 
+// Server представляет WebSocket сервер для signaling
+type Server struct {
+	hub      *Hub
+	config   *config.Config
+	port     string
+	upgrader websocket.Upgrader
+}
+
+// NewServer создает новый WebSocket signaling сервер с сессиями в хабе hub
+func NewServer(cfg *config.Config, hub *Hub) *Server {
 	return &Server{
-		hub:    hub,
-		config: cfg,
-		port:   cfg.Server.WebSocketPort,
+		hub:      hub,
+		config:   cfg,
+		port:     cfg.Server.WebSocketPort,
+		upgrader: newUpgrader(cfg.Signaling.AllowedOrigins),
 	}
 }
 
@@ -107,275 +123,499 @@ func (s *Server) Start() error {
 	return http.ListenAndServe(addr, nil)
 }
 
-// handleWebSocket обрабатывает WebSocket подключения
+// handleWebSocket обрабатывает WebSocket подключения. Секреты не передаются в URL:
+// устройство аутентифицируется билетом в Sec-WebSocket-Protocol или первым сообщением
+// "auth". Параметры запроса содержат только сведения о клиенте.
 func (s *Server) handleWebSocket(w http.ResponseWriter, r *http.Request) {
-	conn, err := upgrader.Upgrade(w, r, nil)
+	conn, err := s.upgrader.Upgrade(w, r, nil)
 	if err != nil {
 		s.hub.log.Error().Err(err).Msg("Failed to upgrade connection")
 		return
 	}
 
-	deviceIDStr := r.URL.Query().Get("device_id")
-	deviceToken := r.URL.Query().Get("device_token")
+	// Лимит действует и на первое сообщение: сервер закрывает соединение с кодом 1009
+	conn.SetReadLimit(s.config.Signaling.MaxMessageSize)
 
-	if deviceIDStr == "" || deviceToken == "" {
-		conn.WriteJSON(SignalingMessage{
-			Type:  "error",
-			Error: "device_id and device_token are required",
-		})
-		conn.Close()
+	if r.URL.Query().Has("device_token") {
+		rejectConnection(conn, jsonCodec{}, newProtocolError(ErrorUnauthenticated, "device_token in the query string is not supported, send an auth message"))
 		return
 	}
 
-	deviceID, err := uuid.Parse(deviceIDStr)
+	result, err := s.handshake(conn, r)
 	if err != nil {
-		conn.WriteJSON(SignalingMessage{
-			Type:  "error",
-			Error: "invalid device_id",
-		})
-		conn.Close()
+		rejectConnection(conn, result.codec, err)
 		return
 	}
 
-	device, err := s.hub.deviceRepo.GetByToken(deviceToken)
-	if err != nil || device == nil {
-		conn.WriteJSON(SignalingMessage{
-			Type:  "error",
-			Error: "invalid device_token",
-		})
-		conn.Close()
+	device := result.device
+	deviceID := device.ID
+
+	if err := applyClientInfo(device, r.URL.Query()); err != nil {
+		rejectConnection(conn, result.codec, newProtocolError(ErrorInvalidMessage, err.Error()))
 		return
 	}
 
-	if device.ID != deviceID {
-		conn.WriteJSON(SignalingMessage{
-			Type:  "error",
-			Error: "device_id does not match device_token",
-		})
-		conn.Close()
-		return
+	if err := s.hub.deviceRepo.UpdateClientInfo(device); err != nil {
+		s.hub.log.Error().Err(err).Str("device_id", deviceID.String()).Msg("Failed to update device client info")
 	}
 
 	s.hub.deviceRepo.UpdateLastSeen(deviceID)
 
-	client := &Client{
-		ID:       uuid.New(),
-		UserID:   device.UserID,
-		DeviceID: deviceID,
-		Conn:     conn,
-		Send:     make(chan SignalingMessage, 256),
-		Hub:      s.hub,
-		LastSeen: time.Now(),
-	}
+	client := newClient(s.hub, newWSTransport(conn, result.codec), result)
+	client.sendGreeting()
 
-	s.hub.register <- client
+	if !s.hub.registerClient(client) {
+		conn.WriteControl(websocket.CloseMessage, websocket.FormatCloseMessage(websocket.CloseGoingAway, "server shutting down"), time.Now().Add(writeWait))
+		conn.Close()
+		return
+	}
 
 	go client.writePump()
 	go client.readPump()
 }
 
-// run обрабатывает регистрацию/отмену регистрации клиентов и рассылку сообщений
-func (h *Hub) run() {
-	for {
-		select {
-		case client := <-h.register:
-			h.mu.Lock()
-			h.clients[client.DeviceID] = client
-			h.mu.Unlock()
-			h.log.Info().
-				Str("device_id", client.DeviceID.String()).
-				Msg("Client registered")
-
-		case client := <-h.unregister:
-			h.mu.Lock()
-			if _, ok := h.clients[client.DeviceID]; ok {
-				delete(h.clients, client.DeviceID)
-				close(client.Send)
-			}
-			h.mu.Unlock()
-			h.log.Info().
-				Str("device_id", client.DeviceID.String()).
-				Msg("Client unregistered")
-
-		case message := <-h.broadcast:
-			h.mu.RLock()
-			if toDeviceID, err := uuid.Parse(message.ToDeviceID); err == nil {
-				if client, ok := h.clients[toDeviceID]; ok {
-					select {
-					case client.Send <- message:
-					default:
-						close(client.Send)
-						delete(h.clients, toDeviceID)
-					}
-				} else {
-					h.log.Warn().
-						Str("device_id", message.ToDeviceID).
-						Msg("Target device not connected")
-				}
-			}
-			h.mu.RUnlock()
-		}
+// applyClientInfo обновляет устройство сведениями о клиенте из параметров подключения.
+// Не переданные параметры сохраняют прежние значения.
+func applyClientInfo(device *models.Device, query url.Values) error {
+	if platform := query.Get("platform"); platform != "" {
+		device.Platform = models.DevicePlatform(platform)
 	}
-}
-
-// readPump читает сообщения из WebSocket соединения
-func (c *Client) readPump() {
-	defer func() {
-		c.Hub.unregister <- c
-		c.Conn.Close()
-	}()
-
-	c.Conn.SetReadDeadline(time.Now().Add(60 * time.Second))
-	c.Conn.SetPongHandler(func(string) error {
-		c.Conn.SetReadDeadline(time.Now().Add(60 * time.Second))
-		return nil
-	})
 
-	for {
-		var msg SignalingMessage
-		err := c.Conn.ReadJSON(&msg)
-		if err != nil {
-			if websocket.IsUnexpectedCloseError(err, websocket.CloseGoingAway, websocket.CloseAbnormalClosure) {
-				c.Hub.log.Error().Err(err).Msg("WebSocket error")
-			}
-			break
-		}
+	if osVersion := query.Get("os_version"); osVersion != "" {
+		device.OSVersion = osVersion
+	}
 
-		c.mu.Lock()
-		c.LastSeen = time.Now()
-		c.mu.Unlock()
+	if appVersion := query.Get("app_version"); appVersion != "" {
+		device.AppVersion = appVersion
+	}
 
-		c.handleMessage(msg)
+	if protocols := query.Get("transfer_protocols"); protocols != "" {
+		device.TransferProtocols = nil
+		for _, protocol := range strings.Split(protocols, ",") {
+			device.TransferProtocols = append(device.TransferProtocols, models.TransferProtocol(strings.TrimSpace(protocol)))
+		}
 	}
-}
 
-// writePump отправляет сообщения в WebSocket соединение
-func (c *Client) writePump() {
-	ticker := time.NewTicker(54 * time.Second)
-	defer func() {
-		ticker.Stop()
-		c.Conn.Close()
-	}()
-
-	for {
-		select {
-		case message, ok := <-c.Send:
-			c.Conn.SetWriteDeadline(time.Now().Add(10 * time.Second))
-			if !ok {
-				c.Conn.WriteMessage(websocket.CloseMessage, []byte{})
-				return
-			}
-
-			if err := c.Conn.WriteJSON(message); err != nil {
-				c.Hub.log.Error().Err(err).Msg("Failed to write message")
-				return
-			}
-
-		case <-ticker.C:
-			c.Conn.SetWriteDeadline(time.Now().Add(10 * time.Second))
-			if err := c.Conn.WriteMessage(websocket.PingMessage, nil); err != nil {
-				return
-			}
+	if maxFileSize := query.Get("max_file_size"); maxFileSize != "" {
+		size, err := strconv.ParseInt(maxFileSize, 10, 64)
+		if err != nil {
+			return errors.New("invalid max_file_size")
+		}
+
+		// 0 снимает ограничение
+		if size == 0 {
+			device.MaxFileSize = nil
+		} else {
+			device.MaxFileSize = &size
 		}
 	}
+
+	return device.Validate()
 }
 
 // handleMessage обрабатывает входящее сообщение
 func (c *Client) handleMessage(msg SignalingMessage) {
+	// По id отправитель сопоставляет сообщение с ack или delivery-failed
+	if msg.ID == "" {
+		msg.ID = uuid.NewString()
+	}
+
+	if msg.Type != "presence" {
+		c.mu.Lock()
+		wakeUp := c.presence == presence.StatusAway && !c.awayReported
+		c.mu.Unlock()
+
+		if wakeUp {
+			c.Hub.setPresence(c, presence.StatusOnline)
+		}
+	}
+
 	switch msg.Type {
+	case "presence":
+		c.handlePresence(msg)
+	case "device-approve":
+		c.handleApproval(msg, models.DeviceApprovalApproved)
+	case "device-reject":
+		c.handleApproval(msg, models.DeviceApprovalRejected)
 	case "offer":
 		c.handleOffer(msg)
 	case "answer":
 		c.handleAnswer(msg)
 	case "ice-candidate":
 		c.handleICECandidate(msg)
+	case "ice-state":
+		c.handleICEState(msg)
+	case "transfer-request":
+		c.handleTransferRequest(msg)
+	case "transfer-accept":
+		c.handleTransferResponse(msg, models.TransferStatusInProgress)
+	case "transfer-reject":
+		c.handleTransferResponse(msg, models.TransferStatusRejected)
+	case "auth", "hello":
+		c.sendError(msg.ID, ErrorFailedPrecondition, "connection is already authenticated")
 	default:
-		c.Hub.log.Warn().
-			Str("type", msg.Type).
-			Msg("Unknown message type")
+		c.sendError(msg.ID, ErrorUnknownType, fmt.Sprintf("unknown message type %q", msg.Type))
 	}
 }
 
-// handleOffer обрабатывает SDP offer
-func (c *Client) handleOffer(msg SignalingMessage) {
-	if msg.ToDeviceID == "" {
-		c.sendError("to_device_id is required for offer")
+// handlePresence обрабатывает смену статуса, о которой сообщает сам клиент
+// (например, приложение свернуто)
+func (c *Client) handlePresence(msg SignalingMessage) {
+	var data PresenceMessage
+	if err := json.Unmarshal(msg.Data, &data); err != nil {
+		c.sendError(msg.ID, ErrorInvalidMessage, "invalid presence data")
 		return
 	}
 
-	toDeviceID, err := uuid.Parse(msg.ToDeviceID)
+	if data.Status != presence.StatusOnline && data.Status != presence.StatusAway {
+		c.sendError(msg.ID, ErrorInvalidMessage, "presence status must be online or away")
+		return
+	}
+
+	c.mu.Lock()
+	c.awayReported = data.Status == presence.StatusAway
+	c.mu.Unlock()
+
+	c.Hub.setPresence(c, data.Status)
+}
+
+// handleApproval подтверждает или отклоняет ожидающее устройство пользователя.
+// Результат приходит всем устройствам пользователя событием device-approved или device-rejected.
+func (c *Client) handleApproval(msg SignalingMessage, approvalStatus models.DeviceApprovalStatus) {
+	var data ApprovalMessage
+	if err := json.Unmarshal(msg.Data, &data); err != nil {
+		c.sendError(msg.ID, ErrorInvalidMessage, "invalid approval data")
+		return
+	}
+
+	deviceID, err := uuid.Parse(data.DeviceID)
 	if err != nil {
-		c.sendError("invalid to_device_id")
+		c.sendError(msg.ID, ErrorInvalidMessage, "invalid device_id")
 		return
 	}
 
-	toDevice, err := c.Hub.deviceRepo.GetByID(toDeviceID)
-	if err != nil || toDevice == nil {
-		c.sendError("target device not found")
+	target, err := c.Hub.deviceRepo.GetByID(deviceID)
+	if err != nil || target == nil {
+		c.sendError(msg.ID, ErrorNotFound, "device not found")
 		return
 	}
 
-	if toDevice.UserID != c.UserID {
-		c.sendError("devices must belong to the same user")
+	// Статус отправителя перечитывается из БД на случай изменений после подключения
+	approver, err := c.Hub.deviceRepo.GetByID(c.DeviceID)
+	if err != nil || approver == nil {
+		c.sendError(msg.ID, ErrorNotFound, "device not found")
 		return
 	}
 
-	c.Hub.broadcast <- SignalingMessage{
+	if err := approver.CanDecideApproval(target); err != nil {
+		c.sendError(msg.ID, ErrorPermissionDenied, err.Error())
+		return
+	}
+
+	if err := c.Hub.deviceRepo.DecideApproval(target, approvalStatus, approver.ID); err != nil {
+		if err == sql.ErrNoRows {
+			c.sendError(msg.ID, ErrorFailedPrecondition, "device is not pending approval")
+		} else {
+			c.sendError(msg.ID, ErrorInternal, "failed to update device approval")
+		}
+		return
+	}
+
+	eventType := events.TypeDeviceApproved
+	if approvalStatus == models.DeviceApprovalRejected {
+		eventType = events.TypeDeviceRejected
+	}
+
+	err = c.Hub.events.Publish(context.Background(), eventType, target.UserID, &events.DeviceApproval{
+		DeviceID:          target.ID,
+		Name:              target.Name,
+		DeviceType:        string(target.DeviceType),
+		ApprovalStatus:    string(target.ApprovalStatus),
+		DecidedByDeviceID: target.ApprovedByDeviceID,
+	})
+	if err != nil {
+		c.Hub.log.Error().Err(err).Msg("Failed to publish approval event")
+	}
+}
+
+// handleOffer обрабатывает SDP offer
+func (c *Client) handleOffer(msg SignalingMessage) {
+	if !c.checkPeer(msg) {
+		return
+	}
+
+	c.Hub.route(SignalingMessage{
 		Type:         "offer",
+		ID:           msg.ID,
 		FromDeviceID: c.DeviceID.String(),
 		ToDeviceID:   msg.ToDeviceID,
 		SDP:          msg.SDP,
+	}, c)
+}
+
+// checkPeer проверяет адресата сообщения между устройствами: устройство существует,
+// активно и подтверждено, и отправитель может обмениваться с ним сигналами. Разрешение запоминается в сессии на
+// peerCacheTTL, чтобы поток ICE candidates не проверялся в БД на каждое сообщение.
+// При ошибке клиент получает error.
+func (c *Client) checkPeer(msg SignalingMessage) bool {
+	if msg.ToDeviceID == "" {
+		c.sendError(msg.ID, ErrorInvalidMessage, fmt.Sprintf("to_device_id is required for %s", msg.Type))
+		return false
+	}
+
+	toDeviceID, err := uuid.Parse(msg.ToDeviceID)
+	if err != nil {
+		c.sendError(msg.ID, ErrorInvalidMessage, "invalid to_device_id")
+		return false
+	}
+
+	if checkedAt, ok := c.peers[toDeviceID]; ok && time.Since(checkedAt) < peerCacheTTL {
+		return true
+	}
+
+	toDevice, err := c.Hub.deviceRepo.GetByID(toDeviceID)
+	if err != nil || toDevice == nil || !toDevice.IsActive() {
+		c.sendError(msg.ID, ErrorNotFound, "target device not found")
+		return false
+	}
+
+	// Неподтвержденное устройство не может подключиться: сообщение только заняло бы его очередь
+	if !toDevice.IsApproved() {
+		c.sendError(msg.ID, ErrorFailedPrecondition, "target device is not approved")
+		return false
+	}
+
+	if !c.canSignal(toDevice) {
+		c.sendError(msg.ID, ErrorPermissionDenied, "devices must belong to the same user or organization")
+		return false
 	}
+
+	c.peers[toDeviceID] = time.Now()
+	return true
+}
+
+// canSignal проверяет, что целевое устройство принадлежит тому же пользователю
+// или зарегистрировано в организации, участником которой является отправитель
+func (c *Client) canSignal(toDevice *models.Device) bool {
+	if toDevice.UserID == c.UserID {
+		return true
+	}
+
+	if toDevice.OrganizationID == nil {
+		return false
+	}
+
+	isMember, err := c.Hub.orgRepo.IsMember(*toDevice.OrganizationID, c.UserID)
+	if err != nil {
+		c.Hub.log.Error().Err(err).Msg("Failed to check organization membership")
+		return false
+	}
+
+	return isMember
 }
 
 // handleAnswer обрабатывает SDP answer
 func (c *Client) handleAnswer(msg SignalingMessage) {
-	if msg.ToDeviceID == "" {
-		c.sendError("to_device_id is required for answer")
+	if !c.checkPeer(msg) {
 		return
 	}
 
-	c.Hub.broadcast <- SignalingMessage{
+	c.Hub.route(SignalingMessage{
 		Type:         "answer",
+		ID:           msg.ID,
 		FromDeviceID: c.DeviceID.String(),
 		ToDeviceID:   msg.ToDeviceID,
 		SDP:          msg.SDP,
-	}
+	}, c)
 }
 
-// handleICECandidate обрабатывает ICE candidate
-func (c *Client) handleICECandidate(msg SignalingMessage) {
-	if msg.ToDeviceID == "" {
-		c.sendError("to_device_id is required for ice-candidate")
+// handleICEState передает оркестратору результат ICE согласования и пересылает
+// его второму участнику передачи
+func (c *Client) handleICEState(msg SignalingMessage) {
+	var data ICEStateMessage
+	if err := json.Unmarshal(msg.Data, &data); err != nil {
+		c.sendError(msg.ID, ErrorInvalidMessage, "invalid ice-state data")
 		return
 	}
 
-	c.Hub.broadcast <- SignalingMessage{
-		Type:         "ice-candidate",
+	transferID, err := uuid.Parse(data.TransferID)
+	if err != nil {
+		c.sendError(msg.ID, ErrorInvalidMessage, "invalid transfer_id")
+		return
+	}
+
+	transfer, err := c.Hub.fallback.Report(c.DeviceID, fallback.Report{
+		TransferID: transferID,
+		State:      fallback.ICEState(data.State),
+		Path:       models.TransferProtocol(data.Path),
+	})
+	if err != nil {
+		code := ErrorFailedPrecondition
+		if errors.Is(err, fallback.ErrTransferNotFound) {
+			code = ErrorNotFound
+		}
+
+		c.sendError(msg.ID, code, err.Error())
+		return
+	}
+
+	peerID := transfer.ToDeviceID
+	if peerID != nil && *peerID == c.DeviceID {
+		peerID = transfer.FromDeviceID
+	}
+	if peerID == nil {
+		return
+	}
+
+	c.Hub.route(SignalingMessage{
+		Type:         "ice-state",
+		ID:           msg.ID,
 		FromDeviceID: c.DeviceID.String(),
-		ToDeviceID:   msg.ToDeviceID,
-		Candidate:    msg.Candidate,
+		ToDeviceID:   peerID.String(),
+		Data:         msg.Data,
+	}, c)
+}
+
+// handleTransferRequest предлагает получателю ожидающую P2P передачу отправителя.
+// Неподключенный получатель будится push-уведомлением, запрос ждет его в очереди.
+func (c *Client) handleTransferRequest(msg SignalingMessage) {
+	transfer, data, ok := c.readTransferMessage(msg)
+	if !ok {
+		return
+	}
+
+	if transfer.FromDeviceID == nil || *transfer.FromDeviceID != c.DeviceID || transfer.ToDeviceID == nil {
+		c.sendError(msg.ID, ErrorPermissionDenied, "only the sending device can request a transfer")
+		return
+	}
+
+	if transfer.Status != models.TransferStatusPending {
+		c.sendError(msg.ID, ErrorFailedPrecondition, fmt.Sprintf("transfer is already %s", transfer.Status))
+		return
+	}
+
+	if transfer.DeliveryPath == models.TransferProtocolCloud {
+		c.sendError(msg.ID, ErrorFailedPrecondition, "transfer is delivered through cloud storage")
+		return
+	}
+
+	file, err := c.Hub.fileRepo.GetByID(transfer.FileID)
+	if err != nil || file == nil {
+		c.sendError(msg.ID, ErrorNotFound, "file not found")
+		return
 	}
+
+	data.FileID = file.ID.String()
+	data.FileName = file.Name
+	data.FileSize = file.Size
+	data.MimeType = file.MimeType
+	data.Status = string(transfer.Status)
+	data.Reason = ""
+
+	if !c.Hub.isConnected(*transfer.ToDeviceID) {
+		go c.Hub.pushTransferRequest(transfer)
+	}
+
+	c.Hub.sendTransferMessage(c, msg, *transfer.ToDeviceID, data)
 }
 
-// sendError отправляет сообщение об ошибке клиенту
-func (c *Client) sendError(errorMsg string) {
-	select {
-	case c.Send <- SignalingMessage{
-		Type:  "error",
-		Error: errorMsg,
-	}:
-	default:
-		c.Hub.log.Warn().Msg("Failed to send error message")
+// handleTransferResponse принимает (next == in_progress) или отклоняет (next == rejected)
+// ожидающую передачу от имени получателя и сообщает ответ отправителю
+func (c *Client) handleTransferResponse(msg SignalingMessage, next models.TransferStatus) {
+	transfer, data, ok := c.readTransferMessage(msg)
+	if !ok {
+		return
 	}
+
+	if transfer.ToDeviceID == nil || *transfer.ToDeviceID != c.DeviceID {
+		c.sendError(msg.ID, ErrorPermissionDenied, "only the receiving device can respond to a transfer")
+		return
+	}
+
+	if transfer.Status != models.TransferStatusPending {
+		c.sendError(msg.ID, ErrorFailedPrecondition, fmt.Sprintf("transfer is already %s", transfer.Status))
+		return
+	}
+
+	reason := ""
+	if next == models.TransferStatusRejected {
+		reason = data.Reason
+	}
+
+	previousBytes := transfer.BytesTransferred
+	previousAt := transfer.UpdatedAt
+
+	if err := transfer.TransitionTo(next, reason); err != nil {
+		c.sendError(msg.ID, ErrorFailedPrecondition, err.Error())
+		return
+	}
+
+	if err := c.Hub.transferRepo.Transition(transfer, models.TransferStatusPending); err != nil {
+		if err == sql.ErrNoRows {
+			c.sendError(msg.ID, ErrorFailedPrecondition, "transfer status was changed concurrently")
+		} else {
+			c.sendError(msg.ID, ErrorInternal, "failed to update transfer")
+		}
+		return
+	}
+
+	var totalSize int64
+	if file, err := c.Hub.fileRepo.GetByID(transfer.FileID); err == nil && file != nil {
+		totalSize = file.Size
+	}
+
+	c.Hub.progress.Publish(context.Background(), progress.NewUpdate(transfer, totalSize, previousBytes, previousAt))
+
+	if transfer.FromDeviceID == nil {
+		return
+	}
+
+	data.FileID = transfer.FileID.String()
+	data.Status = string(transfer.Status)
+	data.Reason = transfer.FailureReason
+
+	c.Hub.sendTransferMessage(c, msg, *transfer.FromDeviceID, data)
+}
+
+// readTransferMessage разбирает данные сообщения о передаче и загружает передачу,
+// в которой участвует устройство клиента. При ошибке клиент получает сообщение error.
+func (c *Client) readTransferMessage(msg SignalingMessage) (*models.Transfer, *TransferRequestMessage, bool) {
+	var data TransferRequestMessage
+	if err := json.Unmarshal(msg.Data, &data); err != nil {
+		c.sendError(msg.ID, ErrorInvalidMessage, fmt.Sprintf("invalid %s data", msg.Type))
+		return nil, nil, false
+	}
+
+	transferID, err := uuid.Parse(data.TransferID)
+	if err != nil {
+		c.sendError(msg.ID, ErrorInvalidMessage, "invalid transfer_id")
+		return nil, nil, false
+	}
+
+	transfer, err := c.Hub.transferRepo.GetByID(transferID)
+	if err != nil {
+		c.sendError(msg.ID, ErrorInternal, "failed to get transfer")
+		return nil, nil, false
+	}
+	if transfer == nil || !transfer.HasDevice(c.DeviceID) {
+		c.sendError(msg.ID, ErrorNotFound, "transfer not found")
+		return nil, nil, false
+	}
+
+	return transfer, &data, true
 }
 
-// Shutdown останавливает WebSocket сервер
-func (s *Server) Shutdown(ctx context.Context) error {
-	s.hub.mu.Lock()
-	for _, client := range s.hub.clients {
-		client.Conn.Close()
+// handleICECandidate обрабатывает ICE candidate
+func (c *Client) handleICECandidate(msg SignalingMessage) {
+	if !c.checkPeer(msg) {
+		return
 	}
-	s.hub.mu.Unlock()
-	return nil
+
+	c.Hub.route(SignalingMessage{
+		Type:         "ice-candidate",
+		ID:           msg.ID,
+		FromDeviceID: c.DeviceID.String(),
+		ToDeviceID:   msg.ToDeviceID,
+		Candidate:    msg.Candidate,
+	}, c)
 }
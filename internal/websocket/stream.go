@@ -0,0 +1,173 @@
+package websocket
+
+import (
+	"errors"
+	"fmt"
+	"net/url"
+	"time"
+
+	signalingpb "github.com/backend-app/backend/pkg/proto/signaling"
+	"github.com/gorilla/websocket"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/proto"
+)
+
+// streamCodes - статус gRPC для ошибки подключения потока
+var streamCodes = map[ErrorCode]codes.Code{
+	ErrorInvalidMessage:     codes.InvalidArgument,
+	ErrorUnknownType:        codes.InvalidArgument,
+	ErrorUnsupportedVersion: codes.Unimplemented,
+	ErrorUnauthenticated:    codes.Unauthenticated,
+	ErrorPermissionDenied:   codes.PermissionDenied,
+	ErrorNotFound:           codes.NotFound,
+	ErrorFailedPrecondition: codes.FailedPrecondition,
+	ErrorInternal:           codes.Internal,
+}
+
+// streamTransport - сессия в потоке gRPC SignalingService.Connect. Сообщения передаются
+// в Envelope протокола v2; ping не нужен, соединение проверяет keepalive HTTP/2.
+type streamTransport struct {
+	stream  signalingpb.SignalingService_ConnectServer
+	maxSize int64 // максимальный размер сообщения клиента
+	status  error // статус, с которым завершится RPC после закрытия сессии
+}
+
+func (t *streamTransport) Receive() (SignalingMessage, error) {
+	envelope, err := t.stream.Recv()
+	if err != nil {
+		return SignalingMessage{}, err
+	}
+
+	if t.maxSize > 0 && int64(proto.Size(envelope)) > t.maxSize {
+		return SignalingMessage{}, errMessageTooBig
+	}
+
+	return fromEnvelope(envelope)
+}
+
+func (t *streamTransport) Write(message SignalingMessage) error {
+	envelope, err := toEnvelope(message)
+	if err != nil {
+		return fmt.Errorf("%w: %v", errEncode, err)
+	}
+
+	return t.stream.Send(envelope)
+}
+
+func (t *streamTransport) Ping() error {
+	return nil
+}
+
+// WriteClose переводит код закрытия сессии в статус RPC
+func (t *streamTransport) WriteClose(code int, text string) {
+	switch code {
+	case websocket.CloseNormalClosure, websocket.CloseAbnormalClosure:
+		t.status = nil
+	case websocket.CloseGoingAway:
+		t.status = status.Error(codes.Unavailable, text)
+	case websocket.CloseTryAgainLater, websocket.CloseMessageTooBig, closeRateLimited:
+		t.status = status.Error(codes.ResourceExhausted, text)
+	default:
+		t.status = status.Error(codes.Aborted, text)
+	}
+}
+
+// Close ничего не делает: поток завершается, когда ServeStream возвращает статус
+func (t *streamTransport) Close() error {
+	return nil
+}
+
+// ServeStream обслуживает сессию устройства в потоке gRPC SignalingService.Connect и
+// возвращает статус RPC, когда сессия закрыта. Поток использует протокол v2: клиент
+// первым присылает hello, сервер отвечает welcome. Сведения о клиенте передаются в
+// metadata под теми же именами, что и параметры URL WebSocket.
+func (h *Hub) ServeStream(stream signalingpb.SignalingService_ConnectServer) error {
+	result, err := h.streamHandshake(stream)
+	if err != nil {
+		return streamError(err)
+	}
+
+	device := result.device
+
+	md, _ := metadata.FromIncomingContext(stream.Context())
+	if err := applyClientInfo(device, url.Values(md)); err != nil {
+		return status.Error(codes.InvalidArgument, err.Error())
+	}
+
+	if err := h.deviceRepo.UpdateClientInfo(device); err != nil {
+		h.log.Error().Err(err).Str("device_id", device.ID.String()).Msg("Failed to update device client info")
+	}
+
+	h.deviceRepo.UpdateLastSeen(device.ID)
+
+	conn := &streamTransport{stream: stream, maxSize: h.signalingCfg.MaxMessageSize}
+	client := newClient(h, conn, result)
+	client.sendGreeting()
+
+	if !h.registerClient(client) {
+		return status.Error(codes.Unavailable, "server shutting down")
+	}
+
+	// Поток живет, пока работает обработчик RPC, поэтому writePump выполняется в нем
+	go client.readPump()
+	client.writePump()
+
+	return conn.status
+}
+
+// streamHandshake аутентифицирует поток по hello, которое клиент должен прислать за AuthTimeout
+func (h *Hub) streamHandshake(stream signalingpb.SignalingService_ConnectServer) (*handshake, error) {
+	received := make(chan *signalingpb.Envelope, 1)
+	failed := make(chan error, 1)
+
+	// Recv прерывается, только когда обработчик RPC завершается
+	go func() {
+		envelope, err := stream.Recv()
+		if err != nil {
+			failed <- err
+			return
+		}
+		received <- envelope
+	}()
+
+	timer := time.NewTimer(h.signalingCfg.AuthTimeout)
+	defer timer.Stop()
+
+	var envelope *signalingpb.Envelope
+	select {
+	case envelope = <-received:
+	case err := <-failed:
+		return nil, err
+	case <-timer.C:
+		return nil, newProtocolError(ErrorUnauthenticated, "authentication timeout")
+	}
+
+	if h.signalingCfg.MaxMessageSize > 0 && int64(proto.Size(envelope)) > h.signalingCfg.MaxMessageSize {
+		violationsMetric.Add(violationMessageTooLarge, 1)
+		return nil, status.Error(codes.ResourceExhausted, errMessageTooBig.Error())
+	}
+
+	msg, err := fromEnvelope(envelope)
+	if err != nil {
+		return nil, err
+	}
+
+	result := &handshake{version: ProtocolV2}
+	return result, h.acceptHello(result, msg, "")
+}
+
+// streamError переводит ошибку подключения потока в статус gRPC
+func streamError(err error) error {
+	var protoErr *protocolError
+	if errors.As(err, &protoErr) {
+		return status.Error(streamCodes[protoErr.code], protoErr.message)
+	}
+
+	if _, ok := status.FromError(err); ok {
+		return err
+	}
+
+	return status.Error(codes.Internal, err.Error())
+}
@@ -0,0 +1,87 @@
+package websocket
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/gorilla/websocket"
+)
+
+// errEncode - сообщение не удалось перевести в формат транспорта. Сессия пропускает
+// такое сообщение и продолжает работу.
+var errEncode = errors.New("failed to encode message")
+
+// transport - соединение сессии: WebSocket или поток gRPC. Хаб и обработчики сообщений
+// не зависят от транспорта, поэтому сессии разных транспортов обмениваются сообщениями.
+// Receive вызывает только readPump, остальные методы - только writePump.
+type transport interface {
+	// Receive возвращает следующее сообщение клиента. Ошибка *protocolError - сообщение
+	// не разобрано, но соединение можно читать дальше; остальные ошибки завершают сессию.
+	Receive() (SignalingMessage, error)
+	// Write отправляет сообщение клиенту
+	Write(message SignalingMessage) error
+	// Ping проверяет, что клиент на связи
+	Ping() error
+	// WriteClose сообщает клиенту код (коды WebSocket) и причину закрытия сессии
+	WriteClose(code int, text string)
+	// Close закрывает соединение
+	Close() error
+}
+
+// wsTransport - сессия в WebSocket соединении. Формат кадров задает кодек версии протокола.
+type wsTransport struct {
+	conn  *websocket.Conn
+	codec codec
+}
+
+func newWSTransport(conn *websocket.Conn, c codec) *wsTransport {
+	conn.SetReadDeadline(time.Now().Add(pongWait))
+	conn.SetPongHandler(func(string) error {
+		conn.SetReadDeadline(time.Now().Add(pongWait))
+		return nil
+	})
+
+	return &wsTransport{conn: conn, codec: c}
+}
+
+func (t *wsTransport) Receive() (SignalingMessage, error) {
+	frameType, payload, err := t.conn.ReadMessage()
+	if errors.Is(err, websocket.ErrReadLimit) {
+		return SignalingMessage{}, errMessageTooBig
+	}
+	if err != nil {
+		return SignalingMessage{}, err
+	}
+
+	return t.codec.Decode(frameType, payload)
+}
+
+func (t *wsTransport) Write(message SignalingMessage) error {
+	frameType, payload, err := t.codec.Encode(message)
+	if err != nil {
+		return fmt.Errorf("%w: %v", errEncode, err)
+	}
+
+	t.conn.SetWriteDeadline(time.Now().Add(writeWait))
+	return t.conn.WriteMessage(frameType, payload)
+}
+
+func (t *wsTransport) Ping() error {
+	t.conn.SetWriteDeadline(time.Now().Add(writeWait))
+	return t.conn.WriteMessage(websocket.PingMessage, nil)
+}
+
+func (t *wsTransport) WriteClose(code int, text string) {
+	// 1006 не передается по сети: соединение уже оборвано
+	if code == websocket.CloseAbnormalClosure {
+		return
+	}
+
+	message := websocket.FormatCloseMessage(code, text)
+	t.conn.WriteControl(websocket.CloseMessage, message, time.Now().Add(writeWait))
+}
+
+func (t *wsTransport) Close() error {
+	return t.conn.Close()
+}
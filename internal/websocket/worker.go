@@ -0,0 +1,81 @@
+package websocket
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+)
+
+// deviceWorker - очередь операций с Redis и базой для одного устройства: владение в
+// кластере, присутствие и очередь сообщений. Операции выполняются по порядку в своей
+// горутине, поэтому run не ждет ввода-вывода, а состояние устройства в Redis меняется
+// в том же порядке, что и набор его сессий.
+type deviceWorker struct {
+	tasks []func()
+}
+
+// schedule добавляет операцию в очередь устройства. Горутина очереди запускается с
+// первой операцией и завершается, когда очередь пуста. После остановки хаба операция
+// выполняется сразу в вызывающей горутине.
+func (h *Hub) schedule(deviceID uuid.UUID, task func()) {
+	h.workersMu.Lock()
+
+	if h.workersStopped {
+		h.workersMu.Unlock()
+		task()
+		return
+	}
+
+	if worker, ok := h.workers[deviceID]; ok {
+		worker.tasks = append(worker.tasks, task)
+		h.workersMu.Unlock()
+		return
+	}
+
+	worker := &deviceWorker{tasks: []func(){task}}
+	h.workers[deviceID] = worker
+	h.workersWG.Add(1)
+	h.workersMu.Unlock()
+
+	go h.runWorker(deviceID, worker)
+}
+
+func (h *Hub) runWorker(deviceID uuid.UUID, worker *deviceWorker) {
+	defer h.workersWG.Done()
+
+	for {
+		h.workersMu.Lock()
+		if len(worker.tasks) == 0 {
+			delete(h.workers, deviceID)
+			h.workersMu.Unlock()
+			return
+		}
+
+		task := worker.tasks[0]
+		worker.tasks = worker.tasks[1:]
+		h.workersMu.Unlock()
+
+		task()
+	}
+}
+
+// waitWorkers ждет, пока очереди устройств выполнят накопленные операции. Новые
+// операции после этого выполняются без очереди.
+func (h *Hub) waitWorkers(ctx context.Context) error {
+	h.workersMu.Lock()
+	h.workersStopped = true
+	h.workersMu.Unlock()
+
+	done := make(chan struct{})
+	go func() {
+		h.workersWG.Wait()
+		close(done)
+	}()
+
+	select {
+	case <-done:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
@@ -1,15 +1,28 @@
 package config
 
 import (
+	"crypto/rand"
+	"encoding/hex"
+	"fmt"
 	"os"
+	"strconv"
+	"strings"
+	"time"
 )
 
 type Config struct {
-	Server   ServerConfig
-	Database DatabaseConfig
-	Redis    RedisConfig
-	Storage  StorageConfig
-	WebRTC   WebRTCConfig
+	Server    ServerConfig
+	Database  DatabaseConfig
+	Redis     RedisConfig
+	Storage   StorageConfig
+	WebRTC    WebRTCConfig
+	Account   AccountConfig
+	Pairing   PairingConfig
+	Device    DeviceConfig
+	Transfer  TransferConfig
+	Presence  PresenceConfig
+	Signaling SignalingConfig
+	Push      PushConfig
 }
 
 type ServerConfig struct {
@@ -18,6 +31,8 @@ type ServerConfig struct {
 	WebSocketPort string
 	Environment   string
 	JWTSecret     string
+
+	TrustedProxies []string // Адреса и подсети прокси, которым доверяется X-Forwarded-For (пусто - адрес соединения)
 }
 
 type DatabaseConfig struct {
@@ -55,6 +70,83 @@ type WebRTCConfig struct {
 	TURNPublicIP string // Публичный IP адрес TURN сервера (для relay)
 }
 
+type AccountConfig struct {
+	DeletionGracePeriod time.Duration // Период, в течение которого удаление аккаунта можно отменить
+	ExportTTL           time.Duration // Время хранения готового архива экспорта
+	JobInterval         time.Duration // Интервал запуска фоновых задач экспорта и удаления
+}
+
+type PairingConfig struct {
+	CodeTTL       time.Duration // Время жизни кода сопряжения
+	CodeLength    int           // Количество цифр в коде (6-8)
+	MaxAttempts   int           // Максимум попыток ввода кода с одного адреса за AttemptWindow
+	AttemptWindow time.Duration // Окно ограничения попыток ввода кода
+
+	MaxFailedAttempts int // Максимум неверных кодов со всех адресов за AttemptWindow
+}
+
+type DeviceConfig struct {
+	TokenGracePeriod        time.Duration // Сколько старый токен устройства действует после ротации
+	InactivityDays          int           // Порог неактивности по умолчанию, после которого устройство отключается (0 - не отключать)
+	InactivityWarningPeriod time.Duration // За сколько до отключения пользователь получает предупреждение
+	PruneInterval           time.Duration // Интервал запуска задачи отключения неактивных устройств
+}
+
+type TransferConfig struct {
+	DeliveredFileTTL time.Duration // Сколько файл облачной передачи хранится после получения всеми устройствами
+	CleanupInterval  time.Duration // Интервал удаления файлов с истекшим сроком хранения
+	ICETimeout       time.Duration // Сколько ждать ICE соединения, прежде чем перевести P2P передачу на запасной способ
+	StalledTimeout   time.Duration // Через сколько без обновлений передача в процессе завершается с ошибкой
+	PendingTTL       time.Duration // Сколько передача ждет, пока ее примут, прежде чем истечь
+	WatchdogInterval time.Duration // Интервал проверки зависших передач
+}
+
+type PresenceConfig struct {
+	TTL           time.Duration // Время жизни записи присутствия без продления хабом
+	AwayAfter     time.Duration // Через сколько без сообщений от клиента устройство считается away
+	CheckInterval time.Duration // Интервал продления записей и проверки неактивных клиентов
+}
+
+// SignalingConfig - аутентификация на signaling сервере, работа нескольких signaling
+// серверов за балансировщиком, очередь сообщений неподключенным устройствам и лимиты
+// сообщений клиентов
+type SignalingConfig struct {
+	AllowedOrigins []string             // Origin браузерных клиентов; пусто - только тот же хост, "*" - любой
+	AuthTimeout    time.Duration        // Сколько сервер ждет сообщение auth после подключения
+	TicketTTL      time.Duration        // Сколько действует билет из POST /signaling/ticket
+	ClusterEnabled bool                 // Маршрутизировать сообщения между узлами через Redis
+	NodeID         string               // Идентификатор узла; по умолчанию имя хоста со случайным суффиксом
+	NodeTTL        time.Duration        // Сколько живут пульс узла и владение устройствами без продления
+	QueueTTL       time.Duration        // Сколько сообщение ждет неподключенное устройство до delivery-failed
+	QueueSize      int                  // Максимум сообщений в очереди одного устройства
+	MaxMessageSize int64                // Максимальный размер сообщения клиента в байтах
+	RateLimits     map[string]RateLimit // Лимит частоты по типу сообщения; "*" - для остальных типов
+}
+
+// RateLimit - token bucket: Rate сообщений в секунду, не больше Burst подряд
+type RateLimit struct {
+	Rate  float64
+	Burst int
+}
+
+// PushConfig - учетные данные провайдеров push-уведомлений. Провайдер без
+// настроек заменяется логированием уведомлений.
+type PushConfig struct {
+	Timeout time.Duration // Таймаут отправки одного уведомления
+
+	FCMCredentialsFile string // JSON ключ сервисного аккаунта Firebase
+
+	APNsKeyFile string // Ключ .p8 для token-based аутентификации
+	APNsKeyID   string
+	APNsTeamID  string
+	APNsTopic   string // Bundle ID приложения
+	APNsSandbox bool   // Использовать api.sandbox.push.apple.com
+
+	WebPushVAPIDPublicKey  string // Публичный ключ VAPID (base64url, несжатая точка P-256)
+	WebPushVAPIDPrivateKey string // Приватный ключ VAPID (base64url)
+	WebPushSubject         string // Контакт отправителя: mailto: или https: URL
+}
+
 func Load() (*Config, error) {
 	return &Config{
 		Server: ServerConfig{
@@ -63,6 +155,8 @@ func Load() (*Config, error) {
 			WebSocketPort: getEnv("WS_PORT", "8081"),
 			Environment:   getEnv("ENV", "development"),
 			JWTSecret:     getEnv("JWT_SECRET", "your-secret-key-change-in-production"),
+
+			TrustedProxies: getEnvList("TRUSTED_PROXIES"),
 		},
 		Database: DatabaseConfig{
 			Host:     getEnv("DB_HOST", "localhost"),
@@ -98,6 +192,62 @@ func Load() (*Config, error) {
 			TURNRealm:    getEnv("TURN_REALM", "local"),
 			TURNPublicIP: getEnv("TURN_PUBLIC_IP", ""),
 		},
+		Account: AccountConfig{
+			DeletionGracePeriod: getEnvDuration("ACCOUNT_DELETION_GRACE_PERIOD", 30*24*time.Hour),
+			ExportTTL:           getEnvDuration("ACCOUNT_EXPORT_TTL", 7*24*time.Hour),
+			JobInterval:         getEnvDuration("ACCOUNT_JOB_INTERVAL", time.Minute),
+		},
+		Pairing: PairingConfig{
+			CodeTTL:       getEnvDuration("PAIRING_CODE_TTL", 5*time.Minute),
+			CodeLength:    getEnvInt("PAIRING_CODE_LENGTH", 8),
+			MaxAttempts:   getEnvInt("PAIRING_MAX_ATTEMPTS", 5),
+			AttemptWindow: getEnvDuration("PAIRING_ATTEMPT_WINDOW", 15*time.Minute),
+
+			MaxFailedAttempts: getEnvInt("PAIRING_MAX_FAILED_ATTEMPTS", 100),
+		},
+		Device: DeviceConfig{
+			TokenGracePeriod:        getEnvDuration("DEVICE_TOKEN_GRACE_PERIOD", 24*time.Hour),
+			InactivityDays:          getEnvInt("DEVICE_INACTIVITY_DAYS", 90),
+			InactivityWarningPeriod: getEnvDuration("DEVICE_INACTIVITY_WARNING_PERIOD", 7*24*time.Hour),
+			PruneInterval:           getEnvDuration("DEVICE_PRUNE_INTERVAL", time.Hour),
+		},
+		Transfer: TransferConfig{
+			DeliveredFileTTL: getEnvDuration("TRANSFER_DELIVERED_FILE_TTL", time.Hour),
+			CleanupInterval:  getEnvDuration("FILE_CLEANUP_INTERVAL", 10*time.Minute),
+			ICETimeout:       getEnvDuration("TRANSFER_ICE_TIMEOUT", 30*time.Second),
+			StalledTimeout:   getEnvDuration("TRANSFER_STALLED_TIMEOUT", 10*time.Minute),
+			PendingTTL:       getEnvDuration("TRANSFER_PENDING_TTL", 24*time.Hour),
+			WatchdogInterval: getEnvDuration("TRANSFER_WATCHDOG_INTERVAL", time.Minute),
+		},
+		Presence: PresenceConfig{
+			TTL:           getEnvDuration("PRESENCE_TTL", 90*time.Second),
+			AwayAfter:     getEnvDuration("PRESENCE_AWAY_AFTER", 5*time.Minute),
+			CheckInterval: getEnvDuration("PRESENCE_CHECK_INTERVAL", 30*time.Second),
+		},
+		Signaling: SignalingConfig{
+			AllowedOrigins: getEnvList("SIGNALING_ALLOWED_ORIGINS"),
+			AuthTimeout:    getEnvDuration("SIGNALING_AUTH_TIMEOUT", 10*time.Second),
+			TicketTTL:      getEnvDuration("SIGNALING_TICKET_TTL", time.Minute),
+			ClusterEnabled: getEnv("SIGNALING_CLUSTER_ENABLED", "false") == "true",
+			NodeID:         getEnv("NODE_ID", defaultNodeID()),
+			NodeTTL:        getEnvDuration("SIGNALING_NODE_TTL", 90*time.Second),
+			QueueTTL:       getEnvDuration("SIGNALING_QUEUE_TTL", 30*time.Second),
+			QueueSize:      getEnvInt("SIGNALING_QUEUE_SIZE", 100),
+			MaxMessageSize: int64(getEnvInt("SIGNALING_MAX_MESSAGE_SIZE", 64*1024)),
+			RateLimits:     getEnvRateLimits("SIGNALING_RATE_LIMITS", "*:10:20,ice-candidate:50:100"),
+		},
+		Push: PushConfig{
+			Timeout:                getEnvDuration("PUSH_TIMEOUT", 10*time.Second),
+			FCMCredentialsFile:     getEnv("FCM_CREDENTIALS_FILE", ""),
+			APNsKeyFile:            getEnv("APNS_KEY_FILE", ""),
+			APNsKeyID:              getEnv("APNS_KEY_ID", ""),
+			APNsTeamID:             getEnv("APNS_TEAM_ID", ""),
+			APNsTopic:              getEnv("APNS_TOPIC", ""),
+			APNsSandbox:            getEnv("APNS_SANDBOX", "false") == "true",
+			WebPushVAPIDPublicKey:  getEnv("WEBPUSH_VAPID_PUBLIC_KEY", ""),
+			WebPushVAPIDPrivateKey: getEnv("WEBPUSH_VAPID_PRIVATE_KEY", ""),
+			WebPushSubject:         getEnv("WEBPUSH_SUBJECT", ""),
+		},
 	}, nil
 }
 
@@ -107,3 +257,85 @@ func getEnv(key, defaultValue string) string {
 	}
 	return defaultValue
 }
+
+// getEnvRateLimits читает лимиты в формате "тип:в_секунду:подряд" через запятую.
+// Если значение не разбирается, используются лимиты по умолчанию.
+func getEnvRateLimits(key, defaultValue string) map[string]RateLimit {
+	if limits, err := parseRateLimits(os.Getenv(key)); err == nil && len(limits) > 0 {
+		return limits
+	}
+
+	limits, _ := parseRateLimits(defaultValue)
+	return limits
+}
+
+func parseRateLimits(value string) (map[string]RateLimit, error) {
+	limits := make(map[string]RateLimit)
+	for _, entry := range strings.Split(value, ",") {
+		if entry = strings.TrimSpace(entry); entry == "" {
+			continue
+		}
+
+		parts := strings.Split(entry, ":")
+		if len(parts) != 3 {
+			return nil, fmt.Errorf("invalid rate limit %q", entry)
+		}
+
+		rate, err := strconv.ParseFloat(parts[1], 64)
+		if err != nil || rate <= 0 {
+			return nil, fmt.Errorf("invalid rate in %q", entry)
+		}
+
+		burst, err := strconv.Atoi(parts[2])
+		if err != nil || burst < 1 {
+			return nil, fmt.Errorf("invalid burst in %q", entry)
+		}
+
+		limits[strings.TrimSpace(parts[0])] = RateLimit{Rate: rate, Burst: burst}
+	}
+
+	return limits, nil
+}
+
+// defaultNodeID - идентификатор узла по умолчанию: имя хоста (в Kubernetes - имя пода)
+// со случайным суффиксом, чтобы процессы на одном хосте не делили записи кластера
+func defaultNodeID() string {
+	b := make([]byte, 4)
+	rand.Read(b)
+	suffix := hex.EncodeToString(b)
+
+	name, err := os.Hostname()
+	if err != nil || name == "" {
+		return suffix
+	}
+	return name + "-" + suffix
+}
+
+func getEnvDuration(key string, defaultValue time.Duration) time.Duration {
+	if value := os.Getenv(key); value != "" {
+		if d, err := time.ParseDuration(value); err == nil {
+			return d
+		}
+	}
+	return defaultValue
+}
+
+func getEnvInt(key string, defaultValue int) int {
+	if value := os.Getenv(key); value != "" {
+		if i, err := strconv.Atoi(value); err == nil {
+			return i
+		}
+	}
+	return defaultValue
+}
+
+// getEnvList разбирает список значений через запятую
+func getEnvList(key string) []string {
+	var values []string
+	for _, value := range strings.Split(os.Getenv(key), ",") {
+		if value = strings.TrimSpace(value); value != "" {
+			values = append(values, value)
+		}
+	}
+	return values
+}
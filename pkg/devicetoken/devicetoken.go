@@ -0,0 +1,24 @@
+package devicetoken
+
+import (
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+)
+
+// Generate создает новый случайный токен устройства.
+// Токен показывается клиенту один раз, в базе хранится только его хеш.
+func Generate() (string, error) {
+	b := make([]byte, 32)
+	if _, err := rand.Read(b); err != nil {
+		return "", err
+	}
+	return base64.RawURLEncoding.EncodeToString(b), nil
+}
+
+// Hash возвращает SHA-256 хеш токена в hex (совпадает с encode(sha256(...), 'hex') в Postgres)
+func Hash(token string) string {
+	sum := sha256.Sum256([]byte(token))
+	return hex.EncodeToString(sum[:])
+}
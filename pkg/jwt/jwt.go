@@ -9,8 +9,9 @@ import (
 )
 
 type Claims struct {
-	UserID uuid.UUID `json:"user_id"`
-	Type   string    `json:"type"` // "access" or "refresh"
+	UserID   uuid.UUID  `json:"user_id"`
+	DeviceID *uuid.UUID `json:"device_id,omitempty"` // only for "signaling"
+	Type     string     `json:"type"`                // "access", "refresh" or "signaling"
 	jwt.RegisteredClaims
 }
 
@@ -49,6 +50,24 @@ func GenerateRefreshToken(userID uuid.UUID, secret string) (string, error) {
 	return token.SignedString([]byte(secret))
 }
 
+func GenerateSignalingTicket(userID, deviceID uuid.UUID, secret string, ttl time.Duration) (string, time.Time, error) {
+	expiresAt := time.Now().Add(ttl)
+	claims := &Claims{
+		UserID:   userID,
+		DeviceID: &deviceID,
+		Type:     "signaling",
+		RegisteredClaims: jwt.RegisteredClaims{
+			ExpiresAt: jwt.NewNumericDate(expiresAt),
+			IssuedAt:  jwt.NewNumericDate(time.Now()),
+			NotBefore: jwt.NewNumericDate(time.Now()),
+		},
+	}
+
+	token := jwt.NewWithClaims(jwt.SigningMethodHS256, claims)
+	signed, err := token.SignedString([]byte(secret))
+	return signed, expiresAt, err
+}
+
 func ValidateToken(tokenString, secret string) (*Claims, error) {
 	token, err := jwt.ParseWithClaims(tokenString, &Claims{}, func(token *jwt.Token) (interface{}, error) {
 		if _, ok := token.Method.(*jwt.SigningMethodHMAC); !ok {
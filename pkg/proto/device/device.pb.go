@@ -22,12 +22,14 @@ const (
 )
 
 type RegisterDeviceRequest struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	UserId        string                 `protobuf:"bytes,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
-	Name          string                 `protobuf:"bytes,2,opt,name=name,proto3" json:"name,omitempty"`
-	DeviceType    string                 `protobuf:"bytes,3,opt,name=device_type,json=deviceType,proto3" json:"device_type,omitempty"`
-	unknownFields protoimpl.UnknownFields
-	sizeCache     protoimpl.SizeCache
+	state          protoimpl.MessageState `protogen:"open.v1"`
+	UserId         string                 `protobuf:"bytes,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	Name           string                 `protobuf:"bytes,2,opt,name=name,proto3" json:"name,omitempty"`
+	DeviceType     string                 `protobuf:"bytes,3,opt,name=device_type,json=deviceType,proto3" json:"device_type,omitempty"`
+	OrganizationId string                 `protobuf:"bytes,4,opt,name=organization_id,json=organizationId,proto3" json:"organization_id,omitempty"`
+	Info           *DeviceInfo            `protobuf:"bytes,5,opt,name=info,proto3" json:"info,omitempty"`
+	unknownFields  protoimpl.UnknownFields
+	sizeCache      protoimpl.SizeCache
 }
 
 func (x *RegisterDeviceRequest) Reset() {
@@ -81,6 +83,20 @@ func (x *RegisterDeviceRequest) GetDeviceType() string {
 	return ""
 }
 
+func (x *RegisterDeviceRequest) GetOrganizationId() string {
+	if x != nil {
+		return x.OrganizationId
+	}
+	return ""
+}
+
+func (x *RegisterDeviceRequest) GetInfo() *DeviceInfo {
+	if x != nil {
+		return x.Info
+	}
+	return nil
+}
+
 type RegisterDeviceResponse struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	Device        *Device                `protobuf:"bytes,1,opt,name=device,proto3" json:"device,omitempty"`
@@ -230,10 +246,12 @@ func (x *GetDeviceResponse) GetDevice() *Device {
 }
 
 type ListDevicesRequest struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	UserId        string                 `protobuf:"bytes,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
-	unknownFields protoimpl.UnknownFields
-	sizeCache     protoimpl.SizeCache
+	state              protoimpl.MessageState `protogen:"open.v1"`
+	UserId             string                 `protobuf:"bytes,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	OrganizationId     string                 `protobuf:"bytes,2,opt,name=organization_id,json=organizationId,proto3" json:"organization_id,omitempty"`
+	IncludeDeactivated bool                   `protobuf:"varint,3,opt,name=include_deactivated,json=includeDeactivated,proto3" json:"include_deactivated,omitempty"`
+	unknownFields      protoimpl.UnknownFields
+	sizeCache          protoimpl.SizeCache
 }
 
 func (x *ListDevicesRequest) Reset() {
@@ -273,6 +291,20 @@ func (x *ListDevicesRequest) GetUserId() string {
 	return ""
 }
 
+func (x *ListDevicesRequest) GetOrganizationId() string {
+	if x != nil {
+		return x.OrganizationId
+	}
+	return ""
+}
+
+func (x *ListDevicesRequest) GetIncludeDeactivated() bool {
+	if x != nil {
+		return x.IncludeDeactivated
+	}
+	return false
+}
+
 type ListDevicesResponse struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	Devices       []*Device              `protobuf:"bytes,1,rep,name=devices,proto3" json:"devices,omitempty"`
@@ -613,34 +645,29 @@ func (x *UpdateLastSeenResponse) GetSuccess() bool {
 	return false
 }
 
-type Device struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
-	UserId        string                 `protobuf:"bytes,2,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
-	Name          string                 `protobuf:"bytes,3,opt,name=name,proto3" json:"name,omitempty"`
-	DeviceType    string                 `protobuf:"bytes,4,opt,name=device_type,json=deviceType,proto3" json:"device_type,omitempty"`
-	DeviceToken   string                 `protobuf:"bytes,5,opt,name=device_token,json=deviceToken,proto3" json:"device_token,omitempty"`
-	LastSeenAt    string                 `protobuf:"bytes,6,opt,name=last_seen_at,json=lastSeenAt,proto3" json:"last_seen_at,omitempty"`
-	CreatedAt     string                 `protobuf:"bytes,7,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
-	UpdatedAt     string                 `protobuf:"bytes,8,opt,name=updated_at,json=updatedAt,proto3" json:"updated_at,omitempty"`
-	unknownFields protoimpl.UnknownFields
-	sizeCache     protoimpl.SizeCache
+type SetDeviceOrganizationRequest struct {
+	state          protoimpl.MessageState `protogen:"open.v1"`
+	DeviceId       string                 `protobuf:"bytes,1,opt,name=device_id,json=deviceId,proto3" json:"device_id,omitempty"`
+	UserId         string                 `protobuf:"bytes,2,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	OrganizationId string                 `protobuf:"bytes,3,opt,name=organization_id,json=organizationId,proto3" json:"organization_id,omitempty"` // пустая строка - отвязать от организации
+	unknownFields  protoimpl.UnknownFields
+	sizeCache      protoimpl.SizeCache
 }
 
-func (x *Device) Reset() {
-	*x = Device{}
+func (x *SetDeviceOrganizationRequest) Reset() {
+	*x = SetDeviceOrganizationRequest{}
 	mi := &file_pkg_proto_device_device_proto_msgTypes[12]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *Device) String() string {
+func (x *SetDeviceOrganizationRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*Device) ProtoMessage() {}
+func (*SetDeviceOrganizationRequest) ProtoMessage() {}
 
-func (x *Device) ProtoReflect() protoreflect.Message {
+func (x *SetDeviceOrganizationRequest) ProtoReflect() protoreflect.Message {
 	mi := &file_pkg_proto_device_device_proto_msgTypes[12]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -652,126 +679,1608 @@ func (x *Device) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use Device.ProtoReflect.Descriptor instead.
-func (*Device) Descriptor() ([]byte, []int) {
+// Deprecated: Use SetDeviceOrganizationRequest.ProtoReflect.Descriptor instead.
+func (*SetDeviceOrganizationRequest) Descriptor() ([]byte, []int) {
 	return file_pkg_proto_device_device_proto_rawDescGZIP(), []int{12}
 }
 
-func (x *Device) GetId() string {
+func (x *SetDeviceOrganizationRequest) GetDeviceId() string {
 	if x != nil {
-		return x.Id
+		return x.DeviceId
 	}
 	return ""
 }
 
-func (x *Device) GetUserId() string {
+func (x *SetDeviceOrganizationRequest) GetUserId() string {
 	if x != nil {
 		return x.UserId
 	}
 	return ""
 }
 
-func (x *Device) GetName() string {
+func (x *SetDeviceOrganizationRequest) GetOrganizationId() string {
 	if x != nil {
-		return x.Name
+		return x.OrganizationId
 	}
 	return ""
 }
 
-func (x *Device) GetDeviceType() string {
+type SetDeviceOrganizationResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Device        *Device                `protobuf:"bytes,1,opt,name=device,proto3" json:"device,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SetDeviceOrganizationResponse) Reset() {
+	*x = SetDeviceOrganizationResponse{}
+	mi := &file_pkg_proto_device_device_proto_msgTypes[13]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SetDeviceOrganizationResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SetDeviceOrganizationResponse) ProtoMessage() {}
+
+func (x *SetDeviceOrganizationResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_pkg_proto_device_device_proto_msgTypes[13]
 	if x != nil {
-		return x.DeviceType
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SetDeviceOrganizationResponse.ProtoReflect.Descriptor instead.
+func (*SetDeviceOrganizationResponse) Descriptor() ([]byte, []int) {
+	return file_pkg_proto_device_device_proto_rawDescGZIP(), []int{13}
+}
+
+func (x *SetDeviceOrganizationResponse) GetDevice() *Device {
+	if x != nil {
+		return x.Device
+	}
+	return nil
+}
+
+type CreatePairingCodeRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	UserId        string                 `protobuf:"bytes,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	DeviceId      string                 `protobuf:"bytes,2,opt,name=device_id,json=deviceId,proto3" json:"device_id,omitempty"` // авторизованное устройство, запрашивающее код
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CreatePairingCodeRequest) Reset() {
+	*x = CreatePairingCodeRequest{}
+	mi := &file_pkg_proto_device_device_proto_msgTypes[14]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CreatePairingCodeRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CreatePairingCodeRequest) ProtoMessage() {}
+
+func (x *CreatePairingCodeRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_pkg_proto_device_device_proto_msgTypes[14]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CreatePairingCodeRequest.ProtoReflect.Descriptor instead.
+func (*CreatePairingCodeRequest) Descriptor() ([]byte, []int) {
+	return file_pkg_proto_device_device_proto_rawDescGZIP(), []int{14}
+}
+
+func (x *CreatePairingCodeRequest) GetUserId() string {
+	if x != nil {
+		return x.UserId
 	}
 	return ""
 }
 
-func (x *Device) GetDeviceToken() string {
+func (x *CreatePairingCodeRequest) GetDeviceId() string {
 	if x != nil {
-		return x.DeviceToken
+		return x.DeviceId
 	}
 	return ""
 }
 
-func (x *Device) GetLastSeenAt() string {
+type CreatePairingCodeResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Code          string                 `protobuf:"bytes,1,opt,name=code,proto3" json:"code,omitempty"`
+	QrPayload     string                 `protobuf:"bytes,2,opt,name=qr_payload,json=qrPayload,proto3" json:"qr_payload,omitempty"`
+	ExpiresAt     string                 `protobuf:"bytes,3,opt,name=expires_at,json=expiresAt,proto3" json:"expires_at,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CreatePairingCodeResponse) Reset() {
+	*x = CreatePairingCodeResponse{}
+	mi := &file_pkg_proto_device_device_proto_msgTypes[15]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CreatePairingCodeResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CreatePairingCodeResponse) ProtoMessage() {}
+
+func (x *CreatePairingCodeResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_pkg_proto_device_device_proto_msgTypes[15]
 	if x != nil {
-		return x.LastSeenAt
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CreatePairingCodeResponse.ProtoReflect.Descriptor instead.
+func (*CreatePairingCodeResponse) Descriptor() ([]byte, []int) {
+	return file_pkg_proto_device_device_proto_rawDescGZIP(), []int{15}
+}
+
+func (x *CreatePairingCodeResponse) GetCode() string {
+	if x != nil {
+		return x.Code
 	}
 	return ""
 }
 
-func (x *Device) GetCreatedAt() string {
+func (x *CreatePairingCodeResponse) GetQrPayload() string {
 	if x != nil {
-		return x.CreatedAt
+		return x.QrPayload
 	}
 	return ""
 }
 
-func (x *Device) GetUpdatedAt() string {
+func (x *CreatePairingCodeResponse) GetExpiresAt() string {
 	if x != nil {
-		return x.UpdatedAt
+		return x.ExpiresAt
 	}
 	return ""
 }
 
-var File_pkg_proto_device_device_proto protoreflect.FileDescriptor
+type RedeemPairingCodeRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Code          string                 `protobuf:"bytes,1,opt,name=code,proto3" json:"code,omitempty"`
+	Name          string                 `protobuf:"bytes,2,opt,name=name,proto3" json:"name,omitempty"`
+	DeviceType    string                 `protobuf:"bytes,3,opt,name=device_type,json=deviceType,proto3" json:"device_type,omitempty"`
+	ClientIp      string                 `protobuf:"bytes,4,opt,name=client_ip,json=clientIp,proto3" json:"client_ip,omitempty"` // для ограничения числа попыток
+	Info          *DeviceInfo            `protobuf:"bytes,5,opt,name=info,proto3" json:"info,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
 
-const file_pkg_proto_device_device_proto_rawDesc = "" +
-	"\n" +
-	"\x1dpkg/proto/device/device.proto\x12\x06device\"e\n" +
-	"\x15RegisterDeviceRequest\x12\x17\n" +
-	"\auser_id\x18\x01 \x01(\tR\x06userId\x12\x12\n" +
-	"\x04name\x18\x02 \x01(\tR\x04name\x12\x1f\n" +
-	"\vdevice_type\x18\x03 \x01(\tR\n" +
-	"deviceType\"c\n" +
-	"\x16RegisterDeviceResponse\x12&\n" +
-	"\x06device\x18\x01 \x01(\v2\x0e.device.DeviceR\x06device\x12!\n" +
-	"\fdevice_token\x18\x02 \x01(\tR\vdeviceToken\"H\n" +
-	"\x10GetDeviceRequest\x12\x1b\n" +
-	"\tdevice_id\x18\x01 \x01(\tR\bdeviceId\x12\x17\n" +
-	"\auser_id\x18\x02 \x01(\tR\x06userId\";\n" +
-	"\x11GetDeviceResponse\x12&\n" +
-	"\x06device\x18\x01 \x01(\v2\x0e.device.DeviceR\x06device\"-\n" +
-	"\x12ListDevicesRequest\x12\x17\n" +
-	"\auser_id\x18\x01 \x01(\tR\x06userId\"?\n" +
-	"\x13ListDevicesResponse\x12(\n" +
-	"\adevices\x18\x01 \x03(\v2\x0e.device.DeviceR\adevices\"\x80\x01\n" +
-	"\x13UpdateDeviceRequest\x12\x1b\n" +
-	"\tdevice_id\x18\x01 \x01(\tR\bdeviceId\x12\x17\n" +
-	"\auser_id\x18\x02 \x01(\tR\x06userId\x12\x12\n" +
-	"\x04name\x18\x03 \x01(\tR\x04name\x12\x1f\n" +
-	"\vdevice_type\x18\x04 \x01(\tR\n" +
-	"deviceType\">\n" +
-	"\x14UpdateDeviceResponse\x12&\n" +
-	"\x06device\x18\x01 \x01(\v2\x0e.device.DeviceR\x06device\"K\n" +
-	"\x13DeleteDeviceRequest\x12\x1b\n" +
-	"\tdevice_id\x18\x01 \x01(\tR\bdeviceId\x12\x17\n" +
-	"\auser_id\x18\x02 \x01(\tR\x06userId\"0\n" +
-	"\x14DeleteDeviceResponse\x12\x18\n" +
-	"\asuccess\x18\x01 \x01(\bR\asuccess\"4\n" +
-	"\x15UpdateLastSeenRequest\x12\x1b\n" +
-	"\tdevice_id\x18\x01 \x01(\tR\bdeviceId\"2\n" +
-	"\x16UpdateLastSeenResponse\x12\x18\n" +
-	"\asuccess\x18\x01 \x01(\bR\asuccess\"\xe9\x01\n" +
-	"\x06Device\x12\x0e\n" +
-	"\x02id\x18\x01 \x01(\tR\x02id\x12\x17\n" +
-	"\auser_id\x18\x02 \x01(\tR\x06userId\x12\x12\n" +
-	"\x04name\x18\x03 \x01(\tR\x04name\x12\x1f\n" +
-	"\vdevice_type\x18\x04 \x01(\tR\n" +
-	"deviceType\x12!\n" +
-	"\fdevice_token\x18\x05 \x01(\tR\vdeviceToken\x12 \n" +
-	"\flast_seen_at\x18\x06 \x01(\tR\n" +
-	"lastSeenAt\x12\x1d\n" +
-	"\n" +
-	"created_at\x18\a \x01(\tR\tcreatedAt\x12\x1d\n" +
+func (x *RedeemPairingCodeRequest) Reset() {
+	*x = RedeemPairingCodeRequest{}
+	mi := &file_pkg_proto_device_device_proto_msgTypes[16]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RedeemPairingCodeRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RedeemPairingCodeRequest) ProtoMessage() {}
+
+func (x *RedeemPairingCodeRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_pkg_proto_device_device_proto_msgTypes[16]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RedeemPairingCodeRequest.ProtoReflect.Descriptor instead.
+func (*RedeemPairingCodeRequest) Descriptor() ([]byte, []int) {
+	return file_pkg_proto_device_device_proto_rawDescGZIP(), []int{16}
+}
+
+func (x *RedeemPairingCodeRequest) GetCode() string {
+	if x != nil {
+		return x.Code
+	}
+	return ""
+}
+
+func (x *RedeemPairingCodeRequest) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *RedeemPairingCodeRequest) GetDeviceType() string {
+	if x != nil {
+		return x.DeviceType
+	}
+	return ""
+}
+
+func (x *RedeemPairingCodeRequest) GetClientIp() string {
+	if x != nil {
+		return x.ClientIp
+	}
+	return ""
+}
+
+func (x *RedeemPairingCodeRequest) GetInfo() *DeviceInfo {
+	if x != nil {
+		return x.Info
+	}
+	return nil
+}
+
+type RedeemPairingCodeResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Device        *Device                `protobuf:"bytes,1,opt,name=device,proto3" json:"device,omitempty"`
+	DeviceToken   string                 `protobuf:"bytes,2,opt,name=device_token,json=deviceToken,proto3" json:"device_token,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RedeemPairingCodeResponse) Reset() {
+	*x = RedeemPairingCodeResponse{}
+	mi := &file_pkg_proto_device_device_proto_msgTypes[17]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RedeemPairingCodeResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RedeemPairingCodeResponse) ProtoMessage() {}
+
+func (x *RedeemPairingCodeResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_pkg_proto_device_device_proto_msgTypes[17]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RedeemPairingCodeResponse.ProtoReflect.Descriptor instead.
+func (*RedeemPairingCodeResponse) Descriptor() ([]byte, []int) {
+	return file_pkg_proto_device_device_proto_rawDescGZIP(), []int{17}
+}
+
+func (x *RedeemPairingCodeResponse) GetDevice() *Device {
+	if x != nil {
+		return x.Device
+	}
+	return nil
+}
+
+func (x *RedeemPairingCodeResponse) GetDeviceToken() string {
+	if x != nil {
+		return x.DeviceToken
+	}
+	return ""
+}
+
+type RotateDeviceTokenRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	DeviceId      string                 `protobuf:"bytes,1,opt,name=device_id,json=deviceId,proto3" json:"device_id,omitempty"`
+	UserId        string                 `protobuf:"bytes,2,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RotateDeviceTokenRequest) Reset() {
+	*x = RotateDeviceTokenRequest{}
+	mi := &file_pkg_proto_device_device_proto_msgTypes[18]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RotateDeviceTokenRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RotateDeviceTokenRequest) ProtoMessage() {}
+
+func (x *RotateDeviceTokenRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_pkg_proto_device_device_proto_msgTypes[18]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RotateDeviceTokenRequest.ProtoReflect.Descriptor instead.
+func (*RotateDeviceTokenRequest) Descriptor() ([]byte, []int) {
+	return file_pkg_proto_device_device_proto_rawDescGZIP(), []int{18}
+}
+
+func (x *RotateDeviceTokenRequest) GetDeviceId() string {
+	if x != nil {
+		return x.DeviceId
+	}
+	return ""
+}
+
+func (x *RotateDeviceTokenRequest) GetUserId() string {
+	if x != nil {
+		return x.UserId
+	}
+	return ""
+}
+
+type RotateDeviceTokenResponse struct {
+	state                  protoimpl.MessageState `protogen:"open.v1"`
+	DeviceToken            string                 `protobuf:"bytes,1,opt,name=device_token,json=deviceToken,proto3" json:"device_token,omitempty"`
+	PreviousTokenExpiresAt string                 `protobuf:"bytes,2,opt,name=previous_token_expires_at,json=previousTokenExpiresAt,proto3" json:"previous_token_expires_at,omitempty"` // до этого момента старый токен еще принимается
+	unknownFields          protoimpl.UnknownFields
+	sizeCache              protoimpl.SizeCache
+}
+
+func (x *RotateDeviceTokenResponse) Reset() {
+	*x = RotateDeviceTokenResponse{}
+	mi := &file_pkg_proto_device_device_proto_msgTypes[19]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RotateDeviceTokenResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RotateDeviceTokenResponse) ProtoMessage() {}
+
+func (x *RotateDeviceTokenResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_pkg_proto_device_device_proto_msgTypes[19]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RotateDeviceTokenResponse.ProtoReflect.Descriptor instead.
+func (*RotateDeviceTokenResponse) Descriptor() ([]byte, []int) {
+	return file_pkg_proto_device_device_proto_rawDescGZIP(), []int{19}
+}
+
+func (x *RotateDeviceTokenResponse) GetDeviceToken() string {
+	if x != nil {
+		return x.DeviceToken
+	}
+	return ""
+}
+
+func (x *RotateDeviceTokenResponse) GetPreviousTokenExpiresAt() string {
+	if x != nil {
+		return x.PreviousTokenExpiresAt
+	}
+	return ""
+}
+
+type Device struct {
+	state          protoimpl.MessageState `protogen:"open.v1"`
+	Id             string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	UserId         string                 `protobuf:"bytes,2,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	Name           string                 `protobuf:"bytes,3,opt,name=name,proto3" json:"name,omitempty"`
+	DeviceType     string                 `protobuf:"bytes,4,opt,name=device_type,json=deviceType,proto3" json:"device_type,omitempty"`
+	LastSeenAt     string                 `protobuf:"bytes,6,opt,name=last_seen_at,json=lastSeenAt,proto3" json:"last_seen_at,omitempty"`
+	CreatedAt      string                 `protobuf:"bytes,7,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
+	UpdatedAt      string                 `protobuf:"bytes,8,opt,name=updated_at,json=updatedAt,proto3" json:"updated_at,omitempty"`
+	OrganizationId string                 `protobuf:"bytes,9,opt,name=organization_id,json=organizationId,proto3" json:"organization_id,omitempty"`
+	PresenceStatus string                 `protobuf:"bytes,10,opt,name=presence_status,json=presenceStatus,proto3" json:"presence_status,omitempty"` // online, away или offline
+	ConnectedSince string                 `protobuf:"bytes,11,opt,name=connected_since,json=connectedSince,proto3" json:"connected_since,omitempty"` // пусто, если устройство не подключено
+	Info           *DeviceInfo            `protobuf:"bytes,12,opt,name=info,proto3" json:"info,omitempty"`
+	ApprovalStatus string                 `protobuf:"bytes,13,opt,name=approval_status,json=approvalStatus,proto3" json:"approval_status,omitempty"` // pending, approved или rejected
+	PushProvider   string                 `protobuf:"bytes,14,opt,name=push_provider,json=pushProvider,proto3" json:"push_provider,omitempty"`       // fcm, apns, webpush; пусто - уведомления не настроены
+	PruneExempt    bool                   `protobuf:"varint,15,opt,name=prune_exempt,json=pruneExempt,proto3" json:"prune_exempt,omitempty"`         // не отключается по неактивности
+	DeactivatedAt  string                 `protobuf:"bytes,16,opt,name=deactivated_at,json=deactivatedAt,proto3" json:"deactivated_at,omitempty"`    // пусто, если устройство активно
+	unknownFields  protoimpl.UnknownFields
+	sizeCache      protoimpl.SizeCache
+}
+
+func (x *Device) Reset() {
+	*x = Device{}
+	mi := &file_pkg_proto_device_device_proto_msgTypes[20]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Device) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Device) ProtoMessage() {}
+
+func (x *Device) ProtoReflect() protoreflect.Message {
+	mi := &file_pkg_proto_device_device_proto_msgTypes[20]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Device.ProtoReflect.Descriptor instead.
+func (*Device) Descriptor() ([]byte, []int) {
+	return file_pkg_proto_device_device_proto_rawDescGZIP(), []int{20}
+}
+
+func (x *Device) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *Device) GetUserId() string {
+	if x != nil {
+		return x.UserId
+	}
+	return ""
+}
+
+func (x *Device) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *Device) GetDeviceType() string {
+	if x != nil {
+		return x.DeviceType
+	}
+	return ""
+}
+
+func (x *Device) GetLastSeenAt() string {
+	if x != nil {
+		return x.LastSeenAt
+	}
+	return ""
+}
+
+func (x *Device) GetCreatedAt() string {
+	if x != nil {
+		return x.CreatedAt
+	}
+	return ""
+}
+
+func (x *Device) GetUpdatedAt() string {
+	if x != nil {
+		return x.UpdatedAt
+	}
+	return ""
+}
+
+func (x *Device) GetOrganizationId() string {
+	if x != nil {
+		return x.OrganizationId
+	}
+	return ""
+}
+
+func (x *Device) GetPresenceStatus() string {
+	if x != nil {
+		return x.PresenceStatus
+	}
+	return ""
+}
+
+func (x *Device) GetConnectedSince() string {
+	if x != nil {
+		return x.ConnectedSince
+	}
+	return ""
+}
+
+func (x *Device) GetInfo() *DeviceInfo {
+	if x != nil {
+		return x.Info
+	}
+	return nil
+}
+
+func (x *Device) GetApprovalStatus() string {
+	if x != nil {
+		return x.ApprovalStatus
+	}
+	return ""
+}
+
+func (x *Device) GetPushProvider() string {
+	if x != nil {
+		return x.PushProvider
+	}
+	return ""
+}
+
+func (x *Device) GetPruneExempt() bool {
+	if x != nil {
+		return x.PruneExempt
+	}
+	return false
+}
+
+func (x *Device) GetDeactivatedAt() string {
+	if x != nil {
+		return x.DeactivatedAt
+	}
+	return ""
+}
+
+// DeviceInfo - платформа, версии клиента и возможности устройства
+type DeviceInfo struct {
+	state             protoimpl.MessageState `protogen:"open.v1"`
+	Platform          string                 `protobuf:"bytes,1,opt,name=platform,proto3" json:"platform,omitempty"` // windows, macos, linux, ios, android, web, other
+	OsVersion         string                 `protobuf:"bytes,2,opt,name=os_version,json=osVersion,proto3" json:"os_version,omitempty"`
+	AppVersion        string                 `protobuf:"bytes,3,opt,name=app_version,json=appVersion,proto3" json:"app_version,omitempty"`
+	TransferProtocols []string               `protobuf:"bytes,4,rep,name=transfer_protocols,json=transferProtocols,proto3" json:"transfer_protocols,omitempty"` // webrtc, relay, cloud; пусто - все протоколы
+	MaxFileSize       int64                  `protobuf:"varint,5,opt,name=max_file_size,json=maxFileSize,proto3" json:"max_file_size,omitempty"`                // 0 - без ограничения
+	unknownFields     protoimpl.UnknownFields
+	sizeCache         protoimpl.SizeCache
+}
+
+func (x *DeviceInfo) Reset() {
+	*x = DeviceInfo{}
+	mi := &file_pkg_proto_device_device_proto_msgTypes[21]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DeviceInfo) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DeviceInfo) ProtoMessage() {}
+
+func (x *DeviceInfo) ProtoReflect() protoreflect.Message {
+	mi := &file_pkg_proto_device_device_proto_msgTypes[21]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DeviceInfo.ProtoReflect.Descriptor instead.
+func (*DeviceInfo) Descriptor() ([]byte, []int) {
+	return file_pkg_proto_device_device_proto_rawDescGZIP(), []int{21}
+}
+
+func (x *DeviceInfo) GetPlatform() string {
+	if x != nil {
+		return x.Platform
+	}
+	return ""
+}
+
+func (x *DeviceInfo) GetOsVersion() string {
+	if x != nil {
+		return x.OsVersion
+	}
+	return ""
+}
+
+func (x *DeviceInfo) GetAppVersion() string {
+	if x != nil {
+		return x.AppVersion
+	}
+	return ""
+}
+
+func (x *DeviceInfo) GetTransferProtocols() []string {
+	if x != nil {
+		return x.TransferProtocols
+	}
+	return nil
+}
+
+func (x *DeviceInfo) GetMaxFileSize() int64 {
+	if x != nil {
+		return x.MaxFileSize
+	}
+	return 0
+}
+
+// Подтверждение выполняется доверенным устройством пользователя: запрос
+// содержит его device_token
+type ApproveDeviceRequest struct {
+	state               protoimpl.MessageState `protogen:"open.v1"`
+	DeviceId            string                 `protobuf:"bytes,1,opt,name=device_id,json=deviceId,proto3" json:"device_id,omitempty"`
+	UserId              string                 `protobuf:"bytes,2,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	ApproverDeviceToken string                 `protobuf:"bytes,3,opt,name=approver_device_token,json=approverDeviceToken,proto3" json:"approver_device_token,omitempty"`
+	unknownFields       protoimpl.UnknownFields
+	sizeCache           protoimpl.SizeCache
+}
+
+func (x *ApproveDeviceRequest) Reset() {
+	*x = ApproveDeviceRequest{}
+	mi := &file_pkg_proto_device_device_proto_msgTypes[22]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ApproveDeviceRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ApproveDeviceRequest) ProtoMessage() {}
+
+func (x *ApproveDeviceRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_pkg_proto_device_device_proto_msgTypes[22]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ApproveDeviceRequest.ProtoReflect.Descriptor instead.
+func (*ApproveDeviceRequest) Descriptor() ([]byte, []int) {
+	return file_pkg_proto_device_device_proto_rawDescGZIP(), []int{22}
+}
+
+func (x *ApproveDeviceRequest) GetDeviceId() string {
+	if x != nil {
+		return x.DeviceId
+	}
+	return ""
+}
+
+func (x *ApproveDeviceRequest) GetUserId() string {
+	if x != nil {
+		return x.UserId
+	}
+	return ""
+}
+
+func (x *ApproveDeviceRequest) GetApproverDeviceToken() string {
+	if x != nil {
+		return x.ApproverDeviceToken
+	}
+	return ""
+}
+
+type ApproveDeviceResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Device        *Device                `protobuf:"bytes,1,opt,name=device,proto3" json:"device,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ApproveDeviceResponse) Reset() {
+	*x = ApproveDeviceResponse{}
+	mi := &file_pkg_proto_device_device_proto_msgTypes[23]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ApproveDeviceResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ApproveDeviceResponse) ProtoMessage() {}
+
+func (x *ApproveDeviceResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_pkg_proto_device_device_proto_msgTypes[23]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ApproveDeviceResponse.ProtoReflect.Descriptor instead.
+func (*ApproveDeviceResponse) Descriptor() ([]byte, []int) {
+	return file_pkg_proto_device_device_proto_rawDescGZIP(), []int{23}
+}
+
+func (x *ApproveDeviceResponse) GetDevice() *Device {
+	if x != nil {
+		return x.Device
+	}
+	return nil
+}
+
+type RejectDeviceRequest struct {
+	state               protoimpl.MessageState `protogen:"open.v1"`
+	DeviceId            string                 `protobuf:"bytes,1,opt,name=device_id,json=deviceId,proto3" json:"device_id,omitempty"`
+	UserId              string                 `protobuf:"bytes,2,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	ApproverDeviceToken string                 `protobuf:"bytes,3,opt,name=approver_device_token,json=approverDeviceToken,proto3" json:"approver_device_token,omitempty"`
+	unknownFields       protoimpl.UnknownFields
+	sizeCache           protoimpl.SizeCache
+}
+
+func (x *RejectDeviceRequest) Reset() {
+	*x = RejectDeviceRequest{}
+	mi := &file_pkg_proto_device_device_proto_msgTypes[24]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RejectDeviceRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RejectDeviceRequest) ProtoMessage() {}
+
+func (x *RejectDeviceRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_pkg_proto_device_device_proto_msgTypes[24]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RejectDeviceRequest.ProtoReflect.Descriptor instead.
+func (*RejectDeviceRequest) Descriptor() ([]byte, []int) {
+	return file_pkg_proto_device_device_proto_rawDescGZIP(), []int{24}
+}
+
+func (x *RejectDeviceRequest) GetDeviceId() string {
+	if x != nil {
+		return x.DeviceId
+	}
+	return ""
+}
+
+func (x *RejectDeviceRequest) GetUserId() string {
+	if x != nil {
+		return x.UserId
+	}
+	return ""
+}
+
+func (x *RejectDeviceRequest) GetApproverDeviceToken() string {
+	if x != nil {
+		return x.ApproverDeviceToken
+	}
+	return ""
+}
+
+type RejectDeviceResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Device        *Device                `protobuf:"bytes,1,opt,name=device,proto3" json:"device,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RejectDeviceResponse) Reset() {
+	*x = RejectDeviceResponse{}
+	mi := &file_pkg_proto_device_device_proto_msgTypes[25]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RejectDeviceResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RejectDeviceResponse) ProtoMessage() {}
+
+func (x *RejectDeviceResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_pkg_proto_device_device_proto_msgTypes[25]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RejectDeviceResponse.ProtoReflect.Descriptor instead.
+func (*RejectDeviceResponse) Descriptor() ([]byte, []int) {
+	return file_pkg_proto_device_device_proto_rawDescGZIP(), []int{25}
+}
+
+func (x *RejectDeviceResponse) GetDevice() *Device {
+	if x != nil {
+		return x.Device
+	}
+	return nil
+}
+
+// Пустые provider и token отключают push-уведомления устройства
+type SetPushTokenRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	DeviceId      string                 `protobuf:"bytes,1,opt,name=device_id,json=deviceId,proto3" json:"device_id,omitempty"`
+	UserId        string                 `protobuf:"bytes,2,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	Provider      string                 `protobuf:"bytes,3,opt,name=provider,proto3" json:"provider,omitempty"` // fcm, apns, webpush
+	Token         string                 `protobuf:"bytes,4,opt,name=token,proto3" json:"token,omitempty"`       // для webpush - JSON PushSubscription
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SetPushTokenRequest) Reset() {
+	*x = SetPushTokenRequest{}
+	mi := &file_pkg_proto_device_device_proto_msgTypes[26]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SetPushTokenRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SetPushTokenRequest) ProtoMessage() {}
+
+func (x *SetPushTokenRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_pkg_proto_device_device_proto_msgTypes[26]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SetPushTokenRequest.ProtoReflect.Descriptor instead.
+func (*SetPushTokenRequest) Descriptor() ([]byte, []int) {
+	return file_pkg_proto_device_device_proto_rawDescGZIP(), []int{26}
+}
+
+func (x *SetPushTokenRequest) GetDeviceId() string {
+	if x != nil {
+		return x.DeviceId
+	}
+	return ""
+}
+
+func (x *SetPushTokenRequest) GetUserId() string {
+	if x != nil {
+		return x.UserId
+	}
+	return ""
+}
+
+func (x *SetPushTokenRequest) GetProvider() string {
+	if x != nil {
+		return x.Provider
+	}
+	return ""
+}
+
+func (x *SetPushTokenRequest) GetToken() string {
+	if x != nil {
+		return x.Token
+	}
+	return ""
+}
+
+type SetPushTokenResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Device        *Device                `protobuf:"bytes,1,opt,name=device,proto3" json:"device,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SetPushTokenResponse) Reset() {
+	*x = SetPushTokenResponse{}
+	mi := &file_pkg_proto_device_device_proto_msgTypes[27]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SetPushTokenResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SetPushTokenResponse) ProtoMessage() {}
+
+func (x *SetPushTokenResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_pkg_proto_device_device_proto_msgTypes[27]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SetPushTokenResponse.ProtoReflect.Descriptor instead.
+func (*SetPushTokenResponse) Descriptor() ([]byte, []int) {
+	return file_pkg_proto_device_device_proto_rawDescGZIP(), []int{27}
+}
+
+func (x *SetPushTokenResponse) GetDevice() *Device {
+	if x != nil {
+		return x.Device
+	}
+	return nil
+}
+
+type SetDevicePruneExemptRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	DeviceId      string                 `protobuf:"bytes,1,opt,name=device_id,json=deviceId,proto3" json:"device_id,omitempty"`
+	UserId        string                 `protobuf:"bytes,2,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	Exempt        bool                   `protobuf:"varint,3,opt,name=exempt,proto3" json:"exempt,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SetDevicePruneExemptRequest) Reset() {
+	*x = SetDevicePruneExemptRequest{}
+	mi := &file_pkg_proto_device_device_proto_msgTypes[28]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SetDevicePruneExemptRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SetDevicePruneExemptRequest) ProtoMessage() {}
+
+func (x *SetDevicePruneExemptRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_pkg_proto_device_device_proto_msgTypes[28]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SetDevicePruneExemptRequest.ProtoReflect.Descriptor instead.
+func (*SetDevicePruneExemptRequest) Descriptor() ([]byte, []int) {
+	return file_pkg_proto_device_device_proto_rawDescGZIP(), []int{28}
+}
+
+func (x *SetDevicePruneExemptRequest) GetDeviceId() string {
+	if x != nil {
+		return x.DeviceId
+	}
+	return ""
+}
+
+func (x *SetDevicePruneExemptRequest) GetUserId() string {
+	if x != nil {
+		return x.UserId
+	}
+	return ""
+}
+
+func (x *SetDevicePruneExemptRequest) GetExempt() bool {
+	if x != nil {
+		return x.Exempt
+	}
+	return false
+}
+
+type SetDevicePruneExemptResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Device        *Device                `protobuf:"bytes,1,opt,name=device,proto3" json:"device,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SetDevicePruneExemptResponse) Reset() {
+	*x = SetDevicePruneExemptResponse{}
+	mi := &file_pkg_proto_device_device_proto_msgTypes[29]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SetDevicePruneExemptResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SetDevicePruneExemptResponse) ProtoMessage() {}
+
+func (x *SetDevicePruneExemptResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_pkg_proto_device_device_proto_msgTypes[29]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SetDevicePruneExemptResponse.ProtoReflect.Descriptor instead.
+func (*SetDevicePruneExemptResponse) Descriptor() ([]byte, []int) {
+	return file_pkg_proto_device_device_proto_rawDescGZIP(), []int{29}
+}
+
+func (x *SetDevicePruneExemptResponse) GetDevice() *Device {
+	if x != nil {
+		return x.Device
+	}
+	return nil
+}
+
+// InactivityPolicy - через сколько дней без подключения устройства пользователя отключаются
+type InactivityPolicy struct {
+	state                protoimpl.MessageState `protogen:"open.v1"`
+	InactivityDays       int32                  `protobuf:"varint,1,opt,name=inactivity_days,json=inactivityDays,proto3" json:"inactivity_days,omitempty"`                     // 0 - устройства не отключаются
+	IsDefault            bool                   `protobuf:"varint,2,opt,name=is_default,json=isDefault,proto3" json:"is_default,omitempty"`                                    // порог по умолчанию сервера
+	WarningPeriodSeconds int64                  `protobuf:"varint,3,opt,name=warning_period_seconds,json=warningPeriodSeconds,proto3" json:"warning_period_seconds,omitempty"` // за сколько до отключения приходит предупреждение
+	unknownFields        protoimpl.UnknownFields
+	sizeCache            protoimpl.SizeCache
+}
+
+func (x *InactivityPolicy) Reset() {
+	*x = InactivityPolicy{}
+	mi := &file_pkg_proto_device_device_proto_msgTypes[30]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *InactivityPolicy) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*InactivityPolicy) ProtoMessage() {}
+
+func (x *InactivityPolicy) ProtoReflect() protoreflect.Message {
+	mi := &file_pkg_proto_device_device_proto_msgTypes[30]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use InactivityPolicy.ProtoReflect.Descriptor instead.
+func (*InactivityPolicy) Descriptor() ([]byte, []int) {
+	return file_pkg_proto_device_device_proto_rawDescGZIP(), []int{30}
+}
+
+func (x *InactivityPolicy) GetInactivityDays() int32 {
+	if x != nil {
+		return x.InactivityDays
+	}
+	return 0
+}
+
+func (x *InactivityPolicy) GetIsDefault() bool {
+	if x != nil {
+		return x.IsDefault
+	}
+	return false
+}
+
+func (x *InactivityPolicy) GetWarningPeriodSeconds() int64 {
+	if x != nil {
+		return x.WarningPeriodSeconds
+	}
+	return 0
+}
+
+type GetInactivityPolicyRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	UserId        string                 `protobuf:"bytes,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetInactivityPolicyRequest) Reset() {
+	*x = GetInactivityPolicyRequest{}
+	mi := &file_pkg_proto_device_device_proto_msgTypes[31]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetInactivityPolicyRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetInactivityPolicyRequest) ProtoMessage() {}
+
+func (x *GetInactivityPolicyRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_pkg_proto_device_device_proto_msgTypes[31]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetInactivityPolicyRequest.ProtoReflect.Descriptor instead.
+func (*GetInactivityPolicyRequest) Descriptor() ([]byte, []int) {
+	return file_pkg_proto_device_device_proto_rawDescGZIP(), []int{31}
+}
+
+func (x *GetInactivityPolicyRequest) GetUserId() string {
+	if x != nil {
+		return x.UserId
+	}
+	return ""
+}
+
+type GetInactivityPolicyResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Policy        *InactivityPolicy      `protobuf:"bytes,1,opt,name=policy,proto3" json:"policy,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetInactivityPolicyResponse) Reset() {
+	*x = GetInactivityPolicyResponse{}
+	mi := &file_pkg_proto_device_device_proto_msgTypes[32]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetInactivityPolicyResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetInactivityPolicyResponse) ProtoMessage() {}
+
+func (x *GetInactivityPolicyResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_pkg_proto_device_device_proto_msgTypes[32]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetInactivityPolicyResponse.ProtoReflect.Descriptor instead.
+func (*GetInactivityPolicyResponse) Descriptor() ([]byte, []int) {
+	return file_pkg_proto_device_device_proto_rawDescGZIP(), []int{32}
+}
+
+func (x *GetInactivityPolicyResponse) GetPolicy() *InactivityPolicy {
+	if x != nil {
+		return x.Policy
+	}
+	return nil
+}
+
+type UpdateInactivityPolicyRequest struct {
+	state          protoimpl.MessageState `protogen:"open.v1"`
+	UserId         string                 `protobuf:"bytes,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	InactivityDays int32                  `protobuf:"varint,2,opt,name=inactivity_days,json=inactivityDays,proto3" json:"inactivity_days,omitempty"` // 0 - не отключать устройства
+	UseDefault     bool                   `protobuf:"varint,3,opt,name=use_default,json=useDefault,proto3" json:"use_default,omitempty"`             // вернуть порог по умолчанию, inactivity_days игнорируется
+	unknownFields  protoimpl.UnknownFields
+	sizeCache      protoimpl.SizeCache
+}
+
+func (x *UpdateInactivityPolicyRequest) Reset() {
+	*x = UpdateInactivityPolicyRequest{}
+	mi := &file_pkg_proto_device_device_proto_msgTypes[33]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *UpdateInactivityPolicyRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UpdateInactivityPolicyRequest) ProtoMessage() {}
+
+func (x *UpdateInactivityPolicyRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_pkg_proto_device_device_proto_msgTypes[33]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UpdateInactivityPolicyRequest.ProtoReflect.Descriptor instead.
+func (*UpdateInactivityPolicyRequest) Descriptor() ([]byte, []int) {
+	return file_pkg_proto_device_device_proto_rawDescGZIP(), []int{33}
+}
+
+func (x *UpdateInactivityPolicyRequest) GetUserId() string {
+	if x != nil {
+		return x.UserId
+	}
+	return ""
+}
+
+func (x *UpdateInactivityPolicyRequest) GetInactivityDays() int32 {
+	if x != nil {
+		return x.InactivityDays
+	}
+	return 0
+}
+
+func (x *UpdateInactivityPolicyRequest) GetUseDefault() bool {
+	if x != nil {
+		return x.UseDefault
+	}
+	return false
+}
+
+type UpdateInactivityPolicyResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Policy        *InactivityPolicy      `protobuf:"bytes,1,opt,name=policy,proto3" json:"policy,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *UpdateInactivityPolicyResponse) Reset() {
+	*x = UpdateInactivityPolicyResponse{}
+	mi := &file_pkg_proto_device_device_proto_msgTypes[34]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *UpdateInactivityPolicyResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UpdateInactivityPolicyResponse) ProtoMessage() {}
+
+func (x *UpdateInactivityPolicyResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_pkg_proto_device_device_proto_msgTypes[34]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UpdateInactivityPolicyResponse.ProtoReflect.Descriptor instead.
+func (*UpdateInactivityPolicyResponse) Descriptor() ([]byte, []int) {
+	return file_pkg_proto_device_device_proto_rawDescGZIP(), []int{34}
+}
+
+func (x *UpdateInactivityPolicyResponse) GetPolicy() *InactivityPolicy {
+	if x != nil {
+		return x.Policy
+	}
+	return nil
+}
+
+// IssueSignalingTicketRequest - устройство подтверждает себя токеном устройства
+type IssueSignalingTicketRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	DeviceId      string                 `protobuf:"bytes,1,opt,name=device_id,json=deviceId,proto3" json:"device_id,omitempty"`
+	DeviceToken   string                 `protobuf:"bytes,2,opt,name=device_token,json=deviceToken,proto3" json:"device_token,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *IssueSignalingTicketRequest) Reset() {
+	*x = IssueSignalingTicketRequest{}
+	mi := &file_pkg_proto_device_device_proto_msgTypes[35]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *IssueSignalingTicketRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*IssueSignalingTicketRequest) ProtoMessage() {}
+
+func (x *IssueSignalingTicketRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_pkg_proto_device_device_proto_msgTypes[35]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use IssueSignalingTicketRequest.ProtoReflect.Descriptor instead.
+func (*IssueSignalingTicketRequest) Descriptor() ([]byte, []int) {
+	return file_pkg_proto_device_device_proto_rawDescGZIP(), []int{35}
+}
+
+func (x *IssueSignalingTicketRequest) GetDeviceId() string {
+	if x != nil {
+		return x.DeviceId
+	}
+	return ""
+}
+
+func (x *IssueSignalingTicketRequest) GetDeviceToken() string {
+	if x != nil {
+		return x.DeviceToken
+	}
+	return ""
+}
+
+type IssueSignalingTicketResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Ticket        string                 `protobuf:"bytes,1,opt,name=ticket,proto3" json:"ticket,omitempty"` // передается signaling серверу вместо device_token
+	ExpiresAt     string                 `protobuf:"bytes,2,opt,name=expires_at,json=expiresAt,proto3" json:"expires_at,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *IssueSignalingTicketResponse) Reset() {
+	*x = IssueSignalingTicketResponse{}
+	mi := &file_pkg_proto_device_device_proto_msgTypes[36]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *IssueSignalingTicketResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*IssueSignalingTicketResponse) ProtoMessage() {}
+
+func (x *IssueSignalingTicketResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_pkg_proto_device_device_proto_msgTypes[36]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use IssueSignalingTicketResponse.ProtoReflect.Descriptor instead.
+func (*IssueSignalingTicketResponse) Descriptor() ([]byte, []int) {
+	return file_pkg_proto_device_device_proto_rawDescGZIP(), []int{36}
+}
+
+func (x *IssueSignalingTicketResponse) GetTicket() string {
+	if x != nil {
+		return x.Ticket
+	}
+	return ""
+}
+
+func (x *IssueSignalingTicketResponse) GetExpiresAt() string {
+	if x != nil {
+		return x.ExpiresAt
+	}
+	return ""
+}
+
+var File_pkg_proto_device_device_proto protoreflect.FileDescriptor
+
+const file_pkg_proto_device_device_proto_rawDesc = "" +
+	"\n" +
+	"\x1dpkg/proto/device/device.proto\x12\x06device\"\xb6\x01\n" +
+	"\x15RegisterDeviceRequest\x12\x17\n" +
+	"\auser_id\x18\x01 \x01(\tR\x06userId\x12\x12\n" +
+	"\x04name\x18\x02 \x01(\tR\x04name\x12\x1f\n" +
+	"\vdevice_type\x18\x03 \x01(\tR\n" +
+	"deviceType\x12'\n" +
+	"\x0forganization_id\x18\x04 \x01(\tR\x0eorganizationId\x12&\n" +
+	"\x04info\x18\x05 \x01(\v2\x12.device.DeviceInfoR\x04info\"c\n" +
+	"\x16RegisterDeviceResponse\x12&\n" +
+	"\x06device\x18\x01 \x01(\v2\x0e.device.DeviceR\x06device\x12!\n" +
+	"\fdevice_token\x18\x02 \x01(\tR\vdeviceToken\"H\n" +
+	"\x10GetDeviceRequest\x12\x1b\n" +
+	"\tdevice_id\x18\x01 \x01(\tR\bdeviceId\x12\x17\n" +
+	"\auser_id\x18\x02 \x01(\tR\x06userId\";\n" +
+	"\x11GetDeviceResponse\x12&\n" +
+	"\x06device\x18\x01 \x01(\v2\x0e.device.DeviceR\x06device\"\x87\x01\n" +
+	"\x12ListDevicesRequest\x12\x17\n" +
+	"\auser_id\x18\x01 \x01(\tR\x06userId\x12'\n" +
+	"\x0forganization_id\x18\x02 \x01(\tR\x0eorganizationId\x12/\n" +
+	"\x13include_deactivated\x18\x03 \x01(\bR\x12includeDeactivated\"?\n" +
+	"\x13ListDevicesResponse\x12(\n" +
+	"\adevices\x18\x01 \x03(\v2\x0e.device.DeviceR\adevices\"\x80\x01\n" +
+	"\x13UpdateDeviceRequest\x12\x1b\n" +
+	"\tdevice_id\x18\x01 \x01(\tR\bdeviceId\x12\x17\n" +
+	"\auser_id\x18\x02 \x01(\tR\x06userId\x12\x12\n" +
+	"\x04name\x18\x03 \x01(\tR\x04name\x12\x1f\n" +
+	"\vdevice_type\x18\x04 \x01(\tR\n" +
+	"deviceType\">\n" +
+	"\x14UpdateDeviceResponse\x12&\n" +
+	"\x06device\x18\x01 \x01(\v2\x0e.device.DeviceR\x06device\"K\n" +
+	"\x13DeleteDeviceRequest\x12\x1b\n" +
+	"\tdevice_id\x18\x01 \x01(\tR\bdeviceId\x12\x17\n" +
+	"\auser_id\x18\x02 \x01(\tR\x06userId\"0\n" +
+	"\x14DeleteDeviceResponse\x12\x18\n" +
+	"\asuccess\x18\x01 \x01(\bR\asuccess\"4\n" +
+	"\x15UpdateLastSeenRequest\x12\x1b\n" +
+	"\tdevice_id\x18\x01 \x01(\tR\bdeviceId\"2\n" +
+	"\x16UpdateLastSeenResponse\x12\x18\n" +
+	"\asuccess\x18\x01 \x01(\bR\asuccess\"}\n" +
+	"\x1cSetDeviceOrganizationRequest\x12\x1b\n" +
+	"\tdevice_id\x18\x01 \x01(\tR\bdeviceId\x12\x17\n" +
+	"\auser_id\x18\x02 \x01(\tR\x06userId\x12'\n" +
+	"\x0forganization_id\x18\x03 \x01(\tR\x0eorganizationId\"G\n" +
+	"\x1dSetDeviceOrganizationResponse\x12&\n" +
+	"\x06device\x18\x01 \x01(\v2\x0e.device.DeviceR\x06device\"P\n" +
+	"\x18CreatePairingCodeRequest\x12\x17\n" +
+	"\auser_id\x18\x01 \x01(\tR\x06userId\x12\x1b\n" +
+	"\tdevice_id\x18\x02 \x01(\tR\bdeviceId\"m\n" +
+	"\x19CreatePairingCodeResponse\x12\x12\n" +
+	"\x04code\x18\x01 \x01(\tR\x04code\x12\x1d\n" +
+	"\n" +
+	"qr_payload\x18\x02 \x01(\tR\tqrPayload\x12\x1d\n" +
+	"\n" +
+	"expires_at\x18\x03 \x01(\tR\texpiresAt\"\xa8\x01\n" +
+	"\x18RedeemPairingCodeRequest\x12\x12\n" +
+	"\x04code\x18\x01 \x01(\tR\x04code\x12\x12\n" +
+	"\x04name\x18\x02 \x01(\tR\x04name\x12\x1f\n" +
+	"\vdevice_type\x18\x03 \x01(\tR\n" +
+	"deviceType\x12\x1b\n" +
+	"\tclient_ip\x18\x04 \x01(\tR\bclientIp\x12&\n" +
+	"\x04info\x18\x05 \x01(\v2\x12.device.DeviceInfoR\x04info\"f\n" +
+	"\x19RedeemPairingCodeResponse\x12&\n" +
+	"\x06device\x18\x01 \x01(\v2\x0e.device.DeviceR\x06device\x12!\n" +
+	"\fdevice_token\x18\x02 \x01(\tR\vdeviceToken\"P\n" +
+	"\x18RotateDeviceTokenRequest\x12\x1b\n" +
+	"\tdevice_id\x18\x01 \x01(\tR\bdeviceId\x12\x17\n" +
+	"\auser_id\x18\x02 \x01(\tR\x06userId\"y\n" +
+	"\x19RotateDeviceTokenResponse\x12!\n" +
+	"\fdevice_token\x18\x01 \x01(\tR\vdeviceToken\x129\n" +
+	"\x19previous_token_expires_at\x18\x02 \x01(\tR\x16previousTokenExpiresAt\"\x95\x04\n" +
+	"\x06Device\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x12\x17\n" +
+	"\auser_id\x18\x02 \x01(\tR\x06userId\x12\x12\n" +
+	"\x04name\x18\x03 \x01(\tR\x04name\x12\x1f\n" +
+	"\vdevice_type\x18\x04 \x01(\tR\n" +
+	"deviceType\x12 \n" +
+	"\flast_seen_at\x18\x06 \x01(\tR\n" +
+	"lastSeenAt\x12\x1d\n" +
+	"\n" +
+	"created_at\x18\a \x01(\tR\tcreatedAt\x12\x1d\n" +
+	"\n" +
+	"updated_at\x18\b \x01(\tR\tupdatedAt\x12'\n" +
+	"\x0forganization_id\x18\t \x01(\tR\x0eorganizationId\x12'\n" +
+	"\x0fpresence_status\x18\n" +
+	" \x01(\tR\x0epresenceStatus\x12'\n" +
+	"\x0fconnected_since\x18\v \x01(\tR\x0econnectedSince\x12&\n" +
+	"\x04info\x18\f \x01(\v2\x12.device.DeviceInfoR\x04info\x12'\n" +
+	"\x0fapproval_status\x18\r \x01(\tR\x0eapprovalStatus\x12#\n" +
+	"\rpush_provider\x18\x0e \x01(\tR\fpushProvider\x12!\n" +
+	"\fprune_exempt\x18\x0f \x01(\bR\vpruneExempt\x12%\n" +
+	"\x0edeactivated_at\x18\x10 \x01(\tR\rdeactivatedAtJ\x04\b\x05\x10\x06R\fdevice_token\"\xbb\x01\n" +
+	"\n" +
+	"DeviceInfo\x12\x1a\n" +
+	"\bplatform\x18\x01 \x01(\tR\bplatform\x12\x1d\n" +
+	"\n" +
+	"os_version\x18\x02 \x01(\tR\tosVersion\x12\x1f\n" +
+	"\vapp_version\x18\x03 \x01(\tR\n" +
+	"appVersion\x12-\n" +
+	"\x12transfer_protocols\x18\x04 \x03(\tR\x11transferProtocols\x12\"\n" +
+	"\rmax_file_size\x18\x05 \x01(\x03R\vmaxFileSize\"\x80\x01\n" +
+	"\x14ApproveDeviceRequest\x12\x1b\n" +
+	"\tdevice_id\x18\x01 \x01(\tR\bdeviceId\x12\x17\n" +
+	"\auser_id\x18\x02 \x01(\tR\x06userId\x122\n" +
+	"\x15approver_device_token\x18\x03 \x01(\tR\x13approverDeviceToken\"?\n" +
+	"\x15ApproveDeviceResponse\x12&\n" +
+	"\x06device\x18\x01 \x01(\v2\x0e.device.DeviceR\x06device\"\x7f\n" +
+	"\x13RejectDeviceRequest\x12\x1b\n" +
+	"\tdevice_id\x18\x01 \x01(\tR\bdeviceId\x12\x17\n" +
+	"\auser_id\x18\x02 \x01(\tR\x06userId\x122\n" +
+	"\x15approver_device_token\x18\x03 \x01(\tR\x13approverDeviceToken\">\n" +
+	"\x14RejectDeviceResponse\x12&\n" +
+	"\x06device\x18\x01 \x01(\v2\x0e.device.DeviceR\x06device\"}\n" +
+	"\x13SetPushTokenRequest\x12\x1b\n" +
+	"\tdevice_id\x18\x01 \x01(\tR\bdeviceId\x12\x17\n" +
+	"\auser_id\x18\x02 \x01(\tR\x06userId\x12\x1a\n" +
+	"\bprovider\x18\x03 \x01(\tR\bprovider\x12\x14\n" +
+	"\x05token\x18\x04 \x01(\tR\x05token\">\n" +
+	"\x14SetPushTokenResponse\x12&\n" +
+	"\x06device\x18\x01 \x01(\v2\x0e.device.DeviceR\x06device\"k\n" +
+	"\x1bSetDevicePruneExemptRequest\x12\x1b\n" +
+	"\tdevice_id\x18\x01 \x01(\tR\bdeviceId\x12\x17\n" +
+	"\auser_id\x18\x02 \x01(\tR\x06userId\x12\x16\n" +
+	"\x06exempt\x18\x03 \x01(\bR\x06exempt\"F\n" +
+	"\x1cSetDevicePruneExemptResponse\x12&\n" +
+	"\x06device\x18\x01 \x01(\v2\x0e.device.DeviceR\x06device\"\x90\x01\n" +
+	"\x10InactivityPolicy\x12'\n" +
+	"\x0finactivity_days\x18\x01 \x01(\x05R\x0einactivityDays\x12\x1d\n" +
+	"\n" +
+	"is_default\x18\x02 \x01(\bR\tisDefault\x124\n" +
+	"\x16warning_period_seconds\x18\x03 \x01(\x03R\x14warningPeriodSeconds\"5\n" +
+	"\x1aGetInactivityPolicyRequest\x12\x17\n" +
+	"\auser_id\x18\x01 \x01(\tR\x06userId\"O\n" +
+	"\x1bGetInactivityPolicyResponse\x120\n" +
+	"\x06policy\x18\x01 \x01(\v2\x18.device.InactivityPolicyR\x06policy\"\x82\x01\n" +
+	"\x1dUpdateInactivityPolicyRequest\x12\x17\n" +
+	"\auser_id\x18\x01 \x01(\tR\x06userId\x12'\n" +
+	"\x0finactivity_days\x18\x02 \x01(\x05R\x0einactivityDays\x12\x1f\n" +
+	"\vuse_default\x18\x03 \x01(\bR\n" +
+	"useDefault\"R\n" +
+	"\x1eUpdateInactivityPolicyResponse\x120\n" +
+	"\x06policy\x18\x01 \x01(\v2\x18.device.InactivityPolicyR\x06policy\"]\n" +
+	"\x1bIssueSignalingTicketRequest\x12\x1b\n" +
+	"\tdevice_id\x18\x01 \x01(\tR\bdeviceId\x12!\n" +
+	"\fdevice_token\x18\x02 \x01(\tR\vdeviceToken\"U\n" +
+	"\x1cIssueSignalingTicketResponse\x12\x16\n" +
+	"\x06ticket\x18\x01 \x01(\tR\x06ticket\x12\x1d\n" +
 	"\n" +
-	"updated_at\x18\b \x01(\tR\tupdatedAt2\xd1\x03\n" +
+	"expires_at\x18\x02 \x01(\tR\texpiresAt2\xb8\v\n" +
 	"\rDeviceService\x12O\n" +
 	"\x0eRegisterDevice\x12\x1d.device.RegisterDeviceRequest\x1a\x1e.device.RegisterDeviceResponse\x12@\n" +
 	"\tGetDevice\x12\x18.device.GetDeviceRequest\x1a\x19.device.GetDeviceResponse\x12F\n" +
 	"\vListDevices\x12\x1a.device.ListDevicesRequest\x1a\x1b.device.ListDevicesResponse\x12I\n" +
 	"\fUpdateDevice\x12\x1b.device.UpdateDeviceRequest\x1a\x1c.device.UpdateDeviceResponse\x12I\n" +
 	"\fDeleteDevice\x12\x1b.device.DeleteDeviceRequest\x1a\x1c.device.DeleteDeviceResponse\x12O\n" +
-	"\x0eUpdateLastSeen\x12\x1d.device.UpdateLastSeenRequest\x1a\x1e.device.UpdateLastSeenResponseB.Z,github.com/backend-app/backend/pkg/proto/deviceb\x06proto3"
+	"\x0eUpdateLastSeen\x12\x1d.device.UpdateLastSeenRequest\x1a\x1e.device.UpdateLastSeenResponse\x12d\n" +
+	"\x15SetDeviceOrganization\x12$.device.SetDeviceOrganizationRequest\x1a%.device.SetDeviceOrganizationResponse\x12X\n" +
+	"\x11CreatePairingCode\x12 .device.CreatePairingCodeRequest\x1a!.device.CreatePairingCodeResponse\x12X\n" +
+	"\x11RedeemPairingCode\x12 .device.RedeemPairingCodeRequest\x1a!.device.RedeemPairingCodeResponse\x12X\n" +
+	"\x11RotateDeviceToken\x12 .device.RotateDeviceTokenRequest\x1a!.device.RotateDeviceTokenResponse\x12L\n" +
+	"\rApproveDevice\x12\x1c.device.ApproveDeviceRequest\x1a\x1d.device.ApproveDeviceResponse\x12I\n" +
+	"\fRejectDevice\x12\x1b.device.RejectDeviceRequest\x1a\x1c.device.RejectDeviceResponse\x12I\n" +
+	"\fSetPushToken\x12\x1b.device.SetPushTokenRequest\x1a\x1c.device.SetPushTokenResponse\x12a\n" +
+	"\x14SetDevicePruneExempt\x12#.device.SetDevicePruneExemptRequest\x1a$.device.SetDevicePruneExemptResponse\x12^\n" +
+	"\x13GetInactivityPolicy\x12\".device.GetInactivityPolicyRequest\x1a#.device.GetInactivityPolicyResponse\x12g\n" +
+	"\x16UpdateInactivityPolicy\x12%.device.UpdateInactivityPolicyRequest\x1a&.device.UpdateInactivityPolicyResponse\x12a\n" +
+	"\x14IssueSignalingTicket\x12#.device.IssueSignalingTicketRequest\x1a$.device.IssueSignalingTicketResponseB1Z/github.com/backend-app/backend/pkg/proto/deviceb\x06proto3"
 
 var (
 	file_pkg_proto_device_device_proto_rawDescOnce sync.Once
@@ -785,44 +2294,101 @@ func file_pkg_proto_device_device_proto_rawDescGZIP() []byte {
 	return file_pkg_proto_device_device_proto_rawDescData
 }
 
-var file_pkg_proto_device_device_proto_msgTypes = make([]protoimpl.MessageInfo, 13)
+var file_pkg_proto_device_device_proto_msgTypes = make([]protoimpl.MessageInfo, 37)
 var file_pkg_proto_device_device_proto_goTypes = []any{
-	(*RegisterDeviceRequest)(nil),  // 0: device.RegisterDeviceRequest
-	(*RegisterDeviceResponse)(nil), // 1: device.RegisterDeviceResponse
-	(*GetDeviceRequest)(nil),       // 2: device.GetDeviceRequest
-	(*GetDeviceResponse)(nil),      // 3: device.GetDeviceResponse
-	(*ListDevicesRequest)(nil),     // 4: device.ListDevicesRequest
-	(*ListDevicesResponse)(nil),    // 5: device.ListDevicesResponse
-	(*UpdateDeviceRequest)(nil),    // 6: device.UpdateDeviceRequest
-	(*UpdateDeviceResponse)(nil),   // 7: device.UpdateDeviceResponse
-	(*DeleteDeviceRequest)(nil),    // 8: device.DeleteDeviceRequest
-	(*DeleteDeviceResponse)(nil),   // 9: device.DeleteDeviceResponse
-	(*UpdateLastSeenRequest)(nil),  // 10: device.UpdateLastSeenRequest
-	(*UpdateLastSeenResponse)(nil), // 11: device.UpdateLastSeenResponse
-	(*Device)(nil),                 // 12: device.Device
+	(*RegisterDeviceRequest)(nil),          // 0: device.RegisterDeviceRequest
+	(*RegisterDeviceResponse)(nil),         // 1: device.RegisterDeviceResponse
+	(*GetDeviceRequest)(nil),               // 2: device.GetDeviceRequest
+	(*GetDeviceResponse)(nil),              // 3: device.GetDeviceResponse
+	(*ListDevicesRequest)(nil),             // 4: device.ListDevicesRequest
+	(*ListDevicesResponse)(nil),            // 5: device.ListDevicesResponse
+	(*UpdateDeviceRequest)(nil),            // 6: device.UpdateDeviceRequest
+	(*UpdateDeviceResponse)(nil),           // 7: device.UpdateDeviceResponse
+	(*DeleteDeviceRequest)(nil),            // 8: device.DeleteDeviceRequest
+	(*DeleteDeviceResponse)(nil),           // 9: device.DeleteDeviceResponse
+	(*UpdateLastSeenRequest)(nil),          // 10: device.UpdateLastSeenRequest
+	(*UpdateLastSeenResponse)(nil),         // 11: device.UpdateLastSeenResponse
+	(*SetDeviceOrganizationRequest)(nil),   // 12: device.SetDeviceOrganizationRequest
+	(*SetDeviceOrganizationResponse)(nil),  // 13: device.SetDeviceOrganizationResponse
+	(*CreatePairingCodeRequest)(nil),       // 14: device.CreatePairingCodeRequest
+	(*CreatePairingCodeResponse)(nil),      // 15: device.CreatePairingCodeResponse
+	(*RedeemPairingCodeRequest)(nil),       // 16: device.RedeemPairingCodeRequest
+	(*RedeemPairingCodeResponse)(nil),      // 17: device.RedeemPairingCodeResponse
+	(*RotateDeviceTokenRequest)(nil),       // 18: device.RotateDeviceTokenRequest
+	(*RotateDeviceTokenResponse)(nil),      // 19: device.RotateDeviceTokenResponse
+	(*Device)(nil),                         // 20: device.Device
+	(*DeviceInfo)(nil),                     // 21: device.DeviceInfo
+	(*ApproveDeviceRequest)(nil),           // 22: device.ApproveDeviceRequest
+	(*ApproveDeviceResponse)(nil),          // 23: device.ApproveDeviceResponse
+	(*RejectDeviceRequest)(nil),            // 24: device.RejectDeviceRequest
+	(*RejectDeviceResponse)(nil),           // 25: device.RejectDeviceResponse
+	(*SetPushTokenRequest)(nil),            // 26: device.SetPushTokenRequest
+	(*SetPushTokenResponse)(nil),           // 27: device.SetPushTokenResponse
+	(*SetDevicePruneExemptRequest)(nil),    // 28: device.SetDevicePruneExemptRequest
+	(*SetDevicePruneExemptResponse)(nil),   // 29: device.SetDevicePruneExemptResponse
+	(*InactivityPolicy)(nil),               // 30: device.InactivityPolicy
+	(*GetInactivityPolicyRequest)(nil),     // 31: device.GetInactivityPolicyRequest
+	(*GetInactivityPolicyResponse)(nil),    // 32: device.GetInactivityPolicyResponse
+	(*UpdateInactivityPolicyRequest)(nil),  // 33: device.UpdateInactivityPolicyRequest
+	(*UpdateInactivityPolicyResponse)(nil), // 34: device.UpdateInactivityPolicyResponse
+	(*IssueSignalingTicketRequest)(nil),    // 35: device.IssueSignalingTicketRequest
+	(*IssueSignalingTicketResponse)(nil),   // 36: device.IssueSignalingTicketResponse
 }
 var file_pkg_proto_device_device_proto_depIdxs = []int32{
-	12, // 0: device.RegisterDeviceResponse.device:type_name -> device.Device
-	12, // 1: device.GetDeviceResponse.device:type_name -> device.Device
-	12, // 2: device.ListDevicesResponse.devices:type_name -> device.Device
-	12, // 3: device.UpdateDeviceResponse.device:type_name -> device.Device
-	0,  // 4: device.DeviceService.RegisterDevice:input_type -> device.RegisterDeviceRequest
-	2,  // 5: device.DeviceService.GetDevice:input_type -> device.GetDeviceRequest
-	4,  // 6: device.DeviceService.ListDevices:input_type -> device.ListDevicesRequest
-	6,  // 7: device.DeviceService.UpdateDevice:input_type -> device.UpdateDeviceRequest
-	8,  // 8: device.DeviceService.DeleteDevice:input_type -> device.DeleteDeviceRequest
-	10, // 9: device.DeviceService.UpdateLastSeen:input_type -> device.UpdateLastSeenRequest
-	1,  // 10: device.DeviceService.RegisterDevice:output_type -> device.RegisterDeviceResponse
-	3,  // 11: device.DeviceService.GetDevice:output_type -> device.GetDeviceResponse
-	5,  // 12: device.DeviceService.ListDevices:output_type -> device.ListDevicesResponse
-	7,  // 13: device.DeviceService.UpdateDevice:output_type -> device.UpdateDeviceResponse
-	9,  // 14: device.DeviceService.DeleteDevice:output_type -> device.DeleteDeviceResponse
-	11, // 15: device.DeviceService.UpdateLastSeen:output_type -> device.UpdateLastSeenResponse
-	10, // [10:16] is the sub-list for method output_type
-	4,  // [4:10] is the sub-list for method input_type
-	4,  // [4:4] is the sub-list for extension type_name
-	4,  // [4:4] is the sub-list for extension extendee
-	0,  // [0:4] is the sub-list for field type_name
+	21, // 0: device.RegisterDeviceRequest.info:type_name -> device.DeviceInfo
+	20, // 1: device.RegisterDeviceResponse.device:type_name -> device.Device
+	20, // 2: device.GetDeviceResponse.device:type_name -> device.Device
+	20, // 3: device.ListDevicesResponse.devices:type_name -> device.Device
+	20, // 4: device.UpdateDeviceResponse.device:type_name -> device.Device
+	20, // 5: device.SetDeviceOrganizationResponse.device:type_name -> device.Device
+	21, // 6: device.RedeemPairingCodeRequest.info:type_name -> device.DeviceInfo
+	20, // 7: device.RedeemPairingCodeResponse.device:type_name -> device.Device
+	21, // 8: device.Device.info:type_name -> device.DeviceInfo
+	20, // 9: device.ApproveDeviceResponse.device:type_name -> device.Device
+	20, // 10: device.RejectDeviceResponse.device:type_name -> device.Device
+	20, // 11: device.SetPushTokenResponse.device:type_name -> device.Device
+	20, // 12: device.SetDevicePruneExemptResponse.device:type_name -> device.Device
+	30, // 13: device.GetInactivityPolicyResponse.policy:type_name -> device.InactivityPolicy
+	30, // 14: device.UpdateInactivityPolicyResponse.policy:type_name -> device.InactivityPolicy
+	0,  // 15: device.DeviceService.RegisterDevice:input_type -> device.RegisterDeviceRequest
+	2,  // 16: device.DeviceService.GetDevice:input_type -> device.GetDeviceRequest
+	4,  // 17: device.DeviceService.ListDevices:input_type -> device.ListDevicesRequest
+	6,  // 18: device.DeviceService.UpdateDevice:input_type -> device.UpdateDeviceRequest
+	8,  // 19: device.DeviceService.DeleteDevice:input_type -> device.DeleteDeviceRequest
+	10, // 20: device.DeviceService.UpdateLastSeen:input_type -> device.UpdateLastSeenRequest
+	12, // 21: device.DeviceService.SetDeviceOrganization:input_type -> device.SetDeviceOrganizationRequest
+	14, // 22: device.DeviceService.CreatePairingCode:input_type -> device.CreatePairingCodeRequest
+	16, // 23: device.DeviceService.RedeemPairingCode:input_type -> device.RedeemPairingCodeRequest
+	18, // 24: device.DeviceService.RotateDeviceToken:input_type -> device.RotateDeviceTokenRequest
+	22, // 25: device.DeviceService.ApproveDevice:input_type -> device.ApproveDeviceRequest
+	24, // 26: device.DeviceService.RejectDevice:input_type -> device.RejectDeviceRequest
+	26, // 27: device.DeviceService.SetPushToken:input_type -> device.SetPushTokenRequest
+	28, // 28: device.DeviceService.SetDevicePruneExempt:input_type -> device.SetDevicePruneExemptRequest
+	31, // 29: device.DeviceService.GetInactivityPolicy:input_type -> device.GetInactivityPolicyRequest
+	33, // 30: device.DeviceService.UpdateInactivityPolicy:input_type -> device.UpdateInactivityPolicyRequest
+	35, // 31: device.DeviceService.IssueSignalingTicket:input_type -> device.IssueSignalingTicketRequest
+	1,  // 32: device.DeviceService.RegisterDevice:output_type -> device.RegisterDeviceResponse
+	3,  // 33: device.DeviceService.GetDevice:output_type -> device.GetDeviceResponse
+	5,  // 34: device.DeviceService.ListDevices:output_type -> device.ListDevicesResponse
+	7,  // 35: device.DeviceService.UpdateDevice:output_type -> device.UpdateDeviceResponse
+	9,  // 36: device.DeviceService.DeleteDevice:output_type -> device.DeleteDeviceResponse
+	11, // 37: device.DeviceService.UpdateLastSeen:output_type -> device.UpdateLastSeenResponse
+	13, // 38: device.DeviceService.SetDeviceOrganization:output_type -> device.SetDeviceOrganizationResponse
+	15, // 39: device.DeviceService.CreatePairingCode:output_type -> device.CreatePairingCodeResponse
+	17, // 40: device.DeviceService.RedeemPairingCode:output_type -> device.RedeemPairingCodeResponse
+	19, // 41: device.DeviceService.RotateDeviceToken:output_type -> device.RotateDeviceTokenResponse
+	23, // 42: device.DeviceService.ApproveDevice:output_type -> device.ApproveDeviceResponse
+	25, // 43: device.DeviceService.RejectDevice:output_type -> device.RejectDeviceResponse
+	27, // 44: device.DeviceService.SetPushToken:output_type -> device.SetPushTokenResponse
+	29, // 45: device.DeviceService.SetDevicePruneExempt:output_type -> device.SetDevicePruneExemptResponse
+	32, // 46: device.DeviceService.GetInactivityPolicy:output_type -> device.GetInactivityPolicyResponse
+	34, // 47: device.DeviceService.UpdateInactivityPolicy:output_type -> device.UpdateInactivityPolicyResponse
+	36, // 48: device.DeviceService.IssueSignalingTicket:output_type -> device.IssueSignalingTicketResponse
+	32, // [32:49] is the sub-list for method output_type
+	15, // [15:32] is the sub-list for method input_type
+	15, // [15:15] is the sub-list for extension type_name
+	15, // [15:15] is the sub-list for extension extendee
+	0,  // [0:15] is the sub-list for field type_name
 }
 
 func init() { file_pkg_proto_device_device_proto_init() }
@@ -836,7 +2402,7 @@ func file_pkg_proto_device_device_proto_init() {
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: unsafe.Slice(unsafe.StringData(file_pkg_proto_device_device_proto_rawDesc), len(file_pkg_proto_device_device_proto_rawDesc)),
 			NumEnums:      0,
-			NumMessages:   13,
+			NumMessages:   37,
 			NumExtensions: 0,
 			NumServices:   1,
 		},
@@ -19,12 +19,23 @@ import (
 const _ = grpc.SupportPackageIsVersion9
 
 const (
-	DeviceService_RegisterDevice_FullMethodName = "/device.DeviceService/RegisterDevice"
-	DeviceService_GetDevice_FullMethodName      = "/device.DeviceService/GetDevice"
-	DeviceService_ListDevices_FullMethodName    = "/device.DeviceService/ListDevices"
-	DeviceService_UpdateDevice_FullMethodName   = "/device.DeviceService/UpdateDevice"
-	DeviceService_DeleteDevice_FullMethodName   = "/device.DeviceService/DeleteDevice"
-	DeviceService_UpdateLastSeen_FullMethodName = "/device.DeviceService/UpdateLastSeen"
+	DeviceService_RegisterDevice_FullMethodName         = "/device.DeviceService/RegisterDevice"
+	DeviceService_GetDevice_FullMethodName              = "/device.DeviceService/GetDevice"
+	DeviceService_ListDevices_FullMethodName            = "/device.DeviceService/ListDevices"
+	DeviceService_UpdateDevice_FullMethodName           = "/device.DeviceService/UpdateDevice"
+	DeviceService_DeleteDevice_FullMethodName           = "/device.DeviceService/DeleteDevice"
+	DeviceService_UpdateLastSeen_FullMethodName         = "/device.DeviceService/UpdateLastSeen"
+	DeviceService_SetDeviceOrganization_FullMethodName  = "/device.DeviceService/SetDeviceOrganization"
+	DeviceService_CreatePairingCode_FullMethodName      = "/device.DeviceService/CreatePairingCode"
+	DeviceService_RedeemPairingCode_FullMethodName      = "/device.DeviceService/RedeemPairingCode"
+	DeviceService_RotateDeviceToken_FullMethodName      = "/device.DeviceService/RotateDeviceToken"
+	DeviceService_ApproveDevice_FullMethodName          = "/device.DeviceService/ApproveDevice"
+	DeviceService_RejectDevice_FullMethodName           = "/device.DeviceService/RejectDevice"
+	DeviceService_SetPushToken_FullMethodName           = "/device.DeviceService/SetPushToken"
+	DeviceService_SetDevicePruneExempt_FullMethodName   = "/device.DeviceService/SetDevicePruneExempt"
+	DeviceService_GetInactivityPolicy_FullMethodName    = "/device.DeviceService/GetInactivityPolicy"
+	DeviceService_UpdateInactivityPolicy_FullMethodName = "/device.DeviceService/UpdateInactivityPolicy"
+	DeviceService_IssueSignalingTicket_FullMethodName   = "/device.DeviceService/IssueSignalingTicket"
 )
 
 // DeviceServiceClient is the client API for DeviceService service.
@@ -37,6 +48,17 @@ type DeviceServiceClient interface {
 	UpdateDevice(ctx context.Context, in *UpdateDeviceRequest, opts ...grpc.CallOption) (*UpdateDeviceResponse, error)
 	DeleteDevice(ctx context.Context, in *DeleteDeviceRequest, opts ...grpc.CallOption) (*DeleteDeviceResponse, error)
 	UpdateLastSeen(ctx context.Context, in *UpdateLastSeenRequest, opts ...grpc.CallOption) (*UpdateLastSeenResponse, error)
+	SetDeviceOrganization(ctx context.Context, in *SetDeviceOrganizationRequest, opts ...grpc.CallOption) (*SetDeviceOrganizationResponse, error)
+	CreatePairingCode(ctx context.Context, in *CreatePairingCodeRequest, opts ...grpc.CallOption) (*CreatePairingCodeResponse, error)
+	RedeemPairingCode(ctx context.Context, in *RedeemPairingCodeRequest, opts ...grpc.CallOption) (*RedeemPairingCodeResponse, error)
+	RotateDeviceToken(ctx context.Context, in *RotateDeviceTokenRequest, opts ...grpc.CallOption) (*RotateDeviceTokenResponse, error)
+	ApproveDevice(ctx context.Context, in *ApproveDeviceRequest, opts ...grpc.CallOption) (*ApproveDeviceResponse, error)
+	RejectDevice(ctx context.Context, in *RejectDeviceRequest, opts ...grpc.CallOption) (*RejectDeviceResponse, error)
+	SetPushToken(ctx context.Context, in *SetPushTokenRequest, opts ...grpc.CallOption) (*SetPushTokenResponse, error)
+	SetDevicePruneExempt(ctx context.Context, in *SetDevicePruneExemptRequest, opts ...grpc.CallOption) (*SetDevicePruneExemptResponse, error)
+	GetInactivityPolicy(ctx context.Context, in *GetInactivityPolicyRequest, opts ...grpc.CallOption) (*GetInactivityPolicyResponse, error)
+	UpdateInactivityPolicy(ctx context.Context, in *UpdateInactivityPolicyRequest, opts ...grpc.CallOption) (*UpdateInactivityPolicyResponse, error)
+	IssueSignalingTicket(ctx context.Context, in *IssueSignalingTicketRequest, opts ...grpc.CallOption) (*IssueSignalingTicketResponse, error)
 }
 
 type deviceServiceClient struct {
@@ -107,6 +129,116 @@ func (c *deviceServiceClient) UpdateLastSeen(ctx context.Context, in *UpdateLast
 	return out, nil
 }
 
+func (c *deviceServiceClient) SetDeviceOrganization(ctx context.Context, in *SetDeviceOrganizationRequest, opts ...grpc.CallOption) (*SetDeviceOrganizationResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(SetDeviceOrganizationResponse)
+	err := c.cc.Invoke(ctx, DeviceService_SetDeviceOrganization_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *deviceServiceClient) CreatePairingCode(ctx context.Context, in *CreatePairingCodeRequest, opts ...grpc.CallOption) (*CreatePairingCodeResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(CreatePairingCodeResponse)
+	err := c.cc.Invoke(ctx, DeviceService_CreatePairingCode_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *deviceServiceClient) RedeemPairingCode(ctx context.Context, in *RedeemPairingCodeRequest, opts ...grpc.CallOption) (*RedeemPairingCodeResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(RedeemPairingCodeResponse)
+	err := c.cc.Invoke(ctx, DeviceService_RedeemPairingCode_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *deviceServiceClient) RotateDeviceToken(ctx context.Context, in *RotateDeviceTokenRequest, opts ...grpc.CallOption) (*RotateDeviceTokenResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(RotateDeviceTokenResponse)
+	err := c.cc.Invoke(ctx, DeviceService_RotateDeviceToken_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *deviceServiceClient) ApproveDevice(ctx context.Context, in *ApproveDeviceRequest, opts ...grpc.CallOption) (*ApproveDeviceResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ApproveDeviceResponse)
+	err := c.cc.Invoke(ctx, DeviceService_ApproveDevice_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *deviceServiceClient) RejectDevice(ctx context.Context, in *RejectDeviceRequest, opts ...grpc.CallOption) (*RejectDeviceResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(RejectDeviceResponse)
+	err := c.cc.Invoke(ctx, DeviceService_RejectDevice_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *deviceServiceClient) SetPushToken(ctx context.Context, in *SetPushTokenRequest, opts ...grpc.CallOption) (*SetPushTokenResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(SetPushTokenResponse)
+	err := c.cc.Invoke(ctx, DeviceService_SetPushToken_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *deviceServiceClient) SetDevicePruneExempt(ctx context.Context, in *SetDevicePruneExemptRequest, opts ...grpc.CallOption) (*SetDevicePruneExemptResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(SetDevicePruneExemptResponse)
+	err := c.cc.Invoke(ctx, DeviceService_SetDevicePruneExempt_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *deviceServiceClient) GetInactivityPolicy(ctx context.Context, in *GetInactivityPolicyRequest, opts ...grpc.CallOption) (*GetInactivityPolicyResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(GetInactivityPolicyResponse)
+	err := c.cc.Invoke(ctx, DeviceService_GetInactivityPolicy_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *deviceServiceClient) UpdateInactivityPolicy(ctx context.Context, in *UpdateInactivityPolicyRequest, opts ...grpc.CallOption) (*UpdateInactivityPolicyResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(UpdateInactivityPolicyResponse)
+	err := c.cc.Invoke(ctx, DeviceService_UpdateInactivityPolicy_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *deviceServiceClient) IssueSignalingTicket(ctx context.Context, in *IssueSignalingTicketRequest, opts ...grpc.CallOption) (*IssueSignalingTicketResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(IssueSignalingTicketResponse)
+	err := c.cc.Invoke(ctx, DeviceService_IssueSignalingTicket_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 // DeviceServiceServer is the server API for DeviceService service.
 // All implementations must embed UnimplementedDeviceServiceServer
 // for forward compatibility.
@@ -117,6 +249,17 @@ type DeviceServiceServer interface {
 	UpdateDevice(context.Context, *UpdateDeviceRequest) (*UpdateDeviceResponse, error)
 	DeleteDevice(context.Context, *DeleteDeviceRequest) (*DeleteDeviceResponse, error)
 	UpdateLastSeen(context.Context, *UpdateLastSeenRequest) (*UpdateLastSeenResponse, error)
+	SetDeviceOrganization(context.Context, *SetDeviceOrganizationRequest) (*SetDeviceOrganizationResponse, error)
+	CreatePairingCode(context.Context, *CreatePairingCodeRequest) (*CreatePairingCodeResponse, error)
+	RedeemPairingCode(context.Context, *RedeemPairingCodeRequest) (*RedeemPairingCodeResponse, error)
+	RotateDeviceToken(context.Context, *RotateDeviceTokenRequest) (*RotateDeviceTokenResponse, error)
+	ApproveDevice(context.Context, *ApproveDeviceRequest) (*ApproveDeviceResponse, error)
+	RejectDevice(context.Context, *RejectDeviceRequest) (*RejectDeviceResponse, error)
+	SetPushToken(context.Context, *SetPushTokenRequest) (*SetPushTokenResponse, error)
+	SetDevicePruneExempt(context.Context, *SetDevicePruneExemptRequest) (*SetDevicePruneExemptResponse, error)
+	GetInactivityPolicy(context.Context, *GetInactivityPolicyRequest) (*GetInactivityPolicyResponse, error)
+	UpdateInactivityPolicy(context.Context, *UpdateInactivityPolicyRequest) (*UpdateInactivityPolicyResponse, error)
+	IssueSignalingTicket(context.Context, *IssueSignalingTicketRequest) (*IssueSignalingTicketResponse, error)
 	mustEmbedUnimplementedDeviceServiceServer()
 }
 
@@ -145,6 +288,39 @@ func (UnimplementedDeviceServiceServer) DeleteDevice(context.Context, *DeleteDev
 func (UnimplementedDeviceServiceServer) UpdateLastSeen(context.Context, *UpdateLastSeenRequest) (*UpdateLastSeenResponse, error) {
 	return nil, status.Error(codes.Unimplemented, "method UpdateLastSeen not implemented")
 }
+func (UnimplementedDeviceServiceServer) SetDeviceOrganization(context.Context, *SetDeviceOrganizationRequest) (*SetDeviceOrganizationResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method SetDeviceOrganization not implemented")
+}
+func (UnimplementedDeviceServiceServer) CreatePairingCode(context.Context, *CreatePairingCodeRequest) (*CreatePairingCodeResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method CreatePairingCode not implemented")
+}
+func (UnimplementedDeviceServiceServer) RedeemPairingCode(context.Context, *RedeemPairingCodeRequest) (*RedeemPairingCodeResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method RedeemPairingCode not implemented")
+}
+func (UnimplementedDeviceServiceServer) RotateDeviceToken(context.Context, *RotateDeviceTokenRequest) (*RotateDeviceTokenResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method RotateDeviceToken not implemented")
+}
+func (UnimplementedDeviceServiceServer) ApproveDevice(context.Context, *ApproveDeviceRequest) (*ApproveDeviceResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method ApproveDevice not implemented")
+}
+func (UnimplementedDeviceServiceServer) RejectDevice(context.Context, *RejectDeviceRequest) (*RejectDeviceResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method RejectDevice not implemented")
+}
+func (UnimplementedDeviceServiceServer) SetPushToken(context.Context, *SetPushTokenRequest) (*SetPushTokenResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method SetPushToken not implemented")
+}
+func (UnimplementedDeviceServiceServer) SetDevicePruneExempt(context.Context, *SetDevicePruneExemptRequest) (*SetDevicePruneExemptResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method SetDevicePruneExempt not implemented")
+}
+func (UnimplementedDeviceServiceServer) GetInactivityPolicy(context.Context, *GetInactivityPolicyRequest) (*GetInactivityPolicyResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method GetInactivityPolicy not implemented")
+}
+func (UnimplementedDeviceServiceServer) UpdateInactivityPolicy(context.Context, *UpdateInactivityPolicyRequest) (*UpdateInactivityPolicyResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method UpdateInactivityPolicy not implemented")
+}
+func (UnimplementedDeviceServiceServer) IssueSignalingTicket(context.Context, *IssueSignalingTicketRequest) (*IssueSignalingTicketResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method IssueSignalingTicket not implemented")
+}
 func (UnimplementedDeviceServiceServer) mustEmbedUnimplementedDeviceServiceServer() {}
 func (UnimplementedDeviceServiceServer) testEmbeddedByValue()                       {}
 
@@ -274,6 +450,204 @@ func _DeviceService_UpdateLastSeen_Handler(srv interface{}, ctx context.Context,
 	return interceptor(ctx, in, info, handler)
 }
 
+func _DeviceService_SetDeviceOrganization_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SetDeviceOrganizationRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(DeviceServiceServer).SetDeviceOrganization(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: DeviceService_SetDeviceOrganization_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(DeviceServiceServer).SetDeviceOrganization(ctx, req.(*SetDeviceOrganizationRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _DeviceService_CreatePairingCode_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CreatePairingCodeRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(DeviceServiceServer).CreatePairingCode(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: DeviceService_CreatePairingCode_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(DeviceServiceServer).CreatePairingCode(ctx, req.(*CreatePairingCodeRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _DeviceService_RedeemPairingCode_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RedeemPairingCodeRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(DeviceServiceServer).RedeemPairingCode(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: DeviceService_RedeemPairingCode_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(DeviceServiceServer).RedeemPairingCode(ctx, req.(*RedeemPairingCodeRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _DeviceService_RotateDeviceToken_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RotateDeviceTokenRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(DeviceServiceServer).RotateDeviceToken(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: DeviceService_RotateDeviceToken_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(DeviceServiceServer).RotateDeviceToken(ctx, req.(*RotateDeviceTokenRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _DeviceService_ApproveDevice_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ApproveDeviceRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(DeviceServiceServer).ApproveDevice(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: DeviceService_ApproveDevice_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(DeviceServiceServer).ApproveDevice(ctx, req.(*ApproveDeviceRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _DeviceService_RejectDevice_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RejectDeviceRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(DeviceServiceServer).RejectDevice(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: DeviceService_RejectDevice_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(DeviceServiceServer).RejectDevice(ctx, req.(*RejectDeviceRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _DeviceService_SetPushToken_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SetPushTokenRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(DeviceServiceServer).SetPushToken(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: DeviceService_SetPushToken_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(DeviceServiceServer).SetPushToken(ctx, req.(*SetPushTokenRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _DeviceService_SetDevicePruneExempt_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SetDevicePruneExemptRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(DeviceServiceServer).SetDevicePruneExempt(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: DeviceService_SetDevicePruneExempt_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(DeviceServiceServer).SetDevicePruneExempt(ctx, req.(*SetDevicePruneExemptRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _DeviceService_GetInactivityPolicy_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetInactivityPolicyRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(DeviceServiceServer).GetInactivityPolicy(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: DeviceService_GetInactivityPolicy_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(DeviceServiceServer).GetInactivityPolicy(ctx, req.(*GetInactivityPolicyRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _DeviceService_UpdateInactivityPolicy_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(UpdateInactivityPolicyRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(DeviceServiceServer).UpdateInactivityPolicy(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: DeviceService_UpdateInactivityPolicy_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(DeviceServiceServer).UpdateInactivityPolicy(ctx, req.(*UpdateInactivityPolicyRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _DeviceService_IssueSignalingTicket_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(IssueSignalingTicketRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(DeviceServiceServer).IssueSignalingTicket(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: DeviceService_IssueSignalingTicket_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(DeviceServiceServer).IssueSignalingTicket(ctx, req.(*IssueSignalingTicketRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 // DeviceService_ServiceDesc is the grpc.ServiceDesc for DeviceService service.
 // It's only intended for direct use with grpc.RegisterService,
 // and not to be introspected or modified (even as a copy)
@@ -305,6 +679,50 @@ var DeviceService_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "UpdateLastSeen",
 			Handler:    _DeviceService_UpdateLastSeen_Handler,
 		},
+		{
+			MethodName: "SetDeviceOrganization",
+			Handler:    _DeviceService_SetDeviceOrganization_Handler,
+		},
+		{
+			MethodName: "CreatePairingCode",
+			Handler:    _DeviceService_CreatePairingCode_Handler,
+		},
+		{
+			MethodName: "RedeemPairingCode",
+			Handler:    _DeviceService_RedeemPairingCode_Handler,
+		},
+		{
+			MethodName: "RotateDeviceToken",
+			Handler:    _DeviceService_RotateDeviceToken_Handler,
+		},
+		{
+			MethodName: "ApproveDevice",
+			Handler:    _DeviceService_ApproveDevice_Handler,
+		},
+		{
+			MethodName: "RejectDevice",
+			Handler:    _DeviceService_RejectDevice_Handler,
+		},
+		{
+			MethodName: "SetPushToken",
+			Handler:    _DeviceService_SetPushToken_Handler,
+		},
+		{
+			MethodName: "SetDevicePruneExempt",
+			Handler:    _DeviceService_SetDevicePruneExempt_Handler,
+		},
+		{
+			MethodName: "GetInactivityPolicy",
+			Handler:    _DeviceService_GetInactivityPolicy_Handler,
+		},
+		{
+			MethodName: "UpdateInactivityPolicy",
+			Handler:    _DeviceService_UpdateInactivityPolicy_Handler,
+		},
+		{
+			MethodName: "IssueSignalingTicket",
+			Handler:    _DeviceService_IssueSignalingTicket_Handler,
+		},
 	},
 	Streams:  []grpc.StreamDesc{},
 	Metadata: "pkg/proto/device/device.proto",
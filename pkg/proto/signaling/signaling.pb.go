@@ -0,0 +1,1308 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.36.11
+// 	protoc        v3.12.4
+// source: pkg/proto/signaling/signaling.proto
+
+package signaling
+
+import (
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	structpb "google.golang.org/protobuf/types/known/structpb"
+	reflect "reflect"
+	sync "sync"
+	unsafe "unsafe"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+type ErrorCode int32
+
+const (
+	ErrorCode_ERROR_CODE_UNSPECIFIED         ErrorCode = 0
+	ErrorCode_ERROR_CODE_INVALID_MESSAGE     ErrorCode = 1 // сообщение не разобрано или в нем не хватает полей
+	ErrorCode_ERROR_CODE_UNKNOWN_TYPE        ErrorCode = 2
+	ErrorCode_ERROR_CODE_UNSUPPORTED_VERSION ErrorCode = 3
+	ErrorCode_ERROR_CODE_UNAUTHENTICATED     ErrorCode = 4
+	ErrorCode_ERROR_CODE_PERMISSION_DENIED   ErrorCode = 5
+	ErrorCode_ERROR_CODE_NOT_FOUND           ErrorCode = 6
+	ErrorCode_ERROR_CODE_FAILED_PRECONDITION ErrorCode = 7 // например, передача уже не ожидает ответа
+	ErrorCode_ERROR_CODE_INTERNAL            ErrorCode = 8
+)
+
+// Enum value maps for ErrorCode.
+var (
+	ErrorCode_name = map[int32]string{
+		0: "ERROR_CODE_UNSPECIFIED",
+		1: "ERROR_CODE_INVALID_MESSAGE",
+		2: "ERROR_CODE_UNKNOWN_TYPE",
+		3: "ERROR_CODE_UNSUPPORTED_VERSION",
+		4: "ERROR_CODE_UNAUTHENTICATED",
+		5: "ERROR_CODE_PERMISSION_DENIED",
+		6: "ERROR_CODE_NOT_FOUND",
+		7: "ERROR_CODE_FAILED_PRECONDITION",
+		8: "ERROR_CODE_INTERNAL",
+	}
+	ErrorCode_value = map[string]int32{
+		"ERROR_CODE_UNSPECIFIED":         0,
+		"ERROR_CODE_INVALID_MESSAGE":     1,
+		"ERROR_CODE_UNKNOWN_TYPE":        2,
+		"ERROR_CODE_UNSUPPORTED_VERSION": 3,
+		"ERROR_CODE_UNAUTHENTICATED":     4,
+		"ERROR_CODE_PERMISSION_DENIED":   5,
+		"ERROR_CODE_NOT_FOUND":           6,
+		"ERROR_CODE_FAILED_PRECONDITION": 7,
+		"ERROR_CODE_INTERNAL":            8,
+	}
+)
+
+func (x ErrorCode) Enum() *ErrorCode {
+	p := new(ErrorCode)
+	*p = x
+	return p
+}
+
+func (x ErrorCode) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (ErrorCode) Descriptor() protoreflect.EnumDescriptor {
+	return file_pkg_proto_signaling_signaling_proto_enumTypes[0].Descriptor()
+}
+
+func (ErrorCode) Type() protoreflect.EnumType {
+	return &file_pkg_proto_signaling_signaling_proto_enumTypes[0]
+}
+
+func (x ErrorCode) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use ErrorCode.Descriptor instead.
+func (ErrorCode) EnumDescriptor() ([]byte, []int) {
+	return file_pkg_proto_signaling_signaling_proto_rawDescGZIP(), []int{0}
+}
+
+// Envelope - сообщение signaling протокола v2 в обе стороны. В WebSocket текстовые
+// кадры содержат protojson (имена полей как в схеме), бинарные - protobuf; в gRPC
+// Envelope передается как есть.
+type Envelope struct {
+	state        protoimpl.MessageState `protogen:"open.v1"`
+	Type         string                 `protobuf:"bytes,1,opt,name=type,proto3" json:"type,omitempty"` // "hello", "welcome", "offer", "answer", "ice-candidate", "presence", ...
+	Id           string                 `protobuf:"bytes,2,opt,name=id,proto3" json:"id,omitempty"`     // идентификатор сообщения клиента; ack, delivery-failed и error приходят с ним же
+	FromDeviceId string                 `protobuf:"bytes,3,opt,name=from_device_id,json=fromDeviceId,proto3" json:"from_device_id,omitempty"`
+	ToDeviceId   string                 `protobuf:"bytes,4,opt,name=to_device_id,json=toDeviceId,proto3" json:"to_device_id,omitempty"`
+	Sdp          *SessionDescription    `protobuf:"bytes,5,opt,name=sdp,proto3" json:"sdp,omitempty"`
+	Candidate    *IceCandidate          `protobuf:"bytes,6,opt,name=candidate,proto3" json:"candidate,omitempty"`
+	Error        *Error                 `protobuf:"bytes,7,opt,name=error,proto3" json:"error,omitempty"` // только для "error"
+	// Данные сообщения; поле определяется типом
+	//
+	// Types that are valid to be assigned to Payload:
+	//
+	//	*Envelope_Hello
+	//	*Envelope_Welcome
+	//	*Envelope_Presence
+	//	*Envelope_Approval
+	//	*Envelope_IceState
+	//	*Envelope_TransferFallback
+	//	*Envelope_Transfer
+	//	*Envelope_Ack
+	//	*Envelope_DeliveryFailed
+	//	*Envelope_Event
+	Payload       isEnvelope_Payload `protobuf_oneof:"payload"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Envelope) Reset() {
+	*x = Envelope{}
+	mi := &file_pkg_proto_signaling_signaling_proto_msgTypes[0]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Envelope) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Envelope) ProtoMessage() {}
+
+func (x *Envelope) ProtoReflect() protoreflect.Message {
+	mi := &file_pkg_proto_signaling_signaling_proto_msgTypes[0]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Envelope.ProtoReflect.Descriptor instead.
+func (*Envelope) Descriptor() ([]byte, []int) {
+	return file_pkg_proto_signaling_signaling_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *Envelope) GetType() string {
+	if x != nil {
+		return x.Type
+	}
+	return ""
+}
+
+func (x *Envelope) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *Envelope) GetFromDeviceId() string {
+	if x != nil {
+		return x.FromDeviceId
+	}
+	return ""
+}
+
+func (x *Envelope) GetToDeviceId() string {
+	if x != nil {
+		return x.ToDeviceId
+	}
+	return ""
+}
+
+func (x *Envelope) GetSdp() *SessionDescription {
+	if x != nil {
+		return x.Sdp
+	}
+	return nil
+}
+
+func (x *Envelope) GetCandidate() *IceCandidate {
+	if x != nil {
+		return x.Candidate
+	}
+	return nil
+}
+
+func (x *Envelope) GetError() *Error {
+	if x != nil {
+		return x.Error
+	}
+	return nil
+}
+
+func (x *Envelope) GetPayload() isEnvelope_Payload {
+	if x != nil {
+		return x.Payload
+	}
+	return nil
+}
+
+func (x *Envelope) GetHello() *Hello {
+	if x != nil {
+		if x, ok := x.Payload.(*Envelope_Hello); ok {
+			return x.Hello
+		}
+	}
+	return nil
+}
+
+func (x *Envelope) GetWelcome() *Welcome {
+	if x != nil {
+		if x, ok := x.Payload.(*Envelope_Welcome); ok {
+			return x.Welcome
+		}
+	}
+	return nil
+}
+
+func (x *Envelope) GetPresence() *Presence {
+	if x != nil {
+		if x, ok := x.Payload.(*Envelope_Presence); ok {
+			return x.Presence
+		}
+	}
+	return nil
+}
+
+func (x *Envelope) GetApproval() *Approval {
+	if x != nil {
+		if x, ok := x.Payload.(*Envelope_Approval); ok {
+			return x.Approval
+		}
+	}
+	return nil
+}
+
+func (x *Envelope) GetIceState() *IceState {
+	if x != nil {
+		if x, ok := x.Payload.(*Envelope_IceState); ok {
+			return x.IceState
+		}
+	}
+	return nil
+}
+
+func (x *Envelope) GetTransferFallback() *TransferFallback {
+	if x != nil {
+		if x, ok := x.Payload.(*Envelope_TransferFallback); ok {
+			return x.TransferFallback
+		}
+	}
+	return nil
+}
+
+func (x *Envelope) GetTransfer() *Transfer {
+	if x != nil {
+		if x, ok := x.Payload.(*Envelope_Transfer); ok {
+			return x.Transfer
+		}
+	}
+	return nil
+}
+
+func (x *Envelope) GetAck() *Ack {
+	if x != nil {
+		if x, ok := x.Payload.(*Envelope_Ack); ok {
+			return x.Ack
+		}
+	}
+	return nil
+}
+
+func (x *Envelope) GetDeliveryFailed() *DeliveryFailed {
+	if x != nil {
+		if x, ok := x.Payload.(*Envelope_DeliveryFailed); ok {
+			return x.DeliveryFailed
+		}
+	}
+	return nil
+}
+
+func (x *Envelope) GetEvent() *structpb.Struct {
+	if x != nil {
+		if x, ok := x.Payload.(*Envelope_Event); ok {
+			return x.Event
+		}
+	}
+	return nil
+}
+
+type isEnvelope_Payload interface {
+	isEnvelope_Payload()
+}
+
+type Envelope_Hello struct {
+	Hello *Hello `protobuf:"bytes,10,opt,name=hello,proto3,oneof"`
+}
+
+type Envelope_Welcome struct {
+	Welcome *Welcome `protobuf:"bytes,11,opt,name=welcome,proto3,oneof"`
+}
+
+type Envelope_Presence struct {
+	Presence *Presence `protobuf:"bytes,12,opt,name=presence,proto3,oneof"` // "presence"
+}
+
+type Envelope_Approval struct {
+	Approval *Approval `protobuf:"bytes,13,opt,name=approval,proto3,oneof"` // "device-approve", "device-reject"
+}
+
+type Envelope_IceState struct {
+	IceState *IceState `protobuf:"bytes,14,opt,name=ice_state,json=iceState,proto3,oneof"` // "ice-state"
+}
+
+type Envelope_TransferFallback struct {
+	TransferFallback *TransferFallback `protobuf:"bytes,15,opt,name=transfer_fallback,json=transferFallback,proto3,oneof"` // "transfer-fallback"
+}
+
+type Envelope_Transfer struct {
+	Transfer *Transfer `protobuf:"bytes,16,opt,name=transfer,proto3,oneof"` // "transfer-request", "transfer-accept", "transfer-reject"
+}
+
+type Envelope_Ack struct {
+	Ack *Ack `protobuf:"bytes,17,opt,name=ack,proto3,oneof"` // "ack"
+}
+
+type Envelope_DeliveryFailed struct {
+	DeliveryFailed *DeliveryFailed `protobuf:"bytes,18,opt,name=delivery_failed,json=deliveryFailed,proto3,oneof"` // "delivery-failed"
+}
+
+type Envelope_Event struct {
+	Event *structpb.Struct `protobuf:"bytes,19,opt,name=event,proto3,oneof"` // события сервисов: "inbox", "device-approved", "transfer-progress" и другие
+}
+
+func (*Envelope_Hello) isEnvelope_Payload() {}
+
+func (*Envelope_Welcome) isEnvelope_Payload() {}
+
+func (*Envelope_Presence) isEnvelope_Payload() {}
+
+func (*Envelope_Approval) isEnvelope_Payload() {}
+
+func (*Envelope_IceState) isEnvelope_Payload() {}
+
+func (*Envelope_TransferFallback) isEnvelope_Payload() {}
+
+func (*Envelope_Transfer) isEnvelope_Payload() {}
+
+func (*Envelope_Ack) isEnvelope_Payload() {}
+
+func (*Envelope_DeliveryFailed) isEnvelope_Payload() {}
+
+func (*Envelope_Event) isEnvelope_Payload() {}
+
+// SessionDescription - SDP offer или answer
+type SessionDescription struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Type          string                 `protobuf:"bytes,1,opt,name=type,proto3" json:"type,omitempty"` // "offer" или "answer"
+	Sdp           string                 `protobuf:"bytes,2,opt,name=sdp,proto3" json:"sdp,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SessionDescription) Reset() {
+	*x = SessionDescription{}
+	mi := &file_pkg_proto_signaling_signaling_proto_msgTypes[1]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SessionDescription) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SessionDescription) ProtoMessage() {}
+
+func (x *SessionDescription) ProtoReflect() protoreflect.Message {
+	mi := &file_pkg_proto_signaling_signaling_proto_msgTypes[1]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SessionDescription.ProtoReflect.Descriptor instead.
+func (*SessionDescription) Descriptor() ([]byte, []int) {
+	return file_pkg_proto_signaling_signaling_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *SessionDescription) GetType() string {
+	if x != nil {
+		return x.Type
+	}
+	return ""
+}
+
+func (x *SessionDescription) GetSdp() string {
+	if x != nil {
+		return x.Sdp
+	}
+	return ""
+}
+
+type IceCandidate struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Candidate     string                 `protobuf:"bytes,1,opt,name=candidate,proto3" json:"candidate,omitempty"`
+	SdpMlineIndex int32                  `protobuf:"varint,2,opt,name=sdp_mline_index,json=sdpMlineIndex,proto3" json:"sdp_mline_index,omitempty"`
+	SdpMid        string                 `protobuf:"bytes,3,opt,name=sdp_mid,json=sdpMid,proto3" json:"sdp_mid,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *IceCandidate) Reset() {
+	*x = IceCandidate{}
+	mi := &file_pkg_proto_signaling_signaling_proto_msgTypes[2]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *IceCandidate) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*IceCandidate) ProtoMessage() {}
+
+func (x *IceCandidate) ProtoReflect() protoreflect.Message {
+	mi := &file_pkg_proto_signaling_signaling_proto_msgTypes[2]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use IceCandidate.ProtoReflect.Descriptor instead.
+func (*IceCandidate) Descriptor() ([]byte, []int) {
+	return file_pkg_proto_signaling_signaling_proto_rawDescGZIP(), []int{2}
+}
+
+func (x *IceCandidate) GetCandidate() string {
+	if x != nil {
+		return x.Candidate
+	}
+	return ""
+}
+
+func (x *IceCandidate) GetSdpMlineIndex() int32 {
+	if x != nil {
+		return x.SdpMlineIndex
+	}
+	return 0
+}
+
+func (x *IceCandidate) GetSdpMid() string {
+	if x != nil {
+		return x.SdpMid
+	}
+	return ""
+}
+
+type Error struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Code          ErrorCode              `protobuf:"varint,1,opt,name=code,proto3,enum=signaling.ErrorCode" json:"code,omitempty"`
+	Message       string                 `protobuf:"bytes,2,opt,name=message,proto3" json:"message,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Error) Reset() {
+	*x = Error{}
+	mi := &file_pkg_proto_signaling_signaling_proto_msgTypes[3]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Error) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Error) ProtoMessage() {}
+
+func (x *Error) ProtoReflect() protoreflect.Message {
+	mi := &file_pkg_proto_signaling_signaling_proto_msgTypes[3]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Error.ProtoReflect.Descriptor instead.
+func (*Error) Descriptor() ([]byte, []int) {
+	return file_pkg_proto_signaling_signaling_proto_rawDescGZIP(), []int{3}
+}
+
+func (x *Error) GetCode() ErrorCode {
+	if x != nil {
+		return x.Code
+	}
+	return ErrorCode_ERROR_CODE_UNSPECIFIED
+}
+
+func (x *Error) GetMessage() string {
+	if x != nil {
+		return x.Message
+	}
+	return ""
+}
+
+// Hello - первое сообщение клиента v2. Учетные данные не нужны, если билет передан
+// в Sec-WebSocket-Protocol.
+type Hello struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Capabilities  []string               `protobuf:"bytes,1,rep,name=capabilities,proto3" json:"capabilities,omitempty"` // возможности клиента: "ack", "presence"
+	Ticket        string                 `protobuf:"bytes,2,opt,name=ticket,proto3" json:"ticket,omitempty"`             // билет из POST /api/v1/signaling/ticket
+	Token         string                 `protobuf:"bytes,3,opt,name=token,proto3" json:"token,omitempty"`               // access токен пользователя (JWT) вместе с device_id и device_token
+	DeviceId      string                 `protobuf:"bytes,4,opt,name=device_id,json=deviceId,proto3" json:"device_id,omitempty"`
+	DeviceToken   string                 `protobuf:"bytes,5,opt,name=device_token,json=deviceToken,proto3" json:"device_token,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Hello) Reset() {
+	*x = Hello{}
+	mi := &file_pkg_proto_signaling_signaling_proto_msgTypes[4]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Hello) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Hello) ProtoMessage() {}
+
+func (x *Hello) ProtoReflect() protoreflect.Message {
+	mi := &file_pkg_proto_signaling_signaling_proto_msgTypes[4]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Hello.ProtoReflect.Descriptor instead.
+func (*Hello) Descriptor() ([]byte, []int) {
+	return file_pkg_proto_signaling_signaling_proto_rawDescGZIP(), []int{4}
+}
+
+func (x *Hello) GetCapabilities() []string {
+	if x != nil {
+		return x.Capabilities
+	}
+	return nil
+}
+
+func (x *Hello) GetTicket() string {
+	if x != nil {
+		return x.Ticket
+	}
+	return ""
+}
+
+func (x *Hello) GetToken() string {
+	if x != nil {
+		return x.Token
+	}
+	return ""
+}
+
+func (x *Hello) GetDeviceId() string {
+	if x != nil {
+		return x.DeviceId
+	}
+	return ""
+}
+
+func (x *Hello) GetDeviceToken() string {
+	if x != nil {
+		return x.DeviceToken
+	}
+	return ""
+}
+
+// Welcome - ответ сервера на hello: соединение аутентифицировано
+type Welcome struct {
+	state           protoimpl.MessageState `protogen:"open.v1"`
+	ProtocolVersion uint32                 `protobuf:"varint,1,opt,name=protocol_version,json=protocolVersion,proto3" json:"protocol_version,omitempty"`
+	Capabilities    []string               `protobuf:"bytes,2,rep,name=capabilities,proto3" json:"capabilities,omitempty"` // возможности сервера
+	DeviceId        string                 `protobuf:"bytes,3,opt,name=device_id,json=deviceId,proto3" json:"device_id,omitempty"`
+	UserId          string                 `protobuf:"bytes,4,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	SessionId       string                 `protobuf:"bytes,5,opt,name=session_id,json=sessionId,proto3" json:"session_id,omitempty"`
+	unknownFields   protoimpl.UnknownFields
+	sizeCache       protoimpl.SizeCache
+}
+
+func (x *Welcome) Reset() {
+	*x = Welcome{}
+	mi := &file_pkg_proto_signaling_signaling_proto_msgTypes[5]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Welcome) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Welcome) ProtoMessage() {}
+
+func (x *Welcome) ProtoReflect() protoreflect.Message {
+	mi := &file_pkg_proto_signaling_signaling_proto_msgTypes[5]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Welcome.ProtoReflect.Descriptor instead.
+func (*Welcome) Descriptor() ([]byte, []int) {
+	return file_pkg_proto_signaling_signaling_proto_rawDescGZIP(), []int{5}
+}
+
+func (x *Welcome) GetProtocolVersion() uint32 {
+	if x != nil {
+		return x.ProtocolVersion
+	}
+	return 0
+}
+
+func (x *Welcome) GetCapabilities() []string {
+	if x != nil {
+		return x.Capabilities
+	}
+	return nil
+}
+
+func (x *Welcome) GetDeviceId() string {
+	if x != nil {
+		return x.DeviceId
+	}
+	return ""
+}
+
+func (x *Welcome) GetUserId() string {
+	if x != nil {
+		return x.UserId
+	}
+	return ""
+}
+
+func (x *Welcome) GetSessionId() string {
+	if x != nil {
+		return x.SessionId
+	}
+	return ""
+}
+
+type Presence struct {
+	state          protoimpl.MessageState `protogen:"open.v1"`
+	DeviceId       string                 `protobuf:"bytes,1,opt,name=device_id,json=deviceId,proto3" json:"device_id,omitempty"`
+	Status         string                 `protobuf:"bytes,2,opt,name=status,proto3" json:"status,omitempty"` // "online", "away" или "offline"
+	ConnectedSince string                 `protobuf:"bytes,3,opt,name=connected_since,json=connectedSince,proto3" json:"connected_since,omitempty"`
+	UpdatedAt      string                 `protobuf:"bytes,4,opt,name=updated_at,json=updatedAt,proto3" json:"updated_at,omitempty"`
+	unknownFields  protoimpl.UnknownFields
+	sizeCache      protoimpl.SizeCache
+}
+
+func (x *Presence) Reset() {
+	*x = Presence{}
+	mi := &file_pkg_proto_signaling_signaling_proto_msgTypes[6]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Presence) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Presence) ProtoMessage() {}
+
+func (x *Presence) ProtoReflect() protoreflect.Message {
+	mi := &file_pkg_proto_signaling_signaling_proto_msgTypes[6]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Presence.ProtoReflect.Descriptor instead.
+func (*Presence) Descriptor() ([]byte, []int) {
+	return file_pkg_proto_signaling_signaling_proto_rawDescGZIP(), []int{6}
+}
+
+func (x *Presence) GetDeviceId() string {
+	if x != nil {
+		return x.DeviceId
+	}
+	return ""
+}
+
+func (x *Presence) GetStatus() string {
+	if x != nil {
+		return x.Status
+	}
+	return ""
+}
+
+func (x *Presence) GetConnectedSince() string {
+	if x != nil {
+		return x.ConnectedSince
+	}
+	return ""
+}
+
+func (x *Presence) GetUpdatedAt() string {
+	if x != nil {
+		return x.UpdatedAt
+	}
+	return ""
+}
+
+type Approval struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	DeviceId      string                 `protobuf:"bytes,1,opt,name=device_id,json=deviceId,proto3" json:"device_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Approval) Reset() {
+	*x = Approval{}
+	mi := &file_pkg_proto_signaling_signaling_proto_msgTypes[7]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Approval) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Approval) ProtoMessage() {}
+
+func (x *Approval) ProtoReflect() protoreflect.Message {
+	mi := &file_pkg_proto_signaling_signaling_proto_msgTypes[7]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Approval.ProtoReflect.Descriptor instead.
+func (*Approval) Descriptor() ([]byte, []int) {
+	return file_pkg_proto_signaling_signaling_proto_rawDescGZIP(), []int{7}
+}
+
+func (x *Approval) GetDeviceId() string {
+	if x != nil {
+		return x.DeviceId
+	}
+	return ""
+}
+
+type IceState struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	TransferId    string                 `protobuf:"bytes,1,opt,name=transfer_id,json=transferId,proto3" json:"transfer_id,omitempty"`
+	State         string                 `protobuf:"bytes,2,opt,name=state,proto3" json:"state,omitempty"` // "checking", "connected" или "failed"
+	Path          string                 `protobuf:"bytes,3,opt,name=path,proto3" json:"path,omitempty"`   // "webrtc" или "relay"
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *IceState) Reset() {
+	*x = IceState{}
+	mi := &file_pkg_proto_signaling_signaling_proto_msgTypes[8]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *IceState) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*IceState) ProtoMessage() {}
+
+func (x *IceState) ProtoReflect() protoreflect.Message {
+	mi := &file_pkg_proto_signaling_signaling_proto_msgTypes[8]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use IceState.ProtoReflect.Descriptor instead.
+func (*IceState) Descriptor() ([]byte, []int) {
+	return file_pkg_proto_signaling_signaling_proto_rawDescGZIP(), []int{8}
+}
+
+func (x *IceState) GetTransferId() string {
+	if x != nil {
+		return x.TransferId
+	}
+	return ""
+}
+
+func (x *IceState) GetState() string {
+	if x != nil {
+		return x.State
+	}
+	return ""
+}
+
+func (x *IceState) GetPath() string {
+	if x != nil {
+		return x.Path
+	}
+	return ""
+}
+
+type TransferFallback struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	TransferId    string                 `protobuf:"bytes,1,opt,name=transfer_id,json=transferId,proto3" json:"transfer_id,omitempty"`
+	DeliveryPath  string                 `protobuf:"bytes,2,opt,name=delivery_path,json=deliveryPath,proto3" json:"delivery_path,omitempty"`
+	Status        string                 `protobuf:"bytes,3,opt,name=status,proto3" json:"status,omitempty"`
+	Reason        string                 `protobuf:"bytes,4,opt,name=reason,proto3" json:"reason,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *TransferFallback) Reset() {
+	*x = TransferFallback{}
+	mi := &file_pkg_proto_signaling_signaling_proto_msgTypes[9]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *TransferFallback) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*TransferFallback) ProtoMessage() {}
+
+func (x *TransferFallback) ProtoReflect() protoreflect.Message {
+	mi := &file_pkg_proto_signaling_signaling_proto_msgTypes[9]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use TransferFallback.ProtoReflect.Descriptor instead.
+func (*TransferFallback) Descriptor() ([]byte, []int) {
+	return file_pkg_proto_signaling_signaling_proto_rawDescGZIP(), []int{9}
+}
+
+func (x *TransferFallback) GetTransferId() string {
+	if x != nil {
+		return x.TransferId
+	}
+	return ""
+}
+
+func (x *TransferFallback) GetDeliveryPath() string {
+	if x != nil {
+		return x.DeliveryPath
+	}
+	return ""
+}
+
+func (x *TransferFallback) GetStatus() string {
+	if x != nil {
+		return x.Status
+	}
+	return ""
+}
+
+func (x *TransferFallback) GetReason() string {
+	if x != nil {
+		return x.Reason
+	}
+	return ""
+}
+
+// Transfer - запрос передачи и ответ на него. Клиент передает transfer_id (и reason
+// при отказе), сведения о файле заполняет сервер.
+type Transfer struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	TransferId    string                 `protobuf:"bytes,1,opt,name=transfer_id,json=transferId,proto3" json:"transfer_id,omitempty"`
+	FileId        string                 `protobuf:"bytes,2,opt,name=file_id,json=fileId,proto3" json:"file_id,omitempty"`
+	FileName      string                 `protobuf:"bytes,3,opt,name=file_name,json=fileName,proto3" json:"file_name,omitempty"`
+	FileSize      int64                  `protobuf:"varint,4,opt,name=file_size,json=fileSize,proto3" json:"file_size,omitempty"`
+	MimeType      string                 `protobuf:"bytes,5,opt,name=mime_type,json=mimeType,proto3" json:"mime_type,omitempty"`
+	Status        string                 `protobuf:"bytes,6,opt,name=status,proto3" json:"status,omitempty"`
+	Reason        string                 `protobuf:"bytes,7,opt,name=reason,proto3" json:"reason,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Transfer) Reset() {
+	*x = Transfer{}
+	mi := &file_pkg_proto_signaling_signaling_proto_msgTypes[10]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Transfer) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Transfer) ProtoMessage() {}
+
+func (x *Transfer) ProtoReflect() protoreflect.Message {
+	mi := &file_pkg_proto_signaling_signaling_proto_msgTypes[10]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Transfer.ProtoReflect.Descriptor instead.
+func (*Transfer) Descriptor() ([]byte, []int) {
+	return file_pkg_proto_signaling_signaling_proto_rawDescGZIP(), []int{10}
+}
+
+func (x *Transfer) GetTransferId() string {
+	if x != nil {
+		return x.TransferId
+	}
+	return ""
+}
+
+func (x *Transfer) GetFileId() string {
+	if x != nil {
+		return x.FileId
+	}
+	return ""
+}
+
+func (x *Transfer) GetFileName() string {
+	if x != nil {
+		return x.FileName
+	}
+	return ""
+}
+
+func (x *Transfer) GetFileSize() int64 {
+	if x != nil {
+		return x.FileSize
+	}
+	return 0
+}
+
+func (x *Transfer) GetMimeType() string {
+	if x != nil {
+		return x.MimeType
+	}
+	return ""
+}
+
+func (x *Transfer) GetStatus() string {
+	if x != nil {
+		return x.Status
+	}
+	return ""
+}
+
+func (x *Transfer) GetReason() string {
+	if x != nil {
+		return x.Reason
+	}
+	return ""
+}
+
+type Ack struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Status        string                 `protobuf:"bytes,1,opt,name=status,proto3" json:"status,omitempty"` // "delivered" или "queued"
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Ack) Reset() {
+	*x = Ack{}
+	mi := &file_pkg_proto_signaling_signaling_proto_msgTypes[11]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Ack) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Ack) ProtoMessage() {}
+
+func (x *Ack) ProtoReflect() protoreflect.Message {
+	mi := &file_pkg_proto_signaling_signaling_proto_msgTypes[11]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Ack.ProtoReflect.Descriptor instead.
+func (*Ack) Descriptor() ([]byte, []int) {
+	return file_pkg_proto_signaling_signaling_proto_rawDescGZIP(), []int{11}
+}
+
+func (x *Ack) GetStatus() string {
+	if x != nil {
+		return x.Status
+	}
+	return ""
+}
+
+type DeliveryFailed struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	MessageType   string                 `protobuf:"bytes,1,opt,name=message_type,json=messageType,proto3" json:"message_type,omitempty"`
+	ToDeviceId    string                 `protobuf:"bytes,2,opt,name=to_device_id,json=toDeviceId,proto3" json:"to_device_id,omitempty"`
+	Reason        string                 `protobuf:"bytes,3,opt,name=reason,proto3" json:"reason,omitempty"` // "expired", "queue_full" или "queue_unavailable"
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DeliveryFailed) Reset() {
+	*x = DeliveryFailed{}
+	mi := &file_pkg_proto_signaling_signaling_proto_msgTypes[12]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DeliveryFailed) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DeliveryFailed) ProtoMessage() {}
+
+func (x *DeliveryFailed) ProtoReflect() protoreflect.Message {
+	mi := &file_pkg_proto_signaling_signaling_proto_msgTypes[12]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DeliveryFailed.ProtoReflect.Descriptor instead.
+func (*DeliveryFailed) Descriptor() ([]byte, []int) {
+	return file_pkg_proto_signaling_signaling_proto_rawDescGZIP(), []int{12}
+}
+
+func (x *DeliveryFailed) GetMessageType() string {
+	if x != nil {
+		return x.MessageType
+	}
+	return ""
+}
+
+func (x *DeliveryFailed) GetToDeviceId() string {
+	if x != nil {
+		return x.ToDeviceId
+	}
+	return ""
+}
+
+func (x *DeliveryFailed) GetReason() string {
+	if x != nil {
+		return x.Reason
+	}
+	return ""
+}
+
+var File_pkg_proto_signaling_signaling_proto protoreflect.FileDescriptor
+
+const file_pkg_proto_signaling_signaling_proto_rawDesc = "" +
+	"\n" +
+	"#pkg/proto/signaling/signaling.proto\x12\tsignaling\x1a\x1cgoogle/protobuf/struct.proto\"\x9f\x06\n" +
+	"\bEnvelope\x12\x12\n" +
+	"\x04type\x18\x01 \x01(\tR\x04type\x12\x0e\n" +
+	"\x02id\x18\x02 \x01(\tR\x02id\x12$\n" +
+	"\x0efrom_device_id\x18\x03 \x01(\tR\ffromDeviceId\x12 \n" +
+	"\fto_device_id\x18\x04 \x01(\tR\n" +
+	"toDeviceId\x12/\n" +
+	"\x03sdp\x18\x05 \x01(\v2\x1d.signaling.SessionDescriptionR\x03sdp\x125\n" +
+	"\tcandidate\x18\x06 \x01(\v2\x17.signaling.IceCandidateR\tcandidate\x12&\n" +
+	"\x05error\x18\a \x01(\v2\x10.signaling.ErrorR\x05error\x12(\n" +
+	"\x05hello\x18\n" +
+	" \x01(\v2\x10.signaling.HelloH\x00R\x05hello\x12.\n" +
+	"\awelcome\x18\v \x01(\v2\x12.signaling.WelcomeH\x00R\awelcome\x121\n" +
+	"\bpresence\x18\f \x01(\v2\x13.signaling.PresenceH\x00R\bpresence\x121\n" +
+	"\bapproval\x18\r \x01(\v2\x13.signaling.ApprovalH\x00R\bapproval\x122\n" +
+	"\tice_state\x18\x0e \x01(\v2\x13.signaling.IceStateH\x00R\biceState\x12J\n" +
+	"\x11transfer_fallback\x18\x0f \x01(\v2\x1b.signaling.TransferFallbackH\x00R\x10transferFallback\x121\n" +
+	"\btransfer\x18\x10 \x01(\v2\x13.signaling.TransferH\x00R\btransfer\x12\"\n" +
+	"\x03ack\x18\x11 \x01(\v2\x0e.signaling.AckH\x00R\x03ack\x12D\n" +
+	"\x0fdelivery_failed\x18\x12 \x01(\v2\x19.signaling.DeliveryFailedH\x00R\x0edeliveryFailed\x12/\n" +
+	"\x05event\x18\x13 \x01(\v2\x17.google.protobuf.StructH\x00R\x05eventB\t\n" +
+	"\apayload\":\n" +
+	"\x12SessionDescription\x12\x12\n" +
+	"\x04type\x18\x01 \x01(\tR\x04type\x12\x10\n" +
+	"\x03sdp\x18\x02 \x01(\tR\x03sdp\"m\n" +
+	"\fIceCandidate\x12\x1c\n" +
+	"\tcandidate\x18\x01 \x01(\tR\tcandidate\x12&\n" +
+	"\x0fsdp_mline_index\x18\x02 \x01(\x05R\rsdpMlineIndex\x12\x17\n" +
+	"\asdp_mid\x18\x03 \x01(\tR\x06sdpMid\"K\n" +
+	"\x05Error\x12(\n" +
+	"\x04code\x18\x01 \x01(\x0e2\x14.signaling.ErrorCodeR\x04code\x12\x18\n" +
+	"\amessage\x18\x02 \x01(\tR\amessage\"\x99\x01\n" +
+	"\x05Hello\x12\"\n" +
+	"\fcapabilities\x18\x01 \x03(\tR\fcapabilities\x12\x16\n" +
+	"\x06ticket\x18\x02 \x01(\tR\x06ticket\x12\x14\n" +
+	"\x05token\x18\x03 \x01(\tR\x05token\x12\x1b\n" +
+	"\tdevice_id\x18\x04 \x01(\tR\bdeviceId\x12!\n" +
+	"\fdevice_token\x18\x05 \x01(\tR\vdeviceToken\"\xad\x01\n" +
+	"\aWelcome\x12)\n" +
+	"\x10protocol_version\x18\x01 \x01(\rR\x0fprotocolVersion\x12\"\n" +
+	"\fcapabilities\x18\x02 \x03(\tR\fcapabilities\x12\x1b\n" +
+	"\tdevice_id\x18\x03 \x01(\tR\bdeviceId\x12\x17\n" +
+	"\auser_id\x18\x04 \x01(\tR\x06userId\x12\x1d\n" +
+	"\n" +
+	"session_id\x18\x05 \x01(\tR\tsessionId\"\x87\x01\n" +
+	"\bPresence\x12\x1b\n" +
+	"\tdevice_id\x18\x01 \x01(\tR\bdeviceId\x12\x16\n" +
+	"\x06status\x18\x02 \x01(\tR\x06status\x12'\n" +
+	"\x0fconnected_since\x18\x03 \x01(\tR\x0econnectedSince\x12\x1d\n" +
+	"\n" +
+	"updated_at\x18\x04 \x01(\tR\tupdatedAt\"'\n" +
+	"\bApproval\x12\x1b\n" +
+	"\tdevice_id\x18\x01 \x01(\tR\bdeviceId\"U\n" +
+	"\bIceState\x12\x1f\n" +
+	"\vtransfer_id\x18\x01 \x01(\tR\n" +
+	"transferId\x12\x14\n" +
+	"\x05state\x18\x02 \x01(\tR\x05state\x12\x12\n" +
+	"\x04path\x18\x03 \x01(\tR\x04path\"\x88\x01\n" +
+	"\x10TransferFallback\x12\x1f\n" +
+	"\vtransfer_id\x18\x01 \x01(\tR\n" +
+	"transferId\x12#\n" +
+	"\rdelivery_path\x18\x02 \x01(\tR\fdeliveryPath\x12\x16\n" +
+	"\x06status\x18\x03 \x01(\tR\x06status\x12\x16\n" +
+	"\x06reason\x18\x04 \x01(\tR\x06reason\"\xcb\x01\n" +
+	"\bTransfer\x12\x1f\n" +
+	"\vtransfer_id\x18\x01 \x01(\tR\n" +
+	"transferId\x12\x17\n" +
+	"\afile_id\x18\x02 \x01(\tR\x06fileId\x12\x1b\n" +
+	"\tfile_name\x18\x03 \x01(\tR\bfileName\x12\x1b\n" +
+	"\tfile_size\x18\x04 \x01(\x03R\bfileSize\x12\x1b\n" +
+	"\tmime_type\x18\x05 \x01(\tR\bmimeType\x12\x16\n" +
+	"\x06status\x18\x06 \x01(\tR\x06status\x12\x16\n" +
+	"\x06reason\x18\a \x01(\tR\x06reason\"\x1d\n" +
+	"\x03Ack\x12\x16\n" +
+	"\x06status\x18\x01 \x01(\tR\x06status\"m\n" +
+	"\x0eDeliveryFailed\x12!\n" +
+	"\fmessage_type\x18\x01 \x01(\tR\vmessageType\x12 \n" +
+	"\fto_device_id\x18\x02 \x01(\tR\n" +
+	"toDeviceId\x12\x16\n" +
+	"\x06reason\x18\x03 \x01(\tR\x06reason*\xa1\x02\n" +
+	"\tErrorCode\x12\x1a\n" +
+	"\x16ERROR_CODE_UNSPECIFIED\x10\x00\x12\x1e\n" +
+	"\x1aERROR_CODE_INVALID_MESSAGE\x10\x01\x12\x1b\n" +
+	"\x17ERROR_CODE_UNKNOWN_TYPE\x10\x02\x12\"\n" +
+	"\x1eERROR_CODE_UNSUPPORTED_VERSION\x10\x03\x12\x1e\n" +
+	"\x1aERROR_CODE_UNAUTHENTICATED\x10\x04\x12 \n" +
+	"\x1cERROR_CODE_PERMISSION_DENIED\x10\x05\x12\x18\n" +
+	"\x14ERROR_CODE_NOT_FOUND\x10\x06\x12\"\n" +
+	"\x1eERROR_CODE_FAILED_PRECONDITION\x10\a\x12\x17\n" +
+	"\x13ERROR_CODE_INTERNAL\x10\b2K\n" +
+	"\x10SignalingService\x127\n" +
+	"\aConnect\x12\x13.signaling.Envelope\x1a\x13.signaling.Envelope(\x010\x01B4Z2github.com/backend-app/backend/pkg/proto/signalingb\x06proto3"
+
+var (
+	file_pkg_proto_signaling_signaling_proto_rawDescOnce sync.Once
+	file_pkg_proto_signaling_signaling_proto_rawDescData []byte
+)
+
+func file_pkg_proto_signaling_signaling_proto_rawDescGZIP() []byte {
+	file_pkg_proto_signaling_signaling_proto_rawDescOnce.Do(func() {
+		file_pkg_proto_signaling_signaling_proto_rawDescData = protoimpl.X.CompressGZIP(unsafe.Slice(unsafe.StringData(file_pkg_proto_signaling_signaling_proto_rawDesc), len(file_pkg_proto_signaling_signaling_proto_rawDesc)))
+	})
+	return file_pkg_proto_signaling_signaling_proto_rawDescData
+}
+
+var file_pkg_proto_signaling_signaling_proto_enumTypes = make([]protoimpl.EnumInfo, 1)
+var file_pkg_proto_signaling_signaling_proto_msgTypes = make([]protoimpl.MessageInfo, 13)
+var file_pkg_proto_signaling_signaling_proto_goTypes = []any{
+	(ErrorCode)(0),             // 0: signaling.ErrorCode
+	(*Envelope)(nil),           // 1: signaling.Envelope
+	(*SessionDescription)(nil), // 2: signaling.SessionDescription
+	(*IceCandidate)(nil),       // 3: signaling.IceCandidate
+	(*Error)(nil),              // 4: signaling.Error
+	(*Hello)(nil),              // 5: signaling.Hello
+	(*Welcome)(nil),            // 6: signaling.Welcome
+	(*Presence)(nil),           // 7: signaling.Presence
+	(*Approval)(nil),           // 8: signaling.Approval
+	(*IceState)(nil),           // 9: signaling.IceState
+	(*TransferFallback)(nil),   // 10: signaling.TransferFallback
+	(*Transfer)(nil),           // 11: signaling.Transfer
+	(*Ack)(nil),                // 12: signaling.Ack
+	(*DeliveryFailed)(nil),     // 13: signaling.DeliveryFailed
+	(*structpb.Struct)(nil),    // 14: google.protobuf.Struct
+}
+var file_pkg_proto_signaling_signaling_proto_depIdxs = []int32{
+	2,  // 0: signaling.Envelope.sdp:type_name -> signaling.SessionDescription
+	3,  // 1: signaling.Envelope.candidate:type_name -> signaling.IceCandidate
+	4,  // 2: signaling.Envelope.error:type_name -> signaling.Error
+	5,  // 3: signaling.Envelope.hello:type_name -> signaling.Hello
+	6,  // 4: signaling.Envelope.welcome:type_name -> signaling.Welcome
+	7,  // 5: signaling.Envelope.presence:type_name -> signaling.Presence
+	8,  // 6: signaling.Envelope.approval:type_name -> signaling.Approval
+	9,  // 7: signaling.Envelope.ice_state:type_name -> signaling.IceState
+	10, // 8: signaling.Envelope.transfer_fallback:type_name -> signaling.TransferFallback
+	11, // 9: signaling.Envelope.transfer:type_name -> signaling.Transfer
+	12, // 10: signaling.Envelope.ack:type_name -> signaling.Ack
+	13, // 11: signaling.Envelope.delivery_failed:type_name -> signaling.DeliveryFailed
+	14, // 12: signaling.Envelope.event:type_name -> google.protobuf.Struct
+	0,  // 13: signaling.Error.code:type_name -> signaling.ErrorCode
+	1,  // 14: signaling.SignalingService.Connect:input_type -> signaling.Envelope
+	1,  // 15: signaling.SignalingService.Connect:output_type -> signaling.Envelope
+	15, // [15:16] is the sub-list for method output_type
+	14, // [14:15] is the sub-list for method input_type
+	14, // [14:14] is the sub-list for extension type_name
+	14, // [14:14] is the sub-list for extension extendee
+	0,  // [0:14] is the sub-list for field type_name
+}
+
+func init() { file_pkg_proto_signaling_signaling_proto_init() }
+func file_pkg_proto_signaling_signaling_proto_init() {
+	if File_pkg_proto_signaling_signaling_proto != nil {
+		return
+	}
+	file_pkg_proto_signaling_signaling_proto_msgTypes[0].OneofWrappers = []any{
+		(*Envelope_Hello)(nil),
+		(*Envelope_Welcome)(nil),
+		(*Envelope_Presence)(nil),
+		(*Envelope_Approval)(nil),
+		(*Envelope_IceState)(nil),
+		(*Envelope_TransferFallback)(nil),
+		(*Envelope_Transfer)(nil),
+		(*Envelope_Ack)(nil),
+		(*Envelope_DeliveryFailed)(nil),
+		(*Envelope_Event)(nil),
+	}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: unsafe.Slice(unsafe.StringData(file_pkg_proto_signaling_signaling_proto_rawDesc), len(file_pkg_proto_signaling_signaling_proto_rawDesc)),
+			NumEnums:      1,
+			NumMessages:   13,
+			NumExtensions: 0,
+			NumServices:   1,
+		},
+		GoTypes:           file_pkg_proto_signaling_signaling_proto_goTypes,
+		DependencyIndexes: file_pkg_proto_signaling_signaling_proto_depIdxs,
+		EnumInfos:         file_pkg_proto_signaling_signaling_proto_enumTypes,
+		MessageInfos:      file_pkg_proto_signaling_signaling_proto_msgTypes,
+	}.Build()
+	File_pkg_proto_signaling_signaling_proto = out.File
+	file_pkg_proto_signaling_signaling_proto_goTypes = nil
+	file_pkg_proto_signaling_signaling_proto_depIdxs = nil
+}
@@ -0,0 +1,129 @@
+// Code generated by protoc-gen-go-grpc. DO NOT EDIT.
+// versions:
+// - protoc-gen-go-grpc v1.6.0
+// - protoc             v3.12.4
+// source: pkg/proto/signaling/signaling.proto
+
+package signaling
+
+import (
+	context "context"
+	grpc "google.golang.org/grpc"
+	codes "google.golang.org/grpc/codes"
+	status "google.golang.org/grpc/status"
+)
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the grpc package it is being compiled against.
+// Requires gRPC-Go v1.64.0 or later.
+const _ = grpc.SupportPackageIsVersion9
+
+const (
+	SignalingService_Connect_FullMethodName = "/signaling.SignalingService/Connect"
+)
+
+// SignalingServiceClient is the client API for SignalingService service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
+//
+// SignalingService - signaling для нативных клиентов без отдельного WebSocket.
+// Сессии потока и WebSocket обслуживает один хаб, поэтому клиенты обоих видов
+// обмениваются сообщениями друг с другом.
+type SignalingServiceClient interface {
+	// Connect - сессия протокола v2: первое сообщение клиента - hello, ответ - welcome.
+	// Сведения о клиенте (platform, os_version, app_version, transfer_protocols,
+	// max_file_size) передаются в metadata.
+	Connect(ctx context.Context, opts ...grpc.CallOption) (grpc.BidiStreamingClient[Envelope, Envelope], error)
+}
+
+type signalingServiceClient struct {
+	cc grpc.ClientConnInterface
+}
+
+func NewSignalingServiceClient(cc grpc.ClientConnInterface) SignalingServiceClient {
+	return &signalingServiceClient{cc}
+}
+
+func (c *signalingServiceClient) Connect(ctx context.Context, opts ...grpc.CallOption) (grpc.BidiStreamingClient[Envelope, Envelope], error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	stream, err := c.cc.NewStream(ctx, &SignalingService_ServiceDesc.Streams[0], SignalingService_Connect_FullMethodName, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &grpc.GenericClientStream[Envelope, Envelope]{ClientStream: stream}
+	return x, nil
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type SignalingService_ConnectClient = grpc.BidiStreamingClient[Envelope, Envelope]
+
+// SignalingServiceServer is the server API for SignalingService service.
+// All implementations must embed UnimplementedSignalingServiceServer
+// for forward compatibility.
+//
+// SignalingService - signaling для нативных клиентов без отдельного WebSocket.
+// Сессии потока и WebSocket обслуживает один хаб, поэтому клиенты обоих видов
+// обмениваются сообщениями друг с другом.
+type SignalingServiceServer interface {
+	// Connect - сессия протокола v2: первое сообщение клиента - hello, ответ - welcome.
+	// Сведения о клиенте (platform, os_version, app_version, transfer_protocols,
+	// max_file_size) передаются в metadata.
+	Connect(grpc.BidiStreamingServer[Envelope, Envelope]) error
+	mustEmbedUnimplementedSignalingServiceServer()
+}
+
+// UnimplementedSignalingServiceServer must be embedded to have
+// forward compatible implementations.
+//
+// NOTE: this should be embedded by value instead of pointer to avoid a nil
+// pointer dereference when methods are called.
+type UnimplementedSignalingServiceServer struct{}
+
+func (UnimplementedSignalingServiceServer) Connect(grpc.BidiStreamingServer[Envelope, Envelope]) error {
+	return status.Error(codes.Unimplemented, "method Connect not implemented")
+}
+func (UnimplementedSignalingServiceServer) mustEmbedUnimplementedSignalingServiceServer() {}
+func (UnimplementedSignalingServiceServer) testEmbeddedByValue()                          {}
+
+// UnsafeSignalingServiceServer may be embedded to opt out of forward compatibility for this service.
+// Use of this interface is not recommended, as added methods to SignalingServiceServer will
+// result in compilation errors.
+type UnsafeSignalingServiceServer interface {
+	mustEmbedUnimplementedSignalingServiceServer()
+}
+
+func RegisterSignalingServiceServer(s grpc.ServiceRegistrar, srv SignalingServiceServer) {
+	// If the following call panics, it indicates UnimplementedSignalingServiceServer was
+	// embedded by pointer and is nil.  This will cause panics if an
+	// unimplemented method is ever invoked, so we test this at initialization
+	// time to prevent it from happening at runtime later due to I/O.
+	if t, ok := srv.(interface{ testEmbeddedByValue() }); ok {
+		t.testEmbeddedByValue()
+	}
+	s.RegisterService(&SignalingService_ServiceDesc, srv)
+}
+
+func _SignalingService_Connect_Handler(srv interface{}, stream grpc.ServerStream) error {
+	return srv.(SignalingServiceServer).Connect(&grpc.GenericServerStream[Envelope, Envelope]{ServerStream: stream})
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type SignalingService_ConnectServer = grpc.BidiStreamingServer[Envelope, Envelope]
+
+// SignalingService_ServiceDesc is the grpc.ServiceDesc for SignalingService service.
+// It's only intended for direct use with grpc.RegisterService,
+// and not to be introspected or modified (even as a copy)
+var SignalingService_ServiceDesc = grpc.ServiceDesc{
+	ServiceName: "signaling.SignalingService",
+	HandlerType: (*SignalingServiceServer)(nil),
+	Methods:     []grpc.MethodDesc{},
+	Streams: []grpc.StreamDesc{
+		{
+			StreamName:    "Connect",
+			Handler:       _SignalingService_Connect_Handler,
+			ServerStreams: true,
+			ClientStreams: true,
+		},
+	},
+	Metadata: "pkg/proto/signaling/signaling.proto",
+}
@@ -27,6 +27,7 @@ type CreateTransferRequest struct {
 	FromDeviceId  string                 `protobuf:"bytes,2,opt,name=from_device_id,json=fromDeviceId,proto3" json:"from_device_id,omitempty"`
 	ToDeviceId    string                 `protobuf:"bytes,3,opt,name=to_device_id,json=toDeviceId,proto3" json:"to_device_id,omitempty"`
 	TransferType  string                 `protobuf:"bytes,4,opt,name=transfer_type,json=transferType,proto3" json:"transfer_type,omitempty"`
+	UserId        string                 `protobuf:"bytes,5,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"` // владелец from_device_id; файл должен быть ему доступен
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
@@ -89,6 +90,13 @@ func (x *CreateTransferRequest) GetTransferType() string {
 	return ""
 }
 
+func (x *CreateTransferRequest) GetUserId() string {
+	if x != nil {
+		return x.UserId
+	}
+	return ""
+}
+
 type CreateTransferResponse struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	Transfer      *Transfer              `protobuf:"bytes,1,opt,name=transfer,proto3" json:"transfer,omitempty"`
@@ -133,9 +141,11 @@ func (x *CreateTransferResponse) GetTransfer() *Transfer {
 	return nil
 }
 
+// Передача доступна владельцу файла и владельцам устройств отправителя и получателя
 type GetTransferRequest struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	TransferId    string                 `protobuf:"bytes,1,opt,name=transfer_id,json=transferId,proto3" json:"transfer_id,omitempty"`
+	UserId        string                 `protobuf:"bytes,2,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
@@ -177,6 +187,13 @@ func (x *GetTransferRequest) GetTransferId() string {
 	return ""
 }
 
+func (x *GetTransferRequest) GetUserId() string {
+	if x != nil {
+		return x.UserId
+	}
+	return ""
+}
+
 type GetTransferResponse struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	Transfer      *Transfer              `protobuf:"bytes,1,opt,name=transfer,proto3" json:"transfer,omitempty"`
@@ -221,13 +238,20 @@ func (x *GetTransferResponse) GetTransfer() *Transfer {
 	return nil
 }
 
+// Статус меняется только по таблице переходов: pending -> in_progress, cancelled,
+// rejected, expired, failed; in_progress -> paused, completed, failed, cancelled;
+// paused -> in_progress, cancelled, expired, failed. Тот же статус обновляет
+// только прогресс.
 type UpdateTransferStatusRequest struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	TransferId    string                 `protobuf:"bytes,1,opt,name=transfer_id,json=transferId,proto3" json:"transfer_id,omitempty"`
-	Status        string                 `protobuf:"bytes,2,opt,name=status,proto3" json:"status,omitempty"`
-	Progress      int64                  `protobuf:"varint,3,opt,name=progress,proto3" json:"progress,omitempty"`
-	unknownFields protoimpl.UnknownFields
-	sizeCache     protoimpl.SizeCache
+	state            protoimpl.MessageState `protogen:"open.v1"`
+	TransferId       string                 `protobuf:"bytes,1,opt,name=transfer_id,json=transferId,proto3" json:"transfer_id,omitempty"`
+	Status           string                 `protobuf:"bytes,2,opt,name=status,proto3" json:"status,omitempty"`
+	Progress         int64                  `protobuf:"varint,3,opt,name=progress,proto3" json:"progress,omitempty"`
+	FailureReason    string                 `protobuf:"bytes,4,opt,name=failure_reason,json=failureReason,proto3" json:"failure_reason,omitempty"`           // для failed, cancelled, rejected, expired
+	BytesTransferred int64                  `protobuf:"varint,5,opt,name=bytes_transferred,json=bytesTransferred,proto3" json:"bytes_transferred,omitempty"` // если задан, progress считается по размеру файла
+	UserId           string                 `protobuf:"bytes,6,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	unknownFields    protoimpl.UnknownFields
+	sizeCache        protoimpl.SizeCache
 }
 
 func (x *UpdateTransferStatusRequest) Reset() {
@@ -281,6 +305,27 @@ func (x *UpdateTransferStatusRequest) GetProgress() int64 {
 	return 0
 }
 
+func (x *UpdateTransferStatusRequest) GetFailureReason() string {
+	if x != nil {
+		return x.FailureReason
+	}
+	return ""
+}
+
+func (x *UpdateTransferStatusRequest) GetBytesTransferred() int64 {
+	if x != nil {
+		return x.BytesTransferred
+	}
+	return 0
+}
+
+func (x *UpdateTransferStatusRequest) GetUserId() string {
+	if x != nil {
+		return x.UserId
+	}
+	return ""
+}
+
 type UpdateTransferStatusResponse struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	Transfer      *Transfer              `protobuf:"bytes,1,opt,name=transfer,proto3" json:"transfer,omitempty"`
@@ -325,10 +370,18 @@ func (x *UpdateTransferStatusResponse) GetTransfer() *Transfer {
 	return nil
 }
 
+// Возвращает передачи с участием файлов или устройств user_id; фильтры
+// объединяются через AND
 type ListTransfersRequest struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	FileId        string                 `protobuf:"bytes,1,opt,name=file_id,json=fileId,proto3" json:"file_id,omitempty"`
 	Status        string                 `protobuf:"bytes,2,opt,name=status,proto3" json:"status,omitempty"`
+	DeviceId      string                 `protobuf:"bytes,3,opt,name=device_id,json=deviceId,proto3" json:"device_id,omitempty"`
+	Direction     string                 `protobuf:"bytes,4,opt,name=direction,proto3" json:"direction,omitempty"` // incoming, outgoing относительно device_id; пусто - в обе стороны
+	Limit         int32                  `protobuf:"varint,5,opt,name=limit,proto3" json:"limit,omitempty"`        // 0 - без ограничения
+	Offset        int32                  `protobuf:"varint,6,opt,name=offset,proto3" json:"offset,omitempty"`
+	UserId        string                 `protobuf:"bytes,7,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	GroupId       string                 `protobuf:"bytes,8,opt,name=group_id,json=groupId,proto3" json:"group_id,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
@@ -377,6 +430,48 @@ func (x *ListTransfersRequest) GetStatus() string {
 	return ""
 }
 
+func (x *ListTransfersRequest) GetDeviceId() string {
+	if x != nil {
+		return x.DeviceId
+	}
+	return ""
+}
+
+func (x *ListTransfersRequest) GetDirection() string {
+	if x != nil {
+		return x.Direction
+	}
+	return ""
+}
+
+func (x *ListTransfersRequest) GetLimit() int32 {
+	if x != nil {
+		return x.Limit
+	}
+	return 0
+}
+
+func (x *ListTransfersRequest) GetOffset() int32 {
+	if x != nil {
+		return x.Offset
+	}
+	return 0
+}
+
+func (x *ListTransfersRequest) GetUserId() string {
+	if x != nil {
+		return x.UserId
+	}
+	return ""
+}
+
+func (x *ListTransfersRequest) GetGroupId() string {
+	if x != nil {
+		return x.GroupId
+	}
+	return ""
+}
+
 type ListTransfersResponse struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	Transfers     []*Transfer            `protobuf:"bytes,1,rep,name=transfers,proto3" json:"transfers,omitempty"`
@@ -424,6 +519,7 @@ func (x *ListTransfersResponse) GetTransfers() []*Transfer {
 type StreamTransferProgressRequest struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	TransferId    string                 `protobuf:"bytes,1,opt,name=transfer_id,json=transferId,proto3" json:"transfer_id,omitempty"`
+	UserId        string                 `protobuf:"bytes,2,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
@@ -465,14 +561,28 @@ func (x *StreamTransferProgressRequest) GetTransferId() string {
 	return ""
 }
 
+func (x *StreamTransferProgressRequest) GetUserId() string {
+	if x != nil {
+		return x.UserId
+	}
+	return ""
+}
+
+// Поток отправляет текущее состояние, затем каждое обновление передачи и
+// закрывается, когда передача переходит в завершающий статус
 type TransferProgressUpdate struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	TransferId    string                 `protobuf:"bytes,1,opt,name=transfer_id,json=transferId,proto3" json:"transfer_id,omitempty"`
-	Status        string                 `protobuf:"bytes,2,opt,name=status,proto3" json:"status,omitempty"`
-	Progress      int64                  `protobuf:"varint,3,opt,name=progress,proto3" json:"progress,omitempty"`
-	TotalSize     int64                  `protobuf:"varint,4,opt,name=total_size,json=totalSize,proto3" json:"total_size,omitempty"`
-	unknownFields protoimpl.UnknownFields
-	sizeCache     protoimpl.SizeCache
+	state            protoimpl.MessageState `protogen:"open.v1"`
+	TransferId       string                 `protobuf:"bytes,1,opt,name=transfer_id,json=transferId,proto3" json:"transfer_id,omitempty"`
+	Status           string                 `protobuf:"bytes,2,opt,name=status,proto3" json:"status,omitempty"`
+	Progress         int64                  `protobuf:"varint,3,opt,name=progress,proto3" json:"progress,omitempty"`
+	TotalSize        int64                  `protobuf:"varint,4,opt,name=total_size,json=totalSize,proto3" json:"total_size,omitempty"`
+	BytesTransferred int64                  `protobuf:"varint,5,opt,name=bytes_transferred,json=bytesTransferred,proto3" json:"bytes_transferred,omitempty"`
+	Rate             float64                `protobuf:"fixed64,6,opt,name=rate,proto3" json:"rate,omitempty"`                              // байт в секунду, 0 - неизвестно
+	EtaSeconds       int64                  `protobuf:"varint,7,opt,name=eta_seconds,json=etaSeconds,proto3" json:"eta_seconds,omitempty"` // 0 - неизвестно
+	FailureReason    string                 `protobuf:"bytes,8,opt,name=failure_reason,json=failureReason,proto3" json:"failure_reason,omitempty"`
+	UpdatedAt        string                 `protobuf:"bytes,9,opt,name=updated_at,json=updatedAt,proto3" json:"updated_at,omitempty"`
+	unknownFields    protoimpl.UnknownFields
+	sizeCache        protoimpl.SizeCache
 }
 
 func (x *TransferProgressUpdate) Reset() {
@@ -533,19 +643,58 @@ func (x *TransferProgressUpdate) GetTotalSize() int64 {
 	return 0
 }
 
+func (x *TransferProgressUpdate) GetBytesTransferred() int64 {
+	if x != nil {
+		return x.BytesTransferred
+	}
+	return 0
+}
+
+func (x *TransferProgressUpdate) GetRate() float64 {
+	if x != nil {
+		return x.Rate
+	}
+	return 0
+}
+
+func (x *TransferProgressUpdate) GetEtaSeconds() int64 {
+	if x != nil {
+		return x.EtaSeconds
+	}
+	return 0
+}
+
+func (x *TransferProgressUpdate) GetFailureReason() string {
+	if x != nil {
+		return x.FailureReason
+	}
+	return ""
+}
+
+func (x *TransferProgressUpdate) GetUpdatedAt() string {
+	if x != nil {
+		return x.UpdatedAt
+	}
+	return ""
+}
+
 type Transfer struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
-	FileId        string                 `protobuf:"bytes,2,opt,name=file_id,json=fileId,proto3" json:"file_id,omitempty"`
-	FromDeviceId  string                 `protobuf:"bytes,3,opt,name=from_device_id,json=fromDeviceId,proto3" json:"from_device_id,omitempty"`
-	ToDeviceId    string                 `protobuf:"bytes,4,opt,name=to_device_id,json=toDeviceId,proto3" json:"to_device_id,omitempty"`
-	TransferType  string                 `protobuf:"bytes,5,opt,name=transfer_type,json=transferType,proto3" json:"transfer_type,omitempty"`
-	Status        string                 `protobuf:"bytes,6,opt,name=status,proto3" json:"status,omitempty"`
-	Progress      int64                  `protobuf:"varint,7,opt,name=progress,proto3" json:"progress,omitempty"`
-	CreatedAt     string                 `protobuf:"bytes,8,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
-	UpdatedAt     string                 `protobuf:"bytes,9,opt,name=updated_at,json=updatedAt,proto3" json:"updated_at,omitempty"`
-	unknownFields protoimpl.UnknownFields
-	sizeCache     protoimpl.SizeCache
+	state            protoimpl.MessageState `protogen:"open.v1"`
+	Id               string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	FileId           string                 `protobuf:"bytes,2,opt,name=file_id,json=fileId,proto3" json:"file_id,omitempty"`
+	FromDeviceId     string                 `protobuf:"bytes,3,opt,name=from_device_id,json=fromDeviceId,proto3" json:"from_device_id,omitempty"`
+	ToDeviceId       string                 `protobuf:"bytes,4,opt,name=to_device_id,json=toDeviceId,proto3" json:"to_device_id,omitempty"`
+	TransferType     string                 `protobuf:"bytes,5,opt,name=transfer_type,json=transferType,proto3" json:"transfer_type,omitempty"`
+	Status           string                 `protobuf:"bytes,6,opt,name=status,proto3" json:"status,omitempty"`
+	Progress         int64                  `protobuf:"varint,7,opt,name=progress,proto3" json:"progress,omitempty"`
+	CreatedAt        string                 `protobuf:"bytes,8,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
+	UpdatedAt        string                 `protobuf:"bytes,9,opt,name=updated_at,json=updatedAt,proto3" json:"updated_at,omitempty"`
+	FailureReason    string                 `protobuf:"bytes,10,opt,name=failure_reason,json=failureReason,proto3" json:"failure_reason,omitempty"`
+	BytesTransferred int64                  `protobuf:"varint,11,opt,name=bytes_transferred,json=bytesTransferred,proto3" json:"bytes_transferred,omitempty"`
+	GroupId          string                 `protobuf:"bytes,12,opt,name=group_id,json=groupId,proto3" json:"group_id,omitempty"`                // пусто - передача не входит в группу
+	DeliveryPath     string                 `protobuf:"bytes,13,opt,name=delivery_path,json=deliveryPath,proto3" json:"delivery_path,omitempty"` // webrtc, relay, cloud; у завершенной передачи - способ, которым доставлен файл
+	unknownFields    protoimpl.UnknownFields
+	sizeCache        protoimpl.SizeCache
 }
 
 func (x *Transfer) Reset() {
@@ -641,65 +790,1029 @@ func (x *Transfer) GetUpdatedAt() string {
 	return ""
 }
 
-var File_pkg_proto_transfer_transfer_proto protoreflect.FileDescriptor
+func (x *Transfer) GetFailureReason() string {
+	if x != nil {
+		return x.FailureReason
+	}
+	return ""
+}
 
-const file_pkg_proto_transfer_transfer_proto_rawDesc = "" +
-	"\n" +
-	"!pkg/proto/transfer/transfer.proto\x12\btransfer\"\x9d\x01\n" +
-	"\x15CreateTransferRequest\x12\x17\n" +
-	"\afile_id\x18\x01 \x01(\tR\x06fileId\x12$\n" +
-	"\x0efrom_device_id\x18\x02 \x01(\tR\ffromDeviceId\x12 \n" +
-	"\fto_device_id\x18\x03 \x01(\tR\n" +
-	"toDeviceId\x12#\n" +
-	"\rtransfer_type\x18\x04 \x01(\tR\ftransferType\"H\n" +
-	"\x16CreateTransferResponse\x12.\n" +
-	"\btransfer\x18\x01 \x01(\v2\x12.transfer.TransferR\btransfer\"5\n" +
-	"\x12GetTransferRequest\x12\x1f\n" +
-	"\vtransfer_id\x18\x01 \x01(\tR\n" +
-	"transferId\"E\n" +
-	"\x13GetTransferResponse\x12.\n" +
-	"\btransfer\x18\x01 \x01(\v2\x12.transfer.TransferR\btransfer\"r\n" +
-	"\x1bUpdateTransferStatusRequest\x12\x1f\n" +
-	"\vtransfer_id\x18\x01 \x01(\tR\n" +
-	"transferId\x12\x16\n" +
-	"\x06status\x18\x02 \x01(\tR\x06status\x12\x1a\n" +
-	"\bprogress\x18\x03 \x01(\x03R\bprogress\"N\n" +
-	"\x1cUpdateTransferStatusResponse\x12.\n" +
-	"\btransfer\x18\x01 \x01(\v2\x12.transfer.TransferR\btransfer\"G\n" +
-	"\x14ListTransfersRequest\x12\x17\n" +
-	"\afile_id\x18\x01 \x01(\tR\x06fileId\x12\x16\n" +
-	"\x06status\x18\x02 \x01(\tR\x06status\"I\n" +
-	"\x15ListTransfersResponse\x120\n" +
-	"\ttransfers\x18\x01 \x03(\v2\x12.transfer.TransferR\ttransfers\"@\n" +
-	"\x1dStreamTransferProgressRequest\x12\x1f\n" +
-	"\vtransfer_id\x18\x01 \x01(\tR\n" +
-	"transferId\"\x8c\x01\n" +
-	"\x16TransferProgressUpdate\x12\x1f\n" +
-	"\vtransfer_id\x18\x01 \x01(\tR\n" +
-	"transferId\x12\x16\n" +
-	"\x06status\x18\x02 \x01(\tR\x06status\x12\x1a\n" +
-	"\bprogress\x18\x03 \x01(\x03R\bprogress\x12\x1d\n" +
-	"\n" +
-	"total_size\x18\x04 \x01(\x03R\ttotalSize\"\x92\x02\n" +
-	"\bTransfer\x12\x0e\n" +
-	"\x02id\x18\x01 \x01(\tR\x02id\x12\x17\n" +
-	"\afile_id\x18\x02 \x01(\tR\x06fileId\x12$\n" +
-	"\x0efrom_device_id\x18\x03 \x01(\tR\ffromDeviceId\x12 \n" +
-	"\fto_device_id\x18\x04 \x01(\tR\n" +
-	"toDeviceId\x12#\n" +
-	"\rtransfer_type\x18\x05 \x01(\tR\ftransferType\x12\x16\n" +
-	"\x06status\x18\x06 \x01(\tR\x06status\x12\x1a\n" +
-	"\bprogress\x18\a \x01(\x03R\bprogress\x12\x1d\n" +
-	"\n" +
-	"created_at\x18\b \x01(\tR\tcreatedAt\x12\x1d\n" +
+func (x *Transfer) GetBytesTransferred() int64 {
+	if x != nil {
+		return x.BytesTransferred
+	}
+	return 0
+}
+
+func (x *Transfer) GetGroupId() string {
+	if x != nil {
+		return x.GroupId
+	}
+	return ""
+}
+
+func (x *Transfer) GetDeliveryPath() string {
+	if x != nil {
+		return x.DeliveryPath
+	}
+	return ""
+}
+
+type GetTransferHistoryRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	TransferId    string                 `protobuf:"bytes,1,opt,name=transfer_id,json=transferId,proto3" json:"transfer_id,omitempty"`
+	UserId        string                 `protobuf:"bytes,2,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetTransferHistoryRequest) Reset() {
+	*x = GetTransferHistoryRequest{}
+	mi := &file_pkg_proto_transfer_transfer_proto_msgTypes[11]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetTransferHistoryRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetTransferHistoryRequest) ProtoMessage() {}
+
+func (x *GetTransferHistoryRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_pkg_proto_transfer_transfer_proto_msgTypes[11]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetTransferHistoryRequest.ProtoReflect.Descriptor instead.
+func (*GetTransferHistoryRequest) Descriptor() ([]byte, []int) {
+	return file_pkg_proto_transfer_transfer_proto_rawDescGZIP(), []int{11}
+}
+
+func (x *GetTransferHistoryRequest) GetTransferId() string {
+	if x != nil {
+		return x.TransferId
+	}
+	return ""
+}
+
+func (x *GetTransferHistoryRequest) GetUserId() string {
+	if x != nil {
+		return x.UserId
+	}
+	return ""
+}
+
+type GetTransferHistoryResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Events        []*TransferEvent       `protobuf:"bytes,1,rep,name=events,proto3" json:"events,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetTransferHistoryResponse) Reset() {
+	*x = GetTransferHistoryResponse{}
+	mi := &file_pkg_proto_transfer_transfer_proto_msgTypes[12]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetTransferHistoryResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetTransferHistoryResponse) ProtoMessage() {}
+
+func (x *GetTransferHistoryResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_pkg_proto_transfer_transfer_proto_msgTypes[12]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetTransferHistoryResponse.ProtoReflect.Descriptor instead.
+func (*GetTransferHistoryResponse) Descriptor() ([]byte, []int) {
+	return file_pkg_proto_transfer_transfer_proto_rawDescGZIP(), []int{12}
+}
+
+func (x *GetTransferHistoryResponse) GetEvents() []*TransferEvent {
+	if x != nil {
+		return x.Events
+	}
+	return nil
+}
+
+// TransferEvent - смена статуса передачи. Событие с from_status == to_status -
+// переход на другой способ доставки.
+type TransferEvent struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	FromStatus    string                 `protobuf:"bytes,1,opt,name=from_status,json=fromStatus,proto3" json:"from_status,omitempty"` // пусто - создание передачи
+	ToStatus      string                 `protobuf:"bytes,2,opt,name=to_status,json=toStatus,proto3" json:"to_status,omitempty"`
+	Reason        string                 `protobuf:"bytes,3,opt,name=reason,proto3" json:"reason,omitempty"`
+	CreatedAt     string                 `protobuf:"bytes,4,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
+	DeliveryPath  string                 `protobuf:"bytes,5,opt,name=delivery_path,json=deliveryPath,proto3" json:"delivery_path,omitempty"` // способ доставки после события
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *TransferEvent) Reset() {
+	*x = TransferEvent{}
+	mi := &file_pkg_proto_transfer_transfer_proto_msgTypes[13]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *TransferEvent) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*TransferEvent) ProtoMessage() {}
+
+func (x *TransferEvent) ProtoReflect() protoreflect.Message {
+	mi := &file_pkg_proto_transfer_transfer_proto_msgTypes[13]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use TransferEvent.ProtoReflect.Descriptor instead.
+func (*TransferEvent) Descriptor() ([]byte, []int) {
+	return file_pkg_proto_transfer_transfer_proto_rawDescGZIP(), []int{13}
+}
+
+func (x *TransferEvent) GetFromStatus() string {
+	if x != nil {
+		return x.FromStatus
+	}
+	return ""
+}
+
+func (x *TransferEvent) GetToStatus() string {
+	if x != nil {
+		return x.ToStatus
+	}
+	return ""
+}
+
+func (x *TransferEvent) GetReason() string {
+	if x != nil {
+		return x.Reason
+	}
+	return ""
+}
+
+func (x *TransferEvent) GetCreatedAt() string {
+	if x != nil {
+		return x.CreatedAt
+	}
+	return ""
+}
+
+func (x *TransferEvent) GetDeliveryPath() string {
+	if x != nil {
+		return x.DeliveryPath
+	}
+	return ""
+}
+
+// Входящие устройства: облачные передачи, которые ждут получения. Устройство
+// должно принадлежать user_id.
+type ListInboxRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	DeviceId      string                 `protobuf:"bytes,1,opt,name=device_id,json=deviceId,proto3" json:"device_id,omitempty"`
+	UserId        string                 `protobuf:"bytes,2,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListInboxRequest) Reset() {
+	*x = ListInboxRequest{}
+	mi := &file_pkg_proto_transfer_transfer_proto_msgTypes[14]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListInboxRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListInboxRequest) ProtoMessage() {}
+
+func (x *ListInboxRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_pkg_proto_transfer_transfer_proto_msgTypes[14]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListInboxRequest.ProtoReflect.Descriptor instead.
+func (*ListInboxRequest) Descriptor() ([]byte, []int) {
+	return file_pkg_proto_transfer_transfer_proto_rawDescGZIP(), []int{14}
+}
+
+func (x *ListInboxRequest) GetDeviceId() string {
+	if x != nil {
+		return x.DeviceId
+	}
+	return ""
+}
+
+func (x *ListInboxRequest) GetUserId() string {
+	if x != nil {
+		return x.UserId
+	}
+	return ""
+}
+
+type ListInboxResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Items         []*InboxItem           `protobuf:"bytes,1,rep,name=items,proto3" json:"items,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListInboxResponse) Reset() {
+	*x = ListInboxResponse{}
+	mi := &file_pkg_proto_transfer_transfer_proto_msgTypes[15]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListInboxResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListInboxResponse) ProtoMessage() {}
+
+func (x *ListInboxResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_pkg_proto_transfer_transfer_proto_msgTypes[15]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListInboxResponse.ProtoReflect.Descriptor instead.
+func (*ListInboxResponse) Descriptor() ([]byte, []int) {
+	return file_pkg_proto_transfer_transfer_proto_rawDescGZIP(), []int{15}
+}
+
+func (x *ListInboxResponse) GetItems() []*InboxItem {
+	if x != nil {
+		return x.Items
+	}
+	return nil
+}
+
+// InboxItem - файл, загруженный отправителем один раз и ожидающий устройство
+type InboxItem struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	DeviceId      string                 `protobuf:"bytes,2,opt,name=device_id,json=deviceId,proto3" json:"device_id,omitempty"`
+	TransferId    string                 `protobuf:"bytes,3,opt,name=transfer_id,json=transferId,proto3" json:"transfer_id,omitempty"`
+	FileId        string                 `protobuf:"bytes,4,opt,name=file_id,json=fileId,proto3" json:"file_id,omitempty"`
+	FromDeviceId  string                 `protobuf:"bytes,5,opt,name=from_device_id,json=fromDeviceId,proto3" json:"from_device_id,omitempty"`
+	FileName      string                 `protobuf:"bytes,6,opt,name=file_name,json=fileName,proto3" json:"file_name,omitempty"`
+	FileSize      int64                  `protobuf:"varint,7,opt,name=file_size,json=fileSize,proto3" json:"file_size,omitempty"`
+	MimeType      string                 `protobuf:"bytes,8,opt,name=mime_type,json=mimeType,proto3" json:"mime_type,omitempty"`
+	CreatedAt     string                 `protobuf:"bytes,9,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *InboxItem) Reset() {
+	*x = InboxItem{}
+	mi := &file_pkg_proto_transfer_transfer_proto_msgTypes[16]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *InboxItem) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*InboxItem) ProtoMessage() {}
+
+func (x *InboxItem) ProtoReflect() protoreflect.Message {
+	mi := &file_pkg_proto_transfer_transfer_proto_msgTypes[16]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use InboxItem.ProtoReflect.Descriptor instead.
+func (*InboxItem) Descriptor() ([]byte, []int) {
+	return file_pkg_proto_transfer_transfer_proto_rawDescGZIP(), []int{16}
+}
+
+func (x *InboxItem) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *InboxItem) GetDeviceId() string {
+	if x != nil {
+		return x.DeviceId
+	}
+	return ""
+}
+
+func (x *InboxItem) GetTransferId() string {
+	if x != nil {
+		return x.TransferId
+	}
+	return ""
+}
+
+func (x *InboxItem) GetFileId() string {
+	if x != nil {
+		return x.FileId
+	}
+	return ""
+}
+
+func (x *InboxItem) GetFromDeviceId() string {
+	if x != nil {
+		return x.FromDeviceId
+	}
+	return ""
+}
+
+func (x *InboxItem) GetFileName() string {
+	if x != nil {
+		return x.FileName
+	}
+	return ""
+}
+
+func (x *InboxItem) GetFileSize() int64 {
+	if x != nil {
+		return x.FileSize
+	}
+	return 0
+}
+
+func (x *InboxItem) GetMimeType() string {
+	if x != nil {
+		return x.MimeType
+	}
+	return ""
+}
+
+func (x *InboxItem) GetCreatedAt() string {
+	if x != nil {
+		return x.CreatedAt
+	}
+	return ""
+}
+
+// Подтверждение завершает передачу. Когда файл получили все устройства, ему
+// назначается срок хранения, после которого он удаляется.
+type AcknowledgeInboxItemRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	DeviceId      string                 `protobuf:"bytes,1,opt,name=device_id,json=deviceId,proto3" json:"device_id,omitempty"`
+	TransferId    string                 `protobuf:"bytes,2,opt,name=transfer_id,json=transferId,proto3" json:"transfer_id,omitempty"`
+	UserId        string                 `protobuf:"bytes,3,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *AcknowledgeInboxItemRequest) Reset() {
+	*x = AcknowledgeInboxItemRequest{}
+	mi := &file_pkg_proto_transfer_transfer_proto_msgTypes[17]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *AcknowledgeInboxItemRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*AcknowledgeInboxItemRequest) ProtoMessage() {}
+
+func (x *AcknowledgeInboxItemRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_pkg_proto_transfer_transfer_proto_msgTypes[17]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use AcknowledgeInboxItemRequest.ProtoReflect.Descriptor instead.
+func (*AcknowledgeInboxItemRequest) Descriptor() ([]byte, []int) {
+	return file_pkg_proto_transfer_transfer_proto_rawDescGZIP(), []int{17}
+}
+
+func (x *AcknowledgeInboxItemRequest) GetDeviceId() string {
+	if x != nil {
+		return x.DeviceId
+	}
+	return ""
+}
+
+func (x *AcknowledgeInboxItemRequest) GetTransferId() string {
+	if x != nil {
+		return x.TransferId
+	}
+	return ""
+}
+
+func (x *AcknowledgeInboxItemRequest) GetUserId() string {
+	if x != nil {
+		return x.UserId
+	}
+	return ""
+}
+
+type AcknowledgeInboxItemResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Transfer      *Transfer              `protobuf:"bytes,1,opt,name=transfer,proto3" json:"transfer,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *AcknowledgeInboxItemResponse) Reset() {
+	*x = AcknowledgeInboxItemResponse{}
+	mi := &file_pkg_proto_transfer_transfer_proto_msgTypes[18]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *AcknowledgeInboxItemResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*AcknowledgeInboxItemResponse) ProtoMessage() {}
+
+func (x *AcknowledgeInboxItemResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_pkg_proto_transfer_transfer_proto_msgTypes[18]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use AcknowledgeInboxItemResponse.ProtoReflect.Descriptor instead.
+func (*AcknowledgeInboxItemResponse) Descriptor() ([]byte, []int) {
+	return file_pkg_proto_transfer_transfer_proto_rawDescGZIP(), []int{18}
+}
+
+func (x *AcknowledgeInboxItemResponse) GetTransfer() *Transfer {
+	if x != nil {
+		return x.Transfer
+	}
+	return nil
+}
+
+// Отправка одного файла нескольким устройствам: для каждого получателя создается
+// своя передача. all_devices отправляет на все подтвержденные активные устройства
+// пользователя, кроме отправителя, пропуская те, что не могут принять файл.
+type CreateTransferGroupRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	UserId        string                 `protobuf:"bytes,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	FileId        string                 `protobuf:"bytes,2,opt,name=file_id,json=fileId,proto3" json:"file_id,omitempty"`
+	FromDeviceId  string                 `protobuf:"bytes,3,opt,name=from_device_id,json=fromDeviceId,proto3" json:"from_device_id,omitempty"`
+	TransferType  string                 `protobuf:"bytes,4,opt,name=transfer_type,json=transferType,proto3" json:"transfer_type,omitempty"`
+	ToDeviceIds   []string               `protobuf:"bytes,5,rep,name=to_device_ids,json=toDeviceIds,proto3" json:"to_device_ids,omitempty"`
+	AllDevices    bool                   `protobuf:"varint,6,opt,name=all_devices,json=allDevices,proto3" json:"all_devices,omitempty"` // to_device_ids игнорируется
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CreateTransferGroupRequest) Reset() {
+	*x = CreateTransferGroupRequest{}
+	mi := &file_pkg_proto_transfer_transfer_proto_msgTypes[19]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CreateTransferGroupRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CreateTransferGroupRequest) ProtoMessage() {}
+
+func (x *CreateTransferGroupRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_pkg_proto_transfer_transfer_proto_msgTypes[19]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CreateTransferGroupRequest.ProtoReflect.Descriptor instead.
+func (*CreateTransferGroupRequest) Descriptor() ([]byte, []int) {
+	return file_pkg_proto_transfer_transfer_proto_rawDescGZIP(), []int{19}
+}
+
+func (x *CreateTransferGroupRequest) GetUserId() string {
+	if x != nil {
+		return x.UserId
+	}
+	return ""
+}
+
+func (x *CreateTransferGroupRequest) GetFileId() string {
+	if x != nil {
+		return x.FileId
+	}
+	return ""
+}
+
+func (x *CreateTransferGroupRequest) GetFromDeviceId() string {
+	if x != nil {
+		return x.FromDeviceId
+	}
+	return ""
+}
+
+func (x *CreateTransferGroupRequest) GetTransferType() string {
+	if x != nil {
+		return x.TransferType
+	}
+	return ""
+}
+
+func (x *CreateTransferGroupRequest) GetToDeviceIds() []string {
+	if x != nil {
+		return x.ToDeviceIds
+	}
+	return nil
+}
+
+func (x *CreateTransferGroupRequest) GetAllDevices() bool {
+	if x != nil {
+		return x.AllDevices
+	}
+	return false
+}
+
+type CreateTransferGroupResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Group         *TransferGroup         `protobuf:"bytes,1,opt,name=group,proto3" json:"group,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CreateTransferGroupResponse) Reset() {
+	*x = CreateTransferGroupResponse{}
+	mi := &file_pkg_proto_transfer_transfer_proto_msgTypes[20]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CreateTransferGroupResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CreateTransferGroupResponse) ProtoMessage() {}
+
+func (x *CreateTransferGroupResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_pkg_proto_transfer_transfer_proto_msgTypes[20]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CreateTransferGroupResponse.ProtoReflect.Descriptor instead.
+func (*CreateTransferGroupResponse) Descriptor() ([]byte, []int) {
+	return file_pkg_proto_transfer_transfer_proto_rawDescGZIP(), []int{20}
+}
+
+func (x *CreateTransferGroupResponse) GetGroup() *TransferGroup {
+	if x != nil {
+		return x.Group
+	}
+	return nil
+}
+
+// Группа доступна только отправителю
+type GetTransferGroupRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	GroupId       string                 `protobuf:"bytes,1,opt,name=group_id,json=groupId,proto3" json:"group_id,omitempty"`
+	UserId        string                 `protobuf:"bytes,2,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetTransferGroupRequest) Reset() {
+	*x = GetTransferGroupRequest{}
+	mi := &file_pkg_proto_transfer_transfer_proto_msgTypes[21]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetTransferGroupRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetTransferGroupRequest) ProtoMessage() {}
+
+func (x *GetTransferGroupRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_pkg_proto_transfer_transfer_proto_msgTypes[21]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetTransferGroupRequest.ProtoReflect.Descriptor instead.
+func (*GetTransferGroupRequest) Descriptor() ([]byte, []int) {
+	return file_pkg_proto_transfer_transfer_proto_rawDescGZIP(), []int{21}
+}
+
+func (x *GetTransferGroupRequest) GetGroupId() string {
+	if x != nil {
+		return x.GroupId
+	}
+	return ""
+}
+
+func (x *GetTransferGroupRequest) GetUserId() string {
+	if x != nil {
+		return x.UserId
+	}
+	return ""
+}
+
+type GetTransferGroupResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Group         *TransferGroup         `protobuf:"bytes,1,opt,name=group,proto3" json:"group,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetTransferGroupResponse) Reset() {
+	*x = GetTransferGroupResponse{}
+	mi := &file_pkg_proto_transfer_transfer_proto_msgTypes[22]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetTransferGroupResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetTransferGroupResponse) ProtoMessage() {}
+
+func (x *GetTransferGroupResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_pkg_proto_transfer_transfer_proto_msgTypes[22]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetTransferGroupResponse.ProtoReflect.Descriptor instead.
+func (*GetTransferGroupResponse) Descriptor() ([]byte, []int) {
+	return file_pkg_proto_transfer_transfer_proto_rawDescGZIP(), []int{22}
+}
+
+func (x *GetTransferGroupResponse) GetGroup() *TransferGroup {
+	if x != nil {
+		return x.Group
+	}
+	return nil
+}
+
+// TransferGroup - передачи получателей и их сводное состояние
+type TransferGroup struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	FileId        string                 `protobuf:"bytes,2,opt,name=file_id,json=fileId,proto3" json:"file_id,omitempty"`
+	FromDeviceId  string                 `protobuf:"bytes,3,opt,name=from_device_id,json=fromDeviceId,proto3" json:"from_device_id,omitempty"`
+	TransferType  string                 `protobuf:"bytes,4,opt,name=transfer_type,json=transferType,proto3" json:"transfer_type,omitempty"`
+	Status        string                 `protobuf:"bytes,5,opt,name=status,proto3" json:"status,omitempty"`      // pending, in_progress, completed, partial, failed
+	Progress      int64                  `protobuf:"varint,6,opt,name=progress,proto3" json:"progress,omitempty"` // средний прогресс получателей
+	Total         int32                  `protobuf:"varint,7,opt,name=total,proto3" json:"total,omitempty"`
+	Completed     int32                  `protobuf:"varint,8,opt,name=completed,proto3" json:"completed,omitempty"`
+	Failed        int32                  `protobuf:"varint,9,opt,name=failed,proto3" json:"failed,omitempty"` // завершены без получения
+	Transfers     []*Transfer            `protobuf:"bytes,10,rep,name=transfers,proto3" json:"transfers,omitempty"`
+	CreatedAt     string                 `protobuf:"bytes,11,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *TransferGroup) Reset() {
+	*x = TransferGroup{}
+	mi := &file_pkg_proto_transfer_transfer_proto_msgTypes[23]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *TransferGroup) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*TransferGroup) ProtoMessage() {}
+
+func (x *TransferGroup) ProtoReflect() protoreflect.Message {
+	mi := &file_pkg_proto_transfer_transfer_proto_msgTypes[23]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use TransferGroup.ProtoReflect.Descriptor instead.
+func (*TransferGroup) Descriptor() ([]byte, []int) {
+	return file_pkg_proto_transfer_transfer_proto_rawDescGZIP(), []int{23}
+}
+
+func (x *TransferGroup) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *TransferGroup) GetFileId() string {
+	if x != nil {
+		return x.FileId
+	}
+	return ""
+}
+
+func (x *TransferGroup) GetFromDeviceId() string {
+	if x != nil {
+		return x.FromDeviceId
+	}
+	return ""
+}
+
+func (x *TransferGroup) GetTransferType() string {
+	if x != nil {
+		return x.TransferType
+	}
+	return ""
+}
+
+func (x *TransferGroup) GetStatus() string {
+	if x != nil {
+		return x.Status
+	}
+	return ""
+}
+
+func (x *TransferGroup) GetProgress() int64 {
+	if x != nil {
+		return x.Progress
+	}
+	return 0
+}
+
+func (x *TransferGroup) GetTotal() int32 {
+	if x != nil {
+		return x.Total
+	}
+	return 0
+}
+
+func (x *TransferGroup) GetCompleted() int32 {
+	if x != nil {
+		return x.Completed
+	}
+	return 0
+}
+
+func (x *TransferGroup) GetFailed() int32 {
+	if x != nil {
+		return x.Failed
+	}
+	return 0
+}
+
+func (x *TransferGroup) GetTransfers() []*Transfer {
+	if x != nil {
+		return x.Transfers
+	}
+	return nil
+}
+
+func (x *TransferGroup) GetCreatedAt() string {
+	if x != nil {
+		return x.CreatedAt
+	}
+	return ""
+}
+
+var File_pkg_proto_transfer_transfer_proto protoreflect.FileDescriptor
+
+const file_pkg_proto_transfer_transfer_proto_rawDesc = "" +
+	"\n" +
+	"!pkg/proto/transfer/transfer.proto\x12\btransfer\"\xb6\x01\n" +
+	"\x15CreateTransferRequest\x12\x17\n" +
+	"\afile_id\x18\x01 \x01(\tR\x06fileId\x12$\n" +
+	"\x0efrom_device_id\x18\x02 \x01(\tR\ffromDeviceId\x12 \n" +
+	"\fto_device_id\x18\x03 \x01(\tR\n" +
+	"toDeviceId\x12#\n" +
+	"\rtransfer_type\x18\x04 \x01(\tR\ftransferType\x12\x17\n" +
+	"\auser_id\x18\x05 \x01(\tR\x06userId\"H\n" +
+	"\x16CreateTransferResponse\x12.\n" +
+	"\btransfer\x18\x01 \x01(\v2\x12.transfer.TransferR\btransfer\"N\n" +
+	"\x12GetTransferRequest\x12\x1f\n" +
+	"\vtransfer_id\x18\x01 \x01(\tR\n" +
+	"transferId\x12\x17\n" +
+	"\auser_id\x18\x02 \x01(\tR\x06userId\"E\n" +
+	"\x13GetTransferResponse\x12.\n" +
+	"\btransfer\x18\x01 \x01(\v2\x12.transfer.TransferR\btransfer\"\xdf\x01\n" +
+	"\x1bUpdateTransferStatusRequest\x12\x1f\n" +
+	"\vtransfer_id\x18\x01 \x01(\tR\n" +
+	"transferId\x12\x16\n" +
+	"\x06status\x18\x02 \x01(\tR\x06status\x12\x1a\n" +
+	"\bprogress\x18\x03 \x01(\x03R\bprogress\x12%\n" +
+	"\x0efailure_reason\x18\x04 \x01(\tR\rfailureReason\x12+\n" +
+	"\x11bytes_transferred\x18\x05 \x01(\x03R\x10bytesTransferred\x12\x17\n" +
+	"\auser_id\x18\x06 \x01(\tR\x06userId\"N\n" +
+	"\x1cUpdateTransferStatusResponse\x12.\n" +
+	"\btransfer\x18\x01 \x01(\v2\x12.transfer.TransferR\btransfer\"\xe4\x01\n" +
+	"\x14ListTransfersRequest\x12\x17\n" +
+	"\afile_id\x18\x01 \x01(\tR\x06fileId\x12\x16\n" +
+	"\x06status\x18\x02 \x01(\tR\x06status\x12\x1b\n" +
+	"\tdevice_id\x18\x03 \x01(\tR\bdeviceId\x12\x1c\n" +
+	"\tdirection\x18\x04 \x01(\tR\tdirection\x12\x14\n" +
+	"\x05limit\x18\x05 \x01(\x05R\x05limit\x12\x16\n" +
+	"\x06offset\x18\x06 \x01(\x05R\x06offset\x12\x17\n" +
+	"\auser_id\x18\a \x01(\tR\x06userId\x12\x19\n" +
+	"\bgroup_id\x18\b \x01(\tR\agroupId\"I\n" +
+	"\x15ListTransfersResponse\x120\n" +
+	"\ttransfers\x18\x01 \x03(\v2\x12.transfer.TransferR\ttransfers\"Y\n" +
+	"\x1dStreamTransferProgressRequest\x12\x1f\n" +
+	"\vtransfer_id\x18\x01 \x01(\tR\n" +
+	"transferId\x12\x17\n" +
+	"\auser_id\x18\x02 \x01(\tR\x06userId\"\xb4\x02\n" +
+	"\x16TransferProgressUpdate\x12\x1f\n" +
+	"\vtransfer_id\x18\x01 \x01(\tR\n" +
+	"transferId\x12\x16\n" +
+	"\x06status\x18\x02 \x01(\tR\x06status\x12\x1a\n" +
+	"\bprogress\x18\x03 \x01(\x03R\bprogress\x12\x1d\n" +
+	"\n" +
+	"total_size\x18\x04 \x01(\x03R\ttotalSize\x12+\n" +
+	"\x11bytes_transferred\x18\x05 \x01(\x03R\x10bytesTransferred\x12\x12\n" +
+	"\x04rate\x18\x06 \x01(\x01R\x04rate\x12\x1f\n" +
+	"\veta_seconds\x18\a \x01(\x03R\n" +
+	"etaSeconds\x12%\n" +
+	"\x0efailure_reason\x18\b \x01(\tR\rfailureReason\x12\x1d\n" +
+	"\n" +
+	"updated_at\x18\t \x01(\tR\tupdatedAt\"\xa6\x03\n" +
+	"\bTransfer\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x12\x17\n" +
+	"\afile_id\x18\x02 \x01(\tR\x06fileId\x12$\n" +
+	"\x0efrom_device_id\x18\x03 \x01(\tR\ffromDeviceId\x12 \n" +
+	"\fto_device_id\x18\x04 \x01(\tR\n" +
+	"toDeviceId\x12#\n" +
+	"\rtransfer_type\x18\x05 \x01(\tR\ftransferType\x12\x16\n" +
+	"\x06status\x18\x06 \x01(\tR\x06status\x12\x1a\n" +
+	"\bprogress\x18\a \x01(\x03R\bprogress\x12\x1d\n" +
+	"\n" +
+	"created_at\x18\b \x01(\tR\tcreatedAt\x12\x1d\n" +
+	"\n" +
+	"updated_at\x18\t \x01(\tR\tupdatedAt\x12%\n" +
+	"\x0efailure_reason\x18\n" +
+	" \x01(\tR\rfailureReason\x12+\n" +
+	"\x11bytes_transferred\x18\v \x01(\x03R\x10bytesTransferred\x12\x19\n" +
+	"\bgroup_id\x18\f \x01(\tR\agroupId\x12#\n" +
+	"\rdelivery_path\x18\r \x01(\tR\fdeliveryPath\"U\n" +
+	"\x19GetTransferHistoryRequest\x12\x1f\n" +
+	"\vtransfer_id\x18\x01 \x01(\tR\n" +
+	"transferId\x12\x17\n" +
+	"\auser_id\x18\x02 \x01(\tR\x06userId\"M\n" +
+	"\x1aGetTransferHistoryResponse\x12/\n" +
+	"\x06events\x18\x01 \x03(\v2\x17.transfer.TransferEventR\x06events\"\xa9\x01\n" +
+	"\rTransferEvent\x12\x1f\n" +
+	"\vfrom_status\x18\x01 \x01(\tR\n" +
+	"fromStatus\x12\x1b\n" +
+	"\tto_status\x18\x02 \x01(\tR\btoStatus\x12\x16\n" +
+	"\x06reason\x18\x03 \x01(\tR\x06reason\x12\x1d\n" +
+	"\n" +
+	"created_at\x18\x04 \x01(\tR\tcreatedAt\x12#\n" +
+	"\rdelivery_path\x18\x05 \x01(\tR\fdeliveryPath\"H\n" +
+	"\x10ListInboxRequest\x12\x1b\n" +
+	"\tdevice_id\x18\x01 \x01(\tR\bdeviceId\x12\x17\n" +
+	"\auser_id\x18\x02 \x01(\tR\x06userId\">\n" +
+	"\x11ListInboxResponse\x12)\n" +
+	"\x05items\x18\x01 \x03(\v2\x13.transfer.InboxItemR\x05items\"\x8e\x02\n" +
+	"\tInboxItem\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x12\x1b\n" +
+	"\tdevice_id\x18\x02 \x01(\tR\bdeviceId\x12\x1f\n" +
+	"\vtransfer_id\x18\x03 \x01(\tR\n" +
+	"transferId\x12\x17\n" +
+	"\afile_id\x18\x04 \x01(\tR\x06fileId\x12$\n" +
+	"\x0efrom_device_id\x18\x05 \x01(\tR\ffromDeviceId\x12\x1b\n" +
+	"\tfile_name\x18\x06 \x01(\tR\bfileName\x12\x1b\n" +
+	"\tfile_size\x18\a \x01(\x03R\bfileSize\x12\x1b\n" +
+	"\tmime_type\x18\b \x01(\tR\bmimeType\x12\x1d\n" +
+	"\n" +
+	"created_at\x18\t \x01(\tR\tcreatedAt\"t\n" +
+	"\x1bAcknowledgeInboxItemRequest\x12\x1b\n" +
+	"\tdevice_id\x18\x01 \x01(\tR\bdeviceId\x12\x1f\n" +
+	"\vtransfer_id\x18\x02 \x01(\tR\n" +
+	"transferId\x12\x17\n" +
+	"\auser_id\x18\x03 \x01(\tR\x06userId\"N\n" +
+	"\x1cAcknowledgeInboxItemResponse\x12.\n" +
+	"\btransfer\x18\x01 \x01(\v2\x12.transfer.TransferR\btransfer\"\xde\x01\n" +
+	"\x1aCreateTransferGroupRequest\x12\x17\n" +
+	"\auser_id\x18\x01 \x01(\tR\x06userId\x12\x17\n" +
+	"\afile_id\x18\x02 \x01(\tR\x06fileId\x12$\n" +
+	"\x0efrom_device_id\x18\x03 \x01(\tR\ffromDeviceId\x12#\n" +
+	"\rtransfer_type\x18\x04 \x01(\tR\ftransferType\x12\"\n" +
+	"\rto_device_ids\x18\x05 \x03(\tR\vtoDeviceIds\x12\x1f\n" +
+	"\vall_devices\x18\x06 \x01(\bR\n" +
+	"allDevices\"L\n" +
+	"\x1bCreateTransferGroupResponse\x12-\n" +
+	"\x05group\x18\x01 \x01(\v2\x17.transfer.TransferGroupR\x05group\"M\n" +
+	"\x17GetTransferGroupRequest\x12\x19\n" +
+	"\bgroup_id\x18\x01 \x01(\tR\agroupId\x12\x17\n" +
+	"\auser_id\x18\x02 \x01(\tR\x06userId\"I\n" +
+	"\x18GetTransferGroupResponse\x12-\n" +
+	"\x05group\x18\x01 \x01(\v2\x17.transfer.TransferGroupR\x05group\"\xd4\x02\n" +
+	"\rTransferGroup\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x12\x17\n" +
+	"\afile_id\x18\x02 \x01(\tR\x06fileId\x12$\n" +
+	"\x0efrom_device_id\x18\x03 \x01(\tR\ffromDeviceId\x12#\n" +
+	"\rtransfer_type\x18\x04 \x01(\tR\ftransferType\x12\x16\n" +
+	"\x06status\x18\x05 \x01(\tR\x06status\x12\x1a\n" +
+	"\bprogress\x18\x06 \x01(\x03R\bprogress\x12\x14\n" +
+	"\x05total\x18\a \x01(\x05R\x05total\x12\x1c\n" +
+	"\tcompleted\x18\b \x01(\x05R\tcompleted\x12\x16\n" +
+	"\x06failed\x18\t \x01(\x05R\x06failed\x120\n" +
+	"\ttransfers\x18\n" +
+	" \x03(\v2\x12.transfer.TransferR\ttransfers\x12\x1d\n" +
 	"\n" +
-	"updated_at\x18\t \x01(\tR\tupdatedAt2\xd2\x03\n" +
+	"created_at\x18\v \x01(\tR\tcreatedAt2\x9f\a\n" +
 	"\x0fTransferService\x12S\n" +
 	"\x0eCreateTransfer\x12\x1f.transfer.CreateTransferRequest\x1a .transfer.CreateTransferResponse\x12J\n" +
 	"\vGetTransfer\x12\x1c.transfer.GetTransferRequest\x1a\x1d.transfer.GetTransferResponse\x12e\n" +
 	"\x14UpdateTransferStatus\x12%.transfer.UpdateTransferStatusRequest\x1a&.transfer.UpdateTransferStatusResponse\x12P\n" +
 	"\rListTransfers\x12\x1e.transfer.ListTransfersRequest\x1a\x1f.transfer.ListTransfersResponse\x12e\n" +
-	"\x16StreamTransferProgress\x12'.transfer.StreamTransferProgressRequest\x1a .transfer.TransferProgressUpdate0\x01B0Z.github.com/backend-app/backend/pkg/proto/transferb\x06proto3"
+	"\x16StreamTransferProgress\x12'.transfer.StreamTransferProgressRequest\x1a .transfer.TransferProgressUpdate0\x01\x12_\n" +
+	"\x12GetTransferHistory\x12#.transfer.GetTransferHistoryRequest\x1a$.transfer.GetTransferHistoryResponse\x12D\n" +
+	"\tListInbox\x12\x1a.transfer.ListInboxRequest\x1a\x1b.transfer.ListInboxResponse\x12e\n" +
+	"\x14AcknowledgeInboxItem\x12%.transfer.AcknowledgeInboxItemRequest\x1a&.transfer.AcknowledgeInboxItemResponse\x12b\n" +
+	"\x13CreateTransferGroup\x12$.transfer.CreateTransferGroupRequest\x1a%.transfer.CreateTransferGroupResponse\x12Y\n" +
+	"\x10GetTransferGroup\x12!.transfer.GetTransferGroupRequest\x1a\".transfer.GetTransferGroupResponseB3Z1github.com/backend-app/backend/pkg/proto/transferb\x06proto3"
 
 var (
 	file_pkg_proto_transfer_transfer_proto_rawDescOnce sync.Once
@@ -713,7 +1826,7 @@ func file_pkg_proto_transfer_transfer_proto_rawDescGZIP() []byte {
 	return file_pkg_proto_transfer_transfer_proto_rawDescData
 }
 
-var file_pkg_proto_transfer_transfer_proto_msgTypes = make([]protoimpl.MessageInfo, 11)
+var file_pkg_proto_transfer_transfer_proto_msgTypes = make([]protoimpl.MessageInfo, 24)
 var file_pkg_proto_transfer_transfer_proto_goTypes = []any{
 	(*CreateTransferRequest)(nil),         // 0: transfer.CreateTransferRequest
 	(*CreateTransferResponse)(nil),        // 1: transfer.CreateTransferResponse
@@ -726,27 +1839,56 @@ var file_pkg_proto_transfer_transfer_proto_goTypes = []any{
 	(*StreamTransferProgressRequest)(nil), // 8: transfer.StreamTransferProgressRequest
 	(*TransferProgressUpdate)(nil),        // 9: transfer.TransferProgressUpdate
 	(*Transfer)(nil),                      // 10: transfer.Transfer
+	(*GetTransferHistoryRequest)(nil),     // 11: transfer.GetTransferHistoryRequest
+	(*GetTransferHistoryResponse)(nil),    // 12: transfer.GetTransferHistoryResponse
+	(*TransferEvent)(nil),                 // 13: transfer.TransferEvent
+	(*ListInboxRequest)(nil),              // 14: transfer.ListInboxRequest
+	(*ListInboxResponse)(nil),             // 15: transfer.ListInboxResponse
+	(*InboxItem)(nil),                     // 16: transfer.InboxItem
+	(*AcknowledgeInboxItemRequest)(nil),   // 17: transfer.AcknowledgeInboxItemRequest
+	(*AcknowledgeInboxItemResponse)(nil),  // 18: transfer.AcknowledgeInboxItemResponse
+	(*CreateTransferGroupRequest)(nil),    // 19: transfer.CreateTransferGroupRequest
+	(*CreateTransferGroupResponse)(nil),   // 20: transfer.CreateTransferGroupResponse
+	(*GetTransferGroupRequest)(nil),       // 21: transfer.GetTransferGroupRequest
+	(*GetTransferGroupResponse)(nil),      // 22: transfer.GetTransferGroupResponse
+	(*TransferGroup)(nil),                 // 23: transfer.TransferGroup
 }
 var file_pkg_proto_transfer_transfer_proto_depIdxs = []int32{
 	10, // 0: transfer.CreateTransferResponse.transfer:type_name -> transfer.Transfer
 	10, // 1: transfer.GetTransferResponse.transfer:type_name -> transfer.Transfer
 	10, // 2: transfer.UpdateTransferStatusResponse.transfer:type_name -> transfer.Transfer
 	10, // 3: transfer.ListTransfersResponse.transfers:type_name -> transfer.Transfer
-	0,  // 4: transfer.TransferService.CreateTransfer:input_type -> transfer.CreateTransferRequest
-	2,  // 5: transfer.TransferService.GetTransfer:input_type -> transfer.GetTransferRequest
-	4,  // 6: transfer.TransferService.UpdateTransferStatus:input_type -> transfer.UpdateTransferStatusRequest
-	6,  // 7: transfer.TransferService.ListTransfers:input_type -> transfer.ListTransfersRequest
-	8,  // 8: transfer.TransferService.StreamTransferProgress:input_type -> transfer.StreamTransferProgressRequest
-	1,  // 9: transfer.TransferService.CreateTransfer:output_type -> transfer.CreateTransferResponse
-	3,  // 10: transfer.TransferService.GetTransfer:output_type -> transfer.GetTransferResponse
-	5,  // 11: transfer.TransferService.UpdateTransferStatus:output_type -> transfer.UpdateTransferStatusResponse
-	7,  // 12: transfer.TransferService.ListTransfers:output_type -> transfer.ListTransfersResponse
-	9,  // 13: transfer.TransferService.StreamTransferProgress:output_type -> transfer.TransferProgressUpdate
-	9,  // [9:14] is the sub-list for method output_type
-	4,  // [4:9] is the sub-list for method input_type
-	4,  // [4:4] is the sub-list for extension type_name
-	4,  // [4:4] is the sub-list for extension extendee
-	0,  // [0:4] is the sub-list for field type_name
+	13, // 4: transfer.GetTransferHistoryResponse.events:type_name -> transfer.TransferEvent
+	16, // 5: transfer.ListInboxResponse.items:type_name -> transfer.InboxItem
+	10, // 6: transfer.AcknowledgeInboxItemResponse.transfer:type_name -> transfer.Transfer
+	23, // 7: transfer.CreateTransferGroupResponse.group:type_name -> transfer.TransferGroup
+	23, // 8: transfer.GetTransferGroupResponse.group:type_name -> transfer.TransferGroup
+	10, // 9: transfer.TransferGroup.transfers:type_name -> transfer.Transfer
+	0,  // 10: transfer.TransferService.CreateTransfer:input_type -> transfer.CreateTransferRequest
+	2,  // 11: transfer.TransferService.GetTransfer:input_type -> transfer.GetTransferRequest
+	4,  // 12: transfer.TransferService.UpdateTransferStatus:input_type -> transfer.UpdateTransferStatusRequest
+	6,  // 13: transfer.TransferService.ListTransfers:input_type -> transfer.ListTransfersRequest
+	8,  // 14: transfer.TransferService.StreamTransferProgress:input_type -> transfer.StreamTransferProgressRequest
+	11, // 15: transfer.TransferService.GetTransferHistory:input_type -> transfer.GetTransferHistoryRequest
+	14, // 16: transfer.TransferService.ListInbox:input_type -> transfer.ListInboxRequest
+	17, // 17: transfer.TransferService.AcknowledgeInboxItem:input_type -> transfer.AcknowledgeInboxItemRequest
+	19, // 18: transfer.TransferService.CreateTransferGroup:input_type -> transfer.CreateTransferGroupRequest
+	21, // 19: transfer.TransferService.GetTransferGroup:input_type -> transfer.GetTransferGroupRequest
+	1,  // 20: transfer.TransferService.CreateTransfer:output_type -> transfer.CreateTransferResponse
+	3,  // 21: transfer.TransferService.GetTransfer:output_type -> transfer.GetTransferResponse
+	5,  // 22: transfer.TransferService.UpdateTransferStatus:output_type -> transfer.UpdateTransferStatusResponse
+	7,  // 23: transfer.TransferService.ListTransfers:output_type -> transfer.ListTransfersResponse
+	9,  // 24: transfer.TransferService.StreamTransferProgress:output_type -> transfer.TransferProgressUpdate
+	12, // 25: transfer.TransferService.GetTransferHistory:output_type -> transfer.GetTransferHistoryResponse
+	15, // 26: transfer.TransferService.ListInbox:output_type -> transfer.ListInboxResponse
+	18, // 27: transfer.TransferService.AcknowledgeInboxItem:output_type -> transfer.AcknowledgeInboxItemResponse
+	20, // 28: transfer.TransferService.CreateTransferGroup:output_type -> transfer.CreateTransferGroupResponse
+	22, // 29: transfer.TransferService.GetTransferGroup:output_type -> transfer.GetTransferGroupResponse
+	20, // [20:30] is the sub-list for method output_type
+	10, // [10:20] is the sub-list for method input_type
+	10, // [10:10] is the sub-list for extension type_name
+	10, // [10:10] is the sub-list for extension extendee
+	0,  // [0:10] is the sub-list for field type_name
 }
 
 func init() { file_pkg_proto_transfer_transfer_proto_init() }
@@ -760,7 +1902,7 @@ func file_pkg_proto_transfer_transfer_proto_init() {
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: unsafe.Slice(unsafe.StringData(file_pkg_proto_transfer_transfer_proto_rawDesc), len(file_pkg_proto_transfer_transfer_proto_rawDesc)),
 			NumEnums:      0,
-			NumMessages:   11,
+			NumMessages:   24,
 			NumExtensions: 0,
 			NumServices:   1,
 		},
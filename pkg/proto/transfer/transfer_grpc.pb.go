@@ -24,6 +24,11 @@ const (
 	TransferService_UpdateTransferStatus_FullMethodName   = "/transfer.TransferService/UpdateTransferStatus"
 	TransferService_ListTransfers_FullMethodName          = "/transfer.TransferService/ListTransfers"
 	TransferService_StreamTransferProgress_FullMethodName = "/transfer.TransferService/StreamTransferProgress"
+	TransferService_GetTransferHistory_FullMethodName     = "/transfer.TransferService/GetTransferHistory"
+	TransferService_ListInbox_FullMethodName              = "/transfer.TransferService/ListInbox"
+	TransferService_AcknowledgeInboxItem_FullMethodName   = "/transfer.TransferService/AcknowledgeInboxItem"
+	TransferService_CreateTransferGroup_FullMethodName    = "/transfer.TransferService/CreateTransferGroup"
+	TransferService_GetTransferGroup_FullMethodName       = "/transfer.TransferService/GetTransferGroup"
 )
 
 // TransferServiceClient is the client API for TransferService service.
@@ -35,6 +40,11 @@ type TransferServiceClient interface {
 	UpdateTransferStatus(ctx context.Context, in *UpdateTransferStatusRequest, opts ...grpc.CallOption) (*UpdateTransferStatusResponse, error)
 	ListTransfers(ctx context.Context, in *ListTransfersRequest, opts ...grpc.CallOption) (*ListTransfersResponse, error)
 	StreamTransferProgress(ctx context.Context, in *StreamTransferProgressRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[TransferProgressUpdate], error)
+	GetTransferHistory(ctx context.Context, in *GetTransferHistoryRequest, opts ...grpc.CallOption) (*GetTransferHistoryResponse, error)
+	ListInbox(ctx context.Context, in *ListInboxRequest, opts ...grpc.CallOption) (*ListInboxResponse, error)
+	AcknowledgeInboxItem(ctx context.Context, in *AcknowledgeInboxItemRequest, opts ...grpc.CallOption) (*AcknowledgeInboxItemResponse, error)
+	CreateTransferGroup(ctx context.Context, in *CreateTransferGroupRequest, opts ...grpc.CallOption) (*CreateTransferGroupResponse, error)
+	GetTransferGroup(ctx context.Context, in *GetTransferGroupRequest, opts ...grpc.CallOption) (*GetTransferGroupResponse, error)
 }
 
 type transferServiceClient struct {
@@ -104,6 +114,56 @@ func (c *transferServiceClient) StreamTransferProgress(ctx context.Context, in *
 // This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
 type TransferService_StreamTransferProgressClient = grpc.ServerStreamingClient[TransferProgressUpdate]
 
+func (c *transferServiceClient) GetTransferHistory(ctx context.Context, in *GetTransferHistoryRequest, opts ...grpc.CallOption) (*GetTransferHistoryResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(GetTransferHistoryResponse)
+	err := c.cc.Invoke(ctx, TransferService_GetTransferHistory_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *transferServiceClient) ListInbox(ctx context.Context, in *ListInboxRequest, opts ...grpc.CallOption) (*ListInboxResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ListInboxResponse)
+	err := c.cc.Invoke(ctx, TransferService_ListInbox_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *transferServiceClient) AcknowledgeInboxItem(ctx context.Context, in *AcknowledgeInboxItemRequest, opts ...grpc.CallOption) (*AcknowledgeInboxItemResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(AcknowledgeInboxItemResponse)
+	err := c.cc.Invoke(ctx, TransferService_AcknowledgeInboxItem_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *transferServiceClient) CreateTransferGroup(ctx context.Context, in *CreateTransferGroupRequest, opts ...grpc.CallOption) (*CreateTransferGroupResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(CreateTransferGroupResponse)
+	err := c.cc.Invoke(ctx, TransferService_CreateTransferGroup_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *transferServiceClient) GetTransferGroup(ctx context.Context, in *GetTransferGroupRequest, opts ...grpc.CallOption) (*GetTransferGroupResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(GetTransferGroupResponse)
+	err := c.cc.Invoke(ctx, TransferService_GetTransferGroup_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 // TransferServiceServer is the server API for TransferService service.
 // All implementations must embed UnimplementedTransferServiceServer
 // for forward compatibility.
@@ -113,6 +173,11 @@ type TransferServiceServer interface {
 	UpdateTransferStatus(context.Context, *UpdateTransferStatusRequest) (*UpdateTransferStatusResponse, error)
 	ListTransfers(context.Context, *ListTransfersRequest) (*ListTransfersResponse, error)
 	StreamTransferProgress(*StreamTransferProgressRequest, grpc.ServerStreamingServer[TransferProgressUpdate]) error
+	GetTransferHistory(context.Context, *GetTransferHistoryRequest) (*GetTransferHistoryResponse, error)
+	ListInbox(context.Context, *ListInboxRequest) (*ListInboxResponse, error)
+	AcknowledgeInboxItem(context.Context, *AcknowledgeInboxItemRequest) (*AcknowledgeInboxItemResponse, error)
+	CreateTransferGroup(context.Context, *CreateTransferGroupRequest) (*CreateTransferGroupResponse, error)
+	GetTransferGroup(context.Context, *GetTransferGroupRequest) (*GetTransferGroupResponse, error)
 	mustEmbedUnimplementedTransferServiceServer()
 }
 
@@ -138,6 +203,21 @@ func (UnimplementedTransferServiceServer) ListTransfers(context.Context, *ListTr
 func (UnimplementedTransferServiceServer) StreamTransferProgress(*StreamTransferProgressRequest, grpc.ServerStreamingServer[TransferProgressUpdate]) error {
 	return status.Error(codes.Unimplemented, "method StreamTransferProgress not implemented")
 }
+func (UnimplementedTransferServiceServer) GetTransferHistory(context.Context, *GetTransferHistoryRequest) (*GetTransferHistoryResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method GetTransferHistory not implemented")
+}
+func (UnimplementedTransferServiceServer) ListInbox(context.Context, *ListInboxRequest) (*ListInboxResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method ListInbox not implemented")
+}
+func (UnimplementedTransferServiceServer) AcknowledgeInboxItem(context.Context, *AcknowledgeInboxItemRequest) (*AcknowledgeInboxItemResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method AcknowledgeInboxItem not implemented")
+}
+func (UnimplementedTransferServiceServer) CreateTransferGroup(context.Context, *CreateTransferGroupRequest) (*CreateTransferGroupResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method CreateTransferGroup not implemented")
+}
+func (UnimplementedTransferServiceServer) GetTransferGroup(context.Context, *GetTransferGroupRequest) (*GetTransferGroupResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method GetTransferGroup not implemented")
+}
 func (UnimplementedTransferServiceServer) mustEmbedUnimplementedTransferServiceServer() {}
 func (UnimplementedTransferServiceServer) testEmbeddedByValue()                         {}
 
@@ -242,6 +322,96 @@ func _TransferService_StreamTransferProgress_Handler(srv interface{}, stream grp
 // This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
 type TransferService_StreamTransferProgressServer = grpc.ServerStreamingServer[TransferProgressUpdate]
 
+func _TransferService_GetTransferHistory_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetTransferHistoryRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(TransferServiceServer).GetTransferHistory(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: TransferService_GetTransferHistory_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(TransferServiceServer).GetTransferHistory(ctx, req.(*GetTransferHistoryRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _TransferService_ListInbox_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListInboxRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(TransferServiceServer).ListInbox(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: TransferService_ListInbox_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(TransferServiceServer).ListInbox(ctx, req.(*ListInboxRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _TransferService_AcknowledgeInboxItem_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(AcknowledgeInboxItemRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(TransferServiceServer).AcknowledgeInboxItem(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: TransferService_AcknowledgeInboxItem_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(TransferServiceServer).AcknowledgeInboxItem(ctx, req.(*AcknowledgeInboxItemRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _TransferService_CreateTransferGroup_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CreateTransferGroupRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(TransferServiceServer).CreateTransferGroup(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: TransferService_CreateTransferGroup_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(TransferServiceServer).CreateTransferGroup(ctx, req.(*CreateTransferGroupRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _TransferService_GetTransferGroup_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetTransferGroupRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(TransferServiceServer).GetTransferGroup(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: TransferService_GetTransferGroup_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(TransferServiceServer).GetTransferGroup(ctx, req.(*GetTransferGroupRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 // TransferService_ServiceDesc is the grpc.ServiceDesc for TransferService service.
 // It's only intended for direct use with grpc.RegisterService,
 // and not to be introspected or modified (even as a copy)
@@ -265,6 +435,26 @@ var TransferService_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "ListTransfers",
 			Handler:    _TransferService_ListTransfers_Handler,
 		},
+		{
+			MethodName: "GetTransferHistory",
+			Handler:    _TransferService_GetTransferHistory_Handler,
+		},
+		{
+			MethodName: "ListInbox",
+			Handler:    _TransferService_ListInbox_Handler,
+		},
+		{
+			MethodName: "AcknowledgeInboxItem",
+			Handler:    _TransferService_AcknowledgeInboxItem_Handler,
+		},
+		{
+			MethodName: "CreateTransferGroup",
+			Handler:    _TransferService_CreateTransferGroup_Handler,
+		},
+		{
+			MethodName: "GetTransferGroup",
+			Handler:    _TransferService_GetTransferGroup_Handler,
+		},
 	},
 	Streams: []grpc.StreamDesc{
 		{